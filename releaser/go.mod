@@ -1,3 +1,7 @@
 module altinn.studio/releaser
 
 go 1.25.7
+
+require altinn.studio/devenv v0.0.0
+
+replace altinn.studio/devenv => ../src/Runtime/devenv
@@ -0,0 +1,238 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"altinn.studio/releaser/internal/changelog"
+)
+
+// ErrAuditFailed indicates the changelog audit reported findings at the failing severity.
+var ErrAuditFailed = errors.New("changelog audit failed")
+
+// Audit finding severities.
+const (
+	AuditSeverityError   = "error"
+	AuditSeverityWarning = "warning"
+)
+
+// Codes for audit findings that do not come from a sentinel error.
+const (
+	auditCodeEmptyCategory = "EMPTY_CATEGORY"
+	auditCodePatchGap      = "PATCH_GAP"
+)
+
+// AuditRequest selects the optional checks run by the changelog audit.
+// Structural validation always runs.
+type AuditRequest struct {
+	// EmptyCategories warns about category headers without entries.
+	EmptyCategories bool
+	// PatchGaps warns about skipped patch numbers within a vX.Y line.
+	PatchGaps bool
+	// RequirePreamble reports an error unless the changelog starts with a "# " title.
+	RequirePreamble bool
+	// FailOnWarnings makes warnings fail the audit as well as errors.
+	FailOnWarnings bool
+}
+
+// AuditReport is the combined audit result for every registered component.
+type AuditReport struct {
+	Components []ComponentAudit `json:"components"`
+	Errors     int              `json:"errors"`
+	Warnings   int              `json:"warnings"`
+}
+
+// ComponentAudit lists the findings for one component changelog.
+type ComponentAudit struct {
+	Component     string         `json:"component"`
+	ChangelogPath string         `json:"changelogPath"`
+	Findings      []AuditFinding `json:"findings"`
+}
+
+// AuditFinding is a single audit result. Code is the error code for structural
+// failures (see ClassifyError) or a check-specific code for optional checks.
+type AuditFinding struct {
+	Severity string `json:"severity"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+}
+
+// RunAudit audits the working-tree changelogs of all registered components.
+func RunAudit(ctx context.Context, req AuditRequest, log Logger) (AuditReport, error) {
+	if log == nil {
+		log = NopLogger{}
+	}
+	git := NewGitCLI(WithLogger(log))
+	return RunAuditWithDeps(ctx, req, git)
+}
+
+// RunAuditWithDeps audits all component changelogs with injected git dependency.
+// Every component is audited even if some fail. The returned error wraps
+// ErrAuditFailed when any error-level finding is reported, or any finding at all
+// with FailOnWarnings; the report is complete either way.
+func RunAuditWithDeps(ctx context.Context, req AuditRequest, git *GitCLI) (AuditReport, error) {
+	if ctx == nil {
+		return AuditReport{}, errContextRequired
+	}
+	if git == nil {
+		return AuditReport{}, errGitRequired
+	}
+
+	names := ComponentNames()
+	report := AuditReport{
+		Components: make([]ComponentAudit, 0, len(names)),
+		Errors:     0,
+		Warnings:   0,
+	}
+	for _, name := range names {
+		audit := auditComponent(ctx, req, git, name)
+		for _, finding := range audit.Findings {
+			if finding.Severity == AuditSeverityError {
+				report.Errors++
+			} else {
+				report.Warnings++
+			}
+		}
+		report.Components = append(report.Components, audit)
+	}
+
+	if report.Errors > 0 || (req.FailOnWarnings && report.Warnings > 0) {
+		return report, fmt.Errorf(
+			"%w: %d error(s), %d warning(s)",
+			ErrAuditFailed,
+			report.Errors,
+			report.Warnings,
+		)
+	}
+	return report, nil
+}
+
+func auditComponent(ctx context.Context, req AuditRequest, git *GitCLI, name string) ComponentAudit {
+	audit := ComponentAudit{
+		Component:     name,
+		ChangelogPath: "",
+		Findings:      []AuditFinding{},
+	}
+	if comp, err := GetComponent(name); err == nil {
+		audit.ChangelogPath = changelog.NormalizePath(comp.ChangelogPath)
+	}
+
+	_, _, cl, err := readWorkingTreeChangelog(ctx, git, name, "")
+	if err != nil {
+		audit.Findings = append(audit.Findings, auditErrorFinding(err))
+		return audit
+	}
+	if req.RequirePreamble {
+		if err := cl.ValidatePreamble(); err != nil {
+			audit.Findings = append(audit.Findings, auditErrorFinding(err))
+		}
+	}
+	if req.EmptyCategories {
+		audit.Findings = append(audit.Findings, emptyCategoryFindings(cl)...)
+	}
+	if req.PatchGaps {
+		audit.Findings = append(audit.Findings, patchGapFindings(cl)...)
+	}
+	return audit
+}
+
+func auditErrorFinding(err error) AuditFinding {
+	return AuditFinding{
+		Severity: AuditSeverityError,
+		Code:     ClassifyError(err).Code,
+		Message:  err.Error(),
+	}
+}
+
+// emptyCategoryFindings warns about every category header without list items.
+func emptyCategoryFindings(cl *changelog.Changelog) []AuditFinding {
+	var findings []AuditFinding
+	sections := make([]*changelog.Section, 0, len(cl.Versions)+1)
+	if cl.Unreleased != nil {
+		sections = append(sections, cl.Unreleased)
+	}
+	sections = append(sections, cl.Versions...)
+	for _, section := range sections {
+		label := "Unreleased"
+		if section.Version != nil {
+			label = section.Version.Num
+		}
+		for _, cat := range section.Categories {
+			if len(cat.Entries) > 0 {
+				continue
+			}
+			findings = append(findings, AuditFinding{
+				Severity: AuditSeverityWarning,
+				Code:     auditCodeEmptyCategory,
+				Message:  fmt.Sprintf("[%s] ### %s has no entries", label, cat.Name),
+			})
+		}
+	}
+	return findings
+}
+
+// patchGapFindings warns about patch numbers missing between the lowest and highest
+// released patch of each vX.Y line. Prerelease sections count toward their patch.
+func patchGapFindings(cl *changelog.Changelog) []AuditFinding {
+	type line struct{ major, minor int }
+	var lines []line
+	patches := make(map[line][]int)
+	for _, section := range cl.Versions {
+		if section == nil || section.Version == nil {
+			continue
+		}
+		key := line{major: section.Version.Major, minor: section.Version.Minor}
+		if _, ok := patches[key]; !ok {
+			lines = append(lines, key)
+		}
+		if !slices.Contains(patches[key], section.Version.Patch) {
+			patches[key] = append(patches[key], section.Version.Patch)
+		}
+	}
+
+	var findings []AuditFinding
+	for _, key := range lines {
+		released := patches[key]
+		slices.Sort(released)
+		var missing []string
+		for patch := released[0] + 1; patch < released[len(released)-1]; patch++ {
+			if !slices.Contains(released, patch) {
+				missing = append(missing, fmt.Sprintf("v%d.%d.%d", key.major, key.minor, patch))
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		findings = append(findings, AuditFinding{
+			Severity: AuditSeverityWarning,
+			Code:     auditCodePatchGap,
+			Message:  fmt.Sprintf("v%d.%d line skips %s", key.major, key.minor, strings.Join(missing, ", ")),
+		})
+	}
+	return findings
+}
+
+// AuditMarkdown renders the audit report as markdown suitable for an issue body.
+func AuditMarkdown(report AuditReport) string {
+	var b strings.Builder
+	b.WriteString("# Changelog audit\n\n")
+	fmt.Fprintf(&b, "%d error(s), %d warning(s) across %d component(s).\n",
+		report.Errors, report.Warnings, len(report.Components))
+	for _, audit := range report.Components {
+		fmt.Fprintf(&b, "\n## %s\n\n", audit.Component)
+		if audit.ChangelogPath != "" {
+			fmt.Fprintf(&b, "`%s`\n\n", audit.ChangelogPath)
+		}
+		if len(audit.Findings) == 0 {
+			b.WriteString("No findings.\n")
+			continue
+		}
+		for _, finding := range audit.Findings {
+			fmt.Fprintf(&b, "- **%s** `%s`: %s\n", finding.Severity, finding.Code, finding.Message)
+		}
+	}
+	return b.String()
+}
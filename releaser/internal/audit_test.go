@@ -0,0 +1,161 @@
+package internal_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"altinn.studio/releaser/internal"
+)
+
+const auditWarningsChangelog = `# Changelog
+
+## [Unreleased]
+
+### Added
+
+### Fixed
+
+- Pending fix
+
+## [1.0.3] - 2025-03-01
+
+### Fixed
+
+- Third fix
+
+## [1.0.0] - 2025-01-01
+
+### Added
+
+- Initial
+`
+
+func TestRunAuditWithDeps_MixedComponents(t *testing.T) {
+	repo := createStudioctlWorkflowRepo(t, auditWarningsChangelog)
+	writeRepoFile(t, repo, "src/App/fileanalyzers/CHANGELOG.md", `# Changelog
+
+## [Unreleased]
+
+## [1.0.0] - 2025-02-01
+
+### Added
+
+- Duplicate
+
+## [1.0.0] - 2025-01-01
+
+### Added
+
+- Initial
+`)
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+
+	report, err := internal.RunAuditWithDeps(t.Context(), internal.AuditRequest{
+		EmptyCategories: true,
+		PatchGaps:       true,
+		RequirePreamble: true,
+		FailOnWarnings:  false,
+	}, git)
+	if !errors.Is(err, internal.ErrAuditFailed) {
+		t.Fatalf("RunAuditWithDeps() error = %v, want %v", err, internal.ErrAuditFailed)
+	}
+	if code := internal.ClassifyError(err).Code; code != "AUDIT_FAILED" {
+		t.Errorf("ClassifyError().Code = %q, want AUDIT_FAILED", code)
+	}
+
+	content, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("marshal report: %v", err)
+	}
+	var got struct {
+		Components []struct {
+			Component     string `json:"component"`
+			ChangelogPath string `json:"changelogPath"`
+			Findings      []struct {
+				Severity string `json:"severity"`
+				Code     string `json:"code"`
+				Message  string `json:"message"`
+			} `json:"findings"`
+		} `json:"components"`
+		Errors   int `json:"errors"`
+		Warnings int `json:"warnings"`
+	}
+	if err := json.Unmarshal(content, &got); err != nil {
+		t.Fatalf("unmarshal report: %v\n%s", err, content)
+	}
+	if got.Errors != 1 || got.Warnings != 2 || len(got.Components) != 2 {
+		t.Fatalf("report = %s, want 1 error and 2 warnings over 2 components", content)
+	}
+
+	codes := make(map[string][]string)
+	for _, component := range got.Components {
+		for _, finding := range component.Findings {
+			codes[component.Component] = append(codes[component.Component], finding.Severity+"/"+finding.Code)
+		}
+	}
+	want := map[string][]string{
+		"fileanalyzers": {"error/DUPLICATE_VERSION"},
+		"studioctl":     {"warning/EMPTY_CATEGORY", "warning/PATCH_GAP"},
+	}
+	if !reflect.DeepEqual(codes, want) {
+		t.Errorf("findings = %v, want %v", codes, want)
+	}
+	for _, component := range got.Components {
+		if component.Component == "studioctl" && component.ChangelogPath != "src/cli/CHANGELOG.md" {
+			t.Errorf("studioctl changelogPath = %q, want src/cli/CHANGELOG.md", component.ChangelogPath)
+		}
+	}
+
+	markdown := internal.AuditMarkdown(report)
+	for _, want := range []string{
+		"# Changelog audit",
+		"1 error(s), 2 warning(s) across 2 component(s).",
+		"## fileanalyzers",
+		"- **error** `DUPLICATE_VERSION`",
+		"- **warning** `PATCH_GAP`: v1.0 line skips v1.0.1, v1.0.2",
+		"- **warning** `EMPTY_CATEGORY`: [Unreleased] ### Added has no entries",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("markdown missing %q:\n%s", want, markdown)
+		}
+	}
+}
+
+func TestRunAuditWithDeps_WarningsOnly(t *testing.T) {
+	repo := createStudioctlWorkflowRepo(t, auditWarningsChangelog)
+	writeRepoFile(t, repo, "src/App/fileanalyzers/CHANGELOG.md", "# Changelog\n\n## [Unreleased]\n")
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+
+	req := internal.AuditRequest{
+		EmptyCategories: true,
+		PatchGaps:       true,
+		RequirePreamble: false,
+		FailOnWarnings:  false,
+	}
+	report, err := internal.RunAuditWithDeps(t.Context(), req, git)
+	if err != nil {
+		t.Fatalf("RunAuditWithDeps() error = %v, want nil for warnings only", err)
+	}
+	if report.Errors != 0 || report.Warnings != 2 {
+		t.Fatalf("report = %d errors, %d warnings; want 0 and 2", report.Errors, report.Warnings)
+	}
+
+	req.FailOnWarnings = true
+	if _, err := internal.RunAuditWithDeps(t.Context(), req, git); !errors.Is(err, internal.ErrAuditFailed) {
+		t.Fatalf("RunAuditWithDeps(FailOnWarnings) error = %v, want %v", err, internal.ErrAuditFailed)
+	}
+
+	// Optional checks are off by default.
+	report, err = internal.RunAuditWithDeps(t.Context(), internal.AuditRequest{
+		EmptyCategories: false,
+		PatchGaps:       false,
+		RequirePreamble: false,
+		FailOnWarnings:  true,
+	}, git)
+	if err != nil || report.Warnings != 0 {
+		t.Fatalf("RunAuditWithDeps() without checks = %d warnings, %v; want none", report.Warnings, err)
+	}
+}
@@ -17,26 +17,48 @@ var backportBranchVersionPattern = regexp.MustCompile(`^v(\d+)\.(\d+)$`)
 
 // BackportRequest describes the inputs for a backport operation.
 type BackportRequest struct {
-	Prompter      ConfirmationPrompter
+	Prompter ConfirmationPrompter
+	// Author overrides the git user for the backport commits.
+	Author        CommitIdentity
 	Component     string // Component name (e.g., "studioctl")
 	Commit        string
 	Branch        string
 	ChangelogPath string // Optional: override component's default changelog path
-	Open          bool
-	DryRun        bool
+	// Labels are added to each PR after the backport label and the component's ExtraLabels.
+	Labels []string
+	// Trailers are appended to each backport commit message after the cherry-pick line.
+	Trailers []Trailer
+	// Commits backports several commits, each on its own branch and PR (see RunBackportBatchWithDeps).
+	Commits []string
+	// Branches backports Commit to several release lines, each on its own branch and PR
+	// (see RunBackportLinesWithDeps).
+	Branches []string
+	// Parallel bounds how many release lines RunBackportLinesWithDeps backports at once.
+	// Values below 2 backport one line at a time.
+	Parallel int
+	Open     bool
+	DryRun   bool
+	// NoPush stops after the local commit, leaving push and PR creation to the caller.
+	NoPush bool
+	// KeepGoing continues a batch after a failed commit instead of stopping.
+	KeepGoing bool
 }
 
 type backportConfig struct {
 	component      *Component
+	author         CommitIdentity
 	commit         string
 	commitMsg      string
 	releaseBranch  string
 	backportBranch string
 	shortSHA       string
+	labels         []string
+	trailers       []Trailer
 	major          int
 	minor          int
 	openPR         bool
 	dryRun         bool
+	noPush         bool
 }
 
 // RunBackport executes the backport workflow.
@@ -45,7 +67,7 @@ func RunBackport(ctx context.Context, req BackportRequest, log Logger) error {
 		log = NopLogger{}
 	}
 	git := NewGitCLI(WithLogger(log))
-	gh := NewGitHubCLI(WithGHLogger(log))
+	gh := NewThrottledGitHub(NewGitHubCLI(WithGHLogger(log)), WithThrottleLogger(log))
 	return RunBackportWithDeps(ctx, req, git, gh, log)
 }
 
@@ -70,6 +92,11 @@ func RunBackportWithDeps(ctx context.Context, req BackportRequest, git *GitCLI,
 	if err != nil {
 		return err
 	}
+	if !cfg.noPush {
+		if err := ensureGitHubAuthenticated(ctx, gh, cfg.dryRun, log); err != nil {
+			return err
+		}
+	}
 
 	clPath := req.ChangelogPath
 	if clPath == "" {
@@ -124,6 +151,9 @@ func RunBackportWithDeps(ctx context.Context, req BackportRequest, git *GitCLI,
 	if err != nil {
 		return err
 	}
+	if cfg.noPush {
+		return nil
+	}
 	logBackportPR(ctx, log, cfg.openPR, prURL)
 
 	log.Success("Backport complete")
@@ -182,6 +212,12 @@ func parseBackportConfig(req BackportRequest, comp *Component) (*backportConfig,
 	if req.Branch == "" {
 		return nil, errBackportBranchRequired
 	}
+	if err := req.Author.Validate(); err != nil {
+		return nil, err
+	}
+	if err := validateTrailers(req.Trailers); err != nil {
+		return nil, err
+	}
 
 	branchVer := req.Branch
 
@@ -215,10 +251,14 @@ func parseBackportConfig(req BackportRequest, comp *Component) (*backportConfig,
 		releaseBranch:  releaseBranch,
 		backportBranch: backportBranch,
 		shortSHA:       shortSHA,
+		labels:         comp.PRLabels(backportLabel, req.Labels),
+		author:         req.Author,
+		trailers:       req.Trailers,
 		major:          major,
 		minor:          minor,
 		openPR:         req.Open,
 		dryRun:         req.DryRun,
+		noPush:         req.NoPush,
 	}, nil
 }
 
@@ -303,9 +343,13 @@ func executeBackport(
 		return "", err
 	}
 	logChangelogEntries(log, entries)
-	if err := commitBackport(ctx, git, cfg.shortSHA, cfg.commitMsg, cfg.commit, clPath); err != nil {
+	if err := commitBackport(ctx, git, cfg, clPath); err != nil {
 		return "", err
 	}
+	if cfg.noPush {
+		logManualPush(log, cfg.backportBranch)
+		return "", nil
+	}
 	if err := pushBackportBranch(ctx, git, cfg.backportBranch); err != nil {
 		return "", err
 	}
@@ -409,22 +453,32 @@ func resolveChangelogOnlyCherryPickConflict(ctx context.Context, git *GitCLI, cl
 	return true, nil
 }
 
-func commitBackport(ctx context.Context, git *GitCLI, shortSHA, originalMsg, commitSHA, changelogPath string) error {
-	commitMsg := fmt.Sprintf("Backport %s: %s\n\n(cherry picked from commit %s)", shortSHA, originalMsg, commitSHA)
+func commitBackport(ctx context.Context, git *GitCLI, cfg *backportConfig, changelogPath string) error {
+	commitMsg := fmt.Sprintf(
+		"Backport %s: %s\n\n(cherry picked from commit %s)",
+		cfg.shortSHA,
+		cfg.commitMsg,
+		cfg.commit,
+	)
 	// Cherry-pick already stages the picked changes. Only re-stage the changelog after editing it.
 	if err := git.RunWrite(ctx, "add", "--", changelogPath); err != nil {
 		return fmt.Errorf("git add changelog: %w", err)
 	}
-	if err := git.RunWrite(ctx, "commit", "-m", commitMsg); err != nil {
+	if err := git.RunWrite(ctx, commitArgs(cfg.author, appendTrailers(commitMsg, cfg.trailers))...); err != nil {
 		return fmt.Errorf("git commit: %w", err)
 	}
 	return nil
 }
 
+// pushBackportBranch pushes backportBranch and then sets its upstream, so that with parallel
+// worktrees only the quick .git/config write holds the repository lock exclusively, not the push.
 func pushBackportBranch(ctx context.Context, git *GitCLI, backportBranch string) error {
-	if err := git.RunWrite(ctx, "push", "-u", "origin", backportBranch); err != nil {
+	if err := git.RunWrite(ctx, "push", "origin", backportBranch); err != nil {
 		return fmt.Errorf("git push: %w", err)
 	}
+	if err := git.RunWrite(ctx, "branch", "--set-upstream-to=origin/"+backportBranch, backportBranch); err != nil {
+		return fmt.Errorf("set upstream: %w", err)
+	}
 	return nil
 }
 
@@ -437,10 +491,11 @@ func createBackportPR(ctx context.Context, gh GitHubRunner, cfg *backportConfig)
 		cfg.commitMsg,
 	)
 	prURL, err := gh.CreatePR(ctx, PullRequestOptions{
-		Title: prTitle,
-		Body:  prBody,
-		Label: backportLabel,
-		Base:  cfg.releaseBranch,
+		Title:  prTitle,
+		Body:   prBody,
+		Base:   cfg.releaseBranch,
+		Head:   cfg.backportBranch,
+		Labels: cfg.labels,
 	})
 	if err != nil {
 		return "", fmt.Errorf("create PR: %w", err)
@@ -455,13 +510,21 @@ func printBackportDryRun(log Logger, cfg *backportConfig, entries []changelog.En
 	log.Info("Would create backport branch: %s", cfg.backportBranch)
 	logChangelogEntries(log, entries)
 	log.Info("Would create commit: Backport %s: %s", cfg.shortSHA, cfg.commitMsg)
+	if !cfg.author.IsZero() {
+		log.Info("Would commit as: %s", cfg.author)
+	}
+	logTrailers(log, cfg.trailers)
+	if cfg.noPush {
+		log.Info("Would stop before pushing and creating the PR (-no-push)")
+		return
+	}
 	log.Info("Would push to origin/%s", cfg.backportBranch)
 	log.Info(
-		"Would create PR: chore: backport %s to v%d.%d (label: %s)",
+		"Would create PR: chore: backport %s to v%d.%d (labels: %s)",
 		cfg.shortSHA,
 		cfg.major,
 		cfg.minor,
-		backportLabel,
+		strings.Join(cfg.labels, ", "),
 	)
 }
 
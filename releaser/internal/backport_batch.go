@@ -0,0 +1,249 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrBackportsFailed indicates at least one commit in a backport batch failed.
+	ErrBackportsFailed = errors.New("one or more backports failed")
+
+	errBackportBranchExists = errors.New("backport branch already exists locally")
+)
+
+// BackportResult is the outcome of backporting one commit in a batch.
+type BackportResult struct {
+	Err           error  // nil if the backport PR was created (or previewed in dry-run)
+	Commit        string // commit SHA as given
+	ReleaseBranch string // release branch the PR targets
+	Branch        string // backport branch; empty if a failed attempt left nothing on origin
+	PRURL         string
+}
+
+// RunBackportBatch backports each of req.Commits to the release branch.
+func RunBackportBatch(ctx context.Context, req BackportRequest, log Logger) ([]BackportResult, error) {
+	if log == nil {
+		log = NopLogger{}
+	}
+	git := NewGitCLI(WithLogger(log))
+	gh := NewThrottledGitHub(NewGitHubCLI(WithGHLogger(log)), WithThrottleLogger(log))
+	return RunBackportBatchWithDeps(ctx, req, git, gh, log)
+}
+
+// RunBackportBatchWithDeps backports each of req.Commits on its own branch and PR.
+// A failed commit is rolled back: any cherry-pick is aborted, the local backport branch is
+// removed unless it was already pushed, and the original branch is checked out again.
+// Without KeepGoing the batch stops at the first failure; with it every commit is attempted.
+// The returned error wraps ErrBackportsFailed and lists the failing commits.
+func RunBackportBatchWithDeps(
+	ctx context.Context,
+	req BackportRequest,
+	git *GitCLI,
+	gh GitHubRunner,
+	log Logger,
+) ([]BackportResult, error) {
+	if log == nil {
+		log = NopLogger{}
+	}
+	if ctx == nil {
+		return nil, errContextRequired
+	}
+	if req.Component == "" {
+		return nil, errComponentRequired
+	}
+	if len(req.Commits) == 0 {
+		return nil, errBackportCommitRequired
+	}
+
+	comp, err := GetComponent(req.Component)
+	if err != nil {
+		return nil, fmt.Errorf("get component: %w", err)
+	}
+	configs := make([]*backportConfig, 0, len(req.Commits))
+	for _, commit := range req.Commits {
+		single := req
+		single.Commit = commit
+		cfg, err := parseBackportConfig(single, comp)
+		if err != nil {
+			return nil, err
+		}
+		configs = append(configs, cfg)
+	}
+	if !req.NoPush {
+		if err := ensureGitHubAuthenticated(ctx, gh, req.DryRun, log); err != nil {
+			return nil, err
+		}
+	}
+
+	clPath := req.ChangelogPath
+	if clPath == "" {
+		clPath = comp.ChangelogPath
+	}
+	repoRoot, err := git.RepoRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	startBranch, err := git.CurrentBranch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get current branch: %w", err)
+	}
+
+	if !req.DryRun {
+		if err := ensureWorkingTreeClean(ctx, git, log); err != nil {
+			return nil, err
+		}
+		if err := confirmNonMainBranch(req.Prompter, startBranch, "backport",
+			fmt.Sprintf("Will backport %d commits to %s, returning to %s afterwards.",
+				len(configs), configs[0].releaseBranch, startBranch),
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]BackportResult, 0, len(configs))
+	var failed []string
+	for _, cfg := range configs {
+		result := backportBatchCommit(ctx, git, gh, log, repoRoot, clPath, cfg)
+		if !req.DryRun {
+			if err := restoreBackportStart(ctx, git, log, startBranch, &result); err != nil {
+				results = append(results, result)
+				logBackportSummary(log, results, backportCommitLabel)
+				return results, err
+			}
+		}
+		results = append(results, result)
+		if result.Err != nil {
+			failed = append(failed, cfg.shortSHA)
+			if !req.KeepGoing {
+				break
+			}
+		}
+	}
+
+	logBackportSummary(log, results, backportCommitLabel)
+	if len(failed) > 0 {
+		return results, fmt.Errorf("%w: %s", ErrBackportsFailed, strings.Join(failed, ", "))
+	}
+	return results, nil
+}
+
+func backportBatchCommit(
+	ctx context.Context,
+	git *GitCLI,
+	gh GitHubRunner,
+	log Logger,
+	repoRoot, clPath string,
+	cfg *backportConfig,
+) BackportResult {
+	result := BackportResult{Err: nil, Commit: cfg.commit, ReleaseBranch: cfg.releaseBranch, Branch: "", PRURL: ""}
+
+	log.Step("Backporting " + cfg.shortSHA)
+	entries, commitMsg, err := extractEntriesFromCommit(ctx, git, cfg.commit, clPath)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	cfg.commitMsg = commitMsg
+	logBackportState(log, cfg, repoRoot)
+
+	if cfg.dryRun {
+		printBackportDryRun(log, cfg, entries)
+		result.Branch = cfg.backportBranch
+		return result
+	}
+
+	// Never take over (and later delete) a branch this batch did not create.
+	if localBranchExists(ctx, git, cfg.backportBranch) {
+		result.Err = fmt.Errorf("%w: %s", errBackportBranchExists, cfg.backportBranch)
+		return result
+	}
+	result.Branch = cfg.backportBranch
+	result.PRURL, result.Err = executeBackport(ctx, git, gh, log, repoRoot, clPath, cfg, entries)
+	if result.Err == nil && !cfg.noPush {
+		logBackportPR(ctx, log, cfg.openPR, result.PRURL)
+	}
+	return result
+}
+
+// restoreBackportStart returns to startBranch after a batch step. After a failure it discards
+// leftover changes on the backport branch and deletes the branch unless it reached origin.
+func restoreBackportStart(
+	ctx context.Context,
+	git *GitCLI,
+	log Logger,
+	startBranch string,
+	result *BackportResult,
+) error {
+	if result.Err != nil {
+		clean, err := git.WorkingTreeClean(ctx)
+		if err != nil {
+			return fmt.Errorf("check working tree after failed backport: %w", err)
+		}
+		if !clean {
+			if err := git.RunWrite(ctx, "reset", "--hard", "HEAD"); err != nil {
+				return fmt.Errorf("discard failed backport changes: %w", err)
+			}
+		}
+	}
+
+	if err := git.Checkout(ctx, startBranch); err != nil {
+		return fmt.Errorf("return to %s: %w", startBranch, err)
+	}
+	return dropFailedBackportBranch(ctx, git, log, result)
+}
+
+// dropFailedBackportBranch deletes the local branch of a failed backport unless it reached
+// origin. The branch must not be checked out.
+func dropFailedBackportBranch(ctx context.Context, git *GitCLI, log Logger, result *BackportResult) error {
+	if result.Err == nil || result.Branch == "" {
+		return nil
+	}
+	pushed, err := git.RemoteBranchExists(ctx, result.Branch)
+	if err != nil {
+		return fmt.Errorf("check remote backport branch: %w", err)
+	}
+	if pushed {
+		log.Info("Keeping %s: it was already pushed to origin", result.Branch)
+		return nil
+	}
+	if localBranchExists(ctx, git, result.Branch) {
+		if err := git.RunWrite(ctx, "branch", "-D", result.Branch); err != nil {
+			return fmt.Errorf("delete failed backport branch: %w", err)
+		}
+	}
+	result.Branch = ""
+	return nil
+}
+
+func localBranchExists(ctx context.Context, git *GitCLI, branch string) bool {
+	_, err := git.Run(ctx, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+	return err == nil
+}
+
+// logBackportSummary logs one line per result, identified by label.
+func logBackportSummary(log Logger, results []BackportResult, label func(BackportResult) string) {
+	log.Step("Backport summary")
+	for _, result := range results {
+		short := label(result)
+		if result.Err != nil {
+			log.Error("%s: %v", short, result.Err)
+			continue
+		}
+		if result.PRURL != "" {
+			log.Success(fmt.Sprintf("%s: %s (%s)", short, result.Branch, result.PRURL))
+		} else {
+			log.Success(fmt.Sprintf("%s: %s", short, result.Branch))
+		}
+	}
+}
+
+// backportCommitLabel identifies a batch result by its short commit SHA.
+func backportCommitLabel(result BackportResult) string {
+	if len(result.Commit) > backportShortSHALen {
+		return result.Commit[:backportShortSHALen]
+	}
+	return result.Commit
+}
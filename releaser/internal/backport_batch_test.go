@@ -0,0 +1,140 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"altinn.studio/releaser/internal"
+)
+
+const backportBatchChangelog = `# Changelog
+
+## [Unreleased]
+
+## [1.0.0] - 2025-01-01
+
+### Added
+
+- Initial release
+`
+
+func TestRunBackportBatchWithDeps_KeepGoing(t *testing.T) {
+	repo, commits := setupBackportBatchRepo(t)
+
+	results, err := runBackportBatch(t, repo, commits, true)
+	if !errors.Is(err, internal.ErrBackportsFailed) {
+		t.Fatalf("RunBackportBatchWithDeps() error = %v, want ErrBackportsFailed", err)
+	}
+	if len(results) != len(commits) {
+		t.Fatalf("results = %d, want every commit attempted (%d)", len(results), len(commits))
+	}
+
+	for i, wantOK := range []bool{true, false, true} {
+		result := results[i]
+		if result.Commit != commits[i] {
+			t.Errorf("results[%d].Commit = %s, want %s", i, result.Commit, commits[i])
+		}
+		if wantOK != (result.Err == nil) {
+			t.Errorf("results[%d].Err = %v, want success %v", i, result.Err, wantOK)
+		}
+	}
+	if results[0].PRURL == "" || !remoteBranchExists(t, repo, results[0].Branch) {
+		t.Errorf("results[0] = %+v, want pushed branch with PR", results[0])
+	}
+	if results[1].Branch != "" || strings.Contains(gitOut(t, repo, "branch", "--list", "backport/*"), commits[1][:8]) {
+		t.Errorf("failed backport left branch behind: %+v", results[1])
+	}
+	assertBackportStartRestored(t, repo)
+}
+
+func TestRunBackportBatchWithDeps_StopsAtFirstFailure(t *testing.T) {
+	repo, commits := setupBackportBatchRepo(t)
+
+	results, err := runBackportBatch(t, repo, commits, false)
+	if !errors.Is(err, internal.ErrBackportsFailed) {
+		t.Fatalf("RunBackportBatchWithDeps() error = %v, want ErrBackportsFailed", err)
+	}
+	if len(results) != 2 || results[0].Err != nil || results[1].Err == nil {
+		t.Fatalf("results = %+v, want success then failure and no further attempts", results)
+	}
+	assertBackportStartRestored(t, repo)
+}
+
+// setupBackportBatchRepo returns a repo with three commits on main: a clean backport,
+// one that conflicts with the release branch, and another clean backport.
+func setupBackportBatchRepo(t *testing.T) (string, []string) {
+	t.Helper()
+
+	repo := createStudioctlWorkflowRepo(t, backportBatchChangelog)
+	runGitCmd(t, repo, "checkout", "-b", "release/studioctl/v1.0")
+	writeRepoFile(t, repo, "README.md", "release branch\n")
+	runGitCmd(t, repo, "commit", "-am", "release readme")
+	runGitCmd(t, repo, "push", "-u", "origin", "release/studioctl/v1.0")
+	runGitCmd(t, repo, "checkout", "main")
+
+	var entries []string
+	commit := func(file, content, entry string) string {
+		writeRepoFile(t, repo, file, content)
+		entries = append([]string{"- " + entry}, entries...)
+		writeRepoFile(t, repo, "src/cli/CHANGELOG.md", strings.Replace(backportBatchChangelog,
+			"## [Unreleased]\n", "## [Unreleased]\n\n### Fixed\n\n"+strings.Join(entries, "\n")+"\n", 1))
+		runGitCmd(t, repo, "add", ".")
+		runGitCmd(t, repo, "commit", "-m", entry)
+		return revParseHead(t, repo)
+	}
+
+	return repo, []string{
+		commit("src/cli/a.txt", "a\n", "Fix A"),
+		commit("README.md", "main branch\n", "Fix B"),
+		commit("src/cli/c.txt", "c\n", "Fix C"),
+	}
+}
+
+func runBackportBatch(t *testing.T, repo string, commits []string, keepGoing bool) ([]internal.BackportResult, error) {
+	t.Helper()
+	t.Chdir(repo)
+
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+	results, err := internal.RunBackportBatchWithDeps(t.Context(), internal.BackportRequest{
+		Component: "studioctl",
+		Branch:    "v1.0",
+		Commits:   commits,
+		KeepGoing: keepGoing,
+	}, git, &fakeGH{}, internal.NopLogger{})
+	if err != nil {
+		return results, fmt.Errorf("run backport batch: %w", err)
+	}
+	return results, nil
+}
+
+func assertBackportStartRestored(t *testing.T, repo string) {
+	t.Helper()
+
+	if branch := gitOut(t, repo, "rev-parse", "--abbrev-ref", "HEAD"); branch != "main" {
+		t.Errorf("current branch = %s, want main", branch)
+	}
+	if status := gitOut(t, repo, "status", "--porcelain"); status != "" {
+		t.Errorf("working tree not clean after batch:\n%s", status)
+	}
+}
+
+func remoteBranchExists(t *testing.T, repo, branch string) bool {
+	t.Helper()
+	return gitOut(t, repo, "ls-remote", "--heads", "origin", branch) != ""
+}
+
+func gitOut(t *testing.T, repo string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.CommandContext(context.Background(), "git", args...)
+	cmd.Dir = repo
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git %s: %v", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,264 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"altinn.studio/releaser/internal/changelog"
+)
+
+// RunBackportLines backports req.Commit to each of req.Branches.
+func RunBackportLines(ctx context.Context, req BackportRequest, log Logger) ([]BackportResult, error) {
+	if log == nil {
+		log = NopLogger{}
+	}
+	git := NewGitCLI(WithLogger(log))
+	gh := NewThrottledGitHub(NewGitHubCLI(WithGHLogger(log)), WithThrottleLogger(log))
+	return RunBackportLinesWithDeps(ctx, req, git, gh, log)
+}
+
+// RunBackportLinesWithDeps backports req.Commit to every release line in req.Branches, each on
+// its own branch and PR. Each line is built in a temporary linked worktree, so the current
+// checkout is never switched and up to req.Parallel lines can run at once.
+// A failed line has its local backport branch removed unless it was already pushed.
+// Without KeepGoing no further lines are started after a failure; with it every line is attempted.
+// The returned error wraps ErrBackportsFailed and lists the failing release branches.
+func RunBackportLinesWithDeps(
+	ctx context.Context,
+	req BackportRequest,
+	git *GitCLI,
+	gh GitHubRunner,
+	log Logger,
+) ([]BackportResult, error) {
+	if log == nil {
+		log = NopLogger{}
+	}
+	if ctx == nil {
+		return nil, errContextRequired
+	}
+	if req.Component == "" {
+		return nil, errComponentRequired
+	}
+	if len(req.Branches) == 0 {
+		return nil, errBackportBranchRequired
+	}
+
+	comp, err := GetComponent(req.Component)
+	if err != nil {
+		return nil, fmt.Errorf("get component: %w", err)
+	}
+	configs := make([]*backportConfig, 0, len(req.Branches))
+	for _, branch := range req.Branches {
+		single := req
+		single.Branch = branch
+		cfg, err := parseBackportConfig(single, comp)
+		if err != nil {
+			return nil, err
+		}
+		configs = append(configs, cfg)
+	}
+	if !req.NoPush {
+		if err := ensureGitHubAuthenticated(ctx, gh, req.DryRun, log); err != nil {
+			return nil, err
+		}
+	}
+
+	clPath := req.ChangelogPath
+	if clPath == "" {
+		clPath = comp.ChangelogPath
+	}
+	repoRoot, err := git.RepoRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Step("Extracting changelog entries")
+	entries, commitMsg, err := extractEntriesFromCommit(ctx, git, req.Commit, clPath)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Found %d changelog entries", len(entries))
+	for _, cfg := range configs {
+		cfg.commitMsg = commitMsg
+	}
+
+	if req.DryRun {
+		results := make([]BackportResult, 0, len(configs))
+		for _, cfg := range configs {
+			logBackportState(log, cfg, repoRoot)
+			printBackportDryRun(log, cfg, entries)
+			results = append(results, BackportResult{
+				Err:           nil,
+				Commit:        cfg.commit,
+				ReleaseBranch: cfg.releaseBranch,
+				Branch:        cfg.backportBranch,
+				PRURL:         "",
+			})
+		}
+		return results, nil
+	}
+
+	results := runBackportLines(ctx, gh, log, repoRoot, clPath, configs, entries, req.Parallel, req.KeepGoing)
+	logBackportSummary(log, results, func(result BackportResult) string { return result.ReleaseBranch })
+
+	var failed []string
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result.ReleaseBranch)
+		}
+	}
+	if len(failed) > 0 {
+		return results, fmt.Errorf("%w: %s", ErrBackportsFailed, strings.Join(failed, ", "))
+	}
+	return results, nil
+}
+
+// runBackportLines backports configs with at most parallel lines in flight and returns the
+// results of the lines that were started, in config order.
+func runBackportLines(
+	ctx context.Context,
+	gh GitHubRunner,
+	log Logger,
+	repoRoot, clPath string,
+	configs []*backportConfig,
+	entries []changelog.Entry,
+	parallel int,
+	keepGoing bool,
+) []BackportResult {
+	parallel = max(parallel, 1)
+	logMu := &sync.Mutex{}
+	repoLock := &sync.RWMutex{}
+	results := make([]BackportResult, len(configs))
+	slots := make(chan struct{}, parallel)
+	var (
+		wg      sync.WaitGroup
+		stopped atomic.Bool
+	)
+
+	started := 0
+	for i, cfg := range configs {
+		// Wait for a free slot first, so the stop check sees every line that finished before it.
+		slots <- struct{}{}
+		if stopped.Load() {
+			<-slots
+			break
+		}
+		started++
+		wg.Go(func() {
+			defer func() { <-slots }()
+			lineLog := &lineLogger{next: log, mu: logMu, line: cfg.releaseBranch}
+			results[i] = backportLine(ctx, gh, lineLog, repoLock, repoRoot, clPath, cfg, entries)
+			if results[i].Err != nil && !keepGoing {
+				stopped.Store(true)
+			}
+		})
+	}
+	wg.Wait()
+	return results[:started]
+}
+
+// backportLine backports cfg in a temporary worktree created from the repository at repoRoot.
+// Its git commands coordinate through repoLock, since every line's worktree shares one .git.
+func backportLine(
+	ctx context.Context,
+	gh GitHubRunner,
+	log Logger,
+	repoLock *sync.RWMutex,
+	repoRoot, clPath string,
+	cfg *backportConfig,
+	entries []changelog.Entry,
+) BackportResult {
+	result := BackportResult{Err: nil, Commit: cfg.commit, ReleaseBranch: cfg.releaseBranch, Branch: "", PRURL: ""}
+	git := NewGitCLI(WithWorkdir(repoRoot), WithLogger(log), WithRepoLock(repoLock))
+
+	log.Step("Backporting to " + cfg.releaseBranch)
+	// Never take over (and later delete) a branch this run did not create.
+	if localBranchExists(ctx, git, cfg.backportBranch) {
+		result.Err = fmt.Errorf("%w: %s", errBackportBranchExists, cfg.backportBranch)
+		return result
+	}
+
+	worktree, err := os.MkdirTemp("", "releaser-backport-")
+	if err != nil {
+		result.Err = fmt.Errorf("create worktree directory: %w", err)
+		return result
+	}
+	defer os.RemoveAll(worktree) //nolint:errcheck // best-effort cleanup of a temp directory
+	if err := git.AddWorktree(ctx, worktree, "HEAD"); err != nil {
+		result.Err = fmt.Errorf("add worktree: %w", err)
+		return result
+	}
+
+	logBackportState(log, cfg, worktree)
+	result.Branch = cfg.backportBranch
+	worktreeGit := NewGitCLI(WithWorkdir(worktree), WithLogger(log), WithRepoLock(repoLock))
+	result.PRURL, result.Err = executeBackport(ctx, worktreeGit, gh, log, worktree, clPath, cfg, entries)
+
+	if err := git.RemoveWorktree(ctx, worktree); err != nil {
+		log.Error("Could not remove worktree %s: %v", worktree, err)
+	}
+	if result.Err == nil {
+		if !cfg.noPush {
+			logBackportPR(ctx, log, cfg.openPR, result.PRURL)
+		}
+		return result
+	}
+	if err := dropFailedBackportBranch(ctx, git, log, &result); err != nil {
+		log.Error("Could not clean up %s: %v", cfg.backportBranch, err)
+	}
+	return result
+}
+
+// lineLogger tags output with a release line and serializes writes, so concurrent
+// backports can share one Logger.
+type lineLogger struct {
+	next Logger
+	mu   *sync.Mutex
+	line string
+}
+
+func (l *lineLogger) Step(msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.next.Step(l.line + ": " + msg)
+}
+
+func (l *lineLogger) Info(msg string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(args) > 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+	l.next.Info("%s: %s", l.line, msg)
+}
+
+func (l *lineLogger) Command(cmd string, args []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.next.Command(cmd, append([]string{"(" + l.line + ")"}, args...))
+}
+
+func (l *lineLogger) Success(msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.next.Success(l.line + ": " + msg)
+}
+
+func (l *lineLogger) Error(msg string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(args) > 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+	l.next.Error("%s: %s", l.line, msg)
+}
+
+func (l *lineLogger) Detail(key, value string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.next.Detail(l.line+": "+key, value)
+}
@@ -0,0 +1,160 @@
+package internal_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"altinn.studio/releaser/internal"
+)
+
+func TestRunBackportLinesWithDeps_Parallel(t *testing.T) {
+	repo, commit := setupBackportLinesRepo(t)
+
+	gh := &recordingGH{}
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+	results, err := internal.RunBackportLinesWithDeps(t.Context(), internal.BackportRequest{
+		Component: "studioctl",
+		Commit:    commit,
+		Branches:  []string{"v1.0", "v1.1"},
+		Parallel:  2,
+	}, git, gh, internal.NopLogger{})
+	if err != nil {
+		t.Fatalf("RunBackportLinesWithDeps() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("results = %+v, want one per line", results)
+	}
+
+	for i, line := range []string{"v1.0", "v1.1"} {
+		result := results[i]
+		if result.Err != nil || result.ReleaseBranch != "release/studioctl/"+line {
+			t.Errorf("results[%d] = %+v, want success on release/studioctl/%s", i, result, line)
+			continue
+		}
+		if !remoteBranchExists(t, repo, result.Branch) {
+			t.Errorf("%s was not pushed", result.Branch)
+		}
+		if log := gitOut(t, repo, "log", "--format=%s", "origin/"+result.ReleaseBranch+".."+result.Branch); log !=
+			"Backport "+commit[:8]+": Fix on every line" {
+			t.Errorf("%s commits = %q, want only the backport", result.Branch, log)
+		}
+		cl := gitOut(t, repo, "show", result.Branch+":src/cli/CHANGELOG.md")
+		if !strings.Contains(cl, "- Fix on every line") {
+			t.Errorf("%s changelog missing entry:\n%s", result.Branch, cl)
+		}
+	}
+	if results[0].Branch == results[1].Branch {
+		t.Errorf("both lines used branch %s", results[0].Branch)
+	}
+
+	prs := gh.pullRequests()
+	slices.SortFunc(prs, func(a, b internal.PullRequestOptions) int { return strings.Compare(a.Base, b.Base) })
+	if len(prs) != 2 || prs[0].Base != "release/studioctl/v1.0" || prs[1].Base != "release/studioctl/v1.1" {
+		t.Fatalf("PRs = %+v, want one per release branch", prs)
+	}
+	for i, pr := range prs {
+		if pr.Head != results[i].Branch {
+			t.Errorf("PR into %s has head %q, want %q", pr.Base, pr.Head, results[i].Branch)
+		}
+	}
+
+	if branch := gitOut(t, repo, "rev-parse", "--abbrev-ref", "HEAD"); branch != "main" {
+		t.Errorf("current branch = %s, want main", branch)
+	}
+	if status := gitOut(t, repo, "status", "--porcelain"); status != "?? notes.txt" {
+		t.Errorf("working tree status = %q, want only the untouched notes.txt", status)
+	}
+	if worktrees := gitOut(t, repo, "worktree", "list", "--porcelain"); strings.Count(worktrees, "worktree ") != 1 {
+		t.Errorf("temporary worktrees left behind:\n%s", worktrees)
+	}
+}
+
+// TestRunBackportLinesWithDeps_PushesOverlap checks that parallel lines push at the same
+// time: origin accepts a push only once the other line's push has arrived as well.
+func TestRunBackportLinesWithDeps_PushesOverlap(t *testing.T) {
+	repo, commit := setupBackportLinesRepo(t)
+	arrived := t.TempDir()
+	hook := fmt.Sprintf(pushBarrierHook, arrived)
+	hookPath := filepath.Join(gitOut(t, repo, "remote", "get-url", "origin"), "hooks", "pre-receive")
+	if err := os.WriteFile(hookPath, []byte(hook), 0o755); err != nil {
+		t.Fatalf("write pre-receive hook: %v", err)
+	}
+
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+	results, err := internal.RunBackportLinesWithDeps(t.Context(), internal.BackportRequest{
+		Component: "studioctl",
+		Commit:    commit,
+		Branches:  []string{"v1.0", "v1.1"},
+		Parallel:  2,
+	}, git, &recordingGH{}, internal.NopLogger{})
+	if err != nil {
+		t.Fatalf("RunBackportLinesWithDeps() error = %v, want both pushes accepted together: %+v", err, results)
+	}
+	for _, result := range results {
+		if upstream := gitOut(t, repo, "rev-parse", "--abbrev-ref", result.Branch+"@{upstream}"); upstream !=
+			"origin/"+result.Branch {
+			t.Errorf("%s upstream = %q, want origin/%s", result.Branch, upstream, result.Branch)
+		}
+	}
+}
+
+// pushBarrierHook is a pre-receive hook that waits up to 10s for a second push to arrive
+// in the directory given as its format argument.
+const pushBarrierHook = `#!/bin/sh
+touch "%[1]s/$$"
+for i in $(seq 100); do
+	[ "$(ls "%[1]s" | wc -l)" -ge 2 ] && exit 0
+	sleep 0.1
+done
+echo "pushes did not overlap" >&2
+exit 1
+`
+
+// setupBackportLinesRepo creates a repository with release lines v1.0 and v1.1 on origin and a
+// fix on main to backport to both, changes into it and returns it with the fix commit.
+func setupBackportLinesRepo(t *testing.T) (string, string) {
+	t.Helper()
+	repo := createStudioctlWorkflowRepo(t, backportBatchChangelog)
+	for _, line := range []string{"v1.0", "v1.1"} {
+		runGitCmd(t, repo, "checkout", "-b", "release/studioctl/"+line, "main")
+		runGitCmd(t, repo, "push", "-u", "origin", "release/studioctl/"+line)
+	}
+	runGitCmd(t, repo, "checkout", "main")
+	writeRepoFile(t, repo, "src/cli/fix.txt", "fix\n")
+	writeRepoFile(t, repo, "src/cli/CHANGELOG.md", strings.Replace(backportBatchChangelog,
+		"## [Unreleased]\n", "## [Unreleased]\n\n### Fixed\n\n- Fix on every line\n", 1))
+	runGitCmd(t, repo, "add", ".")
+	runGitCmd(t, repo, "commit", "-m", "Fix on every line")
+	commit := revParseHead(t, repo)
+	// Lines are built in worktrees, so work in progress in the checkout is left alone.
+	writeRepoFile(t, repo, "notes.txt", "work in progress\n")
+	t.Chdir(repo)
+	return repo, commit
+}
+
+// recordingGH records created pull requests and is safe for concurrent use.
+type recordingGH struct {
+	prs []internal.PullRequestOptions
+	fakeGH
+
+	mu sync.Mutex
+}
+
+func (g *recordingGH) CreatePR(_ context.Context, opts internal.PullRequestOptions) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.prs = append(g.prs, opts)
+	return "https://example.test/pr/" + opts.Head, nil
+}
+
+func (g *recordingGH) pullRequests() []internal.PullRequestOptions {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return slices.Clone(g.prs)
+}
@@ -1,12 +1,9 @@
 package internal
 
 import (
-	"archive/tar"
-	"compress/gzip"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
-	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -17,81 +14,84 @@ import (
 	"altinn.studio/releaser/internal/version"
 )
 
-// ErrTarballMissingPath indicates a required path is missing from the tarball.
-var ErrTarballMissingPath = errors.New("required path not found in tarball")
-
-// StudioctlBuilder builds studioctl release artifacts.
-// It implements ComponentBuilder and wraps the detailed build steps.
-type StudioctlBuilder struct {
-	log            Logger
-	Pkg            string
-	LdflagsPattern string
-	LocaltestDir   string
-	InstallScripts []string
+// AssetContext describes the release being built to a GoBuilder asset step.
+type AssetContext struct {
+	Log       Logger
+	Version   *version.Version
+	Root      string // repository root
+	OutputDir string // directory the step writes its assets to
+	Tag       string // release tag (e.g., "studioctl/v1.2.3")
 }
 
-const installScriptDefaultVersionPlaceholder = "__STUDIOCTL_DEFAULT_VERSION__"
+// AssetStep adds extra release assets to AssetContext.OutputDir. Steps run in order
+// before the binaries are built, so a broken asset fails the build early.
+type AssetStep func(ctx context.Context, ac AssetContext) error
 
-// NewStudioctlBuilder creates a builder configured for studioctl.
-func NewStudioctlBuilder() *StudioctlBuilder {
-	return &StudioctlBuilder{
+// GoBuilder builds cross-platform release binaries for a Go module, runs optional
+// extra asset steps and writes SHA256SUMS. It implements ComponentBuilder.
+type GoBuilder struct {
+	log            Logger
+	Name           string            // binary name prefix and tag component (e.g., "studioctl")
+	Dir            string            // Go module directory relative to the repo root (e.g., "src/cli")
+	Pkg            string            // package to build, relative to Dir (e.g., "./cmd/studioctl")
+	LdflagsPattern string            // ldflags with one %s for the version; empty for none
+	RepoRoot       string            // optional: defaults to the git repository root
+	platforms      []releasePlatform // nil builds getReleasePlatforms()
+	ExtraAssets    []AssetStep
+}
+
+// NewGoBuilder creates a builder for the Go package pkg in the module at dir.
+func NewGoBuilder(name, dir, pkg, ldflagsPattern string, extraAssets ...AssetStep) *GoBuilder {
+	return &GoBuilder{
 		log:            NopLogger{},
-		Pkg:            "./cmd/studioctl",
-		LdflagsPattern: "-X altinn.studio/studioctl/internal/cmd.version=%s",
-		InstallScripts: []string{
-			"src/cli/cmd/studioctl/install.sh",
-			"src/cli/cmd/studioctl/install.ps1",
-		},
-		LocaltestDir: "src/Runtime/localtest",
+		Name:           name,
+		Dir:            dir,
+		Pkg:            pkg,
+		LdflagsPattern: ldflagsPattern,
+		RepoRoot:       "",
+		platforms:      nil,
+		ExtraAssets:    extraAssets,
 	}
 }
 
-// Build produces all release artifacts for studioctl.
+// Build produces all release artifacts for the component.
 // Returns the list of artifact paths relative to outputDir.
-func (b *StudioctlBuilder) Build(ctx context.Context, ver *version.Version, outputDir string) ([]string, error) {
+func (b *GoBuilder) Build(ctx context.Context, ver *version.Version, outputDir string) ([]string, error) {
 	if b.log == nil {
 		b.log = NopLogger{}
 	}
 
-	git := NewGitCLI()
-	root, err := git.RepoRoot(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	buildDir := filepath.Join(root, "src/cli")
-	resourcesTarball := filepath.Join(root, "build", "localtest-resources.tar.gz")
-	localtestDir := filepath.Join(root, b.LocaltestDir)
-	installScripts := make([]string, len(b.InstallScripts))
-	for i, script := range b.InstallScripts {
-		installScripts[i] = filepath.Join(root, script)
+	root := b.RepoRoot
+	if root == "" {
+		var err error
+		root, err = NewGitCLI().RepoRoot(ctx)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if err := EnsureDir(outputDir); err != nil {
 		return nil, fmt.Errorf("create output directory: %w", err)
 	}
 
-	b.log.Info("Building localtest resources...")
-	if err := b.buildResources(ctx, resourcesTarball, localtestDir); err != nil {
-		return nil, fmt.Errorf("build resources: %w", err)
+	ac := AssetContext{
+		Log:       b.log,
+		Version:   ver,
+		Root:      root,
+		OutputDir: outputDir,
+		Tag:       b.Name + "/" + ver.String(),
 	}
-
-	b.log.Info("Validating tarball contents...")
-	if err := b.validateTarball(ctx, resourcesTarball); err != nil {
-		return nil, fmt.Errorf("validate tarball: %w", err)
+	for _, step := range b.ExtraAssets {
+		if err := step(ctx, ac); err != nil {
+			return nil, err
+		}
 	}
 
 	b.log.Info("Building release binaries for all platforms...")
-	if err := b.buildBinaries(ctx, ver.String(), outputDir, buildDir, b.Pkg); err != nil {
+	if err := b.buildBinaries(ctx, ver.String(), outputDir, filepath.Join(root, b.Dir)); err != nil {
 		return nil, fmt.Errorf("build binaries: %w", err)
 	}
 
-	b.log.Info("Copying additional assets...")
-	releaseTag := "studioctl/" + ver.String()
-	if err := b.copyAssets(ctx, outputDir, resourcesTarball, installScripts, releaseTag); err != nil {
-		return nil, fmt.Errorf("copy assets: %w", err)
-	}
-
 	b.log.Info("Generating checksums...")
 	if err := b.generateChecksums(ctx, outputDir); err != nil {
 		return nil, fmt.Errorf("generate checksums: %w", err)
@@ -101,40 +101,22 @@ func (b *StudioctlBuilder) Build(ctx context.Context, ver *version.Version, outp
 }
 
 // SetLogger sets the logger for build output.
-func (b *StudioctlBuilder) SetLogger(log Logger) {
+func (b *GoBuilder) SetLogger(log Logger) {
 	b.log = log
 }
 
-func (b *StudioctlBuilder) buildResources(_ context.Context, destPath, localtestDir string) error {
-	if err := EnsureDir(filepath.Dir(destPath)); err != nil {
-		return err
-	}
-	return CreateTarGz(destPath, localtestDir, "testdata", "infra")
-}
-
-func (b *StudioctlBuilder) validateTarball(_ context.Context, tarballPath string) error {
-	requiredPaths := []string{"testdata/", "infra/"}
-
-	foundPaths, err := scanTarballPaths(tarballPath, requiredPaths)
-	if err != nil {
-		return err
+func (b *GoBuilder) buildBinaries(ctx context.Context, ver, outputDir, buildDir string) error {
+	ldflags := ""
+	if b.LdflagsPattern != "" {
+		ldflags = fmt.Sprintf(b.LdflagsPattern, ver)
 	}
 
-	for _, required := range requiredPaths {
-		if !foundPaths[required] {
-			return fmt.Errorf("%w: %s", ErrTarballMissingPath, required)
-		}
+	platforms := b.platforms
+	if platforms == nil {
+		platforms = getReleasePlatforms()
 	}
-
-	b.log.Info("Tarball validation passed")
-	return nil
-}
-
-func (b *StudioctlBuilder) buildBinaries(ctx context.Context, ver, outputDir, buildDir, pkgPath string) error {
-	ldflags := fmt.Sprintf(b.LdflagsPattern, ver)
-
-	for _, p := range getReleasePlatforms() {
-		binaryName := fmt.Sprintf("studioctl-%s-%s", p.OS, p.Arch)
+	for _, p := range platforms {
+		binaryName := fmt.Sprintf("%s-%s-%s", b.Name, p.OS, p.Arch)
 		if p.OS == osWindows {
 			binaryName += ".exe"
 		}
@@ -144,7 +126,7 @@ func (b *StudioctlBuilder) buildBinaries(ctx context.Context, ver, outputDir, bu
 		err := GoBuildWithOptions(ctx, BuildOptions{
 			Output:  outputPath,
 			Ldflags: ldflags,
-			Pkg:     pkgPath,
+			Pkg:     b.Pkg,
 			Dir:     buildDir,
 			GOOS:    p.OS,
 			GOARCH:  p.Arch,
@@ -158,51 +140,7 @@ func (b *StudioctlBuilder) buildBinaries(ctx context.Context, ver, outputDir, bu
 	return nil
 }
 
-func (b *StudioctlBuilder) copyAssets(
-	_ context.Context,
-	outputDir, resourcesTarball string,
-	installScripts []string,
-	releaseTag string,
-) error {
-	resourcesDest := filepath.Join(outputDir, "localtest-resources.tar.gz")
-	if err := CopyFile(resourcesTarball, resourcesDest); err != nil {
-		return fmt.Errorf("copy %s: %w", resourcesTarball, err)
-	}
-	b.log.Info("Copied %s", filepath.Base(resourcesDest))
-
-	for _, script := range installScripts {
-		dest := filepath.Join(outputDir, filepath.Base(script))
-		if err := copyInstallScript(script, dest, releaseTag); err != nil {
-			return fmt.Errorf("copy install script %s: %w", script, err)
-		}
-		b.log.Info("Copied %s", filepath.Base(dest))
-	}
-
-	return nil
-}
-
-func copyInstallScript(src, dst, releaseTag string) error {
-	content, err := os.ReadFile(src) //nolint:gosec // G304: src path is from trusted dev tooling input
-	if err != nil {
-		return fmt.Errorf("read source file: %w", err)
-	}
-	info, err := os.Stat(src)
-	if err != nil {
-		return fmt.Errorf("stat source file: %w", err)
-	}
-	if err := EnsureDir(filepath.Dir(dst)); err != nil {
-		return fmt.Errorf("create destination directory: %w", err)
-	}
-
-	// Replace only the assignment placeholder and keep the fallback marker literal.
-	stamped := strings.Replace(string(content), installScriptDefaultVersionPlaceholder, releaseTag, 1)
-	if err := os.WriteFile(dst, []byte(stamped), info.Mode().Perm()); err != nil {
-		return fmt.Errorf("write destination file: %w", err)
-	}
-	return nil
-}
-
-func (b *StudioctlBuilder) generateChecksums(ctx context.Context, outputDir string) error {
+func (b *GoBuilder) generateChecksums(ctx context.Context, outputDir string) error {
 	entries, err := os.ReadDir(outputDir)
 	if err != nil {
 		return fmt.Errorf("read output dir: %w", err)
@@ -218,7 +156,7 @@ func (b *StudioctlBuilder) generateChecksums(ctx context.Context, outputDir stri
 			continue
 		}
 		name := entry.Name()
-		if name == "SHA256SUMS" || name == "release-notes.md" {
+		if name == "SHA256SUMS" || name == "release-notes.md" || name == outputDirMarkerFile {
 			continue
 		}
 
@@ -241,7 +179,7 @@ func (b *StudioctlBuilder) generateChecksums(ctx context.Context, outputDir stri
 	return nil
 }
 
-func (b *StudioctlBuilder) collectArtifacts(outputDir string) ([]string, error) {
+func (b *GoBuilder) collectArtifacts(outputDir string) ([]string, error) {
 	entries, err := filepath.Glob(filepath.Join(outputDir, "*"))
 	if err != nil {
 		return nil, fmt.Errorf("glob artifacts: %w", err)
@@ -249,7 +187,7 @@ func (b *StudioctlBuilder) collectArtifacts(outputDir string) ([]string, error)
 
 	var artifacts []string
 	for _, entry := range entries {
-		if filepath.Base(entry) == releaseNotesFile {
+		if name := filepath.Base(entry); name == releaseNotesFile || name == outputDirMarkerFile {
 			continue
 		}
 		artifacts = append(artifacts, entry)
@@ -258,43 +196,6 @@ func (b *StudioctlBuilder) collectArtifacts(outputDir string) ([]string, error)
 	return artifacts, nil
 }
 
-// scanTarballPaths scans a tarball and returns which of the required paths were found.
-func scanTarballPaths(tarballPath string, requiredPaths []string) (map[string]bool, error) {
-	//nolint:gosec // G304: tarballPath is from trusted dev tooling input
-	f, err := os.Open(tarballPath)
-	if err != nil {
-		return nil, fmt.Errorf("open tarball: %w", err)
-	}
-	defer f.Close() //nolint:errcheck // best-effort close on read-only file
-
-	gzr, err := gzip.NewReader(f)
-	if err != nil {
-		return nil, fmt.Errorf("create gzip reader: %w", err)
-	}
-	defer gzr.Close() //nolint:errcheck // best-effort close on read-only stream
-
-	foundPaths := make(map[string]bool)
-	tr := tar.NewReader(gzr)
-
-	for {
-		header, readErr := tr.Next()
-		if errors.Is(readErr, io.EOF) {
-			break
-		}
-		if readErr != nil {
-			return nil, fmt.Errorf("read tarball: %w", readErr)
-		}
-
-		for _, required := range requiredPaths {
-			if strings.HasPrefix(header.Name, required) {
-				foundPaths[required] = true
-			}
-		}
-	}
-
-	return foundPaths, nil
-}
-
 // fileChecksum calculates SHA256 checksum of a file.
 func fileChecksum(path string) (sum string, err error) {
 	//nolint:gosec // G304: path is from trusted dev tooling input
@@ -333,12 +234,3 @@ func getReleasePlatforms() []releasePlatform {
 		{osWindows, "arm64"},
 	}
 }
-
-// init registers the StudioctlBuilder with the studioctl component.
-//
-//nolint:gochecknoinits // registration pattern for component builders
-func init() {
-	if c, err := GetComponent("studioctl"); err == nil {
-		c.Builder = NewStudioctlBuilder()
-	}
-}
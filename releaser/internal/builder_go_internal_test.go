@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"altinn.studio/releaser/internal/version"
+)
+
+func TestGoBuilder_BuildsNonStudioctlModule(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	pkgDir := filepath.Join(root, "src", "tool", "cmd", "tool")
+	if err := os.MkdirAll(pkgDir, 0o755); err != nil {
+		t.Fatalf("mkdir package dir: %v", err)
+	}
+	goMod := "module example.com/tool\n\ngo 1.22\n"
+	if err := os.WriteFile(filepath.Join(root, "src", "tool", "go.mod"), []byte(goMod), 0o644); err != nil {
+		t.Fatalf("write go.mod: %v", err)
+	}
+	mainGo := "package main\n\nvar version = \"dev\"\n\nfunc main() { println(version) }\n"
+	if err := os.WriteFile(filepath.Join(pkgDir, "main.go"), []byte(mainGo), 0o644); err != nil {
+		t.Fatalf("write main.go: %v", err)
+	}
+
+	var stepTag string
+	builder := NewGoBuilder("tool", "src/tool", "./cmd/tool", "-X main.version=%s",
+		func(_ context.Context, ac AssetContext) error {
+			stepTag = ac.Tag
+			return os.WriteFile(filepath.Join(ac.OutputDir, "extra.txt"), []byte("extra\n"), 0o644)
+		},
+	)
+	builder.RepoRoot = root
+	builder.platforms = []releasePlatform{{OS: runtime.GOOS, Arch: runtime.GOARCH}}
+
+	ver, err := version.Parse("v0.3.0")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	outputDir := filepath.Join(root, "out")
+	artifacts, err := builder.Build(t.Context(), ver, outputDir)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	binaryName := "tool-" + runtime.GOOS + "-" + runtime.GOARCH
+	if runtime.GOOS == osWindows {
+		binaryName += ".exe"
+	}
+	var names []string
+	for _, artifact := range artifacts {
+		names = append(names, filepath.Base(artifact))
+	}
+	slices.Sort(names)
+	want := []string{"SHA256SUMS", "extra.txt", binaryName}
+	slices.Sort(want)
+	if !slices.Equal(names, want) {
+		t.Fatalf("Build() artifacts = %v, want %v", names, want)
+	}
+	if stepTag != "tool/v0.3.0" {
+		t.Errorf("asset step tag = %q, want tool/v0.3.0", stepTag)
+	}
+
+	sums, err := os.ReadFile(filepath.Join(outputDir, "SHA256SUMS"))
+	if err != nil {
+		t.Fatalf("read SHA256SUMS: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(sums)), "\n"); len(lines) != 2 {
+		t.Errorf("SHA256SUMS has %d entries, want binary and extra asset:\n%s", len(lines), sums)
+	}
+
+	out, err := exec.CommandContext(t.Context(), filepath.Join(outputDir, binaryName)).CombinedOutput()
+	if err != nil {
+		t.Fatalf("run built binary: %v", err)
+	}
+	if got := strings.TrimSpace(string(out)); got != "v0.3.0" {
+		t.Errorf("built binary version = %q, want v0.3.0 from ldflags", got)
+	}
+}
+
+func TestNewStudioctlBuilder(t *testing.T) {
+	t.Parallel()
+
+	b := NewStudioctlBuilder()
+	if b.Name != "studioctl" || b.Dir != "src/cli" || b.Pkg != "./cmd/studioctl" {
+		t.Errorf("NewStudioctlBuilder() = %s %s %s, want studioctl src/cli ./cmd/studioctl", b.Name, b.Dir, b.Pkg)
+	}
+	if len(b.ExtraAssets) != 2 {
+		t.Errorf("NewStudioctlBuilder() has %d asset steps, want localtest resources and install scripts", len(b.ExtraAssets))
+	}
+}
@@ -0,0 +1,219 @@
+package internal
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"altinn.studio/releaser/internal/perm"
+)
+
+// ErrTarballMissingPath indicates a required path is missing from the tarball.
+var ErrTarballMissingPath = errors.New("required path not found in tarball")
+
+const installScriptDefaultVersionPlaceholder = "__STUDIOCTL_DEFAULT_VERSION__"
+
+// NewStudioctlBuilder creates a GoBuilder configured for studioctl: the CLI binaries,
+// the localtest resources tarball and the version-stamped install scripts.
+func NewStudioctlBuilder() *GoBuilder {
+	return NewGoBuilder(
+		"studioctl",
+		"src/cli",
+		"./cmd/studioctl",
+		"-X altinn.studio/studioctl/internal/cmd.version=%s",
+		localtestResourcesStep("src/Runtime/localtest"),
+		installScriptsStep("src/cli/cmd/studioctl/install.sh", "src/cli/cmd/studioctl/install.ps1"),
+	)
+}
+
+// localtestResourcesStep packs the localtest testdata and infra directories into
+// localtest-resources.tar.gz, checks both are present and adds it to the release together
+// with localtest-resources.manifest, which lets studioctl diff resources without the archive.
+func localtestResourcesStep(localtestDir string) AssetStep {
+	return func(_ context.Context, ac AssetContext) error {
+		resourcesTarball := filepath.Join(ac.Root, "build", "localtest-resources.tar.gz")
+
+		ac.Log.Info("Building localtest resources...")
+		if err := EnsureDir(filepath.Dir(resourcesTarball)); err != nil {
+			return fmt.Errorf("build resources: %w", err)
+		}
+		if err := CreateTarGz(resourcesTarball, filepath.Join(ac.Root, localtestDir), "testdata", "infra"); err != nil {
+			return fmt.Errorf("build resources: %w", err)
+		}
+
+		ac.Log.Info("Validating tarball contents...")
+		if err := validateTarball(resourcesTarball, "testdata/", "infra/"); err != nil {
+			return fmt.Errorf("validate tarball: %w", err)
+		}
+		ac.Log.Info("Tarball validation passed")
+
+		resourcesDest := filepath.Join(ac.OutputDir, filepath.Base(resourcesTarball))
+		if err := CopyFile(resourcesTarball, resourcesDest); err != nil {
+			return fmt.Errorf("copy assets: copy %s: %w", resourcesTarball, err)
+		}
+		ac.Log.Info("Copied %s", filepath.Base(resourcesDest))
+
+		manifestDest := filepath.Join(ac.OutputDir, "localtest-resources.manifest")
+		if err := writeTarballManifest(resourcesTarball, manifestDest); err != nil {
+			return fmt.Errorf("write resources manifest: %w", err)
+		}
+		ac.Log.Info("Wrote %s", filepath.Base(manifestDest))
+		return nil
+	}
+}
+
+// installScriptsStep copies the install scripts with their default version set to the release tag.
+func installScriptsStep(scripts ...string) AssetStep {
+	return func(_ context.Context, ac AssetContext) error {
+		for _, script := range scripts {
+			src := filepath.Join(ac.Root, script)
+			dest := filepath.Join(ac.OutputDir, filepath.Base(script))
+			if err := copyInstallScript(src, dest, ac.Tag); err != nil {
+				return fmt.Errorf("copy assets: copy install script %s: %w", src, err)
+			}
+			ac.Log.Info("Copied %s", filepath.Base(dest))
+		}
+		return nil
+	}
+}
+
+func validateTarball(tarballPath string, requiredPaths ...string) error {
+	foundPaths, err := scanTarballPaths(tarballPath, requiredPaths)
+	if err != nil {
+		return err
+	}
+
+	for _, required := range requiredPaths {
+		if !foundPaths[required] {
+			return fmt.Errorf("%w: %s", ErrTarballMissingPath, required)
+		}
+	}
+	return nil
+}
+
+func copyInstallScript(src, dst, releaseTag string) error {
+	content, err := os.ReadFile(src) //nolint:gosec // G304: src path is from trusted dev tooling input
+	if err != nil {
+		return fmt.Errorf("read source file: %w", err)
+	}
+	info, err := os.Stat(src)
+	if err != nil {
+		return fmt.Errorf("stat source file: %w", err)
+	}
+	if err := EnsureDir(filepath.Dir(dst)); err != nil {
+		return fmt.Errorf("create destination directory: %w", err)
+	}
+
+	// Replace only the assignment placeholder and keep the fallback marker literal.
+	stamped := strings.Replace(string(content), installScriptDefaultVersionPlaceholder, releaseTag, 1)
+	if err := os.WriteFile(dst, []byte(stamped), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("write destination file: %w", err)
+	}
+	return nil
+}
+
+// scanTarballPaths scans a tarball and returns which of the required paths were found.
+func scanTarballPaths(tarballPath string, requiredPaths []string) (map[string]bool, error) {
+	//nolint:gosec // G304: tarballPath is from trusted dev tooling input
+	f, err := os.Open(tarballPath)
+	if err != nil {
+		return nil, fmt.Errorf("open tarball: %w", err)
+	}
+	defer f.Close() //nolint:errcheck // best-effort close on read-only file
+
+	gzr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("create gzip reader: %w", err)
+	}
+	defer gzr.Close() //nolint:errcheck // best-effort close on read-only stream
+
+	foundPaths := make(map[string]bool)
+	tr := tar.NewReader(gzr)
+
+	for {
+		header, readErr := tr.Next()
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return nil, fmt.Errorf("read tarball: %w", readErr)
+		}
+
+		for _, required := range requiredPaths {
+			if strings.HasPrefix(header.Name, required) {
+				foundPaths[required] = true
+			}
+		}
+	}
+
+	return foundPaths, nil
+}
+
+// writeTarballManifest writes the SHA-256 of every regular file in the tarball to dest as
+// sorted "<sha256>  <path>" lines, the manifest format studioctl keeps for installed resources.
+func writeTarballManifest(tarballPath, dest string) error {
+	//nolint:gosec // G304: tarballPath is from trusted dev tooling input
+	f, err := os.Open(tarballPath)
+	if err != nil {
+		return fmt.Errorf("open tarball: %w", err)
+	}
+	defer f.Close() //nolint:errcheck // best-effort close on read-only file
+
+	gzr, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("create gzip reader: %w", err)
+	}
+	defer gzr.Close() //nolint:errcheck // best-effort close on read-only stream
+
+	sums := make(map[string]string)
+	tr := tar.NewReader(gzr)
+	for {
+		header, readErr := tr.Next()
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return fmt.Errorf("read tarball: %w", readErr)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := filepath.ToSlash(filepath.Clean(header.Name))
+		if strings.HasPrefix(name, "..") || filepath.IsAbs(name) {
+			continue
+		}
+		h := sha256.New()
+		if _, err := io.Copy(h, tr); err != nil {
+			return fmt.Errorf("hash %s: %w", header.Name, err)
+		}
+		sums[name] = hex.EncodeToString(h.Sum(nil))
+	}
+
+	var b strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(sums)) {
+		b.WriteString(sums[name] + "  " + name + "\n")
+	}
+	if err := os.WriteFile(dest, []byte(b.String()), perm.FilePermDefault); err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+	return nil
+}
+
+// init registers the studioctl builder with the studioctl component.
+//
+//nolint:gochecknoinits // registration pattern for component builders
+func init() {
+	if c, err := GetComponent("studioctl"); err == nil {
+		c.Builder = NewStudioctlBuilder()
+	}
+}
@@ -3,8 +3,11 @@ package changelog
 
 import (
 	"bufio"
+	"cmp"
 	"errors"
 	"fmt"
+	"maps"
+	"path"
 	"regexp"
 	"slices"
 	"strconv"
@@ -16,22 +19,28 @@ import (
 
 // Common errors returned by changelog operations.
 var (
-	ErrNoUnreleased       = errors.New("no [Unreleased] section found")
-	ErrUnreleasedEmpty    = errors.New("[Unreleased] section is empty")
-	ErrUnreleasedNoHeader = errors.New("[Unreleased] section missing category header (### Added, ### Fixed, etc.)")
-	ErrUnreleasedNoEntry  = errors.New("[Unreleased] section missing list entry (- item)")
-	ErrVersionNotFound    = errors.New("version not found in changelog")
-	ErrInvalidVersion     = errors.New("invalid version format")
-	ErrVersionExists      = errors.New("version already exists in changelog")
-	ErrNoChangelogInDiff  = errors.New("no CHANGELOG.md changes found in diff")
-	ErrNoEntriesInDiff    = errors.New("no changelog entries found in diff")
-	ErrInvalidCategory    = errors.New("invalid changelog category")
-	ErrCategoryOrder      = errors.New("categories not in standard order")
-	ErrDuplicateVersion   = errors.New("duplicate released version in changelog")
-	ErrVersionOrder       = errors.New("released versions are not in descending semver order")
-	ErrPrereleaseConflict = errors.New("multiple active prerelease release-lines in changelog")
-	ErrNoReleasedVersions = errors.New("no released versions found in changelog")
-	ErrNoMatchingVersion  = errors.New("no matching released version found in changelog")
+	ErrNoUnreleased            = errors.New("no [Unreleased] section found")
+	ErrUnreleasedEmpty         = errors.New("[Unreleased] section is empty")
+	ErrUnreleasedNoHeader      = errors.New("[Unreleased] section missing category header (### Added, ### Fixed, etc.)")
+	ErrUnreleasedNoEntry       = errors.New("[Unreleased] section missing list entry (- item)")
+	ErrVersionNotFound         = errors.New("version not found in changelog")
+	ErrInvalidVersion          = errors.New("invalid version format")
+	ErrVersionExists           = errors.New("version already exists in changelog")
+	ErrNoChangelogInDiff       = errors.New("no CHANGELOG.md changes found in diff")
+	ErrNoEntriesInDiff         = errors.New("no changelog entries found in diff")
+	ErrInvalidCategory         = errors.New("invalid changelog category")
+	ErrCategoryOrder           = errors.New("categories not in standard order")
+	ErrDuplicateVersion        = errors.New("duplicate released version in changelog")
+	ErrVersionOrder            = errors.New("released versions are not in descending semver order")
+	ErrPrereleaseConflict      = errors.New("multiple active prerelease release-lines in changelog")
+	ErrNoReleasedVersions      = errors.New("no released versions found in changelog")
+	ErrNoMatchingVersion       = errors.New("no matching released version found in changelog")
+	ErrNoPrerelease            = errors.New("no prerelease found to promote")
+	ErrEntryInReleased         = errors.New("changelog entry added under a released version section")
+	ErrInvalidNotesStyle       = errors.New("invalid release notes style")
+	ErrNoPreambleTitle         = errors.New("changelog must start with a \"# \" title before the first section")
+	ErrInvalidPrereleaseFilter = errors.New("invalid prerelease entry filter")
+	ErrInvalidFormat           = errors.New("invalid changelog format")
 )
 
 // Section represents a version section in the changelog.
@@ -43,7 +52,7 @@ type Section struct {
 
 // Category represents a category header (### Added, ### Fixed, etc.).
 type Category struct {
-	Name    string   // Added, Changed, Fixed, Removed, Security, Deprecated
+	Name    string   // Migration, Breaking, Added, Changed, Fixed, Removed, Security, Deprecated
 	Entries []string // entry text without leading "- "
 }
 
@@ -53,24 +62,35 @@ type Entry struct {
 	Text     string
 }
 
+// ReleasedEntry is an entry a diff added under an existing released version section.
+type ReleasedEntry struct {
+	Version string
+	Entry
+}
+
 // Changelog represents a parsed Keep a Changelog format document.
 type Changelog struct {
 	Preamble     string     // content before first section (title, description)
 	Unreleased   *Section   // [Unreleased] section, nil if missing
 	Versions     []*Section // released versions in document order (newest first)
 	AddedEntries []Entry    // entries from diff (only if ParseWithDiff used)
+	// ReleasedEntries are the AddedEntries that fall under a released version header the diff did not add.
+	ReleasedEntries []ReleasedEntry
 }
 
-// Version header patterns.
+// Version header patterns. Headers tolerate extra whitespace between the markers, brackets
+// and date, as well as trailing spaces; String re-emits them in the canonical single-space form.
 var (
 	// Matches ## [Unreleased] or ## [Unreleased] - any text.
-	unreleasedPattern = regexp.MustCompile(`^## \[Unreleased\]`)
+	unreleasedPattern = regexp.MustCompile(`^##\s+\[\s*Unreleased\s*\]`)
 
 	// Matches ## [1.2.3] - 2024-01-15 or ## [v1.2.3] - 2024-01-15 or ## [1.2.3-preview.1] - 2024-01-15.
-	versionPattern = regexp.MustCompile(`^## \[v?(\d+\.\d+\.\d+(?:-[a-zA-Z0-9.]+)?)\](?:\s+-\s+(\d{4}-\d{2}-\d{2}))?`)
+	versionPattern = regexp.MustCompile(
+		`^##\s+\[\s*v?(\d+\.\d+\.\d+(?:-[a-zA-Z0-9.]+)?)\s*\](?:\s+-\s+(\d{4}-\d{2}-\d{2}))?`,
+	)
 
 	// Matches ### Added, ### Changed, ### Fixed, ### Removed, etc.
-	categoryPattern = regexp.MustCompile(`^### (\w+)`)
+	categoryPattern = regexp.MustCompile(`^###\s+(\w+)`)
 
 	// Matches list items: "- some text" or "* some text".
 	listItemPattern = regexp.MustCompile(`^[-*]\s+(.+)`)
@@ -80,10 +100,32 @@ var (
 )
 
 // standardCategoryOrder defines the preferred order for changelog categories.
+// The callout categories come first so upgrade steps lead every section.
 //
 //nolint:gochecknoglobals // Read-only package constant.
 var standardCategoryOrder = []string{
-	"Added", "Changed", "Fixed", "Removed", "Security", "Deprecated",
+	"Migration", "Breaking", "Added", "Changed", "Fixed", "Removed", "Security", "Deprecated",
+}
+
+// DefaultCalloutCategory is the callout category of components that do not choose one.
+const DefaultCalloutCategory = "Migration"
+
+// calloutHeaders are the release-note headers of callout categories, which hold
+// upgrade steps rather than regular entries. A changelog uses one of them (see
+// ValidateCalloutCategory). Release notes always decorate them so they stand out; a
+// headers map passed to ExtractNotesWithHeaders or ExtractNotesStyled can replace
+// the default heading.
+//
+//nolint:gochecknoglobals // Read-only lookup table.
+var calloutHeaders = map[string]string{
+	"Migration": "⚠️ Migration",
+	"Breaking":  "💥 Breaking",
+}
+
+// IsCalloutCategory reports whether name is a callout category such as Migration or Breaking.
+func IsCalloutCategory(name string) bool {
+	_, ok := calloutHeaders[name]
+	return ok
 }
 
 // categoryValidator validates category names and order.
@@ -122,6 +164,28 @@ func (v *categoryValidator) validate(categoryName string) error {
 	return nil
 }
 
+// ValidateCategory returns ErrInvalidCategory unless name is a standard changelog category.
+func ValidateCategory(name string) error {
+	return newCategoryValidator().validate(name)
+}
+
+// Skeleton returns a new changelog for name: the "# Changelog" preamble and an empty
+// [Unreleased] section. The categories are listed in a comment rather than as empty
+// headers, so the skeleton passes validation and audits until the first entry is added.
+func Skeleton(name string) string {
+	return `# Changelog
+
+All notable changes to ` + name + ` will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/),
+and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).
+
+## [Unreleased]
+
+<!-- Add entries under "### <Category>" headers, in this order: ` + strings.Join(standardCategoryOrder, ", ") + `. -->
+`
+}
+
 // Parse parses changelog content into an AST representation.
 func Parse(content string) (*Changelog, error) {
 	return ParseWithDiff(content, "", "")
@@ -132,10 +196,11 @@ func Parse(content string) (*Changelog, error) {
 // The diff parameter can be empty string if no diff analysis is needed.
 func ParseWithDiff(content, diff, changelogPath string) (*Changelog, error) {
 	cl := &Changelog{
-		Preamble:     "",
-		Unreleased:   nil,
-		Versions:     nil,
-		AddedEntries: nil,
+		Preamble:        "",
+		Unreleased:      nil,
+		Versions:        nil,
+		AddedEntries:    nil,
+		ReleasedEntries: nil,
 	}
 	if err := parseContent(cl, content); err != nil {
 		return nil, err
@@ -144,15 +209,29 @@ func ParseWithDiff(content, diff, changelogPath string) (*Changelog, error) {
 		return nil, err
 	}
 	if diff != "" && changelogPath != "" {
-		entries, err := extractEntriesFromDiff(diff, changelogPath)
+		entries, released, err := extractEntriesFromDiff(diff, changelogPath)
 		if err != nil && !errors.Is(err, ErrNoChangelogInDiff) && !errors.Is(err, ErrNoEntriesInDiff) {
 			return nil, err
 		}
 		cl.AddedEntries = entries
+		cl.ReleasedEntries = released
 	}
 	return cl, nil
 }
 
+// ValidateAddedEntries returns ErrEntryInReleased if the diff added entries to a released version.
+// Released sections are immutable; new entries belong in [Unreleased].
+func (c *Changelog) ValidateAddedEntries() error {
+	if len(c.ReleasedEntries) == 0 {
+		return nil
+	}
+	lines := make([]string, 0, len(c.ReleasedEntries))
+	for _, e := range c.ReleasedEntries {
+		lines = append(lines, fmt.Sprintf("[%s] %s: %s", e.Version, e.Category, e.Text))
+	}
+	return fmt.Errorf("%w (move to [Unreleased]): %s", ErrEntryInReleased, strings.Join(lines, "; "))
+}
+
 func validateVersionSections(sections []*Section) error {
 	seen := make(map[string]struct{}, len(sections))
 	var prev *semver.Version
@@ -169,7 +248,7 @@ func validateVersionSections(sections []*Section) error {
 		}
 		seen[key] = struct{}{}
 
-		if prev != nil && compareSemver(current, prev) > 0 {
+		if prev != nil && semver.Compare(current, prev) > 0 {
 			return fmt.Errorf("%w: %s appears after %s", ErrVersionOrder, current.String(), prev.String())
 		}
 		prev = current
@@ -210,72 +289,6 @@ func validateActivePrereleaseLine(sections []*Section) error {
 	return nil
 }
 
-func compareSemver(a, b *semver.Version) int {
-	switch {
-	case a.Major > b.Major:
-		return 1
-	case a.Major < b.Major:
-		return -1
-	case a.Minor > b.Minor:
-		return 1
-	case a.Minor < b.Minor:
-		return -1
-	case a.Patch > b.Patch:
-		return 1
-	case a.Patch < b.Patch:
-		return -1
-	}
-
-	if !a.IsPrerelease && !b.IsPrerelease {
-		return 0
-	}
-	if !a.IsPrerelease {
-		return 1
-	}
-	if !b.IsPrerelease {
-		return -1
-	}
-
-	return comparePrerelease(a.Prerelease, b.Prerelease)
-}
-
-func comparePrerelease(a, b string) int {
-	aParts := strings.Split(a, ".")
-	bParts := strings.Split(b, ".")
-	limit := min(len(aParts), len(bParts))
-
-	for i := range limit {
-		if aParts[i] == bParts[i] {
-			continue
-		}
-
-		aNum, aIsNum := parseNumericIdentifier(aParts[i])
-		bNum, bIsNum := parseNumericIdentifier(bParts[i])
-		switch {
-		case aIsNum && bIsNum:
-			if aNum > bNum {
-				return 1
-			}
-			return -1
-		case aIsNum && !bIsNum:
-			return -1
-		case !aIsNum && bIsNum:
-			return 1
-		default:
-			return strings.Compare(aParts[i], bParts[i])
-		}
-	}
-
-	switch {
-	case len(aParts) > len(bParts):
-		return 1
-	case len(aParts) < len(bParts):
-		return -1
-	default:
-		return 0
-	}
-}
-
 func parseNumericIdentifier(value string) (int, bool) {
 	if value == "" {
 		return 0, false
@@ -392,29 +405,38 @@ func parseContent(cl *Changelog, content string) error {
 }
 
 // extractEntriesFromDiff parses a git diff and extracts changelog entries that were added.
+// Entries added under a released version header that already existed are also returned separately;
+// headers the diff itself adds (release promotion) do not count. A hunk without its section
+// header in context is treated as not released, so callers should diff with full context.
 //
-//nolint:gocognit,gocyclo,cyclop // Diff parsing requires sequential state machine logic
-func extractEntriesFromDiff(diffContent, changelogPath string) ([]Entry, error) {
+//nolint:gocognit,gocyclo,cyclop,funlen // Diff parsing requires sequential state machine logic
+func extractEntriesFromDiff(diffContent, changelogPath string) ([]Entry, []ReleasedEntry, error) {
 	changelogStart := findChangelogSection(diffContent, changelogPath)
 	if changelogStart == -1 {
-		return nil, ErrNoChangelogInDiff
+		return nil, nil, ErrNoChangelogInDiff
 	}
 
 	diffSection := diffContent[changelogStart:]
-	changelogDiffPrefix := "diff --git a/" + changelogPath
+	changelogDiffPrefix := diffHeaderPrefix(changelogPath)
 
 	var entries []Entry
+	var released []ReleasedEntry
 	var currentCategory string
+	var releasedVersion string // existing released section the scanner is in; "" when unknown or unreleased
 
 	scanner := bufio.NewScanner(strings.NewReader(diffSection))
 	for scanner.Scan() {
 		line := scanner.Text()
 
-		if strings.HasPrefix(line, "diff --git") && !strings.HasPrefix(line, changelogDiffPrefix) {
+		if strings.HasPrefix(line, "diff --git") && !isDiffHeaderFor(line, changelogDiffPrefix) {
 			break
 		}
 
-		if strings.HasPrefix(line, "@@") || strings.HasPrefix(line, "---") || strings.HasPrefix(line, "+++") ||
+		if strings.HasPrefix(line, "@@") {
+			releasedVersion = ""
+			continue
+		}
+		if strings.HasPrefix(line, "---") || strings.HasPrefix(line, "+++") ||
 			strings.HasPrefix(line, "index ") || strings.HasPrefix(line, "diff --git") {
 			continue
 		}
@@ -431,8 +453,18 @@ func extractEntriesFromDiff(diffContent, changelogPath string) ([]Entry, error)
 			contentLine = line
 		}
 
-		if versionPattern.MatchString(contentLine) {
+		if matches := versionPattern.FindStringSubmatch(contentLine); matches != nil {
 			currentCategory = ""
+			switch {
+			case strings.HasPrefix(line, "+"):
+				releasedVersion = "" // new section, e.g. release promotion
+			case !strings.HasPrefix(line, "-"):
+				releasedVersion = matches[1]
+			}
+			continue
+		}
+		if unreleasedPattern.MatchString(contentLine) && !strings.HasPrefix(line, "-") {
+			releasedVersion = ""
 			continue
 		}
 
@@ -450,33 +482,61 @@ func extractEntriesFromDiff(diffContent, changelogPath string) ([]Entry, error)
 		}
 
 		if matches := listItemPattern.FindStringSubmatch(contentLine); matches != nil {
-			entries = append(entries, Entry{
+			entry := Entry{
 				Category: currentCategory,
 				Text:     matches[1],
-			})
+			}
+			entries = append(entries, entry)
+			if releasedVersion != "" {
+				released = append(released, ReleasedEntry{Version: releasedVersion, Entry: entry})
+			}
 		}
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("scan diff: %w", err)
+		return nil, nil, fmt.Errorf("scan diff: %w", err)
 	}
 
 	if len(entries) == 0 {
-		return nil, ErrNoEntriesInDiff
+		return nil, nil, ErrNoEntriesInDiff
 	}
 
-	return entries, nil
+	return entries, released, nil
 }
 
 // findChangelogSection returns the index where the changelog diff section starts, or -1 if not found.
+// Header paths are compared with backslashes normalized to forward slashes.
 func findChangelogSection(diffContent, changelogPath string) int {
-	pattern := "diff --git a/" + changelogPath
-	if idx := strings.Index(diffContent, pattern); idx != -1 {
-		return idx
+	prefix := diffHeaderPrefix(changelogPath)
+	offset := 0
+	for line := range strings.SplitAfterSeq(diffContent, "\n") {
+		if isDiffHeaderFor(line, prefix) {
+			return offset
+		}
+		offset += len(line)
 	}
 	return -1
 }
 
+// diffHeaderPrefix returns the start of the "diff --git" header line for path.
+// The trailing " b/" keeps paths sharing a prefix (e.g. CHANGELOG.md.orig) from matching.
+func diffHeaderPrefix(changelogPath string) string {
+	return "diff --git a/" + NormalizePath(changelogPath) + " b/"
+}
+
+// isDiffHeaderFor reports whether line is the "diff --git" header starting with prefix,
+// treating backslashes in the header as path separators.
+func isDiffHeaderFor(line, prefix string) bool {
+	return strings.HasPrefix(line, "diff --git") && strings.HasPrefix(strings.ReplaceAll(line, `\`, "/"), prefix)
+}
+
+// NormalizePath converts a repo-relative changelog path to the slash-separated,
+// cleaned form git uses in diff headers and name-only output. Backslashes are
+// treated as separators on every host, so Windows-style config paths match too.
+func NormalizePath(changelogPath string) string {
+	return path.Clean(strings.ReplaceAll(changelogPath, `\`, "/"))
+}
+
 // HasVersion checks if the changelog contains a specific version.
 func (c *Changelog) HasVersion(version string) bool {
 	return c.GetVersion(version) != nil
@@ -496,6 +556,31 @@ func (c *Changelog) GetVersion(version string) *Section {
 	return nil
 }
 
+// DuplicateUnreleasedEntries returns the [Unreleased] entries that exactly repeat an
+// entry in the same category of the most recent released version, which usually means
+// a merge or backport carried an already released entry back into [Unreleased].
+func (c *Changelog) DuplicateUnreleasedEntries() []Entry {
+	if c.Unreleased == nil || len(c.Versions) == 0 || c.Versions[0] == nil {
+		return nil
+	}
+	released := make(map[Entry]struct{})
+	for _, cat := range c.Versions[0].Categories {
+		for _, text := range cat.Entries {
+			released[Entry{Category: cat.Name, Text: text}] = struct{}{}
+		}
+	}
+	var duplicates []Entry
+	for _, cat := range c.Unreleased.Categories {
+		for _, text := range cat.Entries {
+			entry := Entry{Category: cat.Name, Text: text}
+			if _, ok := released[entry]; ok {
+				duplicates = append(duplicates, entry)
+			}
+		}
+	}
+	return duplicates
+}
+
 // LatestPrerelease returns the highest prerelease version found in released sections.
 func (c *Changelog) LatestPrerelease() (*semver.Version, error) {
 	return c.latestVersion(func(ver *semver.Version) bool {
@@ -503,6 +588,38 @@ func (c *Changelog) LatestPrerelease() (*semver.Version, error) {
 	})
 }
 
+// previewPrefix is the prerelease identifier prefix used for preview releases (1.2.3-preview.N).
+const previewPrefix = "preview."
+
+// NextPrereleaseNumber returns the next preview number N for the core version major.minor.patch,
+// one past the highest existing X.Y.Z-preview.N section, or 1 when there is none.
+// Returns ErrVersionExists if the core version was already released as stable.
+func (c *Changelog) NextPrereleaseNumber(major, minor, patch int) (int, error) {
+	if major < 0 || minor < 0 || patch < 0 {
+		return 0, fmt.Errorf("%w: %d.%d.%d", ErrInvalidVersion, major, minor, patch)
+	}
+
+	highest := 0
+	for _, section := range c.Versions {
+		if section == nil || section.Version == nil {
+			continue
+		}
+		ver := section.Version
+		if ver.Major != major || ver.Minor != minor || ver.Patch != patch {
+			continue
+		}
+		if !ver.IsPrerelease {
+			return 0, fmt.Errorf("%w: %s is already released", ErrVersionExists, ver.Num)
+		}
+		number, ok := parseNumericIdentifier(strings.TrimPrefix(ver.Prerelease, previewPrefix))
+		if !strings.HasPrefix(ver.Prerelease, previewPrefix) || !ok {
+			continue
+		}
+		highest = max(highest, number)
+	}
+	return highest + 1, nil
+}
+
 // LatestStableForLine returns the highest stable version for a release line (major.minor).
 func (c *Changelog) LatestStableForLine(major, minor int) (*semver.Version, error) {
 	return c.latestVersion(func(ver *semver.Version) bool {
@@ -520,7 +637,165 @@ func (c *Changelog) ExtractNotes(version string) (string, error) {
 		}
 		return "", ErrVersionNotFound
 	}
-	return sec.String(), nil
+	return sec.render(notesHeaders(nil)), nil
+}
+
+// ExtractNotesWithHeaders is like ExtractNotes but renders each category header
+// through headers (e.g. "Added" -> "✨ Added"). Categories missing from headers
+// keep their plain name. Intended for published release notes only; the changelog
+// file itself should always be rendered plain.
+func (c *Changelog) ExtractNotesWithHeaders(version string, headers map[string]string) (string, error) {
+	notes, err := c.ExtractNotes(version)
+	if err != nil || len(headers) == 0 {
+		return notes, err
+	}
+	return c.GetVersion(version).render(notesHeaders(headers)), nil
+}
+
+// NotesStyle selects how release notes are rendered. The changelog file itself is
+// always rendered in NotesStyleGitHub.
+type NotesStyle string
+
+// Release notes styles.
+const (
+	// NotesStyleGitHub renders "### Category" headers, as in the changelog file.
+	NotesStyleGitHub NotesStyle = "github"
+	// NotesStylePlain renders "**Category**" bold lines instead of headers.
+	NotesStylePlain NotesStyle = "plain"
+	// NotesStyleCompact renders a single list with "[Category]" entry prefixes.
+	NotesStyleCompact NotesStyle = "compact"
+)
+
+// ParseNotesStyle parses a notes style name. An empty name selects NotesStyleGitHub.
+func ParseNotesStyle(name string) (NotesStyle, error) {
+	switch style := NotesStyle(name); style {
+	case "":
+		return NotesStyleGitHub, nil
+	case NotesStyleGitHub, NotesStylePlain, NotesStyleCompact:
+		return style, nil
+	default:
+		return "", fmt.Errorf("%w: %q (valid: %s, %s, %s)",
+			ErrInvalidNotesStyle, name, NotesStyleGitHub, NotesStylePlain, NotesStyleCompact)
+	}
+}
+
+// ExtractNotesStyled is like ExtractNotesWithHeaders but renders the section in style.
+func (c *Changelog) ExtractNotesStyled(version string, style NotesStyle, headers map[string]string) (string, error) {
+	notes, err := c.ExtractNotes(version)
+	if err != nil {
+		return notes, err
+	}
+	return c.GetVersion(version).renderStyle(style, notesHeaders(headers))
+}
+
+// PrereleaseFilter selects how release notes treat entries of a stable version that
+// already shipped in a prerelease of the same core version.
+type PrereleaseFilter string
+
+// Prerelease entry filters.
+const (
+	// PrereleaseFilterInclude keeps every entry unchanged.
+	PrereleaseFilterInclude PrereleaseFilter = "include"
+	// PrereleaseFilterAnnotate keeps every entry but marks the ones that already shipped.
+	PrereleaseFilterAnnotate PrereleaseFilter = "annotate"
+	// PrereleaseFilterExclude drops the entries that already shipped.
+	PrereleaseFilterExclude PrereleaseFilter = "exclude"
+)
+
+// shippedAnnotation is appended to entries marked by PrereleaseFilterAnnotate.
+const shippedAnnotation = " _(shipped in prerelease)_"
+
+// ParsePrereleaseFilter parses a prerelease filter name. An empty name selects PrereleaseFilterInclude.
+func ParsePrereleaseFilter(name string) (PrereleaseFilter, error) {
+	switch filter := PrereleaseFilter(name); filter {
+	case "":
+		return PrereleaseFilterInclude, nil
+	case PrereleaseFilterInclude, PrereleaseFilterAnnotate, PrereleaseFilterExclude:
+		return filter, nil
+	default:
+		return "", fmt.Errorf("%w: %q (valid: %s, %s, %s)", ErrInvalidPrereleaseFilter,
+			name, PrereleaseFilterInclude, PrereleaseFilterAnnotate, PrereleaseFilterExclude)
+	}
+}
+
+// FilterPrereleaseEntries returns a copy of c in which the entries of the stable
+// version that also appear in a prerelease of the same core version (e.g. 1.3.0 and
+// 1.3.0-preview.1) are annotated or removed according to filter. Entries match on
+// category and text. Prerelease versions and PrereleaseFilterInclude return c unchanged.
+// Intended for published release notes only; the changelog file is never rewritten.
+func (c *Changelog) FilterPrereleaseEntries(version string, filter PrereleaseFilter) (*Changelog, error) {
+	section := c.GetVersion(version)
+	if section == nil {
+		return nil, fmt.Errorf("%w: %s", ErrVersionNotFound, version)
+	}
+	if filter == PrereleaseFilterInclude || section.Version.IsPrerelease {
+		return c, nil
+	}
+
+	shipped := make(map[Entry]bool)
+	for _, cat := range collectPrereleaseLineCategories(c.Versions, section.Version) {
+		for _, text := range cat.Entries {
+			shipped[Entry{Category: cat.Name, Text: text}] = true
+		}
+	}
+
+	filtered := &Section{Version: section.Version, Date: section.Date, Categories: nil}
+	for _, cat := range section.Categories {
+		entries := make([]string, 0, len(cat.Entries))
+		for _, text := range cat.Entries {
+			switch {
+			case !shipped[Entry{Category: cat.Name, Text: text}]:
+				entries = append(entries, text)
+			case filter == PrereleaseFilterAnnotate:
+				entries = append(entries, text+shippedAnnotation)
+			}
+		}
+		if len(entries) > 0 {
+			filtered.Categories = append(filtered.Categories, Category{Name: cat.Name, Entries: entries})
+		}
+	}
+
+	newCl := &Changelog{
+		Preamble:        c.Preamble,
+		Unreleased:      c.Unreleased,
+		Versions:        slices.Clone(c.Versions),
+		AddedEntries:    c.AddedEntries,
+		ReleasedEntries: c.ReleasedEntries,
+	}
+	newCl.Versions[slices.Index(c.Versions, section)] = filtered
+	return newCl, nil
+}
+
+// ValidateCalloutCategory returns ErrInvalidCategory if [Unreleased] uses a callout
+// category other than allowed. Released sections are not checked, so entries written
+// before a component switched callout categories stay valid.
+func (c *Changelog) ValidateCalloutCategory(allowed string) error {
+	if c.Unreleased == nil {
+		return nil
+	}
+	for _, cat := range c.Unreleased.Categories {
+		if IsCalloutCategory(cat.Name) && cat.Name != allowed {
+			return fmt.Errorf("%w: %q is not this changelog's callout category; use %q",
+				ErrInvalidCategory, cat.Name, allowed)
+		}
+	}
+	return nil
+}
+
+// ValidatePreamble checks that the preamble starts with a "# " title heading,
+// catching files that begin directly with "## [Unreleased]".
+func (c *Changelog) ValidatePreamble() error {
+	for line := range strings.SplitSeq(c.Preamble, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "# ") {
+			return nil
+		}
+		return fmt.Errorf("%w: found %q", ErrNoPreambleTitle, line)
+	}
+	return ErrNoPreambleTitle
 }
 
 // ValidateUnreleased checks that [Unreleased] section exists and follows
@@ -566,28 +841,70 @@ func (c *Changelog) Promote(version string, date time.Time) (*Changelog, error)
 		return nil, ErrUnreleasedEmpty
 	}
 
-	newCl := &Changelog{
-		Preamble: c.Preamble,
-		Unreleased: &Section{
-			Version:    nil,
-			Date:       time.Time{},
-			Categories: nil,
-		},
-		Versions:     nil, // Set below
-		AddedEntries: c.AddedEntries,
+	unreleased := &Section{
+		Version:    nil,
+		Date:       time.Time{},
+		Categories: nil,
+	}
+	return c.withVersion(unreleased, &Section{
+		Version:    ver,
+		Date:       date,
+		Categories: promotedCategories,
+	})
+}
+
+// PromotePrerelease creates a stable version section from the prerelease history of
+// the same version (e.g., 1.3.0 from 1.3.0-preview.1 and 1.3.0-preview.2).
+// Unlike Promote, [Unreleased] is left untouched, so the stable release carries exactly
+// the notes that already shipped in its prereleases.
+// Returns a new Changelog with the promoted section.
+func (c *Changelog) PromotePrerelease(version string, date time.Time) (*Changelog, error) {
+	normalized := normalizeVersion(version)
+	if normalized == "" {
+		return nil, ErrInvalidVersion
+	}
+
+	if c.HasVersion(normalized) {
+		return nil, ErrVersionExists
+	}
+
+	ver, err := semver.Parse("v" + normalized)
+	if err != nil {
+		return nil, fmt.Errorf("parse version: %w", err)
+	}
+	if ver.IsPrerelease {
+		return nil, fmt.Errorf("%w: %s is itself a prerelease", ErrInvalidVersion, normalized)
+	}
+
+	promotedCategories := collectPrereleaseLineCategories(c.Versions, ver)
+	if len(promotedCategories) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoPrerelease, normalized)
 	}
 
-	newVersion := &Section{
+	return c.withVersion(cloneSection(c.Unreleased), &Section{
 		Version:    ver,
 		Date:       date,
 		Categories: promotedCategories,
+	})
+}
+
+// withVersion returns a copy of c with the given [Unreleased] section and newVersion
+// inserted among the released sections.
+func (c *Changelog) withVersion(unreleased, newVersion *Section) (*Changelog, error) {
+	ver := newVersion.Version
+	newCl := &Changelog{
+		Preamble:        c.Preamble,
+		Unreleased:      unreleased,
+		Versions:        nil, // Set below
+		AddedEntries:    c.AddedEntries,
+		ReleasedEntries: c.ReleasedEntries,
 	}
 
 	// Insert new version so released sections stay semver-descending.
 	newCl.Versions = make([]*Section, 0, len(c.Versions)+1)
 	inserted := false
 	for _, v := range c.Versions {
-		if !inserted && (v == nil || v.Version == nil || compareSemver(ver, v.Version) > 0) {
+		if !inserted && (v == nil || v.Version == nil || semver.Compare(ver, v.Version) > 0) {
 			newCl.Versions = append(newCl.Versions, newVersion)
 			inserted = true
 		}
@@ -705,10 +1022,11 @@ func (c *Changelog) InsertEntries(entries []Entry) (*Changelog, error) {
 	}
 
 	newCl := &Changelog{
-		Preamble:     c.Preamble,
-		Unreleased:   cloneSection(c.Unreleased),
-		Versions:     versions,
-		AddedEntries: c.AddedEntries,
+		Preamble:        c.Preamble,
+		Unreleased:      cloneSection(c.Unreleased),
+		Versions:        versions,
+		AddedEntries:    c.AddedEntries,
+		ReleasedEntries: c.ReleasedEntries,
 	}
 
 	byCategory := make(map[string][]string)
@@ -781,6 +1099,122 @@ func (c *Changelog) String() string {
 	return strings.TrimRight(b.String(), "\n") + "\n"
 }
 
+// Format selects how a whole changelog document is rendered.
+type Format string
+
+// Changelog document formats.
+const (
+	// FormatKeepAChangelog renders Keep a Changelog, the format changelog files are kept in.
+	FormatKeepAChangelog Format = "keepachangelog"
+	// FormatCommon renders Common Changelog (see StringCommon).
+	FormatCommon Format = "common"
+)
+
+// ParseFormat parses a changelog format name. An empty name selects FormatKeepAChangelog.
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(name); format {
+	case "":
+		return FormatKeepAChangelog, nil
+	case FormatKeepAChangelog, FormatCommon:
+		return format, nil
+	default:
+		return "", fmt.Errorf("%w: %q (valid: %s, %s)", ErrInvalidFormat, name, FormatKeepAChangelog, FormatCommon)
+	}
+}
+
+// Render renders the changelog in format.
+func (c *Changelog) Render(format Format) (string, error) {
+	switch format {
+	case FormatKeepAChangelog, "":
+		return c.String(), nil
+	case FormatCommon:
+		return c.StringCommon(), nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidFormat, format)
+	}
+}
+
+// commonGroups maps categories onto the four Common Changelog groups, in the order
+// Common Changelog lists them.
+//
+//nolint:gochecknoglobals // Read-only lookup table.
+var commonGroups = []struct {
+	name string
+	from []string
+}{
+	{name: "Changed", from: []string{"Migration", "Breaking", "Changed", "Deprecated"}},
+	{name: "Added", from: []string{"Added"}},
+	{name: "Removed", from: []string{"Removed"}},
+	{name: "Fixed", from: []string{"Fixed", "Security"}},
+}
+
+// commonBreakingPrefix marks callout entries, which Common Changelog lists first
+// under Changed with a bold "Breaking:" prefix.
+const commonBreakingPrefix = "**Breaking:** "
+
+// StringCommon renders the changelog in Common Changelog style: [Unreleased] is left out,
+// releases stay newest first, and entries are regrouped into Changed, Added, Removed and
+// Fixed. Callout and Deprecated entries go under Changed (callouts first, marked
+// breaking) and Security entries under Fixed. The preamble and release headers are
+// kept as in String.
+func (c *Changelog) StringCommon() string {
+	var b strings.Builder
+
+	if c.Preamble != "" {
+		b.WriteString(c.Preamble)
+		b.WriteString("\n\n")
+	}
+
+	for i, ver := range c.Versions {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("## [")
+		b.WriteString(ver.Version.Num)
+		b.WriteString("]")
+		if !ver.Date.IsZero() {
+			b.WriteString(" - ")
+			b.WriteString(ver.Date.Format("2006-01-02"))
+		}
+		b.WriteString("\n")
+		if content := renderCategories(commonCategories(ver), "### %s\n", nil); content != "" {
+			b.WriteString("\n")
+			b.WriteString(content)
+			b.WriteString("\n")
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// commonCategories regroups the categories of s for StringCommon, in Common Changelog order.
+func commonCategories(s *Section) []Category {
+	grouped := make(map[string][]string, len(commonGroups))
+	for _, cat := range sortedCategories(s.Categories) {
+		group := "Changed"
+		for _, g := range commonGroups {
+			if slices.Contains(g.from, cat.Name) {
+				group = g.name
+				break
+			}
+		}
+		for _, entry := range cat.Entries {
+			if IsCalloutCategory(cat.Name) {
+				entry = commonBreakingPrefix + entry
+			}
+			grouped[group] = append(grouped[group], entry)
+		}
+	}
+
+	categories := make([]Category, 0, len(commonGroups))
+	for _, g := range commonGroups {
+		if entries := grouped[g.name]; len(entries) > 0 {
+			categories = append(categories, Category{Name: g.name, Entries: entries})
+		}
+	}
+	return categories
+}
+
 func (c *Changelog) latestVersion(matches func(*semver.Version) bool) (*semver.Version, error) {
 	var best *semver.Version
 	hasReleased := false
@@ -792,7 +1226,7 @@ func (c *Changelog) latestVersion(matches func(*semver.Version) bool) (*semver.V
 		if !matches(section.Version) {
 			continue
 		}
-		if best == nil || compareSemver(section.Version, best) > 0 {
+		if best == nil || semver.Compare(section.Version, best) > 0 {
 			best = section.Version
 		}
 	}
@@ -827,20 +1261,43 @@ func (s *Section) GetCategory(name string) *Category {
 
 // String renders the section content as markdown (without the header).
 func (s *Section) String() string {
-	if len(s.Categories) == 0 {
-		return ""
+	return s.render(nil)
+}
+
+// render renders the section content, replacing category names found in headers.
+func (s *Section) render(headers map[string]string) string {
+	notes, _ := s.renderStyle(NotesStyleGitHub, headers) //nolint:errcheck // NotesStyleGitHub is always valid
+	return notes
+}
+
+// renderStyle renders the section content in style, replacing category names found in headers.
+func (s *Section) renderStyle(style NotesStyle, headers map[string]string) (string, error) {
+	switch style {
+	case NotesStyleGitHub, "":
+		return s.renderGrouped("### %s\n", headers), nil
+	case NotesStylePlain:
+		return s.renderGrouped("**%s**\n", headers), nil
+	case NotesStyleCompact:
+		return s.renderCompact(headers), nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidNotesStyle, style)
 	}
+}
 
-	var b strings.Builder
-	sorted := sortedCategories(s.Categories)
+// renderGrouped renders each category as a header line in headerFormat followed by its entries.
+func (s *Section) renderGrouped(headerFormat string, headers map[string]string) string {
+	return renderCategories(sortedCategories(s.Categories), headerFormat, headers)
+}
 
-	for i, cat := range sorted {
+// renderCategories renders categories in the given order, each as a header line in
+// headerFormat followed by its entries.
+func renderCategories(categories []Category, headerFormat string, headers map[string]string) string {
+	var b strings.Builder
+	for i, cat := range categories {
 		if i > 0 {
 			b.WriteString("\n")
 		}
-		b.WriteString("### ")
-		b.WriteString(cat.Name)
-		b.WriteString("\n")
+		fmt.Fprintf(&b, headerFormat, categoryHeader(cat.Name, headers))
 		if len(cat.Entries) > 0 {
 			b.WriteString("\n")
 		}
@@ -850,10 +1307,38 @@ func (s *Section) String() string {
 			b.WriteString("\n")
 		}
 	}
+	return strings.TrimRight(b.String(), "\n")
+}
 
+// renderCompact renders all entries as a single list prefixed with their category,
+// matching the prepare PR body.
+func (s *Section) renderCompact(headers map[string]string) string {
+	var b strings.Builder
+	for _, cat := range sortedCategories(s.Categories) {
+		header := categoryHeader(cat.Name, headers)
+		for _, entry := range cat.Entries {
+			fmt.Fprintf(&b, "- [%s] %s\n", header, entry)
+		}
+	}
 	return strings.TrimRight(b.String(), "\n")
 }
 
+// notesHeaders returns headers with the default callout headings added for callout
+// categories it does not already cover.
+func notesHeaders(headers map[string]string) map[string]string {
+	merged := make(map[string]string, len(headers)+len(calloutHeaders))
+	maps.Copy(merged, calloutHeaders)
+	maps.Copy(merged, headers)
+	return merged
+}
+
+func categoryHeader(name string, headers map[string]string) string {
+	if decorated, ok := headers[name]; ok {
+		return decorated
+	}
+	return name
+}
+
 // normalizeVersion strips the 'v' prefix and validates the version format.
 // Also strips an optional "<component>/" prefix if present.
 func normalizeVersion(version string) string {
@@ -895,24 +1380,28 @@ func cloneCategories(cats []Category) []Category {
 	return result
 }
 
-// sortCategories sorts categories in place by standard order.
+// sortCategories sorts categories in place: standard categories first in
+// standardCategoryOrder, then unknown ones by byte-wise name comparison (so the
+// result does not depend on locale). The sort is stable, so categories with the
+// same name keep their document order.
 func sortCategories(cats []Category) {
-	slices.SortFunc(cats, func(a, b Category) int {
-		aIdx := slices.Index(standardCategoryOrder, a.Name)
-		bIdx := slices.Index(standardCategoryOrder, b.Name)
-		if aIdx == -1 {
-			aIdx = len(standardCategoryOrder)
-		}
-		if bIdx == -1 {
-			bIdx = len(standardCategoryOrder)
-		}
-		if aIdx != bIdx {
-			return aIdx - bIdx
+	slices.SortStableFunc(cats, func(a, b Category) int {
+		if byRank := cmp.Compare(categoryRank(a.Name), categoryRank(b.Name)); byRank != 0 {
+			return byRank
 		}
 		return strings.Compare(a.Name, b.Name)
 	})
 }
 
+// categoryRank returns the position of name in standardCategoryOrder, or
+// len(standardCategoryOrder) for unknown categories.
+func categoryRank(name string) int {
+	if idx := slices.Index(standardCategoryOrder, name); idx != -1 {
+		return idx
+	}
+	return len(standardCategoryOrder)
+}
+
 // sortedCategories returns a copy of categories sorted by standard order.
 func sortedCategories(cats []Category) []Category {
 	result := cloneCategories(cats)
@@ -2,6 +2,7 @@ package changelog_test
 
 import (
 	"errors"
+	"fmt"
 	"slices"
 	"strings"
 	"testing"
@@ -186,6 +187,509 @@ func TestExtractNotes(t *testing.T) {
 	}
 }
 
+func TestExtractNotesWithHeaders(t *testing.T) {
+	cl, err := changelog.Parse(sampleChangelog)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	got, err := cl.ExtractNotesWithHeaders("1.2.0", map[string]string{"Added": "✨ Added"})
+	if err != nil {
+		t.Fatalf("ExtractNotesWithHeaders() error = %v", err)
+	}
+	want := `### ✨ Added
+
+- Feature A
+- Feature B
+
+### Changed
+
+- Updated C`
+	if got != want {
+		t.Errorf("ExtractNotesWithHeaders() =\n%s\nwant:\n%s", got, want)
+	}
+
+	if !strings.Contains(cl.String(), "### Added\n") || strings.Contains(cl.String(), "✨") {
+		t.Errorf("String() should stay plain after decorated extraction:\n%s", cl.String())
+	}
+
+	if _, err := cl.ExtractNotesWithHeaders("9.9.9", map[string]string{"Added": "✨ Added"}); !errors.Is(
+		err,
+		changelog.ErrVersionNotFound,
+	) {
+		t.Errorf("ExtractNotesWithHeaders() error = %v, want %v", err, changelog.ErrVersionNotFound)
+	}
+}
+
+func TestExtractNotes_MigrationCallout(t *testing.T) {
+	content := `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- Next feature
+
+## [2.0.0] - 2025-03-01
+
+### Migration
+
+- Run studioctl env down before upgrading
+
+### Added
+
+- New runtime
+
+### Removed
+
+- Old flag
+`
+	cl, err := changelog.Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	got, err := cl.ExtractNotes("2.0.0")
+	if err != nil {
+		t.Fatalf("ExtractNotes() error = %v", err)
+	}
+	want := `### ⚠️ Migration
+
+- Run studioctl env down before upgrading
+
+### Added
+
+- New runtime
+
+### Removed
+
+- Old flag`
+	if got != want {
+		t.Errorf("ExtractNotes() =\n%s\nwant:\n%s", got, want)
+	}
+
+	headers := map[string]string{"Migration": "🚧 Upgrade steps"}
+	got, err = cl.ExtractNotesStyled("2.0.0", changelog.NotesStylePlain, headers)
+	if err != nil {
+		t.Fatalf("ExtractNotesStyled() error = %v", err)
+	}
+	if !strings.HasPrefix(got, "**🚧 Upgrade steps**\n\n- Run studioctl env down before upgrading") {
+		t.Errorf("ExtractNotesStyled() did not lead with the configured callout:\n%s", got)
+	}
+
+	if !strings.Contains(cl.String(), "\n### Migration\n") || strings.Contains(cl.String(), "⚠️") {
+		t.Errorf("String() should keep the plain Migration header:\n%s", cl.String())
+	}
+
+	// Promotion merges categories and must keep the callout at the top of the new section.
+	promoted, err := cl.Promote("2.1.0", time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Promote() error = %v", err)
+	}
+	withMigration, err := promoted.InsertEntries([]changelog.Entry{{Category: "Migration", Text: "Re-run setup"}})
+	if err != nil {
+		t.Fatalf("InsertEntries() error = %v", err)
+	}
+	if got := withMigration.Unreleased.Categories[0].Name; got != "Migration" {
+		t.Errorf("InsertEntries() first category = %q, want Migration", got)
+	}
+}
+
+func TestExtractNotes_BreakingCallout(t *testing.T) {
+	content := `# Changelog
+
+## [Unreleased]
+
+### Breaking
+
+- Drop the --legacy flag
+
+### Fixed
+
+- Next fix
+
+## [2.0.0] - 2025-03-01
+
+### Breaking
+
+- Rename the config file
+
+### Added
+
+- New runtime
+`
+	cl, err := changelog.Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	got, err := cl.ExtractNotes("2.0.0")
+	if err != nil {
+		t.Fatalf("ExtractNotes() error = %v", err)
+	}
+	if !strings.HasPrefix(got, "### 💥 Breaking\n\n- Rename the config file\n") {
+		t.Errorf("ExtractNotes() did not lead with the Breaking callout:\n%s", got)
+	}
+	if common := cl.StringCommon(); !strings.Contains(common, "- **Breaking:** Rename the config file") {
+		t.Errorf("StringCommon() did not mark the Breaking entry:\n%s", common)
+	}
+
+	if err := cl.ValidateCalloutCategory("Breaking"); err != nil {
+		t.Errorf("ValidateCalloutCategory(Breaking) error = %v", err)
+	}
+	if err := cl.ValidateCalloutCategory("Migration"); !errors.Is(err, changelog.ErrInvalidCategory) {
+		t.Errorf("ValidateCalloutCategory(Migration) error = %v, want %v", err, changelog.ErrInvalidCategory)
+	}
+}
+
+func TestParse_MigrationMustLeadSection(t *testing.T) {
+	content := `# Changelog
+
+## [2.0.0] - 2025-03-01
+
+### Added
+
+- New runtime
+
+### Migration
+
+- Run studioctl env down before upgrading
+`
+	if _, err := changelog.Parse(content); !errors.Is(err, changelog.ErrCategoryOrder) {
+		t.Fatalf("Parse() error = %v, want %v", err, changelog.ErrCategoryOrder)
+	}
+}
+
+func TestExtractNotesStyled(t *testing.T) {
+	cl, err := changelog.Parse(sampleChangelog)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	tests := []struct {
+		headers map[string]string
+		name    string
+		style   changelog.NotesStyle
+		want    string
+	}{
+		{
+			name:  "github",
+			style: changelog.NotesStyleGitHub,
+			want:  "### Added\n\n- Feature A\n- Feature B\n\n### Changed\n\n- Updated C",
+		},
+		{
+			name:  "plain",
+			style: changelog.NotesStylePlain,
+			want:  "**Added**\n\n- Feature A\n- Feature B\n\n**Changed**\n\n- Updated C",
+		},
+		{
+			name:  "compact",
+			style: changelog.NotesStyleCompact,
+			want:  "- [Added] Feature A\n- [Added] Feature B\n- [Changed] Updated C",
+		},
+		{
+			name:    "plain with headers",
+			style:   changelog.NotesStylePlain,
+			headers: map[string]string{"Added": "✨ Added"},
+			want:    "**✨ Added**\n\n- Feature A\n- Feature B\n\n**Changed**\n\n- Updated C",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cl.ExtractNotesStyled("1.2.0", tt.style, tt.headers)
+			if err != nil {
+				t.Fatalf("ExtractNotesStyled() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ExtractNotesStyled() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+
+	if !strings.Contains(cl.String(), "### Added\n") {
+		t.Errorf("String() should stay in github style after styled extraction:\n%s", cl.String())
+	}
+	if _, err := cl.ExtractNotesStyled("1.2.0", "html", nil); !errors.Is(err, changelog.ErrInvalidNotesStyle) {
+		t.Errorf("ExtractNotesStyled(html) error = %v, want %v", err, changelog.ErrInvalidNotesStyle)
+	}
+}
+
+func TestParseNotesStyle(t *testing.T) {
+	for name, want := range map[string]changelog.NotesStyle{
+		"":        changelog.NotesStyleGitHub,
+		"github":  changelog.NotesStyleGitHub,
+		"plain":   changelog.NotesStylePlain,
+		"compact": changelog.NotesStyleCompact,
+	} {
+		got, err := changelog.ParseNotesStyle(name)
+		if err != nil || got != want {
+			t.Errorf("ParseNotesStyle(%q) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	if _, err := changelog.ParseNotesStyle("GitHub"); !errors.Is(err, changelog.ErrInvalidNotesStyle) {
+		t.Errorf("ParseNotesStyle(GitHub) error = %v, want %v", err, changelog.ErrInvalidNotesStyle)
+	}
+}
+
+func TestFilterPrereleaseEntries(t *testing.T) {
+	cl, err := changelog.Parse(`# Changelog
+
+## [Unreleased]
+
+## [1.3.0] - 2025-03-01
+
+### Added
+
+- Preview feature
+- Stable-only feature
+
+### Fixed
+
+- Preview fix
+
+## [1.3.0-preview.2] - 2025-02-15
+
+### Fixed
+
+- Preview fix
+
+## [1.3.0-preview.1] - 2025-02-01
+
+### Added
+
+- Preview feature
+
+## [1.2.0] - 2025-01-01
+
+### Added
+
+- Stable-only feature
+`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	tests := []struct {
+		filter changelog.PrereleaseFilter
+		want   string
+	}{
+		{
+			filter: changelog.PrereleaseFilterInclude,
+			want:   "### Added\n\n- Preview feature\n- Stable-only feature\n\n### Fixed\n\n- Preview fix",
+		},
+		{
+			filter: changelog.PrereleaseFilterAnnotate,
+			want: "### Added\n\n- Preview feature _(shipped in prerelease)_\n- Stable-only feature\n\n" +
+				"### Fixed\n\n- Preview fix _(shipped in prerelease)_",
+		},
+		{
+			filter: changelog.PrereleaseFilterExclude,
+			want:   "### Added\n\n- Stable-only feature",
+		},
+	}
+	for _, tt := range tests {
+		filtered, err := cl.FilterPrereleaseEntries("1.3.0", tt.filter)
+		if err != nil {
+			t.Fatalf("FilterPrereleaseEntries(%s) error = %v", tt.filter, err)
+		}
+		notes, err := filtered.ExtractNotes("1.3.0")
+		if err != nil {
+			t.Fatalf("ExtractNotes() error = %v", err)
+		}
+		if strings.TrimSpace(notes) != tt.want {
+			t.Errorf("FilterPrereleaseEntries(%s) notes =\n%s\nwant:\n%s", tt.filter, notes, tt.want)
+		}
+	}
+
+	// The source changelog and prerelease notes are left alone.
+	if notes, _ := cl.ExtractNotes("1.3.0"); !strings.Contains(notes, "- Preview fix") {
+		t.Errorf("source changelog was modified:\n%s", notes)
+	}
+	filtered, err := cl.FilterPrereleaseEntries("1.3.0-preview.2", changelog.PrereleaseFilterExclude)
+	if err != nil || filtered != cl {
+		t.Errorf("FilterPrereleaseEntries(prerelease) = %p, %v; want the changelog unchanged", filtered, err)
+	}
+	if _, err := cl.FilterPrereleaseEntries("9.9.9", changelog.PrereleaseFilterExclude); !errors.Is(err, changelog.ErrVersionNotFound) {
+		t.Errorf("FilterPrereleaseEntries(9.9.9) error = %v, want %v", err, changelog.ErrVersionNotFound)
+	}
+	if _, err := changelog.ParsePrereleaseFilter("drop"); !errors.Is(err, changelog.ErrInvalidPrereleaseFilter) {
+		t.Errorf("ParsePrereleaseFilter(drop) error = %v, want %v", err, changelog.ErrInvalidPrereleaseFilter)
+	}
+}
+
+func TestSectionString_CategoryOrder(t *testing.T) {
+	section := &changelog.Section{Categories: []changelog.Category{
+		{Name: "Performance", Entries: []string{"P"}},
+		{Name: "Fixed", Entries: []string{"F"}},
+		{Name: "Docs", Entries: []string{"D1"}},
+		{Name: "Added", Entries: []string{"A"}},
+		{Name: "docs", Entries: []string{"d"}},
+		{Name: "Security", Entries: []string{"S"}},
+		{Name: "Docs", Entries: []string{"D2"}},
+	}}
+	// Standard categories in standard order, then unknown ones by byte order
+	// (uppercase before lowercase), with duplicates in document order.
+	want := []string{"Added", "Fixed", "Security", "Docs", "Docs", "Performance", "docs"}
+	wantEntries := []string{"A", "F", "S", "D1", "D2", "P", "d"}
+
+	var headers, entries []string
+	for line := range strings.SplitSeq(section.String(), "\n") {
+		if name, ok := strings.CutPrefix(line, "### "); ok {
+			headers = append(headers, name)
+		}
+		if entry, ok := strings.CutPrefix(line, "- "); ok {
+			entries = append(entries, entry)
+		}
+	}
+	if !slices.Equal(headers, want) || !slices.Equal(entries, wantEntries) {
+		t.Fatalf("String() order = %v %v, want %v %v", headers, entries, want, wantEntries)
+	}
+	if section.Categories[0].Name != "Performance" {
+		t.Errorf("String() reordered the section in place: %v", section.Categories)
+	}
+}
+
+func TestChangelogRender_CommonChangelog(t *testing.T) {
+	const input = `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- Pending feature
+
+## [2.0.0] - 2025-03-01
+
+### Migration
+
+- Rename the config file to studioctl.yaml
+
+### Added
+
+- New command
+
+### Changed
+
+- Faster startup
+
+### Fixed
+
+- Crash on exit
+
+### Removed
+
+- Old flag
+
+### Security
+
+- Patch dependency
+
+### Deprecated
+
+- Legacy output
+
+## [1.0.0] - 2025-01-01
+
+### Added
+
+- Initial release
+`
+	cl, err := changelog.Parse(input)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	keep, err := cl.Render(changelog.FormatKeepAChangelog)
+	if err != nil {
+		t.Fatalf("Render(keepachangelog) error = %v", err)
+	}
+	if keep != input {
+		t.Errorf("Render(keepachangelog) =\n%s\nwant\n%s", keep, input)
+	}
+
+	const wantCommon = `# Changelog
+
+## [2.0.0] - 2025-03-01
+
+### Changed
+
+- **Breaking:** Rename the config file to studioctl.yaml
+- Faster startup
+- Legacy output
+
+### Added
+
+- New command
+
+### Removed
+
+- Old flag
+
+### Fixed
+
+- Crash on exit
+- Patch dependency
+
+## [1.0.0] - 2025-01-01
+
+### Added
+
+- Initial release
+`
+	common, err := cl.Render(changelog.FormatCommon)
+	if err != nil {
+		t.Fatalf("Render(common) error = %v", err)
+	}
+	if common != wantCommon {
+		t.Errorf("Render(common) =\n%s\nwant\n%s", common, wantCommon)
+	}
+	if common != cl.StringCommon() {
+		t.Error("Render(common) differs from StringCommon()")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for name, want := range map[string]changelog.Format{
+		"":               changelog.FormatKeepAChangelog,
+		"keepachangelog": changelog.FormatKeepAChangelog,
+		"common":         changelog.FormatCommon,
+	} {
+		got, err := changelog.ParseFormat(name)
+		if err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	if _, err := changelog.ParseFormat("markdown"); !errors.Is(err, changelog.ErrInvalidFormat) {
+		t.Errorf("ParseFormat(markdown) error = %v, want %v", err, changelog.ErrInvalidFormat)
+	}
+}
+
+func TestSkeleton(t *testing.T) {
+	content := changelog.Skeleton("studioctl")
+	cl, err := changelog.Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if err := cl.ValidatePreamble(); err != nil {
+		t.Errorf("ValidatePreamble() error = %v", err)
+	}
+	if !strings.Contains(cl.Preamble, "All notable changes to studioctl") {
+		t.Errorf("Preamble = %q, want component name", cl.Preamble)
+	}
+	if cl.Unreleased == nil || len(cl.Unreleased.Categories) != 0 {
+		t.Errorf("Unreleased = %+v, want empty section", cl.Unreleased)
+	}
+
+	// The first entry turns the skeleton into a regular changelog.
+	updated, err := cl.InsertEntries([]changelog.Entry{{Category: "Added", Text: "First feature"}})
+	if err != nil {
+		t.Fatalf("InsertEntries() error = %v", err)
+	}
+	if err := updated.ValidateUnreleased(); err != nil {
+		t.Errorf("ValidateUnreleased() after first entry error = %v", err)
+	}
+}
+
 func TestParse_CompactCategorySpacing(t *testing.T) {
 	content := `# Changelog
 
@@ -199,17 +703,77 @@ func TestParse_CompactCategorySpacing(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Parse() error = %v", err)
 	}
-	if cl.Unreleased == nil {
-		t.Fatal("Parse() unreleased section = nil, want non-nil")
+	if cl.Unreleased == nil {
+		t.Fatal("Parse() unreleased section = nil, want non-nil")
+	}
+	if len(cl.Unreleased.Categories) != 1 {
+		t.Fatalf("Parse() categories len = %d, want 1", len(cl.Unreleased.Categories))
+	}
+	if got := cl.Unreleased.Categories[0].Entries; len(got) != 1 || got[0] != "Compact spacing entry" {
+		t.Fatalf("Parse() entries = %v, want [Compact spacing entry]", got)
+	}
+	if !strings.Contains(cl.String(), "### Added\n\n- Compact spacing entry") {
+		t.Fatalf("String() did not normalize compact spacing:\n%s", cl.String())
+	}
+}
+
+func TestParse_IrregularHeaderWhitespace(t *testing.T) {
+	content := "# Changelog\n\n" +
+		"##  [Unreleased]  \n\n" +
+		"###   Added \n\n" +
+		"- Unreleased entry\n\n" +
+		"##\t[ 1.2.0 ]  -   2024-01-15   \n\n" +
+		"###  Fixed\n\n" +
+		"- Released entry\n\n" +
+		"## [1.1.0]   \n\n" +
+		"### Changed\n\n" +
+		"- Undated entry\n"
+
+	cl, err := changelog.Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if cl.Unreleased == nil || !cl.Unreleased.HasCategory("Added") {
+		t.Fatalf("Parse() unreleased = %+v, want section with Added", cl.Unreleased)
 	}
-	if len(cl.Unreleased.Categories) != 1 {
-		t.Fatalf("Parse() categories len = %d, want 1", len(cl.Unreleased.Categories))
+	if len(cl.Versions) != 2 {
+		t.Fatalf("Parse() versions len = %d, want 2", len(cl.Versions))
 	}
-	if got := cl.Unreleased.Categories[0].Entries; len(got) != 1 || got[0] != "Compact spacing entry" {
-		t.Fatalf("Parse() entries = %v, want [Compact spacing entry]", got)
+	if got := cl.Versions[0].Version.Num; got != "1.2.0" {
+		t.Fatalf("Parse() versions[0] = %q, want 1.2.0", got)
 	}
-	if !strings.Contains(cl.String(), "### Added\n\n- Compact spacing entry") {
-		t.Fatalf("String() did not normalize compact spacing:\n%s", cl.String())
+	if got := cl.Versions[0].Date.Format("2006-01-02"); got != "2024-01-15" {
+		t.Fatalf("Parse() versions[0] date = %q, want 2024-01-15", got)
+	}
+	if !cl.Versions[0].HasCategory("Fixed") {
+		t.Fatalf("Parse() versions[0] categories = %+v, want Fixed", cl.Versions[0].Categories)
+	}
+	if !cl.Versions[1].Date.IsZero() {
+		t.Fatalf("Parse() versions[1] date = %v, want zero", cl.Versions[1].Date)
+	}
+
+	want := `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- Unreleased entry
+
+## [1.2.0] - 2024-01-15
+
+### Fixed
+
+- Released entry
+
+## [1.1.0]
+
+### Changed
+
+- Undated entry
+`
+	if got := cl.String(); got != want {
+		t.Fatalf("String() =\n%s\nwant\n%s", got, want)
 	}
 }
 
@@ -547,6 +1111,203 @@ func TestPromote_MaintainsSemverOrder(t *testing.T) {
 	}
 }
 
+func TestPromotePrerelease(t *testing.T) {
+	fixedDate := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	const previewHistory = `# Changelog
+
+## [Unreleased]
+
+## [1.3.0-preview.2] - 2024-01-20
+
+### Fixed
+
+- Preview regression
+
+## [1.3.0-preview.1] - 2024-01-10
+
+### Added
+
+- Preview feature
+
+## [1.2.0] - 2024-01-01
+
+### Added
+
+- Old feature
+`
+
+	tests := []struct {
+		wantErr  error
+		name     string
+		content  string
+		version  string
+		contains []string
+		excludes []string
+	}{
+		{
+			name:    "promote from prerelease history with empty unreleased",
+			content: previewHistory,
+			version: "1.3.0",
+			contains: []string{
+				"## [Unreleased]\n\n## [1.3.0] - 2024-02-01\n\n### Added\n\n- Preview feature\n\n### Fixed\n\n- Preview regression",
+				"## [1.3.0-preview.2] - 2024-01-20",
+			},
+			excludes: []string{"- Old feature\n\n### Fixed"},
+		},
+		{
+			name: "unreleased entries stay unreleased",
+			content: strings.Replace(previewHistory, "## [Unreleased]\n",
+				"## [Unreleased]\n\n### Changed\n\n- Not yet shipped\n", 1),
+			version: "v1.3.0",
+			contains: []string{
+				"## [Unreleased]\n\n### Changed\n\n- Not yet shipped\n\n## [1.3.0] - 2024-02-01\n\n### Added",
+			},
+		},
+		{
+			name:    "no prerelease for version",
+			content: previewHistory,
+			version: "1.4.0",
+			wantErr: changelog.ErrNoPrerelease,
+		},
+		{
+			name:    "prerelease target",
+			content: previewHistory,
+			version: "1.3.0-preview.3",
+			wantErr: changelog.ErrInvalidVersion,
+		},
+		{
+			name:    "version exists",
+			content: previewHistory,
+			version: "1.2.0",
+			wantErr: changelog.ErrVersionExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl, err := changelog.Parse(tt.content)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			promoted, err := cl.PromotePrerelease(tt.version, fixedDate)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("PromotePrerelease() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PromotePrerelease() unexpected error = %v", err)
+			}
+			got := promoted.String()
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("PromotePrerelease() result missing expected content:\n%s\n\ngot:\n%s", want, got)
+				}
+			}
+			for _, exclude := range tt.excludes {
+				if strings.Contains(got, exclude) {
+					t.Errorf("PromotePrerelease() result contains unexpected content:\n%s\n\ngot:\n%s", exclude, got)
+				}
+			}
+		})
+	}
+}
+
+// TestNextPrereleaseNumber follows the e2e release sequence: 1.0.0-preview.1, 1.0.0,
+// 1.1.0-preview.1, 1.1.0-preview.2, 1.1.0, then 1.2.0-preview.1.
+func TestNextPrereleaseNumber(t *testing.T) {
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cl, err := changelog.Parse("# Changelog\n\n## [Unreleased]\n")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	release := func(version string) {
+		t.Helper()
+		cl, err = cl.InsertEntries([]changelog.Entry{{Category: "Added", Text: "Feature for " + version}})
+		if err != nil {
+			t.Fatalf("InsertEntries() error = %v", err)
+		}
+		cl, err = cl.Promote(version, date)
+		if err != nil {
+			t.Fatalf("Promote(%s) error = %v", version, err)
+		}
+	}
+	assertNext := func(major, minor, patch, want int) {
+		t.Helper()
+		got, err := cl.NextPrereleaseNumber(major, minor, patch)
+		if err != nil {
+			t.Fatalf("NextPrereleaseNumber(%d.%d.%d) error = %v", major, minor, patch, err)
+		}
+		if got != want {
+			t.Fatalf("NextPrereleaseNumber(%d.%d.%d) = %d, want %d", major, minor, patch, got, want)
+		}
+	}
+
+	assertNext(1, 0, 0, 1)
+	release("1.0.0-preview.1")
+	assertNext(1, 0, 0, 2)
+
+	release("1.0.0")
+	if _, err := cl.NextPrereleaseNumber(1, 0, 0); !errors.Is(err, changelog.ErrVersionExists) {
+		t.Fatalf("NextPrereleaseNumber(1.0.0) after stable error = %v, want ErrVersionExists", err)
+	}
+
+	assertNext(1, 1, 0, 1)
+	release("1.1.0-preview.1")
+	assertNext(1, 1, 0, 2)
+	release("1.1.0-preview.2")
+	assertNext(1, 1, 0, 3)
+	release("1.1.0")
+
+	assertNext(1, 2, 0, 1)
+	release("1.2.0-preview.1")
+	assertNext(1, 2, 0, 2)
+}
+
+func TestNextPrereleaseNumber_IgnoresOtherIdentifiers(t *testing.T) {
+	cl, err := changelog.Parse(`# Changelog
+
+## [Unreleased]
+
+## [2.0.0-rc.7] - 2024-01-03
+
+### Added
+
+- Release candidate
+
+## [2.0.0-preview.10] - 2024-01-02
+
+### Added
+
+- Tenth preview
+
+## [2.0.0-preview.9] - 2024-01-01
+
+### Added
+
+- Ninth preview
+`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	got, err := cl.NextPrereleaseNumber(2, 0, 0)
+	if err != nil {
+		t.Fatalf("NextPrereleaseNumber() error = %v", err)
+	}
+	if got != 11 {
+		t.Errorf("NextPrereleaseNumber() = %d, want 11", got)
+	}
+
+	if _, err := cl.NextPrereleaseNumber(-1, 0, 0); !errors.Is(err, changelog.ErrInvalidVersion) {
+		t.Errorf("NextPrereleaseNumber(-1, 0, 0) error = %v, want ErrInvalidVersion", err)
+	}
+}
+
 func TestValidateUnreleased(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -729,6 +1490,131 @@ func TestParseWithDiff(t *testing.T) {
 	}
 }
 
+const sampleDiffReleasedSection = `diff --git a/src/cli/CHANGELOG.md b/src/cli/CHANGELOG.md
+index abc123..def456 100644
+--- a/src/cli/CHANGELOG.md
++++ b/src/cli/CHANGELOG.md
+@@ -1,12 +1,17 @@
+ # Changelog
+
+ ## [Unreleased]
+
++### Fixed
++
++- Unreleased fix
++
+ ## [1.0.0] - 2024-01-15
+
+ ### Added
+
+ - Initial
++- Sneaked in
+`
+
+const sampleDiffPromotion = `diff --git a/src/cli/CHANGELOG.md b/src/cli/CHANGELOG.md
+index abc123..def456 100644
+--- a/src/cli/CHANGELOG.md
++++ b/src/cli/CHANGELOG.md
+@@ -1,12 +1,18 @@
+ # Changelog
+
+ ## [Unreleased]
+
++## [1.1.0] - 2024-02-01
++
++### Fixed
++
++- Promoted fix
++
+ ## [1.0.0] - 2024-01-15
+
+ ### Added
+`
+
+func TestParseWithDiff_ReleasedEntries(t *testing.T) {
+	tests := []struct {
+		name    string
+		diff    string
+		want    []changelog.ReleasedEntry
+		wantErr bool
+	}{
+		{
+			name: "entry under released version",
+			diff: sampleDiffReleasedSection,
+			want: []changelog.ReleasedEntry{
+				{Version: "1.0.0", Entry: changelog.Entry{Category: "Added", Text: "Sneaked in"}},
+			},
+			wantErr: true,
+		},
+		{name: "entry under unreleased", diff: sampleDiffMultipleEntries, want: nil, wantErr: false},
+		{name: "new version section", diff: sampleDiffPromotion, want: nil, wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl, err := changelog.ParseWithDiff("", tt.diff, testChangelogPath)
+			if err != nil {
+				t.Fatalf("ParseWithDiff() error = %v", err)
+			}
+			if !slices.Equal(cl.ReleasedEntries, tt.want) {
+				t.Errorf("ReleasedEntries = %+v, want %+v", cl.ReleasedEntries, tt.want)
+			}
+
+			err = cl.ValidateAddedEntries()
+			if tt.wantErr != errors.Is(err, changelog.ErrEntryInReleased) {
+				t.Errorf("ValidateAddedEntries() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDuplicateUnreleasedEntries(t *testing.T) {
+	const released = `## [1.1.0] - 2024-02-01
+
+### Added
+
+- Shared feature
+
+### Fixed
+
+- Shared fix
+
+## [1.0.0] - 2024-01-15
+
+### Fixed
+
+- Older fix
+`
+	tests := []struct {
+		name       string
+		unreleased string
+		want       []changelog.Entry
+	}{
+		{
+			name:       "duplicated entry",
+			unreleased: "### Added\n\n- New feature\n\n### Fixed\n\n- Shared fix\n",
+			want:       []changelog.Entry{{Category: "Fixed", Text: "Shared fix"}},
+		},
+		{
+			name:       "distinct entries",
+			unreleased: "### Added\n\n- Shared fix\n\n### Fixed\n\n- Older fix\n",
+			want:       nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl, err := changelog.Parse("# Changelog\n\n## [Unreleased]\n\n" + tt.unreleased + "\n" + released)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := cl.DuplicateUnreleasedEntries(); !slices.Equal(got, tt.want) {
+				t.Errorf("DuplicateUnreleasedEntries() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestParseWithDiff_BackportStyle(t *testing.T) {
 	cl, err := changelog.ParseWithDiff("", sampleDiffBackport, testChangelogPath)
 	if err != nil {
@@ -742,6 +1628,72 @@ func TestParseWithDiff_BackportStyle(t *testing.T) {
 	}
 }
 
+func TestParseWithDiff_ChangelogPathOverride(t *testing.T) {
+	const relocatedDiff = `diff --git a/docs/CHANGELOG.md.orig b/docs/CHANGELOG.md.orig
+index abc123..def456 100644
+--- a/docs/CHANGELOG.md.orig
++++ b/docs/CHANGELOG.md.orig
+@@ -1,3 +1,7 @@
+ ## [Unreleased]
++
++### Added
++
++- Backup entry
+diff --git a/docs/CHANGELOG.md b/docs/CHANGELOG.md
+index abc123..def456 100644
+--- a/docs/CHANGELOG.md
++++ b/docs/CHANGELOG.md
+@@ -1,3 +1,7 @@
+ ## [Unreleased]
++
++### Fixed
++
++- Relocated entry
+`
+
+	for _, path := range []string{"docs/CHANGELOG.md", "./docs/CHANGELOG.md", "docs//CHANGELOG.md"} {
+		t.Run(path, func(t *testing.T) {
+			cl, err := changelog.ParseWithDiff("", relocatedDiff, path)
+			if err != nil {
+				t.Fatalf("ParseWithDiff() error = %v", err)
+			}
+			want := []changelog.Entry{{Category: "Fixed", Text: "Relocated entry"}}
+			if !slices.Equal(cl.AddedEntries, want) {
+				t.Fatalf("AddedEntries = %+v, want %+v", cl.AddedEntries, want)
+			}
+		})
+	}
+}
+
+func TestParseWithDiff_WindowsPathSeparators(t *testing.T) {
+	const diffTemplate = `diff --git a/%[1]s b/%[1]s
+index abc123..def456 100644
+--- a/%[1]s
++++ b/%[1]s
+@@ -1,3 +1,7 @@
+ ## [Unreleased]
++
++### Fixed
++
++- Windows entry
+`
+
+	want := []changelog.Entry{{Category: "Fixed", Text: "Windows entry"}}
+	for _, headerPath := range []string{"src/cli/CHANGELOG.md", `src\cli\CHANGELOG.md`} {
+		for _, configPath := range []string{"src/cli/CHANGELOG.md", `src\cli\CHANGELOG.md`, `src/cli\CHANGELOG.md`} {
+			t.Run(headerPath+" vs "+configPath, func(t *testing.T) {
+				cl, err := changelog.ParseWithDiff("", fmt.Sprintf(diffTemplate, headerPath), configPath)
+				if err != nil {
+					t.Fatalf("ParseWithDiff() error = %v", err)
+				}
+				if !slices.Equal(cl.AddedEntries, want) {
+					t.Fatalf("AddedEntries = %+v, want %+v", cl.AddedEntries, want)
+				}
+			})
+		}
+	}
+}
+
 func TestInsertEntries(t *testing.T) {
 	tests := []struct {
 		wantErr  error
@@ -896,7 +1848,7 @@ func TestParse_InvalidCategory(t *testing.T) {
 
 - Some entry`,
 			wantCategory:  "Invalid",
-			wantValidList: "Added, Changed, Fixed, Removed, Security, Deprecated",
+			wantValidList: "Migration, Breaking, Added, Changed, Fixed, Removed, Security, Deprecated",
 		},
 		{
 			name: "invalid category in version",
@@ -904,11 +1856,11 @@ func TestParse_InvalidCategory(t *testing.T) {
 
 ## [1.0.0] - 2024-01-01
 
-### Breaking
+### Improved
 
-- Breaking change`,
-			wantCategory:  "Breaking",
-			wantValidList: "Added, Changed, Fixed, Removed, Security, Deprecated",
+- Faster startup`,
+			wantCategory:  "Improved",
+			wantValidList: "Migration, Breaking, Added, Changed, Fixed, Removed, Security, Deprecated",
 		},
 		{
 			name: "typo in category",
@@ -920,7 +1872,7 @@ func TestParse_InvalidCategory(t *testing.T) {
 
 - Feature`,
 			wantCategory:  "Adedd",
-			wantValidList: "Added, Changed, Fixed, Removed, Security, Deprecated",
+			wantValidList: "Migration, Breaking, Added, Changed, Fixed, Removed, Security, Deprecated",
 		},
 		{
 			name: "lowercase category",
@@ -932,7 +1884,7 @@ func TestParse_InvalidCategory(t *testing.T) {
 
 - Feature`,
 			wantCategory:  "added",
-			wantValidList: "Added, Changed, Fixed, Removed, Security, Deprecated",
+			wantValidList: "Migration, Breaking, Added, Changed, Fixed, Removed, Security, Deprecated",
 		},
 	}
 
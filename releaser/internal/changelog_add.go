@@ -0,0 +1,158 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"altinn.studio/releaser/internal/changelog"
+	"altinn.studio/releaser/internal/perm"
+)
+
+// ChangelogAddRequest describes an entry to add to a component's [Unreleased] section.
+type ChangelogAddRequest struct {
+	Component     string // Component name (required, e.g., "studioctl")
+	Category      string // Changelog category (required, e.g., "Fixed")
+	Message       string // Entry text (required), with or without a leading "- "
+	ChangelogPath string // Optional: override component's default changelog path
+	DryRun        bool   // Render the resulting section without writing
+	Stage         bool   // Stage the changelog with git add after writing
+}
+
+// ChangelogAddResult is the outcome of adding a changelog entry.
+type ChangelogAddResult struct {
+	Path       string // changelog path relative to the repo root
+	Unreleased string // rendered [Unreleased] section after the insert
+}
+
+// RunChangelogAdd inserts an entry into the component's [Unreleased] section.
+func RunChangelogAdd(ctx context.Context, req ChangelogAddRequest, log Logger) (*ChangelogAddResult, error) {
+	if log == nil {
+		log = NopLogger{}
+	}
+	git := NewGitCLI(WithLogger(log))
+	return RunChangelogAddWithDeps(ctx, req, git)
+}
+
+// RunChangelogAddWithDeps inserts a changelog entry with injected git dependency.
+func RunChangelogAddWithDeps(ctx context.Context, req ChangelogAddRequest, git *GitCLI) (*ChangelogAddResult, error) {
+	if ctx == nil {
+		return nil, errContextRequired
+	}
+	if req.Component == "" {
+		return nil, errComponentRequired
+	}
+	if req.Category == "" {
+		return nil, errEntryCategoryRequired
+	}
+	text := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(req.Message), "- "))
+	if text == "" {
+		return nil, errEntryMessageRequired
+	}
+	if err := changelog.ValidateCategory(req.Category); err != nil {
+		return nil, fmt.Errorf("validate category: %w", err)
+	}
+	if git == nil {
+		return nil, errGitRequired
+	}
+
+	clPath, changelogFile, cl, err := readWorkingTreeChangelog(ctx, git, req.Component, req.ChangelogPath)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := cl.InsertEntries([]changelog.Entry{{Category: req.Category, Text: text}})
+	if err != nil {
+		return nil, fmt.Errorf("insert changelog entry: %w", err)
+	}
+	comp, err := GetComponent(req.Component)
+	if err != nil {
+		return nil, fmt.Errorf("get component: %w", err)
+	}
+	if err := updated.ValidateCalloutCategory(comp.Callout()); err != nil {
+		return nil, fmt.Errorf("validate category: %w", err)
+	}
+
+	result := &ChangelogAddResult{
+		Path:       clPath,
+		Unreleased: renderUnreleased(updated.Unreleased),
+	}
+	if req.DryRun {
+		return result, nil
+	}
+
+	if err := os.WriteFile(changelogFile, []byte(updated.String()), perm.FilePermDefault); err != nil {
+		return nil, fmt.Errorf("write changelog: %w", err)
+	}
+	if req.Stage {
+		if err := git.RunWrite(ctx, "add", "--", changelogFile); err != nil {
+			return nil, fmt.Errorf("stage changelog: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// readWorkingTreeChangelog reads and parses the component changelog from the working tree.
+// It returns the repo-relative path, the absolute file path and the parsed changelog.
+func readWorkingTreeChangelog(
+	ctx context.Context,
+	git *GitCLI,
+	component, override string,
+) (string, string, *changelog.Changelog, error) {
+	comp, err := GetComponent(component)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("get component: %w", err)
+	}
+	clPath, changelogFile, err := resolveWorkingTreeChangelog(ctx, git, comp, override)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	//nolint:gosec // G304: changelog path resolved from trusted component config/request.
+	content, err := os.ReadFile(changelogFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", "", nil, changelogFileMissingError(comp, changelogFile, "", changelogPathHint)
+	}
+	if err != nil {
+		return "", "", nil, fmt.Errorf("read changelog: %w", err)
+	}
+	cl, err := changelog.Parse(string(content))
+	if err != nil {
+		return "", "", nil, fmt.Errorf("parse changelog: %w", err)
+	}
+	return clPath, changelogFile, cl, nil
+}
+
+// resolveWorkingTreeChangelog returns the repo-relative and absolute path of the component
+// changelog, or of override when set.
+func resolveWorkingTreeChangelog(
+	ctx context.Context,
+	git *GitCLI,
+	comp *Component,
+	override string,
+) (string, string, error) {
+	root, err := git.RepoRoot(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("get repo root: %w", err)
+	}
+
+	clPath := comp.ChangelogPath
+	if override != "" {
+		clPath, err = repoRelativePath(root, override)
+		if err != nil {
+			return "", "", fmt.Errorf("resolve changelog path: %w", err)
+		}
+	}
+	clPath = changelog.NormalizePath(clPath)
+	return clPath, filepath.Join(root, filepath.FromSlash(clPath)), nil
+}
+
+func renderUnreleased(section *changelog.Section) string {
+	content := section.String()
+	if content == "" {
+		return "## [Unreleased]\n"
+	}
+	return "## [Unreleased]\n\n" + content + "\n"
+}
@@ -0,0 +1,170 @@
+package internal_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"altinn.studio/releaser/internal"
+	"altinn.studio/releaser/internal/changelog"
+)
+
+const changelogAddBase = `# Changelog
+
+## [Unreleased]
+
+### Fixed
+
+- Existing fix
+
+## [1.0.0] - 2025-01-01
+
+### Added
+
+- Initial
+`
+
+func TestRunChangelogAdd(t *testing.T) {
+	tests := []struct {
+		name     string
+		category string
+		want     string
+	}{
+		{
+			name:     "existing category",
+			category: "Fixed",
+			want: `## [Unreleased]
+
+### Fixed
+
+- Fix X (#123)
+- Existing fix
+`,
+		},
+		{
+			name:     "new category",
+			category: "Added",
+			want: `## [Unreleased]
+
+### Added
+
+- Fix X (#123)
+
+### Fixed
+
+- Existing fix
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := createStudioctlWorkflowRepo(t, changelogAddBase)
+			t.Chdir(repo)
+
+			result, err := internal.RunChangelogAdd(t.Context(), internal.ChangelogAddRequest{
+				Component: "studioctl",
+				Category:  tt.category,
+				Message:   "- Fix X (#123)",
+				Stage:     true,
+			}, internal.NopLogger{})
+			if err != nil {
+				t.Fatalf("RunChangelogAdd() error = %v", err)
+			}
+			if result.Unreleased != tt.want {
+				t.Errorf("RunChangelogAdd() unreleased =\n%s\nwant\n%s", result.Unreleased, tt.want)
+			}
+
+			content := readChangelog(t, repo)
+			if !strings.Contains(content, tt.want) {
+				t.Errorf("changelog =\n%s\nwant it to contain\n%s", content, tt.want)
+			}
+			if !strings.Contains(content, "## [1.0.0] - 2025-01-01\n\n### Added\n\n- Initial\n") {
+				t.Errorf("released section changed:\n%s", content)
+			}
+			if staged := gitOut(t, repo, "diff", "--cached", "--name-only"); staged != "src/cli/CHANGELOG.md" {
+				t.Errorf("staged files = %q, want changelog", staged)
+			}
+		})
+	}
+}
+
+func TestRunChangelogAdd_DryRunDoesNotWrite(t *testing.T) {
+	repo := createStudioctlWorkflowRepo(t, changelogAddBase)
+	t.Chdir(repo)
+
+	result, err := internal.RunChangelogAdd(t.Context(), internal.ChangelogAddRequest{
+		Component: "studioctl",
+		Category:  "Changed",
+		Message:   "Rename Y",
+		DryRun:    true,
+	}, internal.NopLogger{})
+	if err != nil {
+		t.Fatalf("RunChangelogAdd() error = %v", err)
+	}
+	if !strings.Contains(result.Unreleased, "### Changed\n\n- Rename Y\n") {
+		t.Errorf("RunChangelogAdd() unreleased = %q, want new Changed entry", result.Unreleased)
+	}
+	if content := readChangelog(t, repo); content != changelogAddBase {
+		t.Errorf("dry run modified changelog:\n%s", content)
+	}
+}
+
+func TestRunChangelogAdd_InvalidCategory(t *testing.T) {
+	tests := []struct {
+		name     string
+		category string
+	}{
+		{name: "unknown category", category: "Improved"},
+		{name: "other callout category", category: "Breaking"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := createStudioctlWorkflowRepo(t, changelogAddBase)
+			t.Chdir(repo)
+
+			_, err := internal.RunChangelogAdd(t.Context(), internal.ChangelogAddRequest{
+				Component: "studioctl",
+				Category:  tt.category,
+				Message:   "Faster",
+			}, internal.NopLogger{})
+			if !errors.Is(err, changelog.ErrInvalidCategory) {
+				t.Fatalf("RunChangelogAdd() error = %v, want ErrInvalidCategory", err)
+			}
+			if content := readChangelog(t, repo); content != changelogAddBase {
+				t.Errorf("invalid category modified changelog:\n%s", content)
+			}
+		})
+	}
+}
+
+func readChangelog(t *testing.T, repo string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join(repo, "src", "cli", "CHANGELOG.md"))
+	if err != nil {
+		t.Fatalf("read changelog: %v", err)
+	}
+	return string(content)
+}
+
+func TestRunChangelogAdd_MissingFileSuggestsFlag(t *testing.T) {
+	repo := createStudioctlWorkflowRepo(t, changelogAddBase)
+	t.Chdir(repo)
+
+	_, err := internal.RunChangelogAdd(t.Context(), internal.ChangelogAddRequest{
+		Component:     "studioctl",
+		Category:      "Fixed",
+		Message:       "Fix Z",
+		ChangelogPath: "docs/CHANGELOG.md",
+	}, internal.NopLogger{})
+	if !errors.Is(err, internal.ErrChangelogFileMissing) {
+		t.Fatalf("RunChangelogAdd() error = %v, want %v", err, internal.ErrChangelogFileMissing)
+	}
+	if !strings.Contains(err.Error(), "-changelog-path") {
+		t.Errorf("RunChangelogAdd() error = %v, want it to suggest -changelog-path", err)
+	}
+}
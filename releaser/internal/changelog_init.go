@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"altinn.studio/releaser/internal/changelog"
+	"altinn.studio/releaser/internal/perm"
+)
+
+// ErrChangelogExists indicates init-changelog would overwrite an existing changelog.
+var ErrChangelogExists = errors.New("changelog already exists")
+
+// ChangelogInitRequest describes a new component changelog to scaffold.
+type ChangelogInitRequest struct {
+	Component     string // Component name (required, e.g., "studioctl")
+	ChangelogPath string // Optional: override component's default changelog path
+	Force         bool   // Overwrite an existing changelog
+}
+
+// RunChangelogInit writes a changelog skeleton (see changelog.Skeleton) at the component's
+// changelog path and returns that path relative to the repo root.
+func RunChangelogInit(ctx context.Context, req ChangelogInitRequest, log Logger) (string, error) {
+	if log == nil {
+		log = NopLogger{}
+	}
+	git := NewGitCLI(WithLogger(log))
+	return RunChangelogInitWithDeps(ctx, req, git)
+}
+
+// RunChangelogInitWithDeps scaffolds a component changelog with injected git dependency.
+func RunChangelogInitWithDeps(ctx context.Context, req ChangelogInitRequest, git *GitCLI) (string, error) {
+	if ctx == nil {
+		return "", errContextRequired
+	}
+	if req.Component == "" {
+		return "", errComponentRequired
+	}
+	if git == nil {
+		return "", errGitRequired
+	}
+
+	comp, err := GetComponent(req.Component)
+	if err != nil {
+		return "", fmt.Errorf("get component: %w", err)
+	}
+	clPath, changelogFile, err := resolveWorkingTreeChangelog(ctx, git, comp, req.ChangelogPath)
+	if err != nil {
+		return "", err
+	}
+
+	if !req.Force {
+		if _, err := os.Stat(changelogFile); err == nil {
+			return "", fmt.Errorf("%w: %s (use -force to overwrite)", ErrChangelogExists, clPath)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("stat changelog: %w", err)
+		}
+	}
+
+	content := changelog.Skeleton(comp.Name)
+	cl, err := changelog.Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("parse changelog skeleton: %w", err)
+	}
+	if err := cl.ValidatePreamble(); err != nil {
+		return "", fmt.Errorf("validate changelog skeleton: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(changelogFile), perm.DirPermDefault); err != nil {
+		return "", fmt.Errorf("create changelog directory: %w", err)
+	}
+	if err := os.WriteFile(changelogFile, []byte(content), perm.FilePermDefault); err != nil {
+		return "", fmt.Errorf("write changelog: %w", err)
+	}
+	return clPath, nil
+}
@@ -0,0 +1,65 @@
+package internal_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"altinn.studio/releaser/internal"
+	"altinn.studio/releaser/internal/changelog"
+)
+
+func TestRunChangelogInit(t *testing.T) {
+	repo := createStudioctlWorkflowRepo(t, "# Changelog\n")
+	if err := os.Remove(filepath.Join(repo, "src", "cli", "CHANGELOG.md")); err != nil {
+		t.Fatalf("remove changelog: %v", err)
+	}
+	t.Chdir(repo)
+
+	path, err := internal.RunChangelogInit(t.Context(), internal.ChangelogInitRequest{Component: "studioctl"}, nil)
+	if err != nil {
+		t.Fatalf("RunChangelogInit() error = %v", err)
+	}
+	if path != "src/cli/CHANGELOG.md" {
+		t.Errorf("RunChangelogInit() path = %q, want src/cli/CHANGELOG.md", path)
+	}
+
+	content := readChangelog(t, repo)
+	if content != changelog.Skeleton("studioctl") {
+		t.Errorf("changelog =\n%s\nwant skeleton", content)
+	}
+	cl, err := changelog.Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if err := cl.ValidatePreamble(); err != nil {
+		t.Errorf("ValidatePreamble() error = %v", err)
+	}
+	if cl.Unreleased == nil || len(cl.Unreleased.Categories) != 0 || len(cl.Versions) != 0 {
+		t.Errorf("parsed skeleton = %+v, want only an empty [Unreleased]", cl)
+	}
+}
+
+func TestRunChangelogInit_RefusesOverwrite(t *testing.T) {
+	const existing = "# Changelog\n\n## [Unreleased]\n\n### Fixed\n\n- Keep me\n"
+	repo := createStudioctlWorkflowRepo(t, existing)
+	t.Chdir(repo)
+
+	req := internal.ChangelogInitRequest{Component: "studioctl"}
+	_, err := internal.RunChangelogInit(t.Context(), req, nil)
+	if !errors.Is(err, internal.ErrChangelogExists) {
+		t.Fatalf("RunChangelogInit() error = %v, want %v", err, internal.ErrChangelogExists)
+	}
+	if content := readChangelog(t, repo); content != existing {
+		t.Fatalf("changelog overwritten without -force:\n%s", content)
+	}
+
+	req.Force = true
+	if _, err := internal.RunChangelogInit(t.Context(), req, nil); err != nil {
+		t.Fatalf("RunChangelogInit() with Force error = %v", err)
+	}
+	if content := readChangelog(t, repo); content != changelog.Skeleton("studioctl") {
+		t.Fatalf("changelog not replaced with Force:\n%s", content)
+	}
+}
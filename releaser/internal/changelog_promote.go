@@ -0,0 +1,81 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"altinn.studio/releaser/internal/changelog"
+	semver "altinn.studio/releaser/internal/version"
+)
+
+// ChangelogPromoteRequest describes an offline promotion preview of a component changelog.
+type ChangelogPromoteRequest struct {
+	Date          time.Time // Release date for the new section (default: today, UTC)
+	Clock         Clock     // Optional: tells today when Date is zero (default: SystemClock)
+	Component     string    // Component name (required, e.g., "studioctl")
+	Version       string    // Version to promote to (required, e.g., "v1.2.0")
+	ChangelogPath string    // Optional: override component's default changelog path
+	// Format is the output format: keepachangelog (default) or common.
+	Format string
+	// PromotePrerelease builds the stable Version from its prerelease sections, like prepare -promote-prerelease.
+	PromotePrerelease bool
+}
+
+// RunChangelogPromote returns the working-tree changelog with [Unreleased] promoted to req.Version.
+// Nothing is written, fetched, branched or committed; git is only used to locate the repo root.
+func RunChangelogPromote(ctx context.Context, req ChangelogPromoteRequest, log Logger) (string, error) {
+	if log == nil {
+		log = NopLogger{}
+	}
+	git := NewGitCLI(WithLogger(log))
+	return RunChangelogPromoteWithDeps(ctx, req, git)
+}
+
+// RunChangelogPromoteWithDeps previews a changelog promotion with injected git dependency.
+func RunChangelogPromoteWithDeps(ctx context.Context, req ChangelogPromoteRequest, git *GitCLI) (string, error) {
+	if ctx == nil {
+		return "", errContextRequired
+	}
+	if req.Component == "" {
+		return "", errComponentRequired
+	}
+	if req.Version == "" {
+		return "", errReleaseVersionRequired
+	}
+	if git == nil {
+		return "", errGitRequired
+	}
+
+	verStr := req.Version
+	if !strings.HasPrefix(verStr, "v") {
+		verStr = "v" + verStr
+	}
+	if _, err := semver.Parse(verStr); err != nil {
+		return "", fmt.Errorf("parse version: %w", err)
+	}
+	format, err := changelog.ParseFormat(req.Format)
+	if err != nil {
+		return "", err
+	}
+
+	_, _, cl, err := readWorkingTreeChangelog(ctx, git, req.Component, req.ChangelogPath)
+	if err != nil {
+		return "", err
+	}
+
+	date := req.Date
+	if date.IsZero() {
+		date = clockOrSystem(req.Clock).Now().UTC()
+	}
+	promote := cl.Promote
+	if req.PromotePrerelease {
+		promote = cl.PromotePrerelease
+	}
+	promoted, err := promote(verStr, date)
+	if err != nil {
+		return "", fmt.Errorf("promote changelog to %s: %w", verStr, err)
+	}
+	return promoted.Render(format)
+}
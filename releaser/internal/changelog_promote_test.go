@@ -0,0 +1,132 @@
+package internal_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"altinn.studio/releaser/internal"
+	"altinn.studio/releaser/internal/changelog"
+	"altinn.studio/releaser/internal/version"
+)
+
+func TestRunChangelogPromote(t *testing.T) {
+	const base = `# Changelog
+
+## [Unreleased]
+
+### Fixed
+
+- Pending fix
+
+## [1.1.0] - 2025-02-01
+
+### Added
+
+- Feature
+`
+	releaseDate := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		wantErr error
+		name    string
+		content string
+		version string
+		format  string
+		want    string
+	}{
+		{
+			name:    "promotes unreleased",
+			content: base,
+			version: "1.2.0",
+			want: `# Changelog
+
+## [Unreleased]
+
+## [1.2.0] - 2025-03-01
+
+### Fixed
+
+- Pending fix
+
+## [1.1.0] - 2025-02-01
+
+### Added
+
+- Feature
+`,
+		},
+		{
+			name:    "common changelog format",
+			content: base,
+			version: "1.2.0",
+			format:  "common",
+			want: `# Changelog
+
+## [1.2.0] - 2025-03-01
+
+### Fixed
+
+- Pending fix
+
+## [1.1.0] - 2025-02-01
+
+### Added
+
+- Feature
+`,
+		},
+		{
+			name:    "invalid format",
+			content: base,
+			version: "1.2.0",
+			format:  "markdown",
+			wantErr: changelog.ErrInvalidFormat,
+		},
+		{name: "version exists", content: base, version: "v1.1.0", wantErr: changelog.ErrVersionExists},
+		{
+			name:    "unreleased empty",
+			content: "# Changelog\n\n## [Unreleased]\n\n## [1.1.0] - 2025-02-01\n\n### Added\n\n- Feature\n",
+			version: "v1.2.0",
+			wantErr: changelog.ErrUnreleasedEmpty,
+		},
+		{name: "invalid version", content: base, version: "1.2", wantErr: version.ErrInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := createStudioctlWorkflowRepo(t, tt.content)
+			t.Chdir(repo)
+			head := revParseHead(t, repo)
+
+			got, err := internal.RunChangelogPromote(t.Context(), internal.ChangelogPromoteRequest{
+				Date:      releaseDate,
+				Component: "studioctl",
+				Version:   tt.version,
+				Format:    tt.format,
+			}, internal.NopLogger{})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("RunChangelogPromote() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RunChangelogPromote() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RunChangelogPromote() =\n%s\nwant\n%s", got, tt.want)
+			}
+
+			if content := readChangelog(t, repo); content != tt.content {
+				t.Errorf("changelog file modified:\n%s", content)
+			}
+			if status := gitOut(t, repo, "status", "--porcelain"); status != "" {
+				t.Errorf("working tree changed: %s", status)
+			}
+			if after := revParseHead(t, repo); after != head {
+				t.Errorf("HEAD moved from %s to %s", head, after)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package internal
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"altinn.studio/releaser/internal/changelog"
+)
+
+const hoursPerDay = 24
+
+// ChangelogStatsRequest describes a release velocity report for a component changelog.
+type ChangelogStatsRequest struct {
+	Component     string // Component name (required, e.g., "studioctl")
+	ChangelogPath string // Optional: override component's default changelog path
+}
+
+// ChangelogStats summarizes the released versions of a changelog.
+type ChangelogStats struct {
+	Releases []ReleaseStats `json:"releases"` // newest first, as in the changelog
+	// AvgEntriesPerRelease is the mean entry count over all released versions.
+	AvgEntriesPerRelease float64 `json:"avgEntriesPerRelease"`
+	// MedianDaysBetweenReleases is the median of DaysSincePrevious; 0 without any interval.
+	MedianDaysBetweenReleases float64 `json:"medianDaysBetweenReleases"`
+}
+
+// ReleaseStats holds the entry counts of one released version.
+type ReleaseStats struct {
+	// DaysSincePrevious is the number of days since the next older release; nil for
+	// the oldest release or when either section has no date.
+	DaysSincePrevious *int            `json:"daysSincePrevious,omitempty"`
+	Version           string          `json:"version"`
+	Date              string          `json:"date,omitempty"` // YYYY-MM-DD
+	Categories        []CategoryCount `json:"categories"`     // in changelog order
+	Entries           int             `json:"entries"`
+}
+
+// CategoryCount is the number of entries under a category header.
+type CategoryCount struct {
+	Name    string `json:"name"`
+	Entries int    `json:"entries"`
+}
+
+// RunChangelogStats computes release velocity metrics from the working-tree changelog.
+func RunChangelogStats(ctx context.Context, req ChangelogStatsRequest, log Logger) (ChangelogStats, error) {
+	if log == nil {
+		log = NopLogger{}
+	}
+	git := NewGitCLI(WithLogger(log))
+	return RunChangelogStatsWithDeps(ctx, req, git)
+}
+
+// RunChangelogStatsWithDeps computes changelog stats with injected git dependency.
+func RunChangelogStatsWithDeps(ctx context.Context, req ChangelogStatsRequest, git *GitCLI) (ChangelogStats, error) {
+	if ctx == nil {
+		return ChangelogStats{}, errContextRequired
+	}
+	if req.Component == "" {
+		return ChangelogStats{}, errComponentRequired
+	}
+	if git == nil {
+		return ChangelogStats{}, errGitRequired
+	}
+
+	_, _, cl, err := readWorkingTreeChangelog(ctx, git, req.Component, req.ChangelogPath)
+	if err != nil {
+		return ChangelogStats{}, err
+	}
+	return ComputeChangelogStats(cl), nil
+}
+
+// ComputeChangelogStats computes per-release entry counts and the days between releases.
+func ComputeChangelogStats(cl *changelog.Changelog) ChangelogStats {
+	stats := ChangelogStats{
+		Releases:                  make([]ReleaseStats, 0, len(cl.Versions)),
+		AvgEntriesPerRelease:      0,
+		MedianDaysBetweenReleases: 0,
+	}
+
+	totalEntries := 0
+	var intervals []int
+	for i, section := range cl.Versions {
+		release := ReleaseStats{
+			DaysSincePrevious: nil,
+			Version:           section.Version.String(),
+			Date:              "",
+			Categories:        make([]CategoryCount, 0, len(section.Categories)),
+			Entries:           0,
+		}
+		if !section.Date.IsZero() {
+			release.Date = section.Date.Format(time.DateOnly)
+		}
+		for _, cat := range section.Categories {
+			release.Categories = append(release.Categories, CategoryCount{Name: cat.Name, Entries: len(cat.Entries)})
+			release.Entries += len(cat.Entries)
+		}
+		if i+1 < len(cl.Versions) {
+			previous := cl.Versions[i+1].Date
+			if !section.Date.IsZero() && !previous.IsZero() {
+				days := int(section.Date.Sub(previous).Hours() / hoursPerDay)
+				release.DaysSincePrevious = &days
+				intervals = append(intervals, days)
+			}
+		}
+
+		totalEntries += release.Entries
+		stats.Releases = append(stats.Releases, release)
+	}
+
+	if len(stats.Releases) > 0 {
+		stats.AvgEntriesPerRelease = float64(totalEntries) / float64(len(stats.Releases))
+	}
+	stats.MedianDaysBetweenReleases = median(intervals)
+	return stats
+}
+
+func median(values []int) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2 //nolint:mnd // halfway point
+	if len(sorted)%2 == 1 {
+		return float64(sorted[mid])
+	}
+	return float64(sorted[mid-1]+sorted[mid]) / 2 //nolint:mnd // mean of the two middle values
+}
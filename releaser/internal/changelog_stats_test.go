@@ -0,0 +1,157 @@
+package internal_test
+
+import (
+	"reflect"
+	"testing"
+
+	"altinn.studio/releaser/internal"
+	"altinn.studio/releaser/internal/changelog"
+)
+
+const statsChangelog = `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- Not released yet
+
+## [1.2.0] - 2025-03-31
+
+### Added
+
+- Feature C
+- Feature D
+
+### Fixed
+
+- Fix B
+
+## [1.1.0] - 2025-03-01
+
+### Fixed
+
+- Fix A
+
+## [1.0.1]
+
+### Security
+
+- Patch
+
+## [1.0.0] - 2025-01-20
+
+### Added
+
+- Feature A
+- Feature B
+`
+
+func TestComputeChangelogStats(t *testing.T) {
+	t.Parallel()
+
+	cl, err := changelog.Parse(statsChangelog)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	got := internal.ComputeChangelogStats(cl)
+
+	want := []internal.ReleaseStats{
+		{
+			DaysSincePrevious: intPtr(30),
+			Version:           "v1.2.0",
+			Date:              "2025-03-31",
+			Categories:        []internal.CategoryCount{{Name: "Added", Entries: 2}, {Name: "Fixed", Entries: 1}},
+			Entries:           3,
+		},
+		{
+			DaysSincePrevious: nil, // 1.0.1 has no date
+			Version:           "v1.1.0",
+			Date:              "2025-03-01",
+			Categories:        []internal.CategoryCount{{Name: "Fixed", Entries: 1}},
+			Entries:           1,
+		},
+		{
+			DaysSincePrevious: nil,
+			Version:           "v1.0.1",
+			Date:              "",
+			Categories:        []internal.CategoryCount{{Name: "Security", Entries: 1}},
+			Entries:           1,
+		},
+		{
+			DaysSincePrevious: nil,
+			Version:           "v1.0.0",
+			Date:              "2025-01-20",
+			Categories:        []internal.CategoryCount{{Name: "Added", Entries: 2}},
+			Entries:           2,
+		},
+	}
+	if !reflect.DeepEqual(got.Releases, want) {
+		t.Errorf("Releases =\n%+v\nwant\n%+v", got.Releases, want)
+	}
+	if got.AvgEntriesPerRelease != 1.75 {
+		t.Errorf("AvgEntriesPerRelease = %v, want 1.75", got.AvgEntriesPerRelease)
+	}
+	if got.MedianDaysBetweenReleases != 30 {
+		t.Errorf("MedianDaysBetweenReleases = %v, want 30", got.MedianDaysBetweenReleases)
+	}
+}
+
+func TestComputeChangelogStats_MedianOfEvenIntervals(t *testing.T) {
+	t.Parallel()
+
+	cl, err := changelog.Parse(`# Changelog
+
+## [Unreleased]
+
+## [1.3.0] - 2025-02-15
+
+### Fixed
+
+- C
+
+## [1.2.0] - 2025-02-05
+
+### Fixed
+
+- B
+
+## [1.1.0] - 2025-02-01
+
+### Fixed
+
+- A
+`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	got := internal.ComputeChangelogStats(cl)
+	if got.MedianDaysBetweenReleases != 7 {
+		t.Errorf("MedianDaysBetweenReleases = %v, want 7 (intervals 10 and 4)", got.MedianDaysBetweenReleases)
+	}
+	if got.AvgEntriesPerRelease != 1 {
+		t.Errorf("AvgEntriesPerRelease = %v, want 1", got.AvgEntriesPerRelease)
+	}
+}
+
+func TestRunChangelogStats(t *testing.T) {
+	repo := createStudioctlWorkflowRepo(t, statsChangelog)
+	t.Chdir(repo)
+
+	got, err := internal.RunChangelogStats(t.Context(), internal.ChangelogStatsRequest{
+		Component:     "studioctl",
+		ChangelogPath: "",
+	}, internal.NopLogger{})
+	if err != nil {
+		t.Fatalf("RunChangelogStats() error = %v", err)
+	}
+	if len(got.Releases) != 4 || got.Releases[0].Version != "v1.2.0" {
+		t.Errorf("RunChangelogStats() releases = %+v, want 4 starting at v1.2.0", got.Releases)
+	}
+}
+
+func intPtr(v int) *int {
+	return &v
+}
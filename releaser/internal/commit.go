@@ -0,0 +1,216 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidCommitIdentity indicates a malformed commit author name or email.
+var ErrInvalidCommitIdentity = errors.New("invalid commit identity")
+
+// emailPattern is deliberately loose: git accepts any address without whitespace or angle
+// brackets, and bot addresses such as 123+name[bot]@users.noreply.github.com are not RFC 5322 atoms.
+var emailPattern = regexp.MustCompile(`^[^\s<>@]+@[^\s<>@]+\.[^\s<>@]+$`)
+
+// CommitIdentity overrides the git user for the commits prepare and backport create,
+// e.g. to attribute CI release commits to a bot. Empty fields keep the git config value.
+type CommitIdentity struct {
+	Name  string
+	Email string
+}
+
+// IsZero reports whether no override is set.
+func (id CommitIdentity) IsZero() bool {
+	return id.Name == "" && id.Email == ""
+}
+
+// String renders the identity as "Name <email>", leaving out unset parts.
+func (id CommitIdentity) String() string {
+	switch {
+	case id.Email == "":
+		return id.Name
+	case id.Name == "":
+		return "<" + id.Email + ">"
+	default:
+		return id.Name + " <" + id.Email + ">"
+	}
+}
+
+// Validate checks the name is a single line without angle brackets, which git
+// rejects, and the email is a bare address such as bot@example.com.
+func (id CommitIdentity) Validate() error {
+	if strings.ContainsAny(id.Name, "<>\r\n") {
+		return fmt.Errorf("%w: name %q must not contain '<', '>' or line breaks", ErrInvalidCommitIdentity, id.Name)
+	}
+	if id.Email == "" {
+		return nil
+	}
+	if !emailPattern.MatchString(id.Email) {
+		return fmt.Errorf("%w: email %q is not a valid address", ErrInvalidCommitIdentity, id.Email)
+	}
+	return nil
+}
+
+// commitArgs returns the git arguments that create a commit with message, as the
+// identity when one is set. Config overrides set both author and committer.
+func commitArgs(id CommitIdentity, message string) []string {
+	var args []string
+	if id.Name != "" {
+		args = append(args, "-c", "user.name="+id.Name)
+	}
+	if id.Email != "" {
+		args = append(args, "-c", "user.email="+id.Email)
+	}
+	return append(args, "commit", "-m", message)
+}
+
+// commitAuthorLabel describes who a commit will be attributed to for confirmation prompts.
+func commitAuthorLabel(id CommitIdentity) string {
+	if id.IsZero() {
+		return "git config user"
+	}
+	return id.String()
+}
+
+// ErrInvalidTrailer indicates a malformed -trailer value.
+var ErrInvalidTrailer = errors.New("invalid trailer")
+
+// trailerKeyPattern matches git trailer tokens such as Release-Component or Backport-Of.
+var trailerKeyPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*$`)
+
+// Trailer is a "Key: value" git trailer appended to a commit message.
+type Trailer struct {
+	Key   string
+	Value string
+}
+
+// ParseTrailer parses a key=value flag value into a trailer and validates it.
+func ParseTrailer(raw string) (Trailer, error) {
+	key, value, ok := strings.Cut(raw, "=")
+	if !ok {
+		return Trailer{}, fmt.Errorf("%w: %q must be key=value", ErrInvalidTrailer, raw)
+	}
+	trailer := Trailer{Key: strings.TrimSpace(key), Value: strings.TrimSpace(value)}
+	if err := trailer.Validate(); err != nil {
+		return Trailer{}, err
+	}
+	return trailer, nil
+}
+
+// Validate checks the key is a git trailer token (letters, digits and hyphens) and
+// the value is a non-empty single line.
+func (t Trailer) Validate() error {
+	if !trailerKeyPattern.MatchString(t.Key) {
+		return fmt.Errorf("%w: key %q must contain only letters, digits and hyphens", ErrInvalidTrailer, t.Key)
+	}
+	if strings.TrimSpace(t.Value) == "" || strings.ContainsAny(t.Value, "\r\n") {
+		return fmt.Errorf("%w: %s value must be a non-empty single line", ErrInvalidTrailer, t.Key)
+	}
+	return nil
+}
+
+// String renders the trailer as a commit message line.
+func (t Trailer) String() string {
+	return t.Key + ": " + t.Value
+}
+
+func logTrailers(log Logger, trailers []Trailer) {
+	for _, trailer := range trailers {
+		log.Info("Would add trailer: %s", trailer)
+	}
+}
+
+func validateTrailers(trailers []Trailer) error {
+	for _, trailer := range trailers {
+		if err := trailer.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendTrailers adds trailers to message. They join an existing trailer block,
+// such as the "(cherry picked from commit ...)" line of a backport, so git keeps
+// reading them all as trailers; otherwise they start a new paragraph.
+func appendTrailers(message string, trailers []Trailer) string {
+	if len(trailers) == 0 {
+		return message
+	}
+	lines := make([]string, 0, len(trailers))
+	for _, trailer := range trailers {
+		lines = append(lines, trailer.String())
+	}
+	separator := "\n\n"
+	if endsWithTrailerBlock(message) {
+		separator = "\n"
+	}
+	return strings.TrimRight(message, "\n") + separator + strings.Join(lines, "\n")
+}
+
+func endsWithTrailerBlock(message string) bool {
+	paragraphs := strings.Split(strings.TrimRight(message, "\n"), "\n\n")
+	if len(paragraphs) < 2 {
+		return false // the subject line is never a trailer block
+	}
+	for line := range strings.SplitSeq(paragraphs[len(paragraphs)-1], "\n") {
+		if strings.HasPrefix(line, "(cherry picked from commit ") {
+			continue
+		}
+		key, _, ok := strings.Cut(line, ": ")
+		if !ok || !trailerKeyPattern.MatchString(key) {
+			return false
+		}
+	}
+	return true
+}
+
+// DefaultCommitTemplate is the prepare commit message used when no template is set.
+const DefaultCommitTemplate = "Release {title}"
+
+// ErrInvalidCommitTemplate indicates a commit message template with unknown placeholders
+// or no text.
+var ErrInvalidCommitTemplate = errors.New("invalid commit message template")
+
+// commitTemplatePlaceholder matches {name} placeholders in a commit message template.
+var commitTemplatePlaceholder = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// commitTemplatePlaceholders lists the placeholders renderCommitTemplate fills.
+//
+//nolint:gochecknoglobals // Read-only lookup table.
+var commitTemplatePlaceholders = []string{"component", "version", "tag", "title"}
+
+// renderCommitTemplate fills {component}, {version}, {tag} and {title} in template
+// from the release tag. Unknown placeholders are rejected so a typo cannot reach the
+// commit history.
+func renderCommitTemplate(template string, tag *Tag) (string, error) {
+	if template == "" {
+		template = DefaultCommitTemplate
+	}
+	values := map[string]string{
+		"component": tag.Component.Name,
+		"version":   tag.Version.String(),
+		"tag":       tag.Full(),
+		"title":     tag.Component.ReleaseTitle(tag.Version.String()),
+	}
+
+	var unknown []string
+	message := commitTemplatePlaceholder.ReplaceAllStringFunc(template, func(match string) string {
+		name := match[1 : len(match)-1]
+		value, ok := values[name]
+		if !ok {
+			unknown = append(unknown, match)
+			return match
+		}
+		return value
+	})
+	if len(unknown) > 0 {
+		return "", fmt.Errorf("%w: unknown placeholder %s (allowed: {%s})",
+			ErrInvalidCommitTemplate, strings.Join(unknown, ", "), strings.Join(commitTemplatePlaceholders, "}, {"))
+	}
+	if strings.TrimSpace(message) == "" {
+		return "", fmt.Errorf("%w: message is empty", ErrInvalidCommitTemplate)
+	}
+	return message, nil
+}
@@ -0,0 +1,81 @@
+package internal_test
+
+import (
+	"errors"
+	"testing"
+
+	"altinn.studio/releaser/internal"
+)
+
+func TestCommitIdentity_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		id      internal.CommitIdentity
+		wantErr bool
+	}{
+		{name: "unset", id: internal.CommitIdentity{}},
+		{name: "name and email", id: internal.CommitIdentity{Name: "release-bot", Email: "bot@example.com"}},
+		{name: "email only", id: internal.CommitIdentity{Email: "41898282+github-actions[bot]@users.noreply.github.com"}},
+		{name: "missing at", id: internal.CommitIdentity{Email: "bot.example.com"}, wantErr: true},
+		{name: "display name in email", id: internal.CommitIdentity{Email: "Bot <bot@example.com>"}, wantErr: true},
+		{name: "surrounding spaces", id: internal.CommitIdentity{Email: " bot@example.com"}, wantErr: true},
+		{name: "angle bracket in name", id: internal.CommitIdentity{Name: "bot <x>"}, wantErr: true},
+		{name: "newline in name", id: internal.CommitIdentity{Name: "bot\nx"}, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.id.Validate()
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if err != nil && !errors.Is(err, internal.ErrInvalidCommitIdentity) {
+				t.Fatalf("Validate() error = %v, want %v", err, internal.ErrInvalidCommitIdentity)
+			}
+		})
+	}
+}
+
+func TestParseTrailer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{name: "simple", raw: "Release-Component=studioctl", want: "Release-Component: studioctl"},
+		{name: "value keeps equals", raw: "Ref=a=b", want: "Ref: a=b"},
+		{name: "trims spaces", raw: " Backport-Of = abc123 ", want: "Backport-Of: abc123"},
+		{name: "missing equals", raw: "Release-Component", wantErr: true},
+		{name: "empty value", raw: "Release-Component=", wantErr: true},
+		{name: "space in key", raw: "Release Component=studioctl", wantErr: true},
+		{name: "colon in key", raw: "Release:Component=studioctl", wantErr: true},
+		{name: "leading hyphen", raw: "-Component=studioctl", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := internal.ParseTrailer(tc.raw)
+			if tc.wantErr {
+				if !errors.Is(err, internal.ErrInvalidTrailer) {
+					t.Fatalf("ParseTrailer(%q) error = %v, want %v", tc.raw, err, internal.ErrInvalidTrailer)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTrailer(%q) error = %v", tc.raw, err)
+			}
+			if got.String() != tc.want {
+				t.Fatalf("ParseTrailer(%q) = %q, want %q", tc.raw, got, tc.want)
+			}
+		})
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
+	"altinn.studio/releaser/internal/changelog"
 	"altinn.studio/releaser/internal/version"
 )
 
@@ -24,10 +26,15 @@ type ComponentBuilder interface {
 
 // Component represents a releasable component in the repository.
 type Component struct {
-	Builder       ComponentBuilder
-	Name          string
-	ChangelogPath string
-	SourcePath    string
+	Builder         ComponentBuilder
+	PostReleaseHook *ReleaseHook // runs after the GitHub release is created; nil for none
+	Name            string
+	ChangelogPath   string
+	SourcePath      string
+	// CalloutCategory is the changelog category for upgrade steps, "Migration" or "Breaking".
+	// Empty means changelog.DefaultCalloutCategory.
+	CalloutCategory string
+	ExtraLabels     []string // additional labels for prepare and backport PRs
 }
 
 // Component registry.
@@ -35,16 +42,22 @@ type Component struct {
 //nolint:gochecknoglobals // registry pattern
 var components = map[string]*Component{
 	"studioctl": {
-		Name:          "studioctl",
-		ChangelogPath: "src/cli/CHANGELOG.md",
-		SourcePath:    "src/cli",
-		Builder:       nil, // set later to avoid import cycle, see init in builder_go.go
+		Name:            "studioctl",
+		ChangelogPath:   "src/cli/CHANGELOG.md",
+		SourcePath:      "src/cli",
+		Builder:         nil, // set later to avoid import cycle, see init in builder_studioctl.go
+		CalloutCategory: "",
+		ExtraLabels:     nil,
+		PostReleaseHook: nil,
 	},
 	"fileanalyzers": {
-		Name:          "fileanalyzers",
-		ChangelogPath: "src/App/fileanalyzers/CHANGELOG.md",
-		SourcePath:    "src/App/fileanalyzers",
-		Builder:       nil, // YAML handles dotnet pack/push
+		Name:            "fileanalyzers",
+		ChangelogPath:   "src/App/fileanalyzers/CHANGELOG.md",
+		SourcePath:      "src/App/fileanalyzers",
+		Builder:         nil, // YAML handles dotnet pack/push
+		CalloutCategory: "",
+		ExtraLabels:     nil,
+		PostReleaseHook: nil,
 	},
 }
 
@@ -57,6 +70,24 @@ func GetComponent(name string) (*Component, error) {
 	return c, nil
 }
 
+// ComponentNames returns the names of all registered components, sorted.
+func ComponentNames() []string {
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Callout returns the changelog category the component uses for upgrade steps.
+func (c *Component) Callout() string {
+	if c.CalloutCategory == "" {
+		return changelog.DefaultCalloutCategory
+	}
+	return c.CalloutCategory
+}
+
 // ReleaseBranch returns the release branch name (e.g., "release/studioctl/v1.0").
 func (c *Component) ReleaseBranch(major, minor int) string {
 	return fmt.Sprintf("release/%s/v%d.%d", c.Name, major, minor)
@@ -81,6 +112,18 @@ func (c *Component) ReleaseLabel() string {
 	return "release/" + c.Name
 }
 
+// PRLabels returns the labels for a prepare or backport PR: primary first, then the
+// component's ExtraLabels, then extra. Empty and duplicate labels are dropped.
+func (c *Component) PRLabels(primary string, extra []string) []string {
+	labels := make([]string, 0, 1+len(c.ExtraLabels)+len(extra))
+	for _, label := range slices.Concat([]string{primary}, c.ExtraLabels, extra) {
+		if label != "" && !slices.Contains(labels, label) {
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
 // ReleaseTitle returns the GitHub release title (e.g., "studioctl v1.0.0").
 func (c *Component) ReleaseTitle(ver string) string {
 	return c.Name + " " + ver
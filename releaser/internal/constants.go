@@ -4,7 +4,10 @@ package internal
 const (
 	backportLabel       = "backport"
 	backportShortSHALen = 8
+	detachedHEAD        = "HEAD"
 	mainBranch          = "main"
+	outputDirMarkerFile = ".releaser-output"
 	osWindows           = "windows"
 	releaseNotesFile    = "release-notes.md"
+	releaseSummaryFile  = "release-summary.json"
 )
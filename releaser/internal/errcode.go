@@ -0,0 +1,253 @@
+package internal
+
+import (
+	"errors"
+
+	"altinn.studio/releaser/internal/changelog"
+	"altinn.studio/releaser/internal/version"
+)
+
+// Exit statuses shared by all commands.
+const (
+	// ExitStatusUnknown is used for errors without a registered code.
+	ExitStatusUnknown = 1
+	// ExitStatusInvalidArguments is used for missing or malformed command arguments.
+	ExitStatusInvalidArguments = 2
+)
+
+// Exit statuses for specific error codes. Never renumber these; CI wrappers depend on them.
+// Statuses from 60 are environment failures (see CodedError.Kind).
+const (
+	exitStatusActionNotConfirmed     = 10
+	exitStatusComponentNotFound      = 11
+	exitStatusNotOnMain              = 12
+	exitStatusWorkingTreeDirty       = 13
+	exitStatusTagExists              = 14
+	exitStatusReleaseBranchMissing   = 15
+	exitStatusReleaseBranchExists    = 16
+	exitStatusBaseBranchFormat       = 17
+	exitStatusBaseBranchMismatch     = 18
+	exitStatusComponentsInvalid      = 19
+	exitStatusChangelogMissing       = 20
+	exitStatusChangelogNotModified   = 21
+	exitStatusNoNewUnreleasedEntries = 22
+	exitStatusVersionExists          = 23
+	exitStatusNoUnreleasedSection    = 24
+	exitStatusUnreleasedEmpty        = 25
+	exitStatusUnreleasedNoHeader     = 26
+	exitStatusUnreleasedNoEntry      = 27
+	exitStatusVersionNotFound        = 28
+	exitStatusInvalidVersion         = 29
+	exitStatusNoChangelogInDiff      = 30
+	exitStatusNoEntriesInDiff        = 31
+	exitStatusBackportNoEntries      = 32
+	exitStatusInvalidCategory        = 33
+	exitStatusCategoryOrder          = 34
+	exitStatusDuplicateVersion       = 35
+	exitStatusVersionOrder           = 36
+	exitStatusPrereleaseConflict     = 37
+	exitStatusNoReleasedVersions     = 38
+	exitStatusNoMatchingVersion      = 39
+	exitStatusChangelogFileMissing   = 40
+	exitStatusNoPrerelease           = 41
+	exitStatusEntryInReleased        = 42
+	exitStatusReleasedModified       = 43
+	exitStatusBackportsFailed        = 44
+	exitStatusVersionRegression      = 45
+	exitStatusNoPreambleTitle        = 46
+	exitStatusNotesFileEmpty         = 47
+	exitStatusPrepBranchExists       = 48
+	exitStatusAuditFailed            = 49
+	exitStatusBuildFailed            = 50
+	exitStatusTarballMissingPath     = 51
+	exitStatusNoPathsSpecified       = 52
+	exitStatusUnsafeOutputDir        = 53
+	exitStatusReleaseAssetsMissing   = 54
+	exitStatusOutputDirNotManaged    = 55
+	exitStatusBranchBehindRemote     = 56
+	exitStatusChangelogExists        = 57
+	exitStatusPostReleaseHookFailed  = 58
+	exitStatusGHNotAvailable         = 60
+	exitStatusUnsupportedPlatform    = 61
+	exitStatusGitCommandFailed       = 62
+	exitStatusGHCommandFailed        = 63
+	exitStatusRateLimited            = 64
+	exitStatusGHNotAuthenticated     = 65
+)
+
+// CodeUnknown is reported for errors without a registered code.
+const CodeUnknown = "UNKNOWN"
+
+// CodeInvalidArguments is reported for missing or malformed command arguments.
+const CodeInvalidArguments = "INVALID_ARGUMENTS"
+
+// CodedError attaches a stable machine-readable code and process exit status to an error.
+// CI wrappers should match on Code instead of the error message.
+type CodedError struct {
+	Err    error
+	Code   string
+	Status int
+}
+
+// NewCodedError wraps err with a code and exit status.
+func NewCodedError(code string, status int, err error) *CodedError {
+	return &CodedError{Err: err, Code: code, Status: status}
+}
+
+// Error returns the wrapped error message.
+func (e *CodedError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *CodedError) Unwrap() error {
+	return e.Err
+}
+
+// Error kinds group codes by how CI should react to them.
+const (
+	// KindUnknown is reported for errors without a registered code.
+	KindUnknown = "unknown"
+	// KindInvalidArguments means the command line was wrong; fix the invocation.
+	KindInvalidArguments = "invalid_arguments"
+	// KindPrecondition means the repository, changelog or branch state does not allow the release.
+	KindPrecondition = "precondition"
+	// KindBuild means building or uploading release artifacts failed.
+	KindBuild = "build"
+	// KindEnvironment means git, gh or GitHub failed or is unavailable, or a post-release hook
+	// failed after the release was published; these may be transient.
+	KindEnvironment = "environment"
+)
+
+// Kind returns the error kind for the exit status. Statuses not listed here, including
+// command-specific ones, are preconditions.
+func (e *CodedError) Kind() string {
+	switch e.Status {
+	case ExitStatusUnknown:
+		return KindUnknown
+	case ExitStatusInvalidArguments:
+		return KindInvalidArguments
+	case exitStatusBuildFailed, exitStatusTarballMissingPath, exitStatusNoPathsSpecified,
+		exitStatusUnsafeOutputDir, exitStatusReleaseAssetsMissing, exitStatusOutputDirNotManaged:
+		return KindBuild
+	case exitStatusPostReleaseHookFailed:
+		return KindEnvironment
+	}
+	if e.Status >= exitStatusGHNotAvailable {
+		return KindEnvironment
+	}
+	return KindPrecondition
+}
+
+type errorCode struct {
+	err    error
+	code   string
+	status int
+}
+
+// errorCodes maps sentinel errors to stable codes and exit statuses.
+// Codes and statuses must never be renumbered once released; append new entries instead.
+// The first matching entry wins, so generic command failures are listed last.
+var errorCodes = []errorCode{
+	{err: errComponentRequired, code: CodeInvalidArguments, status: ExitStatusInvalidArguments},
+	{err: errBaseBranchRequired, code: CodeInvalidArguments, status: ExitStatusInvalidArguments},
+	{err: errReleaseVersionRequired, code: CodeInvalidArguments, status: ExitStatusInvalidArguments},
+	{err: errValidationBaseRequired, code: CodeInvalidArguments, status: ExitStatusInvalidArguments},
+	{err: errValidationHeadRequired, code: CodeInvalidArguments, status: ExitStatusInvalidArguments},
+	{err: errBackportCommitRequired, code: CodeInvalidArguments, status: ExitStatusInvalidArguments},
+	{err: errBackportBranchRequired, code: CodeInvalidArguments, status: ExitStatusInvalidArguments},
+	{err: errPathOutsideRepo, code: CodeInvalidArguments, status: ExitStatusInvalidArguments},
+	{err: errChangelogPathWithAll, code: CodeInvalidArguments, status: ExitStatusInvalidArguments},
+	{err: ErrAllowDirtyInCI, code: CodeInvalidArguments, status: ExitStatusInvalidArguments},
+	{err: ErrLocalRequiresDryRun, code: CodeInvalidArguments, status: ExitStatusInvalidArguments},
+	{err: ErrInvalidCommitIdentity, code: CodeInvalidArguments, status: ExitStatusInvalidArguments},
+	{err: ErrInvalidTrailer, code: CodeInvalidArguments, status: ExitStatusInvalidArguments},
+	{err: ErrInvalidCategoryHeader, code: CodeInvalidArguments, status: ExitStatusInvalidArguments},
+	{err: ErrInvalidCommitTemplate, code: CodeInvalidArguments, status: ExitStatusInvalidArguments},
+	{err: errEntryCategoryRequired, code: CodeInvalidArguments, status: ExitStatusInvalidArguments},
+	{err: errEntryMessageRequired, code: CodeInvalidArguments, status: ExitStatusInvalidArguments},
+	{err: changelog.ErrInvalidNotesStyle, code: CodeInvalidArguments, status: ExitStatusInvalidArguments},
+	{err: changelog.ErrInvalidPrereleaseFilter, code: CodeInvalidArguments, status: ExitStatusInvalidArguments},
+	{err: changelog.ErrInvalidFormat, code: CodeInvalidArguments, status: ExitStatusInvalidArguments},
+
+	{err: ErrActionNotConfirmed, code: "ACTION_NOT_CONFIRMED", status: exitStatusActionNotConfirmed},
+	{err: ErrComponentNotFound, code: "COMPONENT_NOT_FOUND", status: exitStatusComponentNotFound},
+	{err: ErrNotOnMain, code: "NOT_ON_MAIN", status: exitStatusNotOnMain},
+	{err: ErrWorkingTreeDirty, code: "WORKING_TREE_DIRTY", status: exitStatusWorkingTreeDirty},
+	{err: ErrBranchBehindRemote, code: "BRANCH_BEHIND_REMOTE", status: exitStatusBranchBehindRemote},
+	{err: ErrTagExists, code: "TAG_EXISTS", status: exitStatusTagExists},
+	{err: ErrReleaseBranchMissing, code: "RELEASE_BRANCH_MISSING", status: exitStatusReleaseBranchMissing},
+	{err: errReleaseBranchMissing, code: "RELEASE_BRANCH_MISSING", status: exitStatusReleaseBranchMissing},
+	{err: errReleaseBranchExists, code: "RELEASE_BRANCH_EXISTS", status: exitStatusReleaseBranchExists},
+	{err: errBaseBranchFormat, code: "BASE_BRANCH_FORMAT", status: exitStatusBaseBranchFormat},
+	{err: errBaseBranchMismatch, code: "BASE_BRANCH_MISMATCH", status: exitStatusBaseBranchMismatch},
+	{err: ErrComponentsInvalid, code: "COMPONENTS_INVALID", status: exitStatusComponentsInvalid},
+	{err: ErrPrepBranchExists, code: "PREP_BRANCH_EXISTS", status: exitStatusPrepBranchExists},
+
+	{err: ErrChangelogMissing, code: "CHANGELOG_MISSING", status: exitStatusChangelogMissing},
+	{err: ErrChangelogFileMissing, code: "CHANGELOG_FILE_MISSING", status: exitStatusChangelogFileMissing},
+	{err: ErrChangelogExists, code: "CHANGELOG_EXISTS", status: exitStatusChangelogExists},
+	{err: changelog.ErrNoPrerelease, code: "NO_PRERELEASE", status: exitStatusNoPrerelease},
+	{err: ErrChangelogNotModified, code: "CHANGELOG_NOT_MODIFIED", status: exitStatusChangelogNotModified},
+	{err: ErrReleasedSectionModified, code: "RELEASED_SECTION_MODIFIED", status: exitStatusReleasedModified},
+	{err: ErrNoNewUnreleasedEntries, code: "NO_NEW_UNRELEASED_ENTRIES", status: exitStatusNoNewUnreleasedEntries},
+	{err: errChangelogVersionExists, code: "VERSION_EXISTS", status: exitStatusVersionExists},
+	{err: changelog.ErrVersionExists, code: "VERSION_EXISTS", status: exitStatusVersionExists},
+	{err: changelog.ErrNoUnreleased, code: "NO_UNRELEASED_SECTION", status: exitStatusNoUnreleasedSection},
+	{err: changelog.ErrUnreleasedEmpty, code: "UNRELEASED_EMPTY", status: exitStatusUnreleasedEmpty},
+	{err: changelog.ErrUnreleasedNoHeader, code: "UNRELEASED_NO_HEADER", status: exitStatusUnreleasedNoHeader},
+	{err: changelog.ErrUnreleasedNoEntry, code: "UNRELEASED_NO_ENTRY", status: exitStatusUnreleasedNoEntry},
+	{err: changelog.ErrVersionNotFound, code: "VERSION_NOT_FOUND", status: exitStatusVersionNotFound},
+	{err: changelog.ErrInvalidVersion, code: "INVALID_VERSION", status: exitStatusInvalidVersion},
+	{err: version.ErrInvalidFormat, code: "INVALID_VERSION", status: exitStatusInvalidVersion},
+	{err: errBackportInvalidVersion, code: "INVALID_VERSION", status: exitStatusInvalidVersion},
+	{err: changelog.ErrNoChangelogInDiff, code: "NO_CHANGELOG_IN_DIFF", status: exitStatusNoChangelogInDiff},
+	{err: changelog.ErrNoEntriesInDiff, code: "NO_ENTRIES_IN_DIFF", status: exitStatusNoEntriesInDiff},
+	{err: ErrBackportsFailed, code: "BACKPORTS_FAILED", status: exitStatusBackportsFailed},
+	{err: errBackportNoEntries, code: "BACKPORT_NO_ENTRIES", status: exitStatusBackportNoEntries},
+	{err: ErrVersionRegression, code: "VERSION_REGRESSION", status: exitStatusVersionRegression},
+	{err: changelog.ErrNoPreambleTitle, code: "NO_PREAMBLE_TITLE", status: exitStatusNoPreambleTitle},
+	{err: ErrNotesFileEmpty, code: "NOTES_FILE_EMPTY", status: exitStatusNotesFileEmpty},
+	{err: ErrAuditFailed, code: "AUDIT_FAILED", status: exitStatusAuditFailed},
+	{err: changelog.ErrEntryInReleased, code: "ENTRY_IN_RELEASED_SECTION", status: exitStatusEntryInReleased},
+	{err: changelog.ErrInvalidCategory, code: "INVALID_CATEGORY", status: exitStatusInvalidCategory},
+	{err: changelog.ErrCategoryOrder, code: "CATEGORY_ORDER", status: exitStatusCategoryOrder},
+	{err: changelog.ErrDuplicateVersion, code: "DUPLICATE_VERSION", status: exitStatusDuplicateVersion},
+	{err: changelog.ErrVersionOrder, code: "VERSION_ORDER", status: exitStatusVersionOrder},
+	{err: changelog.ErrPrereleaseConflict, code: "PRERELEASE_CONFLICT", status: exitStatusPrereleaseConflict},
+	{err: changelog.ErrNoReleasedVersions, code: "NO_RELEASED_VERSIONS", status: exitStatusNoReleasedVersions},
+	{err: errNoReleasedVersion, code: "NO_RELEASED_VERSIONS", status: exitStatusNoReleasedVersions},
+	{err: changelog.ErrNoMatchingVersion, code: "NO_MATCHING_VERSION", status: exitStatusNoMatchingVersion},
+	{err: errNoMatchingReleasedVersion, code: "NO_MATCHING_VERSION", status: exitStatusNoMatchingVersion},
+
+	{err: ErrBuildFailed, code: "BUILD_FAILED", status: exitStatusBuildFailed},
+	{err: ErrTarballMissingPath, code: "TARBALL_MISSING_PATH", status: exitStatusTarballMissingPath},
+	{err: ErrNoPathsSpecified, code: "NO_PATHS_SPECIFIED", status: exitStatusNoPathsSpecified},
+	{err: errUnsafeCleanDirPath, code: "UNSAFE_OUTPUT_DIR", status: exitStatusUnsafeOutputDir},
+	{err: ErrReleaseAssetsMissing, code: "RELEASE_ASSETS_MISSING", status: exitStatusReleaseAssetsMissing},
+	{err: ErrOutputDirNotManaged, code: "OUTPUT_DIR_NOT_MANAGED", status: exitStatusOutputDirNotManaged},
+	{err: ErrPostReleaseHookFailed, code: "POST_RELEASE_HOOK_FAILED", status: exitStatusPostReleaseHookFailed},
+
+	{err: ErrGHNotAvailable, code: "GH_NOT_AVAILABLE", status: exitStatusGHNotAvailable},
+	{err: ErrGitHubNotAuthenticated, code: "GH_NOT_AUTHENTICATED", status: exitStatusGHNotAuthenticated},
+	{err: ErrUnsupportedPlatform, code: "UNSUPPORTED_PLATFORM", status: exitStatusUnsupportedPlatform},
+	{err: ErrRateLimited, code: "RATE_LIMITED", status: exitStatusRateLimited},
+	{err: ErrGitCommandFailed, code: "GIT_COMMAND_FAILED", status: exitStatusGitCommandFailed},
+	{err: ErrGHCommandFailed, code: "GH_COMMAND_FAILED", status: exitStatusGHCommandFailed},
+}
+
+// ClassifyError returns err annotated with its stable code and exit status.
+// An explicit CodedError in the chain takes precedence over the mapping table;
+// errors matching neither are reported as CodeUnknown.
+func ClassifyError(err error) *CodedError {
+	var coded *CodedError
+	if errors.As(err, &coded) {
+		return NewCodedError(coded.Code, coded.Status, err)
+	}
+	for _, entry := range errorCodes {
+		if errors.Is(err, entry.err) {
+			return NewCodedError(entry.code, entry.status, err)
+		}
+	}
+	return NewCodedError(CodeUnknown, ExitStatusUnknown, err)
+}
@@ -0,0 +1,128 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"altinn.studio/releaser/internal/changelog"
+	"altinn.studio/releaser/internal/version"
+)
+
+func TestClassifyError_KnownErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err        error
+		wantCode   string
+		wantStatus int
+	}{
+		{err: ErrChangelogMissing, wantCode: "CHANGELOG_MISSING", wantStatus: 20},
+		{err: ErrTagExists, wantCode: "TAG_EXISTS", wantStatus: 14},
+		{err: ErrWorkingTreeDirty, wantCode: "WORKING_TREE_DIRTY", wantStatus: 13},
+		{err: ErrNotOnMain, wantCode: "NOT_ON_MAIN", wantStatus: 12},
+		{err: ErrChangelogNotModified, wantCode: "CHANGELOG_NOT_MODIFIED", wantStatus: 21},
+		{err: changelog.ErrUnreleasedEmpty, wantCode: "UNRELEASED_EMPTY", wantStatus: 25},
+		{err: version.ErrInvalidFormat, wantCode: "INVALID_VERSION", wantStatus: 29},
+		{err: ErrGHCommandFailed, wantCode: "GH_COMMAND_FAILED", wantStatus: 63},
+		{err: errComponentRequired, wantCode: CodeInvalidArguments, wantStatus: ExitStatusInvalidArguments},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.wantCode, func(t *testing.T) {
+			t.Parallel()
+
+			wrapped := fmt.Errorf("run step: %w", tc.err)
+			got := ClassifyError(wrapped)
+			if got.Code != tc.wantCode {
+				t.Fatalf("ClassifyError().Code = %q, want %q", got.Code, tc.wantCode)
+			}
+			if got.Status != tc.wantStatus {
+				t.Fatalf("ClassifyError().Status = %d, want %d", got.Status, tc.wantStatus)
+			}
+			if !errors.Is(got, tc.err) {
+				t.Fatalf("errors.Is(ClassifyError(), %v) = false, want true", tc.err)
+			}
+			if got.Error() != wrapped.Error() {
+				t.Fatalf("ClassifyError().Error() = %q, want %q", got.Error(), wrapped.Error())
+			}
+		})
+	}
+}
+
+func TestClassifyError_EveryMappedErrorSurfacesItsCode(t *testing.T) {
+	t.Parallel()
+
+	for _, entry := range errorCodes {
+		got := ClassifyError(fmt.Errorf("context: %w", entry.err))
+		if got.Code != entry.code || got.Status != entry.status {
+			t.Errorf("ClassifyError(%v) = %s/%d, want %s/%d", entry.err, got.Code, got.Status, entry.code, entry.status)
+		}
+	}
+}
+
+func TestErrorCodes_StableMapping(t *testing.T) {
+	t.Parallel()
+
+	statusByCode := make(map[string]int)
+	codeByStatus := make(map[int]string)
+	for _, entry := range errorCodes {
+		if entry.status <= ExitStatusUnknown {
+			t.Errorf("%s: status %d collides with generic failure status", entry.code, entry.status)
+		}
+		if status, ok := statusByCode[entry.code]; ok && status != entry.status {
+			t.Errorf("%s: mapped to statuses %d and %d", entry.code, status, entry.status)
+		}
+		if code, ok := codeByStatus[entry.status]; ok && code != entry.code {
+			t.Errorf("status %d: shared by codes %s and %s", entry.status, code, entry.code)
+		}
+		statusByCode[entry.code] = entry.status
+		codeByStatus[entry.status] = entry.code
+	}
+}
+
+func TestClassifyError_ExplicitCodeWins(t *testing.T) {
+	t.Parallel()
+
+	coded := NewCodedError("CUSTOM", 99, ErrTagExists)
+	got := ClassifyError(fmt.Errorf("outer: %w", coded))
+	if got.Code != "CUSTOM" || got.Status != 99 {
+		t.Fatalf("ClassifyError() = %s/%d, want CUSTOM/99", got.Code, got.Status)
+	}
+	if !errors.Is(got, ErrTagExists) {
+		t.Fatalf("errors.Is(ClassifyError(), ErrTagExists) = false, want true")
+	}
+}
+
+func TestClassifyError_Unknown(t *testing.T) {
+	t.Parallel()
+
+	got := ClassifyError(errors.New("something else"))
+	if got.Code != CodeUnknown || got.Status != ExitStatusUnknown {
+		t.Fatalf("ClassifyError() = %s/%d, want %s/%d", got.Code, got.Status, CodeUnknown, ExitStatusUnknown)
+	}
+}
+
+func TestCodedError_Kind(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{err: errors.New("unmapped"), want: KindUnknown},
+		{err: errComponentRequired, want: KindInvalidArguments},
+		{err: ErrChangelogMissing, want: KindPrecondition},
+		{err: ErrBranchBehindRemote, want: KindPrecondition},
+		{err: ErrBuildFailed, want: KindBuild},
+		{err: ErrOutputDirNotManaged, want: KindBuild},
+		{err: ErrRateLimited, want: KindEnvironment},
+		{err: ErrGitCommandFailed, want: KindEnvironment},
+		{err: ErrPostReleaseHookFailed, want: KindEnvironment},
+	}
+	for _, tc := range tests {
+		if got := ClassifyError(tc.err).Kind(); got != tc.want {
+			t.Errorf("ClassifyError(%v).Kind() = %q, want %q", tc.err, got, tc.want)
+		}
+	}
+}
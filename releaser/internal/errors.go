@@ -22,7 +22,17 @@ var (
 	errUnsafeCleanDirPath     = errors.New("refusing to clean unsafe directory path")
 	errNoExistingParentPath   = errors.New("path has no existing parent directory")
 	errPromptIORequired       = errors.New("prompt input/output is required")
+	errPathOutsideRepo        = errors.New("path is outside the repository")
+	errChangelogPathWithAll   = errors.New("changelog path override cannot be combined with all components")
+	errEntryCategoryRequired  = errors.New("changelog category is required")
+	errEntryMessageRequired   = errors.New("changelog message is required")
 
 	// ErrActionNotConfirmed indicates a user declined a confirmation prompt.
 	ErrActionNotConfirmed = errors.New("action not confirmed")
+
+	// ErrPrepBranchExists indicates a local branch left over from an interrupted prepare.
+	ErrPrepBranchExists = errors.New("branch left over from an interrupted prepare")
+
+	// ErrLocalRequiresDryRun indicates prepare -local was used without -dry-run.
+	ErrLocalRequiresDryRun = errors.New("local changelog mode is only allowed with dry-run")
 )
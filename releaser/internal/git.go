@@ -6,8 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"os/exec"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
+
+	"altinn.studio/releaser/internal/version"
 )
 
 // Git operation errors.
@@ -15,16 +19,23 @@ var (
 	ErrNotOnMain        = errors.New("prereleases must be triggered from main branch")
 	ErrGitCommandFailed = errors.New("git command failed")
 	ErrWorkingTreeDirty = errors.New("working tree has uncommitted changes")
+	// ErrBranchBehindRemote indicates HEAD lacks commits that are on the origin branch.
+	ErrBranchBehindRemote = errors.New("local branch is behind origin")
 )
 
 // GitRunner defines the interface for git operations.
 type GitRunner interface {
 	// TagExists checks if a tag exists in the repository.
 	TagExists(ctx context.Context, tag string) (bool, error)
+	// ListTags returns the versions of local and origin tags starting with prefix (e.g., "studioctl/"),
+	// sorted oldest first by version.Compare. Tags whose remainder is not a version are skipped.
+	ListTags(ctx context.Context, prefix string) ([]*version.Version, error)
 	// CurrentBranch returns the current branch name.
 	CurrentBranch(ctx context.Context) (string, error)
 	// RemoteBranchExists checks if a branch exists on the remote.
 	RemoteBranchExists(ctx context.Context, branch string) (bool, error)
+	// CommitsBehind fetches origin/<branch> and returns how many of its commits HEAD lacks.
+	CommitsBehind(ctx context.Context, branch string) (int, error)
 	// Checkout switches to the specified ref.
 	Checkout(ctx context.Context, ref string) error
 	// Pull pulls the latest changes from the remote.
@@ -37,12 +48,27 @@ type GitRunner interface {
 	RepoRoot(ctx context.Context) (string, error)
 	// WorkingTreeClean checks if working tree has no uncommitted changes.
 	WorkingTreeClean(ctx context.Context) (bool, error)
+	// CreateAnnotatedTag creates an annotated tag at the target ref and pushes it to origin.
+	CreateAnnotatedTag(ctx context.Context, opts AnnotatedTagOptions) error
+	// DeleteTag deletes a tag from origin and the local repository.
+	DeleteTag(ctx context.Context, name string) error
+	// ResolveCommit returns the full SHA of the commit ref points to.
+	ResolveCommit(ctx context.Context, ref string) (string, error)
+}
+
+// AnnotatedTagOptions configures an annotated tag.
+type AnnotatedTagOptions struct {
+	Name    string // Required: tag name
+	Target  string // Required: commit to tag
+	Message string // Required: tag message
+	Sign    bool   // Sign the tag with the configured GPG/SSH key
 }
 
 // GitCLI implements GitRunner by shelling out to the git CLI.
 type GitCLI struct {
 	log          Logger
 	repoRootErr  error
+	repoLock     *sync.RWMutex
 	workdir      string
 	repoRoot     string
 	repoRootOnce sync.Once
@@ -62,6 +88,14 @@ func WithDryRun(dryRun bool) GitCLIOption {
 	return func(g *GitCLI) { g.dryRun = dryRun }
 }
 
+// WithRepoLock coordinates git commands with every other GitCLI sharing lock. Linked
+// worktrees share one .git directory: commands that write its config or worktree metadata
+// (see sharedStateCommand) hold lock exclusively, and all others hold it shared, since
+// commands such as fetch read every worktree's HEAD and fail while one is being added.
+func WithRepoLock(lock *sync.RWMutex) GitCLIOption {
+	return func(g *GitCLI) { g.repoLock = lock }
+}
+
 // WithLogger sets the logger.
 func WithLogger(log Logger) GitCLIOption {
 	return func(g *GitCLI) { g.log = log }
@@ -98,6 +132,59 @@ func (g *GitCLI) TagExists(ctx context.Context, tag string) (bool, error) {
 	return remoteCode == 0, nil
 }
 
+// ListTags returns the versions of local and origin tags starting with prefix, sorted oldest first.
+func (g *GitCLI) ListTags(ctx context.Context, prefix string) ([]*version.Version, error) {
+	pattern := prefix + "*"
+	local, err := g.run(ctx, "tag", "--list", pattern)
+	if err != nil {
+		return nil, err
+	}
+	remote, err := g.run(ctx, "ls-remote", "--tags", "--refs", "origin", "refs/tags/"+pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := strings.Fields(local)
+	for line := range strings.SplitSeq(remote, "\n") {
+		// ls-remote prints "<sha>\trefs/tags/<tag>".
+		if _, ref, ok := strings.Cut(line, "\t"); ok {
+			tags = append(tags, strings.TrimPrefix(ref, "refs/tags/"))
+		}
+	}
+	slices.Sort(tags)
+	return tagVersions(slices.Compact(tags), prefix), nil
+}
+
+// LatestTag returns the highest version tagged with prefix, or nil when there is none.
+func LatestTag(ctx context.Context, git GitRunner, prefix string) (*version.Version, error) {
+	versions, err := git.ListTags(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+	if len(versions) == 0 {
+		return nil, nil //nolint:nilnil // nil version means no release tag yet
+	}
+	return versions[len(versions)-1], nil
+}
+
+// tagVersions parses the tags that are prefix followed by a version, sorted oldest first.
+func tagVersions(tags []string, prefix string) []*version.Version {
+	versions := make([]*version.Version, 0, len(tags))
+	for _, tag := range tags {
+		rest, ok := strings.CutPrefix(tag, prefix)
+		if !ok {
+			continue
+		}
+		ver, err := version.Parse(rest)
+		if err != nil {
+			continue // not a release tag, e.g. studioctl/latest
+		}
+		versions = append(versions, ver)
+	}
+	slices.SortFunc(versions, version.Compare)
+	return versions
+}
+
 // CurrentBranch returns the current branch name.
 func (g *GitCLI) CurrentBranch(ctx context.Context) (string, error) {
 	return g.run(ctx, "rev-parse", "--abbrev-ref", "HEAD")
@@ -112,6 +199,32 @@ func (g *GitCLI) RemoteBranchExists(ctx context.Context, branch string) (bool, e
 	return code == 0, nil // exit 2 = not found
 }
 
+// CommitsBehind fetches origin/<branch> and returns how many of its commits HEAD lacks.
+// The fetch only updates the remote-tracking ref, so it also runs in dry-run mode.
+func (g *GitCLI) CommitsBehind(ctx context.Context, branch string) (int, error) {
+	if _, err := g.Run(ctx, "fetch", "origin", branch); err != nil {
+		return 0, fmt.Errorf("fetch origin/%s: %w", branch, err)
+	}
+	output, err := g.Run(ctx, "rev-list", "--count", "HEAD..origin/"+branch)
+	if err != nil {
+		return 0, err
+	}
+	count, err := strconv.Atoi(output)
+	if err != nil {
+		return 0, fmt.Errorf("parse rev-list count %q: %w", output, err)
+	}
+	return count, nil
+}
+
+// FileExistsAtRef checks if path exists in the tree of ref.
+func (g *GitCLI) FileExistsAtRef(ctx context.Context, ref, path string) (bool, error) {
+	code, err := g.runExitCode(ctx, "cat-file", "-e", ref+":"+path)
+	if err != nil {
+		return false, err
+	}
+	return code == 0, nil
+}
+
 // Checkout switches to the specified ref.
 func (g *GitCLI) Checkout(ctx context.Context, ref string) error {
 	return g.runWrite(ctx, "checkout", ref)
@@ -132,6 +245,43 @@ func (g *GitCLI) PushWithUpstream(ctx context.Context, remote, branch string) er
 	return g.runWrite(ctx, "push", "-u", remote, branch)
 }
 
+// CreateAnnotatedTag creates an annotated tag at the target ref and pushes it to origin.
+func (g *GitCLI) CreateAnnotatedTag(ctx context.Context, opts AnnotatedTagOptions) error {
+	args := []string{"tag", "-a"}
+	if opts.Sign {
+		args = append(args, "-s")
+	}
+	args = append(args, "-m", opts.Message, opts.Name, opts.Target)
+
+	if err := g.runWrite(ctx, args...); err != nil {
+		return err
+	}
+	return g.runWrite(ctx, "push", "origin", "refs/tags/"+opts.Name)
+}
+
+// DeleteTag deletes a tag from origin and the local repository.
+func (g *GitCLI) DeleteTag(ctx context.Context, name string) error {
+	if err := g.runWrite(ctx, "push", "origin", "--delete", "refs/tags/"+name); err != nil {
+		return err
+	}
+	return g.runWrite(ctx, "tag", "-d", name)
+}
+
+// ResolveCommit returns the full SHA of the commit ref points to.
+func (g *GitCLI) ResolveCommit(ctx context.Context, ref string) (string, error) {
+	return g.run(ctx, "rev-parse", "--verify", ref+"^{commit}")
+}
+
+// AddWorktree checks out ref, detached, in a new linked worktree at path.
+func (g *GitCLI) AddWorktree(ctx context.Context, path, ref string) error {
+	return g.runWrite(ctx, "worktree", "add", "--detach", path, ref)
+}
+
+// RemoveWorktree deletes the linked worktree at path, discarding any changes in it.
+func (g *GitCLI) RemoveWorktree(ctx context.Context, path string) error {
+	return g.runWrite(ctx, "worktree", "remove", "--force", path)
+}
+
 // Run executes a git command and returns stdout.
 func (g *GitCLI) Run(ctx context.Context, args ...string) (string, error) {
 	return g.run(ctx, args...)
@@ -190,13 +340,51 @@ func (g *GitCLI) run(ctx context.Context, args ...string) (string, error) {
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	if err := cmd.Run(); err != nil {
+	if err := g.runLocked(cmd, args); err != nil {
 		return "", fmt.Errorf("%w: %s: %s", ErrGitCommandFailed, strings.Join(args, " "), stderr.String())
 	}
 
 	return strings.TrimSpace(stdout.String()), nil
 }
 
+// runLocked runs cmd while holding the repository lock, if one is set: exclusively when args
+// write shared state, and shared otherwise.
+func (g *GitCLI) runLocked(cmd *exec.Cmd, args []string) error {
+	switch {
+	case g.repoLock == nil:
+	case sharedStateCommand(args):
+		g.repoLock.Lock()
+		defer g.repoLock.Unlock()
+	default:
+		g.repoLock.RLock()
+		defer g.repoLock.RUnlock()
+	}
+	return cmd.Run() //nolint:wrapcheck // callers add the git command to the error
+}
+
+// sharedStateCommand reports whether git args write state shared by all worktrees of a
+// repository: worktree metadata, or .git/config through branch creation, deletion or
+// upstream tracking. Fetch, commit and push without -u only touch their own refs.
+func sharedStateCommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	switch args[0] {
+	case "worktree", "config", "branch":
+		return true
+	case "checkout", "switch":
+		return slices.ContainsFunc(args[1:], func(arg string) bool {
+			return arg == "-b" || arg == "-B" || arg == "-c" || arg == "-C"
+		})
+	case "push":
+		return slices.ContainsFunc(args[1:], func(arg string) bool {
+			return arg == "-u" || arg == "--set-upstream"
+		})
+	default:
+		return false
+	}
+}
+
 func (g *GitCLI) runWrite(ctx context.Context, args ...string) error {
 	if g.dryRun {
 		g.log.Command("git", append([]string{"(dry-run)"}, args...))
@@ -219,7 +407,7 @@ func (g *GitCLI) runExitCode(ctx context.Context, args ...string) (int, error) {
 		cmd.Dir = g.workdir
 	}
 
-	err := cmd.Run()
+	err := g.runLocked(cmd, args)
 	if err == nil {
 		return 0, nil
 	}
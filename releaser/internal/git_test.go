@@ -0,0 +1,96 @@
+package internal_test
+
+import (
+	"slices"
+	"testing"
+
+	"altinn.studio/releaser/internal"
+)
+
+func TestGitCLI_ListTags(t *testing.T) {
+	t.Parallel()
+
+	repo := createStudioctlWorkflowRepo(t, "# Changelog\n")
+	for _, tag := range []string{
+		"studioctl/v1.10.0",
+		"studioctl/v1.2.0",
+		"studioctl/v1.9.1",
+		"studioctl/v1.10.0-preview.10",
+		"studioctl/v1.10.0-preview.2",
+		"studioctl/latest",
+		"studioctl-extra/v9.0.0",
+		"other/v5.0.0",
+	} {
+		runGitCmd(t, repo, "tag", tag)
+	}
+	// Tags only on origin are listed too, and tags in both places only once.
+	runGitCmd(t, repo, "push", "origin", "refs/tags/studioctl/v1.2.0", "refs/tags/studioctl/v1.9.1")
+	runGitCmd(t, repo, "tag", "-d", "studioctl/v1.9.1")
+
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+	versions, err := git.ListTags(t.Context(), "studioctl/")
+	if err != nil {
+		t.Fatalf("ListTags() error = %v", err)
+	}
+
+	got := make([]string, 0, len(versions))
+	for _, ver := range versions {
+		got = append(got, ver.Full)
+	}
+	want := []string{"v1.2.0", "v1.9.1", "v1.10.0-preview.2", "v1.10.0-preview.10", "v1.10.0"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("ListTags() = %v, want %v", got, want)
+	}
+
+	latest, err := internal.LatestTag(t.Context(), git, "studioctl/")
+	if err != nil {
+		t.Fatalf("LatestTag() error = %v", err)
+	}
+	if latest == nil || latest.Full != "v1.10.0" {
+		t.Fatalf("LatestTag() = %v, want v1.10.0", latest)
+	}
+}
+
+func TestLatestTag_NoTags(t *testing.T) {
+	t.Parallel()
+
+	repo := createStudioctlWorkflowRepo(t, "# Changelog\n")
+	runGitCmd(t, repo, "tag", "other/v1.0.0")
+
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+	latest, err := internal.LatestTag(t.Context(), git, "studioctl/")
+	if err != nil {
+		t.Fatalf("LatestTag() error = %v", err)
+	}
+	if latest != nil {
+		t.Fatalf("LatestTag() = %v, want nil", latest)
+	}
+}
+
+func TestGitCLI_CommitsBehind(t *testing.T) {
+	t.Parallel()
+
+	repo := createStudioctlWorkflowRepo(t, "# Changelog\n")
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+
+	behind, err := git.CommitsBehind(t.Context(), "main")
+	if err != nil {
+		t.Fatalf("CommitsBehind() error = %v", err)
+	}
+	if behind != 0 {
+		t.Fatalf("CommitsBehind() up to date = %d, want 0", behind)
+	}
+
+	runGitCmd(t, repo, "commit", "--allow-empty", "-m", "first")
+	runGitCmd(t, repo, "commit", "--allow-empty", "-m", "second")
+	runGitCmd(t, repo, "push", "origin", "main")
+	runGitCmd(t, repo, "reset", "--hard", "HEAD~2")
+
+	behind, err = git.CommitsBehind(t.Context(), "main")
+	if err != nil {
+		t.Fatalf("CommitsBehind() error = %v", err)
+	}
+	if behind != 2 {
+		t.Fatalf("CommitsBehind() behind = %d, want 2", behind)
+	}
+}
@@ -6,21 +6,37 @@ import (
 	"errors"
 	"fmt"
 	"os/exec"
+	"regexp"
 	"strings"
+	"time"
 )
 
 // GitHub operation errors.
 var (
 	ErrGHCommandFailed = errors.New("gh command failed")
 	ErrGHNotAvailable  = errors.New("gh CLI not available")
+
+	ErrGitHubNotAuthenticated = errors.New("gh CLI is not authenticated")
+	ErrReleaseNotFound        = errors.New("release not found")
+)
+
+// transientGHPattern matches gh stderr for server errors and dropped connections.
+var transientGHPattern = regexp.MustCompile(
+	`(?i)HTTP 5\d\d|connection reset|connection refused|i/o timeout|tls handshake timeout|unexpected eof`,
 )
 
 // GitHubRunner defines the interface for GitHub operations.
 type GitHubRunner interface {
-	// CreateRelease creates a GitHub release.
-	CreateRelease(ctx context.Context, opts Options) error
+	// CreateRelease creates a GitHub release and returns its URL.
+	CreateRelease(ctx context.Context, opts Options) (string, error)
 	// CreatePR creates a GitHub pull request.
 	CreatePR(ctx context.Context, opts PullRequestOptions) (string, error)
+	// ReleaseAssets returns the names of the assets attached to the release for tag.
+	ReleaseAssets(ctx context.Context, tag string) ([]string, error)
+	// ReleaseURL returns the URL of the release for tag, or ErrReleaseNotFound.
+	ReleaseURL(ctx context.Context, tag string) (string, error)
+	// EnsureAuthenticated returns ErrGitHubNotAuthenticated unless gh has a usable login.
+	EnsureAuthenticated(ctx context.Context) error
 	// SetWorkdir sets the working directory for gh commands.
 	SetWorkdir(dir string)
 }
@@ -29,8 +45,10 @@ type GitHubRunner interface {
 type PullRequestOptions struct {
 	Title string
 	Body  string
-	Label string
 	Base  string
+	// Head is the branch to merge. Empty uses the branch checked out in the gh workdir.
+	Head   string
+	Labels []string
 }
 
 // Options configures a GitHub release.
@@ -43,11 +61,13 @@ type Options struct {
 	Draft           bool     // Create as draft
 	Prerelease      bool     // Mark as prerelease
 	FailOnNoCommits bool     // Fail if no new commits since last release
+	VerifyTag       bool     // Fail instead of creating the tag if it doesn't exist
 }
 
 // GitHubCLI implements GitHubRunner by shelling out to the gh CLI.
 type GitHubCLI struct {
 	log     Logger
+	clock   Clock
 	workdir string
 	dryRun  bool
 }
@@ -65,10 +85,16 @@ func WithGHLogger(log Logger) GitHubCLIOption {
 	return func(g *GitHubCLI) { g.log = log }
 }
 
+// WithGHClock sets the clock rate limit reset times are measured against.
+func WithGHClock(clock Clock) GitHubCLIOption {
+	return func(g *GitHubCLI) { g.clock = clock }
+}
+
 // NewGitHubCLI creates a new GitHubCLI instance.
 func NewGitHubCLI(opts ...GitHubCLIOption) *GitHubCLI {
 	g := &GitHubCLI{
 		log:     NopLogger{},
+		clock:   SystemClock{},
 		workdir: "",
 		dryRun:  false,
 	}
@@ -78,9 +104,10 @@ func NewGitHubCLI(opts ...GitHubCLIOption) *GitHubCLI {
 	return g
 }
 
-// CreateRelease creates a GitHub release using the gh CLI.
+// CreateRelease creates a GitHub release using the gh CLI and returns its URL.
 // If the tag doesn't exist, gh will create it automatically at the target branch.
-func (g *GitHubCLI) CreateRelease(ctx context.Context, opts Options) error {
+// The URL is empty in dry-run mode.
+func (g *GitHubCLI) CreateRelease(ctx context.Context, opts Options) (string, error) {
 	args := []string{"release", "create", opts.Tag}
 
 	if opts.Title != "" {
@@ -107,9 +134,17 @@ func (g *GitHubCLI) CreateRelease(ctx context.Context, opts Options) error {
 		args = append(args, "--fail-on-no-commits")
 	}
 
+	if opts.VerifyTag {
+		args = append(args, "--verify-tag")
+	}
+
 	args = append(args, opts.Assets...)
 
-	return g.runWrite(ctx, args...)
+	output, err := g.runWriteOutput(ctx, args...)
+	if err != nil {
+		return "", err
+	}
+	return extractPRURL(output), nil
 }
 
 // CreatePR creates a GitHub pull request using the gh CLI.
@@ -122,12 +157,15 @@ func (g *GitHubCLI) CreatePR(ctx context.Context, opts PullRequestOptions) (stri
 	if opts.Body != "" {
 		args = append(args, "--body", opts.Body)
 	}
-	if opts.Label != "" {
-		args = append(args, "--label", opts.Label)
+	for _, label := range opts.Labels {
+		args = append(args, "--label", label)
 	}
 	if opts.Base != "" {
 		args = append(args, "--base", opts.Base)
 	}
+	if opts.Head != "" {
+		args = append(args, "--head", opts.Head)
+	}
 
 	output, err := g.runWriteOutput(ctx, args...)
 	if err != nil {
@@ -143,7 +181,11 @@ func (g *GitHubCLI) CreatePR(ctx context.Context, opts PullRequestOptions) (stri
 		return prURL, nil
 	}
 
-	fallbackURL, fallbackErr := g.runRead(ctx, "pr", "view", "--json", "url", "--jq", ".url")
+	viewArgs := []string{"pr", "view", "--json", "url", "--jq", ".url"}
+	if opts.Head != "" {
+		viewArgs = append(viewArgs, opts.Head)
+	}
+	fallbackURL, fallbackErr := g.runRead(ctx, viewArgs...)
 	if fallbackErr == nil {
 		prURL = strings.TrimSpace(fallbackURL)
 	} else {
@@ -152,6 +194,56 @@ func (g *GitHubCLI) CreatePR(ctx context.Context, opts PullRequestOptions) (stri
 	return prURL, nil
 }
 
+// ReleaseAssets returns the names of the assets attached to the release for tag.
+func (g *GitHubCLI) ReleaseAssets(ctx context.Context, tag string) ([]string, error) {
+	output, err := g.runRead(ctx, "release", "view", tag, "--json", "assets", "--jq", ".assets[].name")
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for line := range strings.SplitSeq(output, "\n") {
+		if name := strings.TrimSpace(line); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// ReleaseURL returns the URL of the release for tag, including drafts, or ErrReleaseNotFound.
+func (g *GitHubCLI) ReleaseURL(ctx context.Context, tag string) (string, error) {
+	url, err := g.runRead(ctx, "release", "view", tag, "--json", "url", "--jq", ".url")
+	if err != nil {
+		if strings.Contains(err.Error(), "release not found") {
+			return "", fmt.Errorf("%w: %s", ErrReleaseNotFound, tag)
+		}
+		return "", err
+	}
+	return url, nil
+}
+
+// findRelease looks up the release for tag before a create is retried, since a failed
+// create may still have gone through on the server.
+func findRelease(ctx context.Context, gh GitHubRunner, tag string) (string, bool, error) {
+	url, err := gh.ReleaseURL(ctx, tag)
+	if errors.Is(err, ErrReleaseNotFound) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("look up release %s: %w", tag, err)
+	}
+	return url, true, nil
+}
+
+// EnsureAuthenticated checks `gh auth status`. It runs in dry-run mode too, since it
+// only reads local credentials.
+func (g *GitHubCLI) EnsureAuthenticated(ctx context.Context) error {
+	if _, err := g.runRead(ctx, "auth", "status"); err != nil {
+		return fmt.Errorf("%w: %w", ErrGitHubNotAuthenticated, err)
+	}
+	return nil
+}
+
 // SetWorkdir sets the working directory for gh commands.
 func (g *GitHubCLI) SetWorkdir(dir string) {
 	g.workdir = dir
@@ -180,7 +272,7 @@ func (g *GitHubCLI) runWriteOutput(ctx context.Context, args ...string) (string,
 	cmd.Stderr = &stderr
 
 	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("%w: %s: %s", ErrGHCommandFailed, strings.Join(args, " "), stderr.String())
+		return "", ghCommandError(args, stderr.String(), g.clock.Now())
 	}
 
 	return strings.TrimSpace(stdout.String()), nil
@@ -199,12 +291,32 @@ func (g *GitHubCLI) runRead(ctx context.Context, args ...string) (string, error)
 	cmd.Stderr = &stderr
 
 	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("%w: %s: %s", ErrGHCommandFailed, strings.Join(args, " "), stderr.String())
+		return "", ghCommandError(args, stderr.String(), g.clock.Now())
 	}
 
 	return strings.TrimSpace(stdout.String()), nil
 }
 
+// ghCommandError builds the error for a failed gh invocation, flagging rate limit responses
+// so ThrottledGitHub can retry them. Reset times are measured from now.
+func ghCommandError(args []string, stderr string, now time.Time) error {
+	err := fmt.Errorf("%w: %s: %s", ErrGHCommandFailed, strings.Join(args, " "), stderr)
+	if retryAfter, limited := parseRateLimit(stderr, now); limited {
+		return &RateLimitError{Err: err, RetryAfter: retryAfter}
+	}
+	return err
+}
+
+// isTransientGHError reports whether a gh failure looks like a network or server
+// hiccup worth retrying. Rate limits are left to ThrottledGitHub.
+func isTransientGHError(err error) bool {
+	if !errors.Is(err, ErrGHCommandFailed) || errors.Is(err, ErrRateLimited) {
+		return false
+	}
+	return transientGHPattern.MatchString(err.Error())
+}
+
+// extractPRURL returns the first URL printed by gh (PR or release).
 func extractPRURL(output string) string {
 	for token := range strings.FieldsSeq(output) {
 		if strings.HasPrefix(token, "https://") || strings.HasPrefix(token, "http://") {
@@ -0,0 +1,194 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrRateLimited indicates GitHub rejected a request because a rate limit was hit.
+var ErrRateLimited = errors.New("github rate limit exceeded")
+
+const (
+	defaultThrottleRetries   = 3
+	defaultThrottleMaxWait   = 5 * time.Minute
+	defaultRateLimitFallback = time.Minute
+)
+
+var (
+	retryAfterPattern     = regexp.MustCompile(`(?i)retry-after:\s*(\d+)`)
+	rateLimitResetPattern = regexp.MustCompile(`(?i)x-ratelimit-reset:\s*(\d+)`)
+)
+
+// RateLimitError is returned by GitHubCLI when gh reports a primary or secondary rate limit.
+// RetryAfter is derived from the Retry-After or X-RateLimit-Reset headers when gh prints them,
+// otherwise it is zero and callers should pick their own backoff.
+type RateLimitError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+// Error returns the underlying gh error message.
+func (e *RateLimitError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying gh error.
+func (e *RateLimitError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrRateLimited.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// parseRateLimit reports whether gh stderr describes a rate limit response and, if the
+// response headers are present, how long to wait before retrying.
+func parseRateLimit(stderr string, now time.Time) (time.Duration, bool) {
+	if !strings.Contains(strings.ToLower(stderr), "rate limit") {
+		return 0, false
+	}
+
+	if m := retryAfterPattern.FindStringSubmatch(stderr); m != nil {
+		if seconds, err := strconv.Atoi(m[1]); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+	if m := rateLimitResetPattern.FindStringSubmatch(stderr); m != nil {
+		if epoch, err := strconv.ParseInt(m[1], 10, 64); err == nil {
+			return max(time.Unix(epoch, 0).Sub(now), 0), true
+		}
+	}
+	return 0, true
+}
+
+// ThrottledGitHub decorates a GitHubRunner, sleeping and retrying operations
+// that fail with ErrRateLimited instead of failing the release.
+type ThrottledGitHub struct {
+	next       GitHubRunner
+	log        Logger
+	sleep      func(ctx context.Context, d time.Duration) error
+	maxWait    time.Duration
+	maxRetries int
+}
+
+// ThrottleOption configures ThrottledGitHub.
+type ThrottleOption func(*ThrottledGitHub)
+
+// WithThrottleLogger sets the logger used to report waits.
+func WithThrottleLogger(log Logger) ThrottleOption {
+	return func(t *ThrottledGitHub) { t.log = log }
+}
+
+// WithThrottleRetries sets how many times a rate-limited operation is retried.
+func WithThrottleRetries(retries int) ThrottleOption {
+	return func(t *ThrottledGitHub) { t.maxRetries = retries }
+}
+
+// WithThrottleMaxWait caps how long a single wait may last.
+func WithThrottleMaxWait(d time.Duration) ThrottleOption {
+	return func(t *ThrottledGitHub) { t.maxWait = d }
+}
+
+// WithThrottleSleep replaces the sleep function (for testing).
+func WithThrottleSleep(sleep func(ctx context.Context, d time.Duration) error) ThrottleOption {
+	return func(t *ThrottledGitHub) { t.sleep = sleep }
+}
+
+// NewThrottledGitHub wraps next with rate-limit-aware retries.
+func NewThrottledGitHub(next GitHubRunner, opts ...ThrottleOption) *ThrottledGitHub {
+	t := &ThrottledGitHub{
+		next:       next,
+		log:        NopLogger{},
+		sleep:      sleepContext,
+		maxWait:    defaultThrottleMaxWait,
+		maxRetries: defaultThrottleRetries,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// CreateRelease creates a GitHub release, retrying on rate limits. Creating a release is
+// not idempotent, so each retry first looks the release up by tag and returns it if found.
+func (t *ThrottledGitHub) CreateRelease(ctx context.Context, opts Options) (string, error) {
+	attempts := 0
+	return throttled(ctx, t, "create release", func() (string, error) {
+		if attempts++; attempts > 1 {
+			if url, found, err := findRelease(ctx, t.next, opts.Tag); err != nil || found {
+				return url, err
+			}
+		}
+		return t.next.CreateRelease(ctx, opts)
+	})
+}
+
+// CreatePR creates a GitHub pull request, retrying on rate limits.
+func (t *ThrottledGitHub) CreatePR(ctx context.Context, opts PullRequestOptions) (string, error) {
+	return throttled(ctx, t, "create pull request", func() (string, error) {
+		return t.next.CreatePR(ctx, opts)
+	})
+}
+
+// ReleaseAssets returns the release asset names, retrying on rate limits.
+func (t *ThrottledGitHub) ReleaseAssets(ctx context.Context, tag string) ([]string, error) {
+	return throttled(ctx, t, "fetch release assets", func() ([]string, error) {
+		return t.next.ReleaseAssets(ctx, tag)
+	})
+}
+
+// ReleaseURL returns the release URL for tag, retrying on rate limits.
+func (t *ThrottledGitHub) ReleaseURL(ctx context.Context, tag string) (string, error) {
+	return throttled(ctx, t, "look up release", func() (string, error) {
+		return t.next.ReleaseURL(ctx, tag)
+	})
+}
+
+// EnsureAuthenticated checks authentication on the wrapped runner without retrying.
+func (t *ThrottledGitHub) EnsureAuthenticated(ctx context.Context) error {
+	return t.next.EnsureAuthenticated(ctx) //nolint:wrapcheck // the preflight caller adds the context
+}
+
+// SetWorkdir sets the working directory on the wrapped runner.
+func (t *ThrottledGitHub) SetWorkdir(dir string) {
+	t.next.SetWorkdir(dir)
+}
+
+func throttled[T any](ctx context.Context, t *ThrottledGitHub, op string, fn func() (T, error)) (T, error) {
+	for attempt := 0; ; attempt++ {
+		result, err := fn()
+		if err == nil || !errors.Is(err, ErrRateLimited) || attempt >= t.maxRetries {
+			return result, err
+		}
+
+		wait := defaultRateLimitFallback
+		var rateErr *RateLimitError
+		if errors.As(err, &rateErr) && rateErr.RetryAfter > 0 {
+			wait = rateErr.RetryAfter
+		}
+		wait = min(wait, t.maxWait)
+
+		t.log.Info("GitHub rate limit hit during %s; retrying in %s (%d/%d)", op, wait, attempt+1, t.maxRetries)
+		if sleepErr := t.sleep(ctx, wait); sleepErr != nil {
+			var zero T
+			return zero, fmt.Errorf("wait for rate limit: %w", sleepErr)
+		}
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("sleep interrupted: %w", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+	tests := []struct {
+		name        string
+		stderr      string
+		wantWait    time.Duration
+		wantLimited bool
+	}{
+		{
+			name:        "retry after header",
+			stderr:      "HTTP 403: You have exceeded a secondary rate limit\nRetry-After: 42\n",
+			wantWait:    42 * time.Second,
+			wantLimited: true,
+		},
+		{
+			name:        "reset header",
+			stderr:      "HTTP 403: API rate limit exceeded\nX-RateLimit-Reset: 1700000090\n",
+			wantWait:    90 * time.Second,
+			wantLimited: true,
+		},
+		{
+			name:        "reset in the past",
+			stderr:      "API rate limit exceeded\nx-ratelimit-reset: 1699999000\n",
+			wantWait:    0,
+			wantLimited: true,
+		},
+		{
+			name:        "rate limit without headers",
+			stderr:      "GraphQL: API rate limit exceeded for user ID 1.",
+			wantWait:    0,
+			wantLimited: true,
+		},
+		{
+			name:        "other error",
+			stderr:      "HTTP 404: Not Found",
+			wantWait:    0,
+			wantLimited: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			wait, limited := parseRateLimit(tc.stderr, now)
+			if limited != tc.wantLimited || wait != tc.wantWait {
+				t.Fatalf("parseRateLimit() = (%s, %v), want (%s, %v)", wait, limited, tc.wantWait, tc.wantLimited)
+			}
+		})
+	}
+}
+
+func TestGHCommandError_RetryAfterFromClock(t *testing.T) {
+	t.Parallel()
+
+	clock := NewFakeClock(time.Unix(1_700_000_000, 0))
+	gh := NewGitHubCLI(WithGHClock(clock))
+	stderr := "HTTP 403: API rate limit exceeded\nX-RateLimit-Reset: 1700000090\n"
+
+	var rateErr *RateLimitError
+	if !errors.As(ghCommandError([]string{"api"}, stderr, gh.clock.Now()), &rateErr) {
+		t.Fatal("ghCommandError() is not a RateLimitError")
+	}
+	if rateErr.RetryAfter != 90*time.Second {
+		t.Fatalf("RetryAfter = %s, want 1m30s", rateErr.RetryAfter)
+	}
+
+	clock.Advance(time.Minute)
+	if !errors.As(ghCommandError([]string{"api"}, stderr, gh.clock.Now()), &rateErr) {
+		t.Fatal("ghCommandError() after Advance is not a RateLimitError")
+	}
+	if rateErr.RetryAfter != 30*time.Second {
+		t.Fatalf("RetryAfter after Advance = %s, want 30s", rateErr.RetryAfter)
+	}
+}
+
+func TestIsTransientGHError(t *testing.T) {
+	t.Parallel()
+
+	ghErr := func(stderr string) error {
+		return ghCommandError([]string{"release", "create"}, stderr, time.Now())
+	}
+	tests := []struct {
+		err  error
+		name string
+		want bool
+	}{
+		{err: ghErr("HTTP 502: Bad Gateway"), name: "server error", want: true},
+		{err: ghErr("read tcp: connection reset by peer"), name: "connection reset", want: true},
+		{err: ghErr("HTTP 422: Validation Failed (already_exists)"), name: "validation error", want: false},
+		{err: ghErr("HTTP 503: API rate limit exceeded"), name: "rate limit", want: false},
+		{err: ErrGHNotAvailable, name: "gh missing", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := isTransientGHError(tt.err); got != tt.want {
+				t.Errorf("isTransientGHError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"altinn.studio/releaser/internal"
+)
+
+func TestThrottledGitHub_RetriesAfterRateLimit(t *testing.T) {
+	t.Parallel()
+
+	gh := &rateLimitedGH{
+		failures: 2,
+		err: &internal.RateLimitError{
+			Err:        fmt.Errorf("%w: release create: API rate limit exceeded", internal.ErrGHCommandFailed),
+			RetryAfter: 7 * time.Second,
+		},
+	}
+	var waits []time.Duration
+	throttled := internal.NewThrottledGitHub(gh,
+		internal.WithThrottleSleep(func(_ context.Context, d time.Duration) error {
+			waits = append(waits, d)
+			return nil
+		}),
+	)
+
+	if _, err := throttled.CreateRelease(t.Context(), internal.Options{Tag: "studioctl/v1.0.0"}); err != nil {
+		t.Fatalf("CreateRelease() error: %v", err)
+	}
+	if gh.calls != 3 {
+		t.Fatalf("calls = %d, want 3", gh.calls)
+	}
+	if len(waits) != 2 || waits[0] != 7*time.Second || waits[1] != 7*time.Second {
+		t.Fatalf("waits = %v, want [7s 7s]", waits)
+	}
+}
+
+func TestThrottledGitHub_CreateReleaseFindsReleaseBeforeRetrying(t *testing.T) {
+	t.Parallel()
+
+	gh := &rateLimitedGH{
+		failures:    1,
+		createdTags: map[string]bool{"studioctl/v1.0.0": true},
+		err: &internal.RateLimitError{
+			Err:        fmt.Errorf("%w: release create: secondary rate limit", internal.ErrGHCommandFailed),
+			RetryAfter: time.Second,
+		},
+	}
+	throttled := internal.NewThrottledGitHub(gh,
+		internal.WithThrottleSleep(func(_ context.Context, _ time.Duration) error { return nil }),
+	)
+
+	url, err := throttled.CreateRelease(t.Context(), internal.Options{Tag: "studioctl/v1.0.0"})
+	if err != nil {
+		t.Fatalf("CreateRelease() error: %v", err)
+	}
+	if gh.calls != 1 {
+		t.Fatalf("create calls = %d, want 1 (the release already exists)", gh.calls)
+	}
+	if url != "https://example.test/releases/studioctl/v1.0.0" {
+		t.Fatalf("CreateRelease() url = %q", url)
+	}
+}
+
+func TestThrottledGitHub_GivesUpAfterMaxRetries(t *testing.T) {
+	t.Parallel()
+
+	gh := &rateLimitedGH{
+		failures: 5,
+		err: &internal.RateLimitError{
+			Err:        fmt.Errorf("%w: pr create: secondary rate limit", internal.ErrGHCommandFailed),
+			RetryAfter: 0,
+		},
+	}
+	var waits []time.Duration
+	throttled := internal.NewThrottledGitHub(gh,
+		internal.WithThrottleRetries(1),
+		internal.WithThrottleMaxWait(30*time.Second),
+		internal.WithThrottleSleep(func(_ context.Context, d time.Duration) error {
+			waits = append(waits, d)
+			return nil
+		}),
+	)
+
+	_, err := throttled.CreatePR(t.Context(), internal.PullRequestOptions{Title: "release"})
+	if !errors.Is(err, internal.ErrRateLimited) {
+		t.Fatalf("CreatePR() error = %v, want %v", err, internal.ErrRateLimited)
+	}
+	if !errors.Is(err, internal.ErrGHCommandFailed) {
+		t.Fatalf("CreatePR() error = %v, want %v", err, internal.ErrGHCommandFailed)
+	}
+	if gh.calls != 2 {
+		t.Fatalf("calls = %d, want 2", gh.calls)
+	}
+	if len(waits) != 1 || waits[0] != 30*time.Second {
+		t.Fatalf("waits = %v, want [30s] (fallback capped by max wait)", waits)
+	}
+}
+
+func TestThrottledGitHub_DoesNotRetryOtherErrors(t *testing.T) {
+	t.Parallel()
+
+	gh := &rateLimitedGH{
+		failures: 1,
+		err:      fmt.Errorf("%w: release view: not found", internal.ErrGHCommandFailed),
+	}
+	throttled := internal.NewThrottledGitHub(gh,
+		internal.WithThrottleSleep(func(_ context.Context, _ time.Duration) error {
+			t.Fatal("unexpected sleep")
+			return nil
+		}),
+	)
+
+	if _, err := throttled.ReleaseAssets(t.Context(), "studioctl/v1.0.0"); !errors.Is(err, internal.ErrGHCommandFailed) {
+		t.Fatalf("ReleaseAssets() error = %v, want %v", err, internal.ErrGHCommandFailed)
+	}
+	if gh.calls != 1 {
+		t.Fatalf("calls = %d, want 1", gh.calls)
+	}
+}
+
+type rateLimitedGH struct {
+	err         error
+	createdTags map[string]bool // releases a failed create still made on the server
+	calls       int
+	failures    int
+}
+
+func (g *rateLimitedGH) attempt() error {
+	g.calls++
+	if g.calls <= g.failures {
+		return g.err
+	}
+	return nil
+}
+
+func (g *rateLimitedGH) CreateRelease(_ context.Context, _ internal.Options) (string, error) {
+	if err := g.attempt(); err != nil {
+		return "", err
+	}
+	return "https://example.test/releases/1", nil
+}
+
+func (g *rateLimitedGH) CreatePR(_ context.Context, _ internal.PullRequestOptions) (string, error) {
+	if err := g.attempt(); err != nil {
+		return "", err
+	}
+	return "https://example.test/pr/1", nil
+}
+
+func (g *rateLimitedGH) ReleaseAssets(_ context.Context, _ string) ([]string, error) {
+	if err := g.attempt(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (g *rateLimitedGH) ReleaseURL(_ context.Context, tag string) (string, error) {
+	if !g.createdTags[tag] {
+		return "", internal.ErrReleaseNotFound
+	}
+	return "https://example.test/releases/" + tag, nil
+}
+
+func (g *rateLimitedGH) EnsureAuthenticated(_ context.Context) error {
+	return nil
+}
+
+func (g *rateLimitedGH) SetWorkdir(_ string) {}
@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+)
+
+// ErrPostReleaseHookFailed is returned when a fatal post-release hook exits with an error.
+var ErrPostReleaseHookFailed = errors.New("post-release hook failed")
+
+// ReleaseHook is a command run after a component's GitHub release is created and its assets are verified.
+// It runs from the repository root with the release described in RELEASE_* environment variables.
+type ReleaseHook struct {
+	Command []string // program and arguments, e.g. {"scripts/publish-docs.sh"}
+	Fatal   bool     // if true, a failing hook fails the release; otherwise it is logged as a warning
+}
+
+// runPostReleaseHook runs the component's post-release hook, if any. Dry runs skip it.
+func (w *Workflow) runPostReleaseHook(ctx context.Context) error {
+	hook := w.config.PostReleaseHook
+	if hook == nil || len(hook.Command) == 0 || w.config.DryRun {
+		return nil
+	}
+
+	w.log.Step("Running post-release hook")
+	w.log.Command(hook.Command[0], hook.Command[1:])
+
+	cmd := exec.CommandContext(ctx, hook.Command[0], hook.Command[1:]...)
+	cmd.Dir = w.config.RepoRoot
+	cmd.Env = append(os.Environ(), w.hookEnv()...)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	runErr := cmd.Run()
+
+	scanner := bufio.NewScanner(&output)
+	for scanner.Scan() {
+		w.log.Info("  %s", scanner.Text())
+	}
+
+	if runErr == nil {
+		w.log.Success("Post-release hook completed")
+		return nil
+	}
+	if hook.Fatal {
+		return fmt.Errorf("%w: %w", ErrPostReleaseHookFailed, runErr)
+	}
+	w.log.Error("WARNING: post-release hook failed; the release %s is published: %v", w.tag.Full(), runErr)
+	return nil
+}
+
+// hookEnv describes the release to a post-release hook.
+func (w *Workflow) hookEnv() []string {
+	return []string{
+		"RELEASE_COMPONENT=" + w.component.Name,
+		"RELEASE_VERSION=" + w.tag.Version.String(),
+		"RELEASE_TAG=" + w.tag.Full(),
+		"RELEASE_URL=" + w.releaseURL,
+		"RELEASE_PRERELEASE=" + strconv.FormatBool(w.tag.Version.IsPrerelease),
+		"RELEASE_DRAFT=" + strconv.FormatBool(w.config.Draft),
+	}
+}
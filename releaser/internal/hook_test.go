@@ -0,0 +1,107 @@
+package internal_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"altinn.studio/releaser/internal"
+)
+
+func TestWorkflow_Run_PostReleaseHook(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake hook is a shell script")
+	}
+
+	tests := []struct {
+		name     string
+		exitCode string
+		dryRun   bool
+		fatal    bool
+		wantRun  bool
+		wantErr  bool
+		wantWarn bool
+	}{
+		{name: "runs after release", exitCode: "0", wantRun: true},
+		{name: "skipped in dry run", exitCode: "0", dryRun: true},
+		{name: "failure is a warning", exitCode: "3", wantRun: true, wantWarn: true},
+		{name: "fatal failure", exitCode: "3", fatal: true, wantRun: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			changelogPath := writeChangelog(t, "# Changelog\n\n## [Unreleased]\n\n"+
+				"## [v1.2.3] - 2025-01-01\n\n### Added\n\n- Test entry\n")
+			hookDir := t.TempDir()
+			envFile := filepath.Join(hookDir, "env")
+			script := filepath.Join(hookDir, "hook.sh")
+			content := "#!/bin/sh\nenv | grep '^RELEASE_' > \"$1\"\n" +
+				"echo published $RELEASE_TAG\nexit " + tt.exitCode + "\n"
+			if err := os.WriteFile(script, []byte(content), 0o755); err != nil {
+				t.Fatalf("write hook script: %v", err)
+			}
+
+			cfg := internal.WorkflowConfig{
+				Component:       "studioctl",
+				Version:         "v1.2.3",
+				ChangelogPath:   changelogPath,
+				OutputDir:       t.TempDir(),
+				RepoRoot:        os.TempDir(),
+				DryRun:          tt.dryRun,
+				Draft:           true,
+				PostReleaseHook: &internal.ReleaseHook{Command: []string{script, envFile}, Fatal: tt.fatal},
+			}
+			git := &fakeGit{currentBranch: "main", remoteBranchExists: true, workingTreeClean: true}
+			buf := &bytes.Buffer{}
+			log := internal.NewConsoleLogger(internal.WithWriters(buf, buf), internal.WithColor(false))
+			workflow, err := internal.NewWorkflow(t.Context(), cfg, git, &fakeGH{}, &fakeBuilder{}, log)
+			if err != nil {
+				t.Fatalf("NewWorkflow() error: %v", err)
+			}
+
+			err = workflow.Run(t.Context())
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("workflow.Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, internal.ErrPostReleaseHookFailed) {
+				t.Fatalf("error = %v, want ErrPostReleaseHookFailed", err)
+			}
+			out := buf.String()
+			if got := strings.Contains(out, "WARNING: post-release hook failed"); got != tt.wantWarn {
+				t.Fatalf("warning logged = %v, want %v; output:\n%s", got, tt.wantWarn, out)
+			}
+
+			env, err := os.ReadFile(envFile)
+			if !tt.wantRun {
+				if !os.IsNotExist(err) {
+					t.Fatalf("hook ran during dry run (stat error: %v)", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("read hook env: %v", err)
+			}
+			for _, want := range []string{
+				"RELEASE_COMPONENT=studioctl",
+				"RELEASE_VERSION=v1.2.3",
+				"RELEASE_TAG=studioctl/v1.2.3",
+				"RELEASE_URL=" + fakeReleaseURL + "studioctl/v1.2.3",
+				"RELEASE_PRERELEASE=false",
+				"RELEASE_DRAFT=true",
+			} {
+				if !strings.Contains(string(env), want+"\n") {
+					t.Errorf("hook env missing %q:\n%s", want, env)
+				}
+			}
+			if !strings.Contains(out, "published studioctl/v1.2.3") {
+				t.Errorf("hook output not logged:\n%s", out)
+			}
+		})
+	}
+}
@@ -23,10 +23,20 @@ type Logger interface {
 	Detail(key, value string)
 }
 
+// ANSI escape sequences for console output markers.
+const (
+	ansiBold  = "\033[1m"
+	ansiRed   = "\033[31m"
+	ansiGreen = "\033[32m"
+	ansiReset = "\033[0m"
+)
+
 // ConsoleLogger implements Logger with formatted console output.
 type ConsoleLogger struct {
-	out io.Writer
-	err io.Writer
+	out      io.Writer
+	err      io.Writer
+	color    bool
+	colorSet bool // color was forced by WithColor
 }
 
 // ConsoleLoggerOption configures ConsoleLogger.
@@ -40,22 +50,63 @@ func WithWriters(out, errOut io.Writer) ConsoleLoggerOption {
 	}
 }
 
+// WithColor forces colored markers on or off, overriding ColorsEnabled.
+func WithColor(enabled bool) ConsoleLoggerOption {
+	return func(l *ConsoleLogger) {
+		l.color = enabled
+		l.colorSet = true
+	}
+}
+
+// ColorsEnabled reports whether console output should be colored. Like studioctl, color is
+// disabled when NO_COLOR is set (even to an empty value) or when w is not a terminal.
+func ColorsEnabled(w io.Writer) bool {
+	return colorsEnabled(isTerminal(w))
+}
+
+func colorsEnabled(terminal bool) bool {
+	_, noColor := os.LookupEnv("NO_COLOR")
+	return !noColor && terminal
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
 // NewConsoleLogger creates a new console logger.
 func NewConsoleLogger(opts ...ConsoleLoggerOption) *ConsoleLogger {
 	l := &ConsoleLogger{
-		out: os.Stdout,
-		err: os.Stderr,
+		out:      os.Stdout,
+		err:      os.Stderr,
+		color:    false,
+		colorSet: false,
 	}
 	for _, opt := range opts {
 		opt(l)
 	}
+	if !l.colorSet {
+		l.color = ColorsEnabled(l.out)
+	}
 	return l
 }
 
+// paint wraps s in the given ANSI style when color is enabled.
+func (l *ConsoleLogger) paint(style, s string) string {
+	if !l.color {
+		return s
+	}
+	return style + s + ansiReset
+}
+
 // Step logs a major workflow step.
 func (l *ConsoleLogger) Step(msg string) {
 	//nolint:errcheck // logging errors are non-critical
-	fmt.Fprintf(l.out, "\n==> %s\n", msg)
+	fmt.Fprintf(l.out, "\n%s %s\n", l.paint(ansiBold, "==>"), msg)
 }
 
 // Info logs an informational message.
@@ -76,7 +127,7 @@ func (l *ConsoleLogger) Command(cmd string, args []string) {
 // Success logs a success message.
 func (l *ConsoleLogger) Success(msg string) {
 	//nolint:errcheck // logging errors are non-critical
-	fmt.Fprintf(l.out, "    OK: %s\n", msg)
+	fmt.Fprintf(l.out, "    %s %s\n", l.paint(ansiGreen, "OK:"), msg)
 }
 
 // Error logs an error message.
@@ -85,7 +136,7 @@ func (l *ConsoleLogger) Error(msg string, args ...any) {
 		msg = fmt.Sprintf(msg, args...)
 	}
 	//nolint:errcheck // logging errors are non-critical
-	fmt.Fprintf(l.err, "    ERROR: %s\n", msg)
+	fmt.Fprintf(l.err, "    %s %s\n", l.paint(ansiRed, "ERROR:"), msg)
 }
 
 // Detail logs a key-value detail line.
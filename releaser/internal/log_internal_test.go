@@ -0,0 +1,18 @@
+package internal
+
+import "testing"
+
+func TestColorsEnabled_Terminal(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	if colorsEnabled(true) {
+		t.Error("colorsEnabled(true) = true, want false when NO_COLOR is set")
+	}
+}
+
+func TestColorsEnabled_NonTerminal(t *testing.T) {
+	t.Parallel()
+
+	if colorsEnabled(false) {
+		t.Error("colorsEnabled(false) = true, want false for non-terminal output")
+	}
+}
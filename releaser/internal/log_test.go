@@ -0,0 +1,46 @@
+package internal_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"altinn.studio/releaser/internal"
+)
+
+func TestConsoleLoggerColor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		opts      []internal.ConsoleLoggerOption
+		wantColor bool
+	}{
+		{name: "non-terminal writer disables color", opts: nil, wantColor: false},
+		{name: "forced off", opts: []internal.ConsoleLoggerOption{internal.WithColor(false)}, wantColor: false},
+		{name: "forced on", opts: []internal.ConsoleLoggerOption{internal.WithColor(true)}, wantColor: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			buf := &bytes.Buffer{}
+			opts := append([]internal.ConsoleLoggerOption{internal.WithWriters(buf, buf)}, tc.opts...)
+			log := internal.NewConsoleLogger(opts...)
+			log.Step("Building")
+			log.Success("built")
+			log.Error("failed")
+
+			out := buf.String()
+			if got := strings.Contains(out, "\033["); got != tc.wantColor {
+				t.Errorf("output has ANSI escapes = %v, want %v: %q", got, tc.wantColor, out)
+			}
+			for _, want := range []string{"==>", "OK:", "ERROR:"} {
+				if !strings.Contains(out, want) {
+					t.Errorf("output missing marker %q: %q", want, out)
+				}
+			}
+		})
+	}
+}
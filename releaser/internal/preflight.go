@@ -27,3 +27,52 @@ func ensureWorkingTreeClean(ctx context.Context, git GitRunner, log Logger) erro
 	log.Error("  or: git stash")
 	return ErrWorkingTreeDirty
 }
+
+// ensureNotBehindRemote fails when HEAD lacks commits from origin/<branch>, so a release
+// is not cut from a stale local checkout. With allowBehind it only warns.
+func ensureNotBehindRemote(ctx context.Context, git GitRunner, log Logger, branch string, allowBehind bool) error {
+	if log == nil {
+		log = NopLogger{}
+	}
+
+	behind, err := git.CommitsBehind(ctx, branch)
+	if err != nil {
+		return fmt.Errorf("compare with origin/%s: %w", branch, err)
+	}
+	if behind == 0 {
+		log.Success("Up to date with origin/" + branch)
+		return nil
+	}
+	if allowBehind {
+		log.Error("WARNING: %s is %d commit(s) behind origin/%s (-allow-behind)", branch, behind, branch)
+		return nil
+	}
+
+	log.Error("%s is %d commit(s) behind origin/%s", branch, behind, branch)
+	log.Error("Update before releasing:")
+	log.Error("  git pull origin %s", branch)
+	return fmt.Errorf("%w: %s is %d commit(s) behind origin/%s", ErrBranchBehindRemote, branch, behind, branch)
+}
+
+// ensureGitHubAuthenticated fails fast when gh cannot talk to GitHub, before any
+// branch, tag or release is touched. Dry runs make no API calls, so they only warn.
+func ensureGitHubAuthenticated(ctx context.Context, gh GitHubRunner, dryRun bool, log Logger) error {
+	if log == nil {
+		log = NopLogger{}
+	}
+
+	err := gh.EnsureAuthenticated(ctx)
+	if err == nil {
+		return nil
+	}
+	if dryRun {
+		log.Error("WARNING: gh is not authenticated; a real run would fail (%v)", err)
+		return nil
+	}
+
+	log.Error("gh is not authenticated with GitHub")
+	log.Error("Log in before releasing:")
+	log.Error("  gh auth login")
+	log.Error("  or set GH_TOKEN (in CI)")
+	return fmt.Errorf("check gh authentication: %w", err)
+}
@@ -1,11 +1,15 @@
 package internal_test
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"altinn.studio/releaser/internal"
 )
@@ -96,6 +100,42 @@ func TestRunBackportWithDeps_FailsOnDirtyWorkingTree(t *testing.T) {
 	}
 }
 
+func TestRunPrepareWithDeps_GitHubNotAuthenticated(t *testing.T) {
+	repo := createStudioctlWorkflowRepo(t, `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- Existing unreleased
+`)
+	t.Chdir(repo)
+
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+	gh := &fakeGH{authErr: internal.ErrGitHubNotAuthenticated}
+	req := internal.PrepareRequest{
+		Component: "studioctl",
+		Version:   "v0.1.0-preview.1",
+		DryRun:    true,
+	}
+
+	if err := internal.RunPrepareWithDeps(t.Context(), req, git, gh, internal.NopLogger{}); err != nil {
+		t.Fatalf("RunPrepareWithDeps() dry-run error = %v, want only a warning", err)
+	}
+
+	req.DryRun = false
+	err := internal.RunPrepareWithDeps(t.Context(), req, git, gh, internal.NopLogger{})
+	if !errors.Is(err, internal.ErrGitHubNotAuthenticated) {
+		t.Fatalf("RunPrepareWithDeps() error = %v, want %v", err, internal.ErrGitHubNotAuthenticated)
+	}
+	if branch := gitOut(t, repo, "rev-parse", "--abbrev-ref", "HEAD"); branch != "main" {
+		t.Fatalf("current branch = %q, want main (no branch should be created)", branch)
+	}
+	if gh.prCreated {
+		t.Fatal("no PR should be created without gh authentication")
+	}
+}
+
 func TestRunPrepareWithDeps_FromNestedDir(t *testing.T) {
 	repo := createStudioctlWorkflowRepo(t, `# Changelog
 
@@ -141,6 +181,87 @@ func TestRunPrepareWithDeps_FromNestedDir(t *testing.T) {
 	}
 }
 
+func TestRunPrepareWithDeps_ChangelogFileMissing(t *testing.T) {
+	repo := createStudioctlWorkflowRepo(t, `# Changelog
+
+## [Unreleased]
+`)
+	t.Chdir(repo)
+
+	git := internal.NewGitCLI(internal.WithLogger(internal.NopLogger{}))
+	err := internal.RunPrepareWithDeps(t.Context(), internal.PrepareRequest{
+		Component:     "studioctl",
+		Version:       "v0.1.0-preview.1",
+		ChangelogPath: "docs/CHANGELOG.md",
+	}, git, &fakeGH{}, internal.NopLogger{})
+
+	if !errors.Is(err, internal.ErrChangelogFileMissing) {
+		t.Fatalf("RunPrepareWithDeps() error = %v, want %v", err, internal.ErrChangelogFileMissing)
+	}
+	root, rootErr := git.RepoRoot(t.Context())
+	if rootErr != nil {
+		t.Fatalf("RepoRoot() error = %v", rootErr)
+	}
+	wantPath := filepath.Join(root, "docs", "CHANGELOG.md")
+	for _, want := range []string{wantPath, "origin/main", "src/cli/CHANGELOG.md"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("RunPrepareWithDeps() error = %v, want message containing %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "-changelog-path") {
+		t.Errorf("RunPrepareWithDeps() error = %v, want no -changelog-path hint: the command has no such flag", err)
+	}
+}
+
+func TestRunPrepareWithDeps_PromotePrerelease(t *testing.T) {
+	repo := createStudioctlWorkflowRepo(t, `# Changelog
+
+## [Unreleased]
+
+## [1.3.0-preview.2] - 2025-02-01
+
+### Fixed
+
+- Fix preview regression
+
+## [1.3.0-preview.1] - 2025-01-01
+
+### Added
+
+- Add preview feature
+`)
+	t.Chdir(repo)
+
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+	gh := &fakeGH{}
+
+	err := internal.RunPrepareWithDeps(t.Context(), internal.PrepareRequest{
+		Component:         "studioctl",
+		Version:           "v1.3.0",
+		PromotePrerelease: true,
+	}, git, gh, internal.NopLogger{})
+	if err != nil {
+		t.Fatalf("RunPrepareWithDeps() error = %v", err)
+	}
+
+	if gh.prBase != "release/studioctl/v1.3" {
+		t.Fatalf("PR base = %q, want %q", gh.prBase, "release/studioctl/v1.3")
+	}
+	content, err := os.ReadFile(filepath.Join(repo, "src", "cli", "CHANGELOG.md"))
+	if err != nil {
+		t.Fatalf("read changelog: %v", err)
+	}
+	want := "## [Unreleased]\n\n## [1.3.0] - "
+	if !strings.Contains(string(content), want) {
+		t.Fatalf("promoted changelog missing %q:\n%s", want, content)
+	}
+	for _, entry := range []string{"- [Added] Add preview feature", "- [Fixed] Fix preview regression"} {
+		if !strings.Contains(gh.prBody, entry) {
+			t.Fatalf("PR body missing %q:\n%s", entry, gh.prBody)
+		}
+	}
+}
+
 func TestRunPrepareWithDeps_PRBodyFormat(t *testing.T) {
 	repo := createStudioctlWorkflowRepo(t, `# Changelog
 
@@ -173,8 +294,8 @@ func TestRunPrepareWithDeps_PRBodyFormat(t *testing.T) {
 	if gh.prTitle != "chore: release studioctl v0.1.0-preview.1" {
 		t.Fatalf("PR title = %q, want %q", gh.prTitle, "chore: release studioctl v0.1.0-preview.1")
 	}
-	if gh.prLabel != "release/studioctl" {
-		t.Fatalf("PR label = %q, want %q", gh.prLabel, "release/studioctl")
+	if !slices.Equal(gh.prLabels, []string{"release/studioctl"}) {
+		t.Fatalf("PR labels = %q, want only %q", gh.prLabels, "release/studioctl")
 	}
 
 	const wantBody = `## Description
@@ -292,3 +413,542 @@ func (p *scriptedPrompter) Confirm(action string, details []string) (bool, error
 	p.answers = p.answers[1:]
 	return answer, nil
 }
+
+func TestRunPrepareWithDeps_ForwardsExtraLabels(t *testing.T) {
+	repo := createStudioctlWorkflowRepo(t, `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- Add feature A (#1234)
+`)
+	t.Chdir(repo)
+
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+	gh := &fakeGH{}
+
+	err := internal.RunPrepareWithDeps(t.Context(), internal.PrepareRequest{
+		Component: "studioctl",
+		Version:   "v0.1.0-preview.1",
+		Labels:    []string{"automated", "needs-review", "automated", "release/studioctl"},
+	}, git, gh, internal.NopLogger{})
+	if err != nil {
+		t.Fatalf("RunPrepareWithDeps() error = %v", err)
+	}
+
+	want := []string{"release/studioctl", "automated", "needs-review"}
+	if !slices.Equal(gh.prLabels, want) {
+		t.Fatalf("PR labels = %q, want %q", gh.prLabels, want)
+	}
+}
+
+func TestRunPrepareWithDeps_NoPush(t *testing.T) {
+	repo := createStudioctlWorkflowRepo(t, `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- Existing unreleased
+`)
+	t.Chdir(repo)
+
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+	// gh is never needed, so a missing login must not matter.
+	gh := &fakeGH{authErr: internal.ErrGitHubNotAuthenticated}
+	prompter := &scriptedPrompter{answers: []bool{true}}
+
+	err := internal.RunPrepareWithDeps(t.Context(), internal.PrepareRequest{
+		Component: "studioctl",
+		Version:   "v0.1.0-preview.1",
+		Prompter:  prompter,
+		NoPush:    true,
+	}, git, gh, internal.NopLogger{})
+	if err != nil {
+		t.Fatalf("RunPrepareWithDeps() error = %v", err)
+	}
+
+	const branch = "release-prep/studioctl-v0.1.0-preview.1"
+	if current := gitOut(t, repo, "rev-parse", "--abbrev-ref", "HEAD"); current != branch {
+		t.Fatalf("current branch = %q, want %q", current, branch)
+	}
+	if subject := gitOut(t, repo, "log", "-1", "--format=%s"); subject != "Release studioctl v0.1.0-preview.1" {
+		t.Fatalf("HEAD commit = %q, want the release commit", subject)
+	}
+	if remoteBranchExists(t, repo, branch) {
+		t.Fatal("prep branch was pushed despite -no-push")
+	}
+	if gh.prCreated {
+		t.Fatal("PR was created despite -no-push")
+	}
+	if len(prompter.calls) != 1 || prompter.calls[0].action != "promote changelog and create commit" {
+		t.Fatalf("prompts = %+v, want only the commit confirmation", prompter.calls)
+	}
+}
+
+func TestRunBackportWithDeps_NoPush(t *testing.T) {
+	repo, commits := setupBackportBatchRepo(t)
+	t.Chdir(repo)
+
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+	gh := &fakeGH{}
+	err := internal.RunBackportWithDeps(t.Context(), internal.BackportRequest{
+		Component: "studioctl",
+		Commit:    commits[0],
+		Branch:    "v1.0",
+		NoPush:    true,
+	}, git, gh, internal.NopLogger{})
+	if err != nil {
+		t.Fatalf("RunBackportWithDeps() error = %v", err)
+	}
+
+	branch := "backport/studioctl-v1.0-" + commits[0][:8]
+	if current := gitOut(t, repo, "rev-parse", "--abbrev-ref", "HEAD"); current != branch {
+		t.Fatalf("current branch = %q, want %q", current, branch)
+	}
+	if remoteBranchExists(t, repo, branch) {
+		t.Fatal("backport branch was pushed despite -no-push")
+	}
+	if gh.prCreated {
+		t.Fatal("PR was created despite -no-push")
+	}
+}
+
+func TestRunPrepareWithDeps_LeftoverPrepBranch(t *testing.T) {
+	const branch = "release-prep/studioctl-v0.1.0-preview.1"
+	for _, resume := range []bool{false, true} {
+		t.Run(fmt.Sprintf("resume=%t", resume), func(t *testing.T) {
+			repo := createStudioctlWorkflowRepo(t, `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- Existing unreleased
+`)
+			// Simulate a prepare that died after committing on the prep branch.
+			runGitCmd(t, repo, "checkout", "-b", branch)
+			writeRepoFile(t, repo, "stale.txt", "stale\n")
+			runGitCmd(t, repo, "add", "stale.txt")
+			runGitCmd(t, repo, "commit", "-m", "stale prep commit")
+			runGitCmd(t, repo, "checkout", "main")
+			t.Chdir(repo)
+
+			git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+			gh := &fakeGH{}
+			err := internal.RunPrepareWithDeps(t.Context(), internal.PrepareRequest{
+				Component: "studioctl",
+				Version:   "v0.1.0-preview.1",
+				Resume:    resume,
+			}, git, gh, internal.NopLogger{})
+
+			if !resume {
+				if !errors.Is(err, internal.ErrPrepBranchExists) || !strings.Contains(err.Error(), branch) {
+					t.Fatalf("RunPrepareWithDeps() error = %v, want %v naming %s", err, internal.ErrPrepBranchExists, branch)
+				}
+				if current := gitOut(t, repo, "rev-parse", "--abbrev-ref", "HEAD"); current != "main" {
+					t.Fatalf("current branch = %q, want main", current)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("RunPrepareWithDeps() error = %v", err)
+			}
+			if !gh.prCreated || !remoteBranchExists(t, repo, branch) {
+				t.Fatal("resumed prepare should push the branch and create the PR")
+			}
+			if log := gitOut(t, repo, "log", "--format=%s", "origin/main.."+branch); log != "Release studioctl v0.1.0-preview.1" {
+				t.Fatalf("prep branch commits = %q, want only the release commit", log)
+			}
+		})
+	}
+}
+
+func TestRunPrepareWithDeps_LocalDryRun(t *testing.T) {
+	repo := createStudioctlWorkflowRepo(t, `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- Committed entry
+`)
+	// Uncommitted edits are only visible in the working tree, and without an
+	// origin any fetch or ls-remote would fail.
+	writeRepoFile(t, repo, "src/cli/CHANGELOG.md", `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- Committed entry
+- Working tree entry
+`)
+	runGitCmd(t, repo, "remote", "remove", "origin")
+	t.Chdir(repo)
+
+	buf := &bytes.Buffer{}
+	log := internal.NewConsoleLogger(internal.WithWriters(buf, buf), internal.WithColor(false))
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+	gh := &fakeGH{authErr: internal.ErrGitHubNotAuthenticated}
+
+	err := internal.RunPrepareWithDeps(t.Context(), internal.PrepareRequest{
+		Component: "studioctl",
+		Version:   "v0.2.0",
+		DryRun:    true,
+		Local:     true,
+	}, git, gh, log)
+	if err != nil {
+		t.Fatalf("RunPrepareWithDeps() error = %v\n%s", err, buf.String())
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"Reading src/cli/CHANGELOG.md from the working tree (-local)",
+		"Would create release branch: release/studioctl/v0.2",
+		"## [0.2.0] - ",
+		"- Working tree entry",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "not authenticated") {
+		t.Errorf("output mentions gh auth although -local skips it:\n%s", out)
+	}
+}
+
+func TestRunPrepareWithDeps_WorktreeLeavesCheckoutUntouched(t *testing.T) {
+	repo := createStudioctlWorkflowRepo(t, `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- Existing unreleased
+`)
+	runGitCmd(t, repo, "checkout", "-b", "feature/wip")
+	writeRepoFile(t, repo, "README.md", "uncommitted edit\n")
+	writeRepoFile(t, repo, "notes.txt", "untracked\n")
+	wantStatus := gitOut(t, repo, "status", "--porcelain")
+	wantHead := revParseHead(t, repo)
+	t.Chdir(repo)
+
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+	gh := &fakeGH{}
+	err := internal.RunPrepareWithDeps(t.Context(), internal.PrepareRequest{
+		Component: "studioctl",
+		Version:   "v0.1.0",
+		Worktree:  true,
+	}, git, gh, internal.NopLogger{})
+	if err != nil {
+		t.Fatalf("RunPrepareWithDeps() error = %v", err)
+	}
+
+	if branch := gitOut(t, repo, "rev-parse", "--abbrev-ref", "HEAD"); branch != "feature/wip" {
+		t.Errorf("current branch = %s, want feature/wip", branch)
+	}
+	if head := revParseHead(t, repo); head != wantHead {
+		t.Errorf("HEAD = %s, want unchanged %s", head, wantHead)
+	}
+	if status := gitOut(t, repo, "status", "--porcelain"); status != wantStatus {
+		t.Errorf("working tree status = %q, want unchanged %q", status, wantStatus)
+	}
+	if readme := gitOut(t, repo, "show", "release-prep/studioctl-v0.1.0:README.md"); readme != "test" {
+		t.Errorf("prep branch README = %q, want the committed version", readme)
+	}
+	if worktrees := gitOut(t, repo, "worktree", "list", "--porcelain"); strings.Count(worktrees, "worktree ") != 1 {
+		t.Errorf("temporary worktree left behind:\n%s", worktrees)
+	}
+
+	for _, branch := range []string{"release/studioctl/v0.1", "release-prep/studioctl-v0.1.0"} {
+		if !remoteBranchExists(t, repo, branch) {
+			t.Errorf("%s was not pushed", branch)
+		}
+	}
+	cl := gitOut(t, repo, "show", "origin/release-prep/studioctl-v0.1.0:src/cli/CHANGELOG.md")
+	if !strings.Contains(cl, "## [0.1.0] - ") {
+		t.Errorf("pushed changelog not promoted:\n%s", cl)
+	}
+	if !gh.prCreated || gh.prBase != "release/studioctl/v0.1" {
+		t.Errorf("PR created = %v with base %q, want a PR into release/studioctl/v0.1", gh.prCreated, gh.prBase)
+	}
+}
+
+func TestRunPrepareWithDeps_ClockDatesPromotedSection(t *testing.T) {
+	repo := createStudioctlWorkflowRepo(t, `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- Leap day entry
+`)
+	t.Chdir(repo)
+
+	buf := &bytes.Buffer{}
+	log := internal.NewConsoleLogger(internal.WithWriters(buf, buf), internal.WithColor(false))
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+
+	err := internal.RunPrepareWithDeps(t.Context(), internal.PrepareRequest{
+		Component: "studioctl",
+		Version:   "v0.2.0",
+		DryRun:    true,
+		Clock:     internal.NewFakeClock(time.Date(2024, time.February, 29, 23, 30, 0, 0, time.UTC)),
+	}, git, &fakeGH{}, log)
+	if err != nil {
+		t.Fatalf("RunPrepareWithDeps() error = %v\n%s", err, buf.String())
+	}
+	if out := buf.String(); !strings.Contains(out, "## [0.2.0] - 2024-02-29") {
+		t.Errorf("output missing pinned promotion date:\n%s", out)
+	}
+}
+
+func TestRunPrepareWithDeps_BaseBranchBehindRemote(t *testing.T) {
+	tests := []struct {
+		wantErr     error
+		name        string
+		behind      bool
+		allowBehind bool
+	}{
+		{name: "behind origin", behind: true, wantErr: internal.ErrBranchBehindRemote},
+		{name: "behind allowed", behind: true, allowBehind: true},
+		{name: "up to date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := createStudioctlWorkflowRepo(t, `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- Entry
+`)
+			if tt.behind {
+				runGitCmd(t, repo, "commit", "--allow-empty", "-m", "pushed elsewhere")
+				runGitCmd(t, repo, "push", "origin", "main")
+				runGitCmd(t, repo, "reset", "--hard", "HEAD~1")
+			}
+			t.Chdir(repo)
+
+			git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+			err := internal.RunPrepareWithDeps(t.Context(), internal.PrepareRequest{
+				Component:   "studioctl",
+				Version:     "v0.2.0-preview.1",
+				DryRun:      true,
+				AllowBehind: tt.allowBehind,
+			}, git, &fakeGH{}, internal.NopLogger{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RunPrepareWithDeps() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunPrepareWithDeps_LocalRequiresDryRun(t *testing.T) {
+	repo := createStudioctlWorkflowRepo(t, `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- Existing unreleased
+`)
+	t.Chdir(repo)
+
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+	gh := &fakeGH{}
+	err := internal.RunPrepareWithDeps(t.Context(), internal.PrepareRequest{
+		Component: "studioctl",
+		Version:   "v0.1.0-preview.1",
+		Local:     true,
+	}, git, gh, internal.NopLogger{})
+	if !errors.Is(err, internal.ErrLocalRequiresDryRun) {
+		t.Fatalf("RunPrepareWithDeps() error = %v, want %v", err, internal.ErrLocalRequiresDryRun)
+	}
+	if current := gitOut(t, repo, "rev-parse", "--abbrev-ref", "HEAD"); current != "main" {
+		t.Fatalf("current branch = %q, want main to stay checked out", current)
+	}
+}
+
+func TestRunPrepareWithDeps_CommitAuthor(t *testing.T) {
+	repo := createStudioctlWorkflowRepo(t, `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- Existing unreleased
+`)
+	t.Chdir(repo)
+
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+	err := internal.RunPrepareWithDeps(t.Context(), internal.PrepareRequest{
+		Component: "studioctl",
+		Version:   "v0.1.0-preview.1",
+		NoPush:    true,
+		Author:    internal.CommitIdentity{Name: "release-bot", Email: "bot@example.com"},
+	}, git, &fakeGH{}, internal.NopLogger{})
+	if err != nil {
+		t.Fatalf("RunPrepareWithDeps() error = %v", err)
+	}
+
+	const want = "release-bot <bot@example.com>|release-bot <bot@example.com>"
+	if got := gitOut(t, repo, "log", "-1", "--format=%an <%ae>|%cn <%ce>"); got != want {
+		t.Fatalf("release commit identity = %q, want %q", got, want)
+	}
+}
+
+func TestRunPrepareWithDeps_InvalidCommitAuthor(t *testing.T) {
+	repo := createStudioctlWorkflowRepo(t, "# Changelog\n\n## [Unreleased]\n\n### Added\n\n- Entry\n")
+	t.Chdir(repo)
+
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+	err := internal.RunPrepareWithDeps(t.Context(), internal.PrepareRequest{
+		Component: "studioctl",
+		Version:   "v0.1.0-preview.1",
+		NoPush:    true,
+		Author:    internal.CommitIdentity{Name: "release-bot", Email: "not-an-email"},
+	}, git, &fakeGH{}, internal.NopLogger{})
+	if !errors.Is(err, internal.ErrInvalidCommitIdentity) {
+		t.Fatalf("RunPrepareWithDeps() error = %v, want %v", err, internal.ErrInvalidCommitIdentity)
+	}
+}
+
+func TestRunBackportWithDeps_CommitAuthor(t *testing.T) {
+	repo, commits := setupBackportBatchRepo(t)
+	t.Chdir(repo)
+
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+	err := internal.RunBackportWithDeps(t.Context(), internal.BackportRequest{
+		Component: "studioctl",
+		Commit:    commits[0],
+		Branch:    "v1.0",
+		NoPush:    true,
+		Author:    internal.CommitIdentity{Email: "bot@example.com"},
+	}, git, &fakeGH{}, internal.NopLogger{})
+	if err != nil {
+		t.Fatalf("RunBackportWithDeps() error = %v", err)
+	}
+
+	// Only the email is overridden; the name still comes from git config.
+	const want = "Test User <bot@example.com>"
+	if got := gitOut(t, repo, "log", "-1", "--format=%an <%ae>"); got != want {
+		t.Fatalf("backport commit author = %q, want %q", got, want)
+	}
+}
+
+func TestRunPrepareWithDeps_Trailers(t *testing.T) {
+	repo := createStudioctlWorkflowRepo(t, "# Changelog\n\n## [Unreleased]\n\n### Added\n\n- Entry\n")
+	t.Chdir(repo)
+
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+	err := internal.RunPrepareWithDeps(t.Context(), internal.PrepareRequest{
+		Component: "studioctl",
+		Version:   "v0.1.0-preview.1",
+		NoPush:    true,
+		Trailers: []internal.Trailer{
+			{Key: "Release-Component", Value: "studioctl"},
+			{Key: "Release-Version", Value: "v0.1.0-preview.1"},
+		},
+	}, git, &fakeGH{}, internal.NopLogger{})
+	if err != nil {
+		t.Fatalf("RunPrepareWithDeps() error = %v", err)
+	}
+
+	if subject := gitOut(t, repo, "log", "-1", "--format=%s"); subject != "Release studioctl v0.1.0-preview.1" {
+		t.Fatalf("subject = %q, want the release subject", subject)
+	}
+	const want = "Release-Component: studioctl\nRelease-Version: v0.1.0-preview.1"
+	if got := gitOut(t, repo, "log", "-1", "--format=%(trailers)"); got != want {
+		t.Fatalf("trailers = %q, want %q", got, want)
+	}
+}
+
+func TestRunBackportWithDeps_Trailers(t *testing.T) {
+	repo, commits := setupBackportBatchRepo(t)
+	t.Chdir(repo)
+
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+	err := internal.RunBackportWithDeps(t.Context(), internal.BackportRequest{
+		Component: "studioctl",
+		Commit:    commits[0],
+		Branch:    "v1.0",
+		NoPush:    true,
+		Trailers:  []internal.Trailer{{Key: "Backport-Of", Value: commits[0]}},
+	}, git, &fakeGH{}, internal.NopLogger{})
+	if err != nil {
+		t.Fatalf("RunBackportWithDeps() error = %v", err)
+	}
+
+	body := gitOut(t, repo, "log", "-1", "--format=%B")
+	wantTail := "(cherry picked from commit " + commits[0] + ")\nBackport-Of: " + commits[0]
+	if !strings.HasSuffix(body, wantTail) {
+		t.Fatalf("commit message = %q, want it to end with %q", body, wantTail)
+	}
+	if got := gitOut(t, repo, "log", "-1", "--format=%(trailers:key=Backport-Of,valueonly)"); got != commits[0] {
+		t.Fatalf("Backport-Of trailer = %q, want %q", got, commits[0])
+	}
+}
+
+func TestRunBackportWithDeps_InvalidTrailer(t *testing.T) {
+	repo, commits := setupBackportBatchRepo(t)
+	t.Chdir(repo)
+
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+	err := internal.RunBackportWithDeps(t.Context(), internal.BackportRequest{
+		Component: "studioctl",
+		Commit:    commits[0],
+		Branch:    "v1.0",
+		NoPush:    true,
+		Trailers:  []internal.Trailer{{Key: "Backport Of", Value: commits[0]}},
+	}, git, &fakeGH{}, internal.NopLogger{})
+	if !errors.Is(err, internal.ErrInvalidTrailer) {
+		t.Fatalf("RunBackportWithDeps() error = %v, want %v", err, internal.ErrInvalidTrailer)
+	}
+}
+
+func TestRunPrepareWithDeps_CommitTemplate(t *testing.T) {
+	repo := createStudioctlWorkflowRepo(t, "# Changelog\n\n## [Unreleased]\n\n### Added\n\n- Entry\n")
+	t.Chdir(repo)
+
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+	err := internal.RunPrepareWithDeps(t.Context(), internal.PrepareRequest{
+		Component:      "studioctl",
+		Version:        "v0.1.0-preview.1",
+		NoPush:         true,
+		CommitTemplate: "chore(release): {component} {version} ({tag})",
+	}, git, &fakeGH{}, internal.NopLogger{})
+	if err != nil {
+		t.Fatalf("RunPrepareWithDeps() error = %v", err)
+	}
+
+	const want = "chore(release): studioctl v0.1.0-preview.1 (studioctl/v0.1.0-preview.1)"
+	if subject := gitOut(t, repo, "log", "-1", "--format=%s"); subject != want {
+		t.Fatalf("subject = %q, want %q", subject, want)
+	}
+}
+
+func TestRunPrepareWithDeps_InvalidCommitTemplate(t *testing.T) {
+	repo := createStudioctlWorkflowRepo(t, "# Changelog\n\n## [Unreleased]\n\n### Added\n\n- Entry\n")
+	t.Chdir(repo)
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+
+	for _, template := range []string{"chore(release): {comp} {version}", "Release {}", "   "} {
+		err := internal.RunPrepareWithDeps(t.Context(), internal.PrepareRequest{
+			Component:      "studioctl",
+			Version:        "v0.1.0-preview.1",
+			NoPush:         true,
+			CommitTemplate: template,
+		}, git, &fakeGH{}, internal.NopLogger{})
+		if !errors.Is(err, internal.ErrInvalidCommitTemplate) {
+			t.Fatalf("RunPrepareWithDeps(%q) error = %v, want %v", template, err, internal.ErrInvalidCommitTemplate)
+		}
+	}
+	if current := gitOut(t, repo, "rev-parse", "--abbrev-ref", "HEAD"); current != "main" {
+		t.Fatalf("current branch = %q, want main to stay checked out", current)
+	}
+}
@@ -2,11 +2,11 @@ package internal
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
-	"time"
 
 	"altinn.studio/releaser/internal/changelog"
 	"altinn.studio/releaser/internal/perm"
@@ -16,23 +16,56 @@ import (
 type releasePrepConfig struct {
 	component           *Component
 	version             *semver.Version
+	author              CommitIdentity
 	branchName          string
 	baseBranch          string
 	releaseBranch       string
 	prTitle             string
 	prBody              string
+	commitMsg           string
 	promoted            string
+	labels              []string
+	trailers            []Trailer
 	createReleaseBranch bool
+	noPush              bool
+	resume              bool // recreate leftover local branches from an interrupted prepare
 }
 
 // PrepareRequest describes the inputs for a release prepare operation.
 type PrepareRequest struct {
-	Prompter      ConfirmationPrompter
+	Prompter ConfirmationPrompter
+	// Clock dates the promoted version section. Nil uses SystemClock.
+	Clock Clock
+	// Author overrides the git user for the release commit.
+	Author        CommitIdentity
 	Component     string
 	Version       string
 	ChangelogPath string
-	Open          bool
-	DryRun        bool
+	// Labels are added to the PR after the release label and the component's ExtraLabels.
+	Labels []string
+	// CommitTemplate is the release commit message with {component}, {version}, {tag} and
+	// {title} placeholders. Empty uses DefaultCommitTemplate.
+	CommitTemplate string
+	// Trailers are appended to the release commit message.
+	Trailers []Trailer
+	Open     bool
+	DryRun   bool
+	// NoPush stops after the local commit, leaving push and PR creation to the caller.
+	NoPush bool
+	// Resume recreates local branches left over from an interrupted prepare instead of failing.
+	Resume bool
+	// PromotePrerelease builds the stable Version from its prerelease sections
+	// (e.g., 1.3.0 from 1.3.0-preview.2) and leaves [Unreleased] untouched.
+	PromotePrerelease bool
+	// Local reads the working-tree changelog instead of fetching origin and skips the
+	// remote release branch checks, so a dry run works offline. It requires DryRun.
+	Local bool
+	// AllowBehind only warns when the current branch is the base branch and lacks
+	// commits from origin, instead of failing.
+	AllowBehind bool
+	// Worktree runs the prepare in a temporary linked worktree, leaving the current
+	// branch and working tree untouched, so they need not be clean.
+	Worktree bool
 }
 
 // RunPrepare executes the release prepare workflow.
@@ -41,7 +74,10 @@ func RunPrepare(ctx context.Context, req PrepareRequest, log Logger) error {
 		log = NopLogger{}
 	}
 	git := NewGitCLI(WithLogger(log))
-	gh := NewGitHubCLI(WithGHLogger(log))
+	gh := NewThrottledGitHub(
+		NewGitHubCLI(WithGHLogger(log), WithGHClock(clockOrSystem(req.Clock))),
+		WithThrottleLogger(log),
+	)
 	return RunPrepareWithDeps(ctx, req, git, gh, log)
 }
 
@@ -59,6 +95,15 @@ func RunPrepareWithDeps(ctx context.Context, req PrepareRequest, git *GitCLI, gh
 	if req.Version == "" {
 		return errReleaseVersionRequired
 	}
+	if req.Local && !req.DryRun {
+		return ErrLocalRequiresDryRun
+	}
+	if err := req.Author.Validate(); err != nil {
+		return err
+	}
+	if err := validateTrailers(req.Trailers); err != nil {
+		return err
+	}
 
 	comp, err := GetComponent(req.Component)
 	if err != nil {
@@ -66,6 +111,11 @@ func RunPrepareWithDeps(ctx context.Context, req PrepareRequest, git *GitCLI, gh
 	}
 
 	log.Step("Preparing release PR for " + comp.Name)
+	if !req.NoPush && !req.Local {
+		if err := ensureGitHubAuthenticated(ctx, gh, req.DryRun, log); err != nil {
+			return err
+		}
+	}
 	current, err := git.CurrentBranch(ctx)
 	if err != nil {
 		return fmt.Errorf("get current branch: %w", err)
@@ -82,20 +132,49 @@ func RunPrepareWithDeps(ctx context.Context, req PrepareRequest, git *GitCLI, gh
 		clPath = comp.ChangelogPath
 	}
 
-	cfg, err := prepareReleasePrepConfig(ctx, git, comp, req.Version, clPath)
+	if req.Local {
+		log.Info("Reading %s from the working tree (-local); origin is not fetched", clPath)
+		log.Info("Release branch existence is not checked; the branch targets below are assumed")
+	}
+	cfg, err := prepareReleasePrepConfig(
+		ctx, git, clockOrSystem(req.Clock), comp, req.Version, clPath, req.PromotePrerelease, req.Local,
+	)
 	if err != nil {
 		return err
 	}
+	cfg.labels = comp.PRLabels(comp.ReleaseLabel(), req.Labels)
+	cfg.noPush = req.NoPush
+	cfg.author = req.Author
+	cfg.trailers = req.Trailers
+	commitMsg, err := renderCommitTemplate(req.CommitTemplate, NewTag(comp, cfg.version))
+	if err != nil {
+		return err
+	}
+	cfg.commitMsg = appendTrailers(commitMsg, req.Trailers)
+	if err := checkLeftoverPrepBranches(ctx, git, log, cfg, req.Resume); err != nil {
+		return err
+	}
 	log.Detail("Prep branch", cfg.branchName)
 	log.Detail("Base branch", cfg.baseBranch)
 	if cfg.createReleaseBranch {
 		log.Detail("Release branch", cfg.releaseBranch)
 	}
+	if !req.Local {
+		if err := ensurePrepareBaseNotBehind(ctx, git, log, cfg, current, req.AllowBehind); err != nil {
+			return err
+		}
+	}
 
 	if req.DryRun {
 		printReleasePrepDryRun(log, cfg)
+		if req.Worktree {
+			log.Info("Would work in a temporary git worktree; %s stays checked out", current)
+		}
 		return nil
 	}
+	if req.Worktree {
+		return executeReleasePrepareInWorktree(ctx, git, gh, log, clPath, cfg, req.Prompter, req.Open)
+	}
 
 	if err := ensureWorkingTreeClean(ctx, git, log); err != nil {
 		return err
@@ -114,11 +193,69 @@ func RunPrepareWithDeps(ctx context.Context, req PrepareRequest, git *GitCLI, gh
 	return executeReleasePrepare(ctx, git, gh, log, repoRoot, clPath, cfg, req.Prompter, req.Open)
 }
 
+// executeReleasePrepareInWorktree runs executeReleasePrepare in a temporary linked worktree
+// and removes it afterwards. The branches it creates stay in the repository; the user's
+// checkout is never switched or touched.
+func executeReleasePrepareInWorktree(
+	ctx context.Context,
+	git *GitCLI,
+	gh GitHubRunner,
+	log Logger,
+	clPath string,
+	cfg *releasePrepConfig,
+	prompter ConfirmationPrompter,
+	openPR bool,
+) error {
+	worktree, err := os.MkdirTemp("", "releaser-prepare-")
+	if err != nil {
+		return fmt.Errorf("create worktree directory: %w", err)
+	}
+	defer os.RemoveAll(worktree) //nolint:errcheck // best-effort cleanup of a temp directory
+
+	log.Step("Creating temporary worktree")
+	if err := git.AddWorktree(ctx, worktree, "HEAD"); err != nil {
+		return fmt.Errorf("add worktree: %w", err)
+	}
+	log.Detail("Worktree", worktree)
+	defer func() {
+		if err := git.RemoveWorktree(ctx, worktree); err != nil {
+			log.Error("Could not remove worktree %s: %v", worktree, err)
+		}
+	}()
+
+	worktreeGit := NewGitCLI(WithWorkdir(worktree), WithLogger(log))
+	return executeReleasePrepare(ctx, worktreeGit, gh, log, worktree, clPath, cfg, prompter, openPR)
+}
+
+// ensurePrepareBaseNotBehind checks a local checkout of the branch prepare starts from
+// is not behind origin. Prepare branches from origin itself, but a stale checkout means
+// the changelog the user edited and reviewed is not the one being released. Other
+// branches are expected to diverge from the base and are not compared.
+func ensurePrepareBaseNotBehind(
+	ctx context.Context,
+	git *GitCLI,
+	log Logger,
+	cfg *releasePrepConfig,
+	current string,
+	allowBehind bool,
+) error {
+	source := cfg.baseBranch
+	if cfg.createReleaseBranch {
+		source = mainBranch
+	}
+	if current != source {
+		return nil
+	}
+	return ensureNotBehindRemote(ctx, git, log, source, allowBehind)
+}
+
 func prepareReleasePrepConfig(
 	ctx context.Context,
 	git *GitCLI,
+	clock Clock,
 	comp *Component,
 	version, clPath string,
+	promotePrerelease, local bool,
 ) (*releasePrepConfig, error) {
 	verStr := version
 	if !strings.HasPrefix(verStr, "v") {
@@ -132,9 +269,15 @@ func prepareReleasePrepConfig(
 
 	tag := NewTag(comp, ver)
 
-	baseBranch, createReleaseBranch, err := determineBranchStrategy(ctx, git, tag)
-	if err != nil {
-		return nil, err
+	var baseBranch string
+	var createReleaseBranch bool
+	if local {
+		baseBranch, createReleaseBranch = assumeBranchStrategy(tag)
+	} else {
+		baseBranch, createReleaseBranch, err = determineBranchStrategy(ctx, git, tag)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	sourceBranch := baseBranch
@@ -142,9 +285,9 @@ func prepareReleasePrepConfig(
 		// First stable release lines are cut from main before promotion.
 		sourceBranch = mainBranch
 	}
-	content, err := readRemoteFile(ctx, git, sourceBranch, clPath)
+	content, err := readPrepChangelog(ctx, git, comp, clPath, sourceBranch, local)
 	if err != nil {
-		return nil, fmt.Errorf("read changelog: %w", err)
+		return nil, err
 	}
 
 	cl, err := changelog.Parse(content)
@@ -152,11 +295,24 @@ func prepareReleasePrepConfig(
 		return nil, fmt.Errorf("parse changelog: %w", err)
 	}
 
+	if ver.Prerelease == previewIdentifier {
+		// vX.Y.Z-preview takes the next preview number for that core version.
+		if ver, err = numberPreview(cl, ver); err != nil {
+			return nil, err
+		}
+		verStr = "v" + ver.Num
+		tag = NewTag(comp, ver)
+	}
+
 	if cl.HasVersion(verStr) {
 		return nil, fmt.Errorf("%w: %s", errChangelogVersionExists, verStr)
 	}
 
-	promotedCl, err := cl.Promote(verStr, time.Now())
+	promote := cl.Promote
+	if promotePrerelease {
+		promote = cl.PromotePrerelease
+	}
+	promotedCl, err := promote(verStr, clock.Now())
 	if err != nil {
 		return nil, fmt.Errorf("promote changelog: %w", err)
 	}
@@ -175,15 +331,107 @@ func prepareReleasePrepConfig(
 		releaseBranch:       tag.ReleaseBranch(),
 		prTitle:             "chore: release " + comp.ReleaseTitle(verStr),
 		prBody:              prBody,
+		commitMsg:           "",
 		promoted:            promoted,
+		labels:              nil,
+		trailers:            nil,
+		author:              CommitIdentity{},
+		noPush:              false,
+		resume:              false,
 	}, nil
 }
 
+// previewIdentifier is the prerelease identifier of an unnumbered preview version (vX.Y.Z-preview).
+const previewIdentifier = "preview"
+
+// numberPreview returns ver numbered as the next vX.Y.Z-preview.N recorded in cl.
+func numberPreview(cl *changelog.Changelog, ver *semver.Version) (*semver.Version, error) {
+	number, err := cl.NextPrereleaseNumber(ver.Major, ver.Minor, ver.Patch)
+	if err != nil {
+		return nil, fmt.Errorf("number preview: %w", err)
+	}
+	numbered, err := semver.Parse(
+		fmt.Sprintf("v%d.%d.%d-%s.%d", ver.Major, ver.Minor, ver.Patch, previewIdentifier, number),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("parse version: %w", err)
+	}
+	return numbered, nil
+}
+
+// readPrepChangelog reads the changelog to promote from origin/sourceBranch, or from
+// the working tree when local is set.
+func readPrepChangelog(
+	ctx context.Context,
+	git *GitCLI,
+	comp *Component,
+	clPath, sourceBranch string,
+	local bool,
+) (string, error) {
+	var content, sourceRef string
+	var err error
+	if local {
+		content, err = readWorkingTreeFile(ctx, git, clPath)
+	} else {
+		content, err = readRemoteFile(ctx, git, sourceBranch, clPath)
+		sourceRef = "origin/" + sourceBranch
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		repoRoot, rootErr := git.RepoRoot(ctx)
+		if rootErr != nil {
+			return "", rootErr
+		}
+		return "", changelogFileMissingError(comp, filepath.Join(repoRoot, clPath), sourceRef, "")
+	}
+	if err != nil {
+		return "", fmt.Errorf("read changelog: %w", err)
+	}
+	return content, nil
+}
+
+// readRemoteFile reads path from origin/branch after fetching it.
+// Returns an error wrapping os.ErrNotExist if the file is not in that branch.
 func readRemoteFile(ctx context.Context, git *GitCLI, branch, path string) (string, error) {
 	if _, err := git.Run(ctx, "fetch", "origin", branch); err != nil {
 		return "", fmt.Errorf("fetch origin/%s: %w", branch, err)
 	}
-	return git.Run(ctx, "show", "origin/"+branch+":"+path)
+	ref := "origin/" + branch
+	exists, err := git.FileExistsAtRef(ctx, ref, path)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", fmt.Errorf("%w: %s:%s", os.ErrNotExist, ref, path)
+	}
+	return git.Run(ctx, "show", ref+":"+path)
+}
+
+// readWorkingTreeFile reads path relative to the repository root as it is on disk.
+// Returns an error wrapping os.ErrNotExist if the file does not exist.
+func readWorkingTreeFile(ctx context.Context, git *GitCLI, path string) (string, error) {
+	repoRoot, err := git.RepoRoot(ctx)
+	if err != nil {
+		return "", err
+	}
+	//nolint:gosec // G304: changelog path resolved from trusted component config/request.
+	content, err := os.ReadFile(filepath.Join(repoRoot, filepath.FromSlash(path)))
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", path, err)
+	}
+	return string(content), nil
+}
+
+// assumeBranchStrategy is determineBranchStrategy without asking origin whether the
+// release branch exists, for offline dry runs.
+func assumeBranchStrategy(tag *Tag) (string, bool) {
+	switch {
+	case tag.Version.IsPrerelease:
+		return mainBranch, false
+	case tag.Version.IsPatchRelease():
+		return tag.ReleaseBranch(), false
+	default:
+		return tag.ReleaseBranch(), true
+	}
 }
 
 func determineBranchStrategy(ctx context.Context, git *GitCLI, tag *Tag) (string, bool, error) {
@@ -246,6 +494,69 @@ func buildPreparePRBody(version string, promotedCl *changelog.Changelog) (string
 	return strings.TrimRight(b.String(), "\n"), nil
 }
 
+// checkLeftoverPrepBranches looks for local branches this prepare would create that an
+// interrupted earlier run left behind. Without resume it fails naming the cleanup command.
+// With resume they are recreated from their base, which is only safe while they exist
+// locally alone, so branches already on origin are refused either way.
+func checkLeftoverPrepBranches(
+	ctx context.Context,
+	git *GitCLI,
+	log Logger,
+	cfg *releasePrepConfig,
+	resume bool,
+) error {
+	branches := []string{cfg.branchName}
+	if cfg.createReleaseBranch {
+		branches = []string{cfg.releaseBranch, cfg.branchName}
+	}
+
+	var leftover []string
+	for _, branch := range branches {
+		if localBranchExists(ctx, git, branch) {
+			leftover = append(leftover, branch)
+		}
+	}
+	if len(leftover) == 0 {
+		return nil
+	}
+
+	if !resume {
+		log.Error("Found local branches from an interrupted prepare:")
+		for _, branch := range leftover {
+			log.Error("  %s", branch)
+		}
+		log.Error("Re-run with -resume to recreate them, or delete them first:")
+		log.Error("  git branch -D %s", strings.Join(leftover, " "))
+		return fmt.Errorf("%w: %s", ErrPrepBranchExists, strings.Join(leftover, ", "))
+	}
+
+	pushed, err := git.RemoteBranchExists(ctx, cfg.branchName)
+	if err != nil {
+		return fmt.Errorf("check remote prep branch: %w", err)
+	}
+	if pushed {
+		log.Error("Prep branch %s is already on origin; a PR may be open for it", cfg.branchName)
+		log.Error("Close the PR and delete it before resuming:")
+		log.Error("  git push origin --delete %s", cfg.branchName)
+		return fmt.Errorf("%w: %s exists on origin", ErrPrepBranchExists, cfg.branchName)
+	}
+
+	for _, branch := range leftover {
+		log.Info("Resuming: leftover branch %s will be recreated", branch)
+	}
+	cfg.resume = true
+	return nil
+}
+
+// branchCreateFlag returns the checkout flag that creates a branch, resetting an
+// existing one when resuming an interrupted prepare.
+func branchCreateFlag(resume bool) string {
+	if resume {
+		return "-B"
+	}
+	return "-b"
+}
+
 func printReleasePrepDryRun(log Logger, cfg *releasePrepConfig) {
 	log.Info("=== DRY RUN ===")
 	if cfg.createReleaseBranch {
@@ -255,7 +566,15 @@ func printReleasePrepDryRun(log Logger, cfg *releasePrepConfig) {
 	log.Info("Would promote changelog to: [%s]", cfg.version.String())
 	log.Info("Would create PR targeting: %s", cfg.baseBranch)
 	log.Info("Would set PR title: %s", cfg.prTitle)
-	log.Info("Would add label: %s", cfg.component.ReleaseLabel())
+	log.Info("Would add labels: %s", strings.Join(cfg.labels, ", "))
+	log.Info("Would create commit: %s", strings.SplitN(cfg.commitMsg, "\n", 2)[0])
+	if !cfg.author.IsZero() {
+		log.Info("Would commit as: %s", cfg.author)
+	}
+	logTrailers(log, cfg.trailers)
+	if cfg.noPush {
+		log.Info("Would stop before pushing and creating the PR (-no-push)")
+	}
 	logPromotedChangelog(log, cfg.promoted)
 }
 
@@ -283,16 +602,17 @@ func executeReleasePrepare(
 	}
 
 	log.Step("Creating prep branch")
-	if err := git.RunWrite(ctx, "checkout", "-b", cfg.branchName, prepBaseRef); err != nil {
+	if err := git.RunWrite(ctx, "checkout", branchCreateFlag(cfg.resume), cfg.branchName, prepBaseRef); err != nil {
 		return fmt.Errorf("create prep branch: %w", err)
 	}
 
-	commitMsg := "Release " + cfg.component.ReleaseTitle(cfg.version.String())
+	commitMsg := cfg.commitMsg
 	if err := confirmMutatingAction(prompter, "promote changelog and create commit",
 		"Branch: "+cfg.branchName,
 		"File: "+clPath,
 		"Version: "+cfg.version.String(),
 		"Commit message: "+commitMsg,
+		"Commit author: "+commitAuthorLabel(cfg.author),
 	); err != nil {
 		return err
 	}
@@ -308,10 +628,19 @@ func executeReleasePrepare(
 	if err := git.RunWrite(ctx, "add", clPath); err != nil {
 		return fmt.Errorf("git add: %w", err)
 	}
-	if err := git.RunWrite(ctx, "commit", "-m", commitMsg); err != nil {
+	if err := git.RunWrite(ctx, commitArgs(cfg.author, commitMsg)...); err != nil {
 		return fmt.Errorf("git commit: %w", err)
 	}
 
+	if cfg.noPush {
+		branches := []string{cfg.branchName}
+		if cfg.createReleaseBranch {
+			branches = []string{cfg.releaseBranch, cfg.branchName}
+		}
+		logManualPush(log, branches...)
+		return nil
+	}
+
 	if err := confirmMutatingAction(prompter, "push prep branch",
 		"Push: "+cfg.branchName+" -> origin/"+cfg.branchName,
 	); err != nil {
@@ -341,6 +670,18 @@ func executeReleasePrepare(
 	return nil
 }
 
+// logManualPush tells the user how to publish branches a -no-push run left local.
+func logManualPush(log Logger, branches ...string) {
+	log.Success("Stopped before pushing (-no-push)")
+	for _, branch := range branches {
+		log.Detail("Local branch", branch)
+	}
+	log.Info("Push when ready:")
+	for _, branch := range branches {
+		log.Info("  git push -u origin %s", branch)
+	}
+}
+
 func handlePreparePRResult(ctx context.Context, log Logger, openPR bool, prURL string) {
 	if prURL == "" {
 		log.Error("PR created, but URL could not be determined")
@@ -362,10 +703,11 @@ func handlePreparePRResult(ctx context.Context, log Logger, openPR bool, prURL s
 func createPreparePR(ctx context.Context, gh GitHubRunner, cfg *releasePrepConfig) (string, error) {
 	// Keep PR creation as a separate step so execution flow stays simple and lint-compliant.
 	prURL, err := gh.CreatePR(ctx, PullRequestOptions{
-		Title: cfg.prTitle,
-		Body:  cfg.prBody,
-		Label: cfg.component.ReleaseLabel(),
-		Base:  cfg.baseBranch,
+		Title:  cfg.prTitle,
+		Body:   cfg.prBody,
+		Base:   cfg.baseBranch,
+		Head:   cfg.branchName,
+		Labels: cfg.labels,
 	})
 	if err != nil {
 		return "", fmt.Errorf("create PR: %w", err)
@@ -390,17 +732,22 @@ func setupBaseBranch(
 	if err := git.RunWrite(ctx, "fetch", "origin", mainBranch); err != nil {
 		return "", fmt.Errorf("fetch main branch: %w", err)
 	}
-	if err := confirmMutatingAction(prompter, "create and push release branch",
-		"Source branch: "+mainBranch,
-		"New branch: "+cfg.releaseBranch,
-		"Push: "+cfg.releaseBranch+" -> origin/"+cfg.releaseBranch,
-	); err != nil {
-		return "", err
+	if !cfg.noPush {
+		if err := confirmMutatingAction(prompter, "create and push release branch",
+			"Source branch: "+mainBranch,
+			"New branch: "+cfg.releaseBranch,
+			"Push: "+cfg.releaseBranch+" -> origin/"+cfg.releaseBranch,
+		); err != nil {
+			return "", err
+		}
 	}
 	log.Info("Creating release branch %s from origin/%s...", cfg.releaseBranch, mainBranch)
-	if err := git.RunWrite(ctx, "checkout", "-b", cfg.releaseBranch, "origin/"+mainBranch); err != nil {
+	if err := git.RunWrite(ctx, "checkout", branchCreateFlag(cfg.resume), cfg.releaseBranch, "origin/"+mainBranch); err != nil {
 		return "", fmt.Errorf("create release branch: %w", err)
 	}
+	if cfg.noPush {
+		return cfg.releaseBranch, nil
+	}
 	if err := git.RunWrite(ctx, "push", "-u", "origin", cfg.releaseBranch); err != nil {
 		return "", fmt.Errorf("push release branch: %w", err)
 	}
@@ -413,7 +760,7 @@ func buildPreparePRPromptDetails(cfg *releasePrepConfig) []string {
 	prDetails = append(prDetails,
 		"Base branch: "+cfg.baseBranch,
 		"Title: "+cfg.prTitle,
-		"Label: "+cfg.component.ReleaseLabel(),
+		"Labels: "+strings.Join(cfg.labels, ", "),
 		"Body:",
 	)
 	return append(prDetails, bodyLines...)
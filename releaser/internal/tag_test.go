@@ -1,6 +1,7 @@
 package internal_test
 
 import (
+	"slices"
 	"testing"
 
 	"altinn.studio/releaser/internal"
@@ -90,3 +91,19 @@ func TestComponentBackportBranch(t *testing.T) {
 		t.Errorf("BackportBranch() = %q, want %q", got, want)
 	}
 }
+
+func TestComponentPRLabels(t *testing.T) {
+	comp := &internal.Component{
+		Builder:       nil,
+		Name:          "example",
+		ChangelogPath: "CHANGELOG.md",
+		SourcePath:    ".",
+		ExtraLabels:   []string{"automated"},
+	}
+
+	got := comp.PRLabels("backport", []string{"needs-review", "", "automated", "backport"})
+	want := []string{"backport", "automated", "needs-review"}
+	if !slices.Equal(got, want) {
+		t.Errorf("PRLabels() = %q, want %q", got, want)
+	}
+}
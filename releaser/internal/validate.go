@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"strings"
@@ -17,14 +18,39 @@ var (
 	ErrChangelogNotModified = errors.New("changelog was not modified")
 	// ErrNoNewUnreleasedEntries indicates [Unreleased] has no entries added compared to base.
 	ErrNoNewUnreleasedEntries = errors.New("unreleased section has no new entries compared to base")
+	// ErrReleasedSectionModified indicates a version section released at base was edited or removed in head.
+	ErrReleasedSectionModified = errors.New("released changelog sections must not change")
+	// ErrComponentsInvalid indicates at least one component failed validation in -component all mode.
+	ErrComponentsInvalid = errors.New("changelog validation failed for one or more components")
 )
 
+// diffFullContext makes every changelog hunk include its section header,
+// so added entries can be attributed to [Unreleased] or a released version.
+const diffFullContext = "--unified=1000000"
+
+// AllComponents is the component name that selects every registered component for validation.
+const AllComponents = "all"
+
+// ComponentValidationResult is the validation outcome for one component.
+type ComponentValidationResult struct {
+	Err           error // nil if the changelog is valid
+	Component     string
+	ChangelogPath string // repo-relative changelog path that was validated
+	// Skipped is set in -component all mode when nothing under the component's source path changed.
+	Skipped bool
+}
+
 // ValidationRequest describes inputs for changelog validation.
 type ValidationRequest struct {
 	Component     string // Component name (required, e.g., "studioctl")
 	Base          string // Base commit SHA (required)
 	Head          string // Head commit SHA (required)
 	ChangelogPath string // Optional: override component's default changelog path
+	// RequirePreamble fails validation unless the changelog starts with a "# " title.
+	RequirePreamble bool
+	// WarnDuplicates logs a warning for [Unreleased] entries that repeat an entry of the
+	// most recent release. Duplicates never fail validation.
+	WarnDuplicates bool
 }
 
 // RunValidation validates changelog changes between base and head.
@@ -59,16 +85,20 @@ func RunValidationWithDeps(ctx context.Context, req ValidationRequest, git *GitC
 		return fmt.Errorf("get component: %w", err)
 	}
 
-	clPath := req.ChangelogPath
-	if clPath == "" {
-		clPath = comp.ChangelogPath
-	}
-
 	root, err := git.RepoRoot(ctx)
 	if err != nil {
 		return fmt.Errorf("get repo root: %w", err)
 	}
 
+	clPath := comp.ChangelogPath
+	if req.ChangelogPath != "" {
+		clPath, err = repoRelativePath(root, req.ChangelogPath)
+		if err != nil {
+			return fmt.Errorf("resolve changelog path: %w", err)
+		}
+	}
+	clPath = changelog.NormalizePath(clPath)
+
 	diffOutput, err := git.Run(ctx, "diff", "--name-only", req.Base, req.Head)
 	if err != nil {
 		return fmt.Errorf("git diff: %w", err)
@@ -88,14 +118,167 @@ func RunValidationWithDeps(ctx context.Context, req ValidationRequest, git *GitC
 		return fmt.Errorf("read changelog: %w", err)
 	}
 
-	cl, err := changelog.Parse(string(content))
+	changelogDiff, err := git.Run(ctx, "diff", diffFullContext, req.Base, req.Head, "--", clPath)
+	if err != nil {
+		return fmt.Errorf("git diff changelog: %w", err)
+	}
+	cl, err := changelog.ParseWithDiff(string(content), changelogDiff, clPath)
 	if err != nil {
 		return fmt.Errorf("parse changelog: %w", err)
 	}
+	if req.RequirePreamble {
+		if err := cl.ValidatePreamble(); err != nil {
+			return fmt.Errorf("validate changelog: %w", err)
+		}
+	}
+	if err := cl.ValidateAddedEntries(); err != nil {
+		return fmt.Errorf("validate changelog: %w", err)
+	}
+	if err := cl.ValidateCalloutCategory(comp.Callout()); err != nil {
+		return fmt.Errorf("validate changelog: %w", err)
+	}
+	if req.WarnDuplicates {
+		warnDuplicateEntries(git.log, cl, clPath)
+	}
 
 	return ValidateUnreleasedOrReleasePromotion(ctx, git, cl, req.Base, clPath)
 }
 
+// warnDuplicateEntries logs [Unreleased] entries already listed under the latest release.
+func warnDuplicateEntries(log Logger, cl *changelog.Changelog, clPath string) {
+	if len(cl.Versions) == 0 {
+		return
+	}
+	latest := cl.Versions[0].Version
+	for _, entry := range cl.DuplicateUnreleasedEntries() {
+		log.Error("WARNING: %s: [Unreleased] %s entry %q is already released in [%s]",
+			clPath, entry.Category, entry.Text, latest.Num)
+	}
+}
+
+// repoRelativePath converts an absolute path inside root to a repo-relative one.
+// Relative paths are already repo-relative and returned unchanged.
+func repoRelativePath(root, path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		return path, nil
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return "", fmt.Errorf("relative to repo root: %w", err)
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %s", errPathOutsideRepo, path)
+	}
+	return rel, nil
+}
+
+// RunValidationAll validates every registered component's changelog between base and head.
+// Components with no changes under their source path are reported as skipped.
+// All components are validated even if some fail; the returned error wraps
+// ErrComponentsInvalid and lists the failing components.
+func RunValidationAll(
+	ctx context.Context,
+	req ValidationRequest,
+	git *GitCLI,
+) ([]ComponentValidationResult, error) {
+	if req.ChangelogPath != "" {
+		return nil, errChangelogPathWithAll
+	}
+	if ctx == nil {
+		return nil, errContextRequired
+	}
+	if req.Base == "" {
+		return nil, errValidationBaseRequired
+	}
+	if req.Head == "" {
+		return nil, errValidationHeadRequired
+	}
+	if git == nil {
+		return nil, errGitRequired
+	}
+
+	diffOutput, err := git.Run(ctx, "diff", "--name-only", req.Base, req.Head)
+	if err != nil {
+		return nil, fmt.Errorf("git diff: %w", err)
+	}
+
+	names := ComponentNames()
+	results := make([]ComponentValidationResult, 0, len(names))
+	var failed []string
+	for _, name := range names {
+		componentReq := req
+		componentReq.Component = name
+		comp, err := GetComponent(name)
+		if err != nil {
+			return nil, fmt.Errorf("get component: %w", err)
+		}
+		if !pathChanged(diffOutput, comp.SourcePath) {
+			results = append(results, ComponentValidationResult{
+				Component:     name,
+				ChangelogPath: validationChangelogPath(componentReq),
+				Skipped:       true,
+			})
+			continue
+		}
+		err = RunValidationWithDeps(ctx, componentReq, git)
+		if err != nil {
+			failed = append(failed, name)
+		}
+		results = append(results, ComponentValidationResult{
+			Err:           err,
+			Component:     name,
+			ChangelogPath: validationChangelogPath(componentReq),
+		})
+	}
+
+	if len(failed) > 0 {
+		return results, fmt.Errorf("%w: %s", ErrComponentsInvalid, strings.Join(failed, ", "))
+	}
+	return results, nil
+}
+
+// RunValidationResults validates one component, or every component for AllComponents,
+// and returns one result per validated component alongside the combined error.
+func RunValidationResults(
+	ctx context.Context,
+	req ValidationRequest,
+	git *GitCLI,
+) ([]ComponentValidationResult, error) {
+	if req.Component == AllComponents {
+		return RunValidationAll(ctx, req, git)
+	}
+	err := RunValidationWithDeps(ctx, req, git)
+	return []ComponentValidationResult{{
+		Err:           err,
+		Component:     req.Component,
+		ChangelogPath: validationChangelogPath(req),
+	}}, err
+}
+
+// validationChangelogPath returns the changelog path req validates, for reports.
+// Absolute override paths are returned as given.
+func validationChangelogPath(req ValidationRequest) string {
+	if req.ChangelogPath != "" {
+		return changelog.NormalizePath(req.ChangelogPath)
+	}
+	comp, err := GetComponent(req.Component)
+	if err != nil {
+		return ""
+	}
+	return changelog.NormalizePath(comp.ChangelogPath)
+}
+
+// pathChanged reports whether git diff --name-only output lists a file under dir.
+func pathChanged(diffOutput, dir string) bool {
+	prefix := changelog.NormalizePath(dir) + "/"
+	for line := range strings.SplitSeq(diffOutput, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // ChangelogWasModified reports whether changelogPath exists in git diff --name-only output.
 func ChangelogWasModified(diffOutput, changelogPath string) bool {
 	for line := range strings.SplitSeq(diffOutput, "\n") {
@@ -125,6 +308,14 @@ func ValidateUnreleasedOrReleasePromotion(
 		return fmt.Errorf("load base changelog: %w", err)
 	}
 
+	if err := validateReleasedSectionsUnchanged(baseChangelog, cl); err != nil {
+		return fmt.Errorf("validate changelog: %w", err)
+	}
+
+	if isPrereleasePromotionDiff(baseChangelog, cl) {
+		return nil
+	}
+
 	if err := cl.ValidateUnreleased(); err != nil {
 		if !isAllowedUnreleasedValidationError(err) {
 			return fmt.Errorf("validate changelog: %w", err)
@@ -172,6 +363,29 @@ func loadBaseChangelog(
 	return baseChangelog, nil
 }
 
+// validateReleasedSectionsUnchanged rejects history rewrites: every version released at base
+// must be present in head with the same date and entries. New sections (promotions) are allowed.
+func validateReleasedSectionsUnchanged(baseChangelog, headChangelog *changelog.Changelog) error {
+	var changed []string
+	for _, baseSection := range baseChangelog.Versions {
+		if baseSection == nil || baseSection.Version == nil {
+			continue
+		}
+		num := baseSection.Version.Num
+		headSection := headChangelog.GetVersion(num)
+		switch {
+		case headSection == nil:
+			changed = append(changed, "["+num+"] removed")
+		case !headSection.Date.Equal(baseSection.Date) || headSection.String() != baseSection.String():
+			changed = append(changed, "["+num+"] edited")
+		}
+	}
+	if len(changed) > 0 {
+		return fmt.Errorf("%w: %s", ErrReleasedSectionModified, strings.Join(changed, ", "))
+	}
+	return nil
+}
+
 func validateHasNewUnreleasedEntries(baseChangelog, headChangelog *changelog.Changelog) error {
 	baseUnreleasedEntries := sectionEntrySet(baseChangelog.Unreleased)
 	headUnreleasedEntries := sectionEntrySet(headChangelog.Unreleased)
@@ -213,6 +427,45 @@ func isReleasePromotionDiff(baseChangelog, headChangelog *changelog.Changelog) (
 	return hasAnyCommonEntry(baseUnreleasedEntries, newReleaseEntries), nil
 }
 
+// isPrereleasePromotionDiff reports whether head only adds one stable section built
+// from the prerelease history of the same version (see Changelog.PromotePrerelease),
+// leaving [Unreleased] unchanged.
+func isPrereleasePromotionDiff(baseChangelog, headChangelog *changelog.Changelog) bool {
+	if baseChangelog == nil || headChangelog == nil {
+		return false
+	}
+	if !maps.Equal(sectionEntrySet(baseChangelog.Unreleased), sectionEntrySet(headChangelog.Unreleased)) {
+		return false
+	}
+
+	var promoted *changelog.Section
+	for _, section := range headChangelog.Versions {
+		if section == nil || section.Version == nil || baseChangelog.HasVersion(section.Version.Num) {
+			continue
+		}
+		if promoted != nil || section.Version.IsPrerelease {
+			return false
+		}
+		promoted = section
+	}
+	if promoted == nil {
+		return false
+	}
+
+	prereleaseEntries := make(map[changelogEntryKey]struct{})
+	for _, section := range baseChangelog.Versions {
+		if section == nil || section.Version == nil || !section.Version.IsPrerelease {
+			continue
+		}
+		v, target := section.Version, promoted.Version
+		if v.Major != target.Major || v.Minor != target.Minor || v.Patch != target.Patch {
+			continue
+		}
+		maps.Copy(prereleaseEntries, sectionEntrySet(section))
+	}
+	return len(prereleaseEntries) > 0 && maps.Equal(prereleaseEntries, sectionEntrySet(promoted))
+}
+
 func sectionEntrySet(section *changelog.Section) map[changelogEntryKey]struct{} {
 	entries := make(map[changelogEntryKey]struct{})
 	if section == nil {
@@ -0,0 +1,133 @@
+package internal_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"altinn.studio/releaser/internal"
+	"altinn.studio/releaser/internal/changelog"
+)
+
+type sarifReport struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []struct {
+		Tool struct {
+			Driver struct {
+				Name  string `json:"name"`
+				Rules []struct {
+					ID string `json:"id"`
+				} `json:"rules"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Results []struct {
+			RuleID  string `json:"ruleId"`
+			Level   string `json:"level"`
+			Message struct {
+				Text string `json:"text"`
+			} `json:"message"`
+			Locations []struct {
+				PhysicalLocation struct {
+					ArtifactLocation struct {
+						URI string `json:"uri"`
+					} `json:"artifactLocation"`
+				} `json:"physicalLocation"`
+			} `json:"locations"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+func TestValidationSARIF_BrokenChangelog(t *testing.T) {
+	repo, base := setupValidationRepo(t, `# Changelog
+
+## [Unreleased]
+
+## [1.0.0] - 2025-01-01
+
+### Added
+
+- Initial
+`)
+	head := commitValidationFile(t, repo, "src/cli/CHANGELOG.md", `# Changelog
+
+## [Unreleased]
+
+## [1.0.0] - 2025-01-01
+
+### Added
+
+- Initial
+- Sneaked into a released section
+`, "edit released section")
+
+	report := validationSARIF(t, repo, base, head, changelog.ErrEntryInReleased)
+
+	if report.Schema == "" || report.Version != "2.1.0" || len(report.Runs) != 1 {
+		t.Fatalf("SARIF header = %q %q with %d runs, want 2.1.0 with one run", report.Schema, report.Version, len(report.Runs))
+	}
+	run := report.Runs[0]
+	if run.Tool.Driver.Name != "releaser" {
+		t.Errorf("driver name = %q, want releaser", run.Tool.Driver.Name)
+	}
+	if len(run.Results) == 0 {
+		t.Fatal("SARIF report has no results for a broken changelog")
+	}
+	result := run.Results[0]
+	if result.RuleID != "ENTRY_IN_RELEASED_SECTION" || result.Level != "error" {
+		t.Errorf("result = %s/%s, want ENTRY_IN_RELEASED_SECTION/error", result.RuleID, result.Level)
+	}
+	if !strings.HasPrefix(result.Message.Text, "studioctl: ") {
+		t.Errorf("message = %q, want component prefix", result.Message.Text)
+	}
+	if len(result.Locations) != 1 || result.Locations[0].PhysicalLocation.ArtifactLocation.URI != "src/cli/CHANGELOG.md" {
+		t.Errorf("locations = %+v, want src/cli/CHANGELOG.md", result.Locations)
+	}
+	if len(run.Tool.Driver.Rules) != 1 || run.Tool.Driver.Rules[0].ID != result.RuleID {
+		t.Errorf("rules = %+v, want one rule %s", run.Tool.Driver.Rules, result.RuleID)
+	}
+}
+
+func TestValidationSARIF_ValidChangelog(t *testing.T) {
+	repo, base := setupValidationRepo(t, "# Changelog\n\n## [Unreleased]\n")
+	head := commitValidationFile(t, repo, "src/cli/CHANGELOG.md", `# Changelog
+
+## [Unreleased]
+
+### Fixed
+
+- Validation entry
+`, "update changelog")
+
+	report := validationSARIF(t, repo, base, head, nil)
+	if len(report.Runs) != 1 || len(report.Runs[0].Results) != 0 {
+		t.Fatalf("SARIF report = %+v, want one run without results", report)
+	}
+}
+
+func validationSARIF(t *testing.T, repo, base, head string, wantErr error) sarifReport {
+	t.Helper()
+
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+	results, err := internal.RunValidationResults(t.Context(), internal.ValidationRequest{
+		Component:       "studioctl",
+		Base:            base,
+		Head:            head,
+		ChangelogPath:   "",
+		RequirePreamble: false,
+	}, git)
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("RunValidationResults() error = %v, want %v", err, wantErr)
+	}
+
+	content, err := internal.ValidationSARIF(results)
+	if err != nil {
+		t.Fatalf("ValidationSARIF() error = %v", err)
+	}
+	var report sarifReport
+	if err := json.Unmarshal(content, &report); err != nil {
+		t.Fatalf("ValidationSARIF() produced invalid JSON: %v\n%s", err, content)
+	}
+	return report
+}
@@ -41,11 +41,16 @@ func TestChangelogWasModified(t *testing.T) {
 func TestRunValidation(t *testing.T) {
 	t.Run("valid changelog update", testRunValidationValidChangelogUpdate)
 	t.Run("fails when changelog changed without new unreleased entries", testRunValidationFailsNoNewUnreleased)
+	t.Run("reject entry added under released section", testRunValidationRejectsEntryInReleasedSection)
+	t.Run("reject edit to released section", testRunValidationRejectsReleasedSectionEdit)
+	t.Run("promotion with released history accepted", testRunValidationAcceptsPromotionWithHistory)
 	t.Run("release promotion accepted with empty unreleased", testRunValidationAcceptsPromotion)
 	t.Run("reject release section not derived from unreleased entries", testRunValidationRejectsSyntheticRelease)
 	t.Run("reject synthetic release header without removals", testRunValidationRejectsSyntheticReleaseHeader)
 	t.Run("fails when changelog not modified", testRunValidationFailsChangelogNotModified)
 	t.Run("fails when unreleased is empty without promotion", testRunValidationFailsEmptyUnreleased)
+	t.Run("prerelease promotion accepted with empty unreleased", testRunValidationAcceptsPrereleasePromotion)
+	t.Run("reject stable section not derived from prereleases", testRunValidationRejectsSyntheticPrereleasePromotion)
 }
 
 func testRunValidationValidChangelogUpdate(t *testing.T) {
@@ -96,6 +101,8 @@ func testRunValidationFailsNoNewUnreleased(t *testing.T) {
 `)
 	head := commitValidationFile(t, repo, "src/cli/CHANGELOG.md", `# Changelog
 
+All notable changes are documented here.
+
 ## [Unreleased]
 
 ### Added
@@ -106,12 +113,132 @@ func testRunValidationFailsNoNewUnreleased(t *testing.T) {
 
 ### Added
 
-- Initial (edited below unreleased)
-`, "edit released section only")
+- Initial
+`, "edit preamble only")
 
 	assertValidationError(t, runValidation(t, repo, base, head), internal.ErrNoNewUnreleasedEntries)
 }
 
+func testRunValidationRejectsEntryInReleasedSection(t *testing.T) {
+	repo, base := setupValidationRepo(t, `# Changelog
+
+## [Unreleased]
+
+## [1.1.0] - 2025-02-01
+
+### Added
+
+- Feature
+
+## [1.0.0] - 2025-01-01
+
+### Added
+
+- Initial
+`)
+	head := commitValidationFile(t, repo, "src/cli/CHANGELOG.md", `# Changelog
+
+## [Unreleased]
+
+### Fixed
+
+- Unreleased fix
+
+## [1.1.0] - 2025-02-01
+
+### Added
+
+- Feature
+
+## [1.0.0] - 2025-01-01
+
+### Added
+
+- Initial
+
+### Fixed
+
+- Late fix
+`, "add entry under released version")
+
+	err := runValidation(t, repo, base, head)
+	assertValidationError(t, err, changelog.ErrEntryInReleased)
+	if !strings.Contains(err.Error(), "[1.0.0] Fixed: Late fix") {
+		t.Errorf("RunValidation() error = %v, want misplaced entry listed", err)
+	}
+}
+
+const validationReleasedHistory = `## [1.1.0] - 2025-02-01
+
+### Added
+
+- Feature
+- Second feature
+
+## [1.0.0] - 2025-01-01
+
+### Added
+
+- Initial
+`
+
+func testRunValidationRejectsReleasedSectionEdit(t *testing.T) {
+	repo, base := setupValidationRepo(t, "# Changelog\n\n## [Unreleased]\n\n"+validationReleasedHistory)
+	head := commitValidationFile(t, repo, "src/cli/CHANGELOG.md", `# Changelog
+
+## [Unreleased]
+
+### Fixed
+
+- Unreleased fix
+
+## [1.1.0] - 2025-02-01
+
+### Added
+
+- Feature
+
+## [1.0.0] - 2025-01-01
+
+### Added
+
+- Initial
+`, "drop entry from released version")
+
+	err := runValidation(t, repo, base, head)
+	assertValidationError(t, err, internal.ErrReleasedSectionModified)
+	if !strings.Contains(err.Error(), "[1.1.0] edited") {
+		t.Errorf("RunValidation() error = %v, want [1.1.0] reported", err)
+	}
+}
+
+func testRunValidationAcceptsPromotionWithHistory(t *testing.T) {
+	repo, base := setupValidationRepo(t, `# Changelog
+
+## [Unreleased]
+
+### Fixed
+
+- Promote me
+
+`+validationReleasedHistory)
+	head := commitValidationFile(t, repo, "src/cli/CHANGELOG.md", `# Changelog
+
+## [Unreleased]
+
+## [1.2.0] - 2025-03-01
+
+### Fixed
+
+- Promote me
+
+`+validationReleasedHistory, "promote 1.2.0")
+
+	if err := runValidation(t, repo, base, head); err != nil {
+		t.Fatalf("RunValidation() error = %v", err)
+	}
+}
+
 func testRunValidationAcceptsPromotion(t *testing.T) {
 	repo, base := setupValidationRepo(t, `# Changelog
 
@@ -213,6 +340,47 @@ func testRunValidationFailsEmptyUnreleased(t *testing.T) {
 	assertValidationError(t, runValidation(t, repo, base, head), changelog.ErrUnreleasedNoHeader)
 }
 
+const prereleaseHistoryChangelog = `# Changelog
+
+## [Unreleased]
+
+## [1.3.0-preview.2] - 2025-02-01
+
+### Fixed
+
+- Preview fix
+
+## [1.3.0-preview.1] - 2025-01-01
+
+### Added
+
+- Preview feature
+`
+
+func testRunValidationAcceptsPrereleasePromotion(t *testing.T) {
+	repo, base := setupValidationRepo(t, prereleaseHistoryChangelog)
+	head := commitValidationFile(t, repo, "src/cli/CHANGELOG.md", strings.Replace(prereleaseHistoryChangelog,
+		"## [Unreleased]\n",
+		"## [Unreleased]\n\n## [1.3.0] - 2025-03-01\n\n### Added\n\n- Preview feature\n\n### Fixed\n\n- Preview fix\n",
+		1,
+	), "promote preview")
+
+	if err := runValidation(t, repo, base, head); err != nil {
+		t.Fatalf("RunValidation() error = %v", err)
+	}
+}
+
+func testRunValidationRejectsSyntheticPrereleasePromotion(t *testing.T) {
+	repo, base := setupValidationRepo(t, prereleaseHistoryChangelog)
+	head := commitValidationFile(t, repo, "src/cli/CHANGELOG.md", strings.Replace(prereleaseHistoryChangelog,
+		"## [Unreleased]\n",
+		"## [Unreleased]\n\n## [1.3.0] - 2025-03-01\n\n### Added\n\n- Preview feature\n- Never previewed\n",
+		1,
+	), "synthetic promotion")
+
+	assertValidationError(t, runValidation(t, repo, base, head), changelog.ErrUnreleasedNoHeader)
+}
+
 func setupValidationRepo(t *testing.T, initialChangelog string) (string, string) {
 	t.Helper()
 	repo := createStudioctlWorkflowRepo(t, initialChangelog)
@@ -254,6 +422,232 @@ func assertValidationError(t *testing.T, err, want error) {
 	}
 }
 
+func TestRunValidation_RequirePreamble(t *testing.T) {
+	const body = `## [Unreleased]
+
+### Fixed
+
+- Validation entry
+
+## [1.0.0] - 2025-01-01
+
+### Added
+
+- Initial
+`
+	tests := []struct {
+		wantErr  error
+		name     string
+		preamble string
+	}{
+		{name: "missing title", preamble: "", wantErr: changelog.ErrNoPreambleTitle},
+		{name: "intro without title", preamble: "All notable changes are documented here.\n\n", wantErr: changelog.ErrNoPreambleTitle},
+		{name: "title present", preamble: "# Changelog\n\nAll notable changes are documented here.\n\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, base := setupValidationRepo(t, "# Changelog\n\n## [Unreleased]\n\n## [1.0.0] - 2025-01-01\n\n### Added\n\n- Initial\n")
+			head := commitValidationFile(t, repo, "src/cli/CHANGELOG.md", tt.preamble+body, "update changelog")
+			t.Chdir(repo)
+
+			req := internal.ValidationRequest{
+				Component:       "studioctl",
+				Base:            base,
+				Head:            head,
+				ChangelogPath:   "",
+				RequirePreamble: false,
+			}
+			if err := internal.RunValidation(t.Context(), req, internal.NopLogger{}); err != nil {
+				t.Fatalf("RunValidation() without -require-preamble error = %v", err)
+			}
+
+			req.RequirePreamble = true
+			err := internal.RunValidation(t.Context(), req, internal.NopLogger{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RunValidation() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunValidation_WarnDuplicates(t *testing.T) {
+	const base = "# Changelog\n\n## [Unreleased]\n\n## [1.0.0] - 2025-01-01\n\n### Added\n\n- Initial\n"
+	tests := []struct {
+		name     string
+		category string
+		wantWarn bool
+	}{
+		{name: "duplicated entry", category: "Added", wantWarn: true},
+		{name: "same text in another category", category: "Fixed", wantWarn: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, baseSHA := setupValidationRepo(t, base)
+			head := commitValidationFile(t, repo, "src/cli/CHANGELOG.md",
+				"# Changelog\n\n## [Unreleased]\n\n### "+tt.category+"\n\n- Initial\n\n"+
+					"## [1.0.0] - 2025-01-01\n\n### Added\n\n- Initial\n", "update changelog")
+			t.Chdir(repo)
+
+			var out strings.Builder
+			log := internal.NewConsoleLogger(internal.WithWriters(&out, &out))
+			req := internal.ValidationRequest{
+				Component:       "studioctl",
+				Base:            baseSHA,
+				Head:            head,
+				ChangelogPath:   "",
+				RequirePreamble: false,
+				WarnDuplicates:  true,
+			}
+			if err := internal.RunValidation(t.Context(), req, log); err != nil {
+				t.Fatalf("RunValidation() error = %v", err)
+			}
+			if got := strings.Contains(out.String(), "WARNING"); got != tt.wantWarn {
+				t.Fatalf("warning logged = %v, want %v; output:\n%s", got, tt.wantWarn, out.String())
+			}
+		})
+	}
+}
+
+func TestRunValidation_ChangelogPathOverride(t *testing.T) {
+	const relocatedPath = "docs/release/CHANGELOG.md"
+
+	repo, _ := setupValidationRepo(t, `# Changelog
+
+## [Unreleased]
+`)
+	base := commitValidationFile(t, repo, relocatedPath, `# Changelog
+
+## [Unreleased]
+
+## [1.0.0] - 2025-01-01
+
+### Added
+
+- Initial
+`, "add relocated changelog")
+	head := commitValidationFile(t, repo, relocatedPath, `# Changelog
+
+## [Unreleased]
+
+### Fixed
+
+- Relocated entry
+
+## [1.0.0] - 2025-01-01
+
+### Added
+
+- Initial
+`, "update relocated changelog")
+
+	tests := []struct {
+		wantErr       error
+		name          string
+		changelogPath string
+	}{
+		{name: "relative override", changelogPath: relocatedPath},
+		{name: "dot-prefixed override", changelogPath: "./" + relocatedPath},
+		{name: "absolute override", changelogPath: filepath.Join(repo, filepath.FromSlash(relocatedPath))},
+		{name: "component default not modified", changelogPath: "", wantErr: internal.ErrChangelogNotModified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(repo)
+			err := internal.RunValidation(t.Context(), internal.ValidationRequest{
+				Component:     "studioctl",
+				Base:          base,
+				Head:          head,
+				ChangelogPath: tt.changelogPath,
+			}, internal.NopLogger{})
+			if tt.wantErr != nil {
+				assertValidationError(t, err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				t.Fatalf("RunValidation() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestRunValidationAll_ReportsEveryComponent(t *testing.T) {
+	const initial = `# Changelog
+
+## [Unreleased]
+`
+	repo, _ := setupValidationRepo(t, initial)
+	base := commitValidationFile(t, repo, "src/App/fileanalyzers/CHANGELOG.md", initial, "add fileanalyzers changelog")
+	studioctlHead := commitValidationFile(t, repo, "src/cli/CHANGELOG.md", `# Changelog
+
+## [Unreleased]
+
+### Fixed
+
+- Studioctl entry
+`, "update studioctl changelog")
+	head := commitValidationFile(t, repo, "src/App/fileanalyzers/Program.cs", "class Program {}\n",
+		"change fileanalyzers source")
+
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+	validateAll := func(head string) (map[string]internal.ComponentValidationResult, error) {
+		t.Helper()
+		results, err := internal.RunValidationAll(t.Context(), internal.ValidationRequest{
+			Component: internal.AllComponents,
+			Base:      base,
+			Head:      head,
+		}, git)
+		got := make(map[string]internal.ComponentValidationResult, len(results))
+		for _, result := range results {
+			got[result.Component] = result
+		}
+		if len(got) != 2 {
+			t.Fatalf("RunValidationAll() results = %v, want studioctl and fileanalyzers", results)
+		}
+		if studioctl := got["studioctl"]; studioctl.Err != nil || studioctl.Skipped {
+			t.Fatalf("studioctl result = %+v, want validated without error", studioctl)
+		}
+		return got, err
+	}
+
+	got, err := validateAll(studioctlHead)
+	if err != nil {
+		t.Fatalf("RunValidationAll() with untouched fileanalyzers error = %v", err)
+	}
+	if fileanalyzers := got["fileanalyzers"]; fileanalyzers.Err != nil || !fileanalyzers.Skipped {
+		t.Fatalf("untouched fileanalyzers result = %+v, want skipped", fileanalyzers)
+	}
+
+	got, err = validateAll(head)
+	if !errors.Is(err, internal.ErrComponentsInvalid) {
+		t.Fatalf("RunValidationAll() error = %v, want %v", err, internal.ErrComponentsInvalid)
+	}
+	if !strings.Contains(err.Error(), "fileanalyzers") || strings.Contains(err.Error(), "studioctl") {
+		t.Fatalf("RunValidationAll() error = %v, want only fileanalyzers listed", err)
+	}
+	if fileanalyzers := got["fileanalyzers"]; !errors.Is(fileanalyzers.Err, internal.ErrChangelogNotModified) {
+		t.Fatalf("fileanalyzers result = %+v, want %v", fileanalyzers, internal.ErrChangelogNotModified)
+	}
+}
+
+func TestRunValidationAll_RejectsChangelogPath(t *testing.T) {
+	repo, head := setupValidationRepo(t, "# Changelog\n\n## [Unreleased]\n")
+	git := internal.NewGitCLI(internal.WithWorkdir(repo), internal.WithLogger(internal.NopLogger{}))
+
+	_, err := internal.RunValidationAll(t.Context(), internal.ValidationRequest{
+		Component:     internal.AllComponents,
+		Base:          head,
+		Head:          head,
+		ChangelogPath: "docs/CHANGELOG.md",
+	}, git)
+	if err == nil {
+		t.Fatal("RunValidationAll() expected error, got nil")
+	}
+	if code := internal.ClassifyError(err).Code; code != internal.CodeInvalidArguments {
+		t.Fatalf("ClassifyError().Code = %q, want %q", code, internal.CodeInvalidArguments)
+	}
+}
+
 func TestRunValidationWithDeps_ValidationErrors(t *testing.T) {
 	repo := createStudioctlWorkflowRepo(t, `# Changelog
 
@@ -269,10 +663,10 @@ func TestRunValidationWithDeps_ValidationErrors(t *testing.T) {
 	head := revParseHead(t, repo)
 
 	tests := []struct {
-		name        string
-		req         internal.ValidationRequest
 		git         *internal.GitCLI
+		name        string
 		wantErrText string
+		req         internal.ValidationRequest
 	}{
 		{
 			name: "missing component",
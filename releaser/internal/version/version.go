@@ -16,6 +16,9 @@ var ErrInvalidFormat = errors.New("invalid version format: expected vX.Y.Z or vX
 // Prerelease identifiers are dot-separated alphanumeric strings.
 var pattern = regexp.MustCompile(`^v(\d+)\.(\d+)\.(\d+)(-([0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*))?$`)
 
+// partialPattern matches vX or vX.Y, which ParseLenient zero-fills.
+var partialPattern = regexp.MustCompile(`^v(\d+)(?:\.(\d+))?$`)
+
 // Version represents a parsed semantic version per semver 2.0.
 type Version struct {
 	Full         string // v1.2.3 or v1.2.3-preview.1
@@ -60,6 +63,24 @@ func Parse(ver string) (*Version, error) {
 	}, nil
 }
 
+// ParseLenient is like Parse but also accepts vX and vX.Y, zero-filling the missing
+// minor and patch components (v1.2 parses as v1.2.0). Partial versions cannot carry a
+// prerelease. Only callers that explicitly want partial input should use it; tags and
+// changelog sections are always matched with the strict Parse.
+func ParseLenient(ver string) (*Version, error) {
+	ver = strings.TrimSpace(ver)
+	matches := partialPattern.FindStringSubmatch(ver)
+	if matches == nil {
+		return Parse(ver)
+	}
+
+	minor := matches[2]
+	if minor == "" {
+		minor = "0"
+	}
+	return Parse("v" + matches[1] + "." + minor + ".0")
+}
+
 // String returns the full version string with v prefix.
 func (v *Version) String() string {
 	return v.Full
@@ -70,3 +91,87 @@ func (v *Version) String() string {
 func (v *Version) IsPatchRelease() bool {
 	return !v.IsPrerelease && v.Patch > 0
 }
+
+// Compare returns -1, 0 or 1 when a is lower than, equal to or higher than b by semver
+// precedence. A prerelease has lower precedence than its stable version.
+func Compare(a, b *Version) int {
+	switch {
+	case a.Major > b.Major:
+		return 1
+	case a.Major < b.Major:
+		return -1
+	case a.Minor > b.Minor:
+		return 1
+	case a.Minor < b.Minor:
+		return -1
+	case a.Patch > b.Patch:
+		return 1
+	case a.Patch < b.Patch:
+		return -1
+	}
+
+	if !a.IsPrerelease && !b.IsPrerelease {
+		return 0
+	}
+	if !a.IsPrerelease {
+		return 1
+	}
+	if !b.IsPrerelease {
+		return -1
+	}
+
+	return comparePrerelease(a.Prerelease, b.Prerelease)
+}
+
+func comparePrerelease(a, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+	limit := min(len(aParts), len(bParts))
+
+	for i := range limit {
+		if aParts[i] == bParts[i] {
+			continue
+		}
+
+		aNum, aIsNum := parseNumericIdentifier(aParts[i])
+		bNum, bIsNum := parseNumericIdentifier(bParts[i])
+		switch {
+		case aIsNum && bIsNum:
+			if aNum > bNum {
+				return 1
+			}
+			return -1
+		case aIsNum && !bIsNum:
+			return -1
+		case !aIsNum && bIsNum:
+			return 1
+		default:
+			return strings.Compare(aParts[i], bParts[i])
+		}
+	}
+
+	switch {
+	case len(aParts) > len(bParts):
+		return 1
+	case len(aParts) < len(bParts):
+		return -1
+	default:
+		return 0
+	}
+}
+
+func parseNumericIdentifier(value string) (int, bool) {
+	if value == "" {
+		return 0, false
+	}
+	for _, char := range value {
+		if char < '0' || char > '9' {
+			return 0, false
+		}
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+	return number, true
+}
@@ -231,3 +231,68 @@ func TestPrereleaseVersionFields(t *testing.T) {
 		t.Error("IsPrerelease = false, want true")
 	}
 }
+
+func TestParseLenient(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "v1", want: "v1.0.0"},
+		{input: "v1.2", want: "v1.2.0"},
+		{input: "v1.2.3", want: "v1.2.3"},
+		{input: " v1.2 ", want: "v1.2.0"},
+		{input: "v1.2.3-rc.1", want: "v1.2.3-rc.1"},
+	}
+	for _, tt := range tests {
+		ver, err := version.ParseLenient(tt.input)
+		if err != nil {
+			t.Errorf("ParseLenient(%q) error: %v", tt.input, err)
+			continue
+		}
+		if ver.String() != tt.want {
+			t.Errorf("ParseLenient(%q) = %q, want %q", tt.input, ver, tt.want)
+		}
+	}
+
+	for _, input := range []string{"v1.2-rc.1", "1.2", "v1.", "v"} {
+		if _, err := version.ParseLenient(input); !errors.Is(err, version.ErrInvalidFormat) {
+			t.Errorf("ParseLenient(%q) error = %v, want %v", input, err, version.ErrInvalidFormat)
+		}
+	}
+
+	// The strict parser is unaffected.
+	for _, input := range []string{"v1", "v1.2"} {
+		if _, err := version.Parse(input); !errors.Is(err, version.ErrInvalidFormat) {
+			t.Errorf("Parse(%q) error = %v, want %v", input, err, version.ErrInvalidFormat)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "v1.2.0", b: "v1.2.0", want: 0},
+		{a: "v1.2.0", b: "v1.1.9", want: 1},
+		{a: "v1.1.0", b: "v1.2.0", want: -1},
+		{a: "v2.0.0", b: "v1.9.9", want: 1},
+		{a: "v1.2.0", b: "v1.2.0-preview.3", want: 1},
+		{a: "v1.2.0-preview.2", b: "v1.2.0-preview.10", want: -1},
+		{a: "v1.2.0-preview", b: "v1.2.0-preview.1", want: -1},
+		{a: "v1.2.0-rc.1", b: "v1.2.0-preview.1", want: 1},
+	}
+	for _, tt := range tests {
+		a, err := version.Parse(tt.a)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.a, err)
+		}
+		b, err := version.Parse(tt.b)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.b, err)
+		}
+		if got := version.Compare(a, b); got != tt.want {
+			t.Errorf("Compare(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
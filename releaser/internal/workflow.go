@@ -8,7 +8,9 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
+	"altinn.studio/devenv/pkg/retry"
 	"altinn.studio/releaser/internal/changelog"
 	"altinn.studio/releaser/internal/perm"
 	"altinn.studio/releaser/internal/version"
@@ -16,21 +18,69 @@ import (
 
 // Workflow errors.
 var (
-	ErrChangelogMissing     = errors.New("changelog version section not found")
-	ErrBuildFailed          = errors.New("build failed")
-	ErrReleaseBranchMissing = errors.New("release branch does not exist for stable release")
+	ErrChangelogMissing      = errors.New("changelog version section not found")
+	ErrChangelogFileMissing  = errors.New("changelog file not found")
+	ErrBuildFailed           = errors.New("build failed")
+	ErrReleaseBranchMissing  = errors.New("release branch does not exist for stable release")
+	ErrReleaseAssetsMissing  = errors.New("release is missing expected assets")
+	ErrAllowDirtyInCI        = errors.New("allow-dirty is not permitted in CI")
+	ErrVersionRegression     = errors.New("version is not newer than the latest published release")
+	ErrNotesFileEmpty        = errors.New("release notes file is empty")
+	ErrOutputDirNotManaged   = errors.New("output directory contains files not written by a release build")
+	ErrInvalidCategoryHeader = errors.New("invalid category header")
 )
 
 // WorkflowConfig configures the release workflow.
 type WorkflowConfig struct {
-	Component             string // Required: component name (e.g., "studioctl")
-	Version               string // Required: version to release (e.g., "v1.0.0")
-	ChangelogPath         string // Optional: override component's default changelog path
-	OutputDir             string // Directory for build artifacts (default: build/release)
-	RepoRoot              string // Repository root directory (for gh CLI, default: ../..)
-	DryRun                bool   // If true, validate but don't create tags/branches/releases
-	Draft                 bool   // If true, create release as draft
-	UnsafeSkipBranchCheck bool   // If true, skip branch validation (for testing)
+	PostReleaseHook       *ReleaseHook               // Optional: override component's post-release hook
+	Prompter              ConfirmationPrompter       // Optional: confirms cleaning an output dir with foreign files (refused in CI)
+	CategoryHeaders       map[string]string          // Optional: release-note header per category (changelog stays plain)
+	Component             string                     // Required: component name (e.g., "studioctl")
+	Version               string                     // Required: version to release (e.g., "v1.0.0")
+	ChangelogPath         string                     // Optional: override component's default changelog path
+	OutputDir             string                     // Directory for build artifacts (default: build/release)
+	RepoRoot              string                     // Repository root directory (for gh CLI, default: ../..)
+	BaseBranch            string                     // Optional: branch CI checked out; stands in for a detached HEAD when CI is set
+	NotesFile             string                     // Optional: publish this markdown file instead of the changelog section
+	NotesStyle            changelog.NotesStyle       // Optional: release-note rendering (default: github)
+	SincePrerelease       changelog.PrereleaseFilter // Optional: annotate or exclude entries shipped in prereleases (default: include)
+	DryRun                bool                       // If true, validate but don't create tags/branches/releases
+	Draft                 bool                       // If true, create release as draft
+	UnsafeSkipBranchCheck bool                       // If true, skip branch validation (for testing)
+	SkipVerifyRelease     bool                       // If true, skip checking uploaded assets after release creation
+	AnnotatedTag          bool                       // If true, create an annotated tag before the release instead of letting gh tag
+	SignTag               bool                       // If true, sign the annotated tag (implies AnnotatedTag)
+	CI                    bool                       // If true, running in CI (allows a detached HEAD with BaseBranch)
+	AllowDirty            bool                       // If true, skip the clean working tree check (local debugging; refused in CI)
+	AllowRegression       bool                       // If true, allow releasing a version older than the latest tag (backfills)
+	AllowBehind           bool                       // If true, only warn when the branch is behind its origin counterpart
+	CheckUnreleasedEmpty  bool                       // If true, warn after the release when [Unreleased] still has entries
+}
+
+// DefaultCategoryHeaders returns the release-note headers for the standard Keep a Changelog
+// categories. Each call returns a fresh map the caller may modify.
+func DefaultCategoryHeaders() map[string]string {
+	return map[string]string{
+		"Added":      "✨ Added",
+		"Changed":    "🔄 Changed",
+		"Fixed":      "🐛 Fixed",
+		"Removed":    "🗑️ Removed",
+		"Security":   "🔒 Security",
+		"Deprecated": "⚠️ Deprecated",
+	}
+}
+
+// ParseCategoryHeader parses a Category=Header flag value.
+func ParseCategoryHeader(raw string) (category, header string, err error) {
+	category, header, ok := strings.Cut(raw, "=")
+	category, header = strings.TrimSpace(category), strings.TrimSpace(header)
+	if !ok || category == "" || header == "" {
+		return "", "", fmt.Errorf("%w: %q must be Category=Header", ErrInvalidCategoryHeader, raw)
+	}
+	if strings.ContainsAny(header, "\r\n") {
+		return "", "", fmt.Errorf("%w: %s header must be a single line", ErrInvalidCategoryHeader, category)
+	}
+	return category, header, nil
 }
 
 // Workflow orchestrates the release process.
@@ -43,6 +93,9 @@ type Workflow struct {
 	tag              *Tag
 	changelogContent string
 	parsedChangelog  *changelog.Changelog
+	releaseURL       string
+	commit           string // SHA checked at preflight; annotated tags point here
+	assets           []string
 	config           WorkflowConfig
 }
 
@@ -72,9 +125,33 @@ func NewWorkflow(
 		return nil, fmt.Errorf("parse version: %w", err)
 	}
 
+	if config.AllowDirty && config.CI {
+		return nil, ErrAllowDirtyInCI
+	}
+	if _, err := changelog.ParseNotesStyle(string(config.NotesStyle)); err != nil {
+		return nil, fmt.Errorf("parse notes style: %w", err)
+	}
+	if _, err := changelog.ParsePrereleaseFilter(string(config.SincePrerelease)); err != nil {
+		return nil, fmt.Errorf("parse prerelease filter: %w", err)
+	}
+
+	if config.NotesFile != "" {
+		notesFile, err := filepath.Abs(config.NotesFile)
+		if err != nil {
+			return nil, fmt.Errorf("resolve notes file path: %w", err)
+		}
+		config.NotesFile = notesFile
+	}
+
 	if config.ChangelogPath == "" {
 		config.ChangelogPath = comp.ChangelogPath
 	}
+	if config.PostReleaseHook == nil {
+		config.PostReleaseHook = comp.PostReleaseHook
+	}
+	if config.SignTag {
+		config.AnnotatedTag = true
+	}
 
 	if config.RepoRoot == "" {
 		root, err := git.RepoRoot(ctx)
@@ -103,6 +180,9 @@ func NewWorkflow(
 		tag:              nil,
 		changelogContent: "",
 		parsedChangelog:  nil,
+		releaseURL:       "",
+		commit:           "",
+		assets:           nil,
 	}, nil
 }
 
@@ -179,18 +259,38 @@ func (w *Workflow) Run(ctx context.Context) error {
 		return err
 	}
 
+	if err := ensureGitHubAuthenticated(ctx, w.gh, w.config.DryRun, w.log); err != nil {
+		return err
+	}
+
 	if err := w.validateTagNotExists(ctx); err != nil {
 		return err
 	}
 
+	if err := w.validateNoRegression(ctx); err != nil {
+		return err
+	}
+
 	if err := w.enforceRefPolicy(ctx); err != nil {
 		return err
 	}
 
+	if err := w.validateNotBehindRemote(ctx); err != nil {
+		return err
+	}
+
+	if err := w.resolveReleaseCommit(ctx); err != nil {
+		return err
+	}
+
 	if err := w.handleChangelog(ctx); err != nil {
 		return err
 	}
 
+	if err := w.previewReleaseNotes(); err != nil {
+		return err
+	}
+
 	if err := w.prepareOutputDir(); err != nil {
 		return err
 	}
@@ -203,6 +303,20 @@ func (w *Workflow) Run(ctx context.Context) error {
 		return err
 	}
 
+	if err := w.verifyRelease(ctx); err != nil {
+		return err
+	}
+
+	if err := w.runPostReleaseHook(ctx); err != nil {
+		return err
+	}
+
+	w.checkUnreleasedEmpty()
+
+	if err := w.writeSummaryArtifact(); err != nil {
+		return err
+	}
+
 	w.printSummary()
 	return nil
 }
@@ -247,6 +361,44 @@ func (w *Workflow) validateTagNotExists(ctx context.Context) error {
 	return nil
 }
 
+// validateNoRegression checks the version is newer than the latest tag on its release
+// line, or than the latest tag overall when it opens a new line. Patch releases on
+// older lines stay allowed, but e.g. v1.1.0 after v1.2.0 would break "latest" resolution.
+func (w *Workflow) validateNoRegression(ctx context.Context) error {
+	w.log.Step("Checking version is newer than published releases")
+
+	published, err := w.git.ListTags(ctx, w.component.Tag(""))
+	if err != nil {
+		return fmt.Errorf("list tags: %w", err)
+	}
+
+	ver := w.tag.Version
+	var latest, latestOnLine *version.Version
+	for _, tagVer := range published {
+		latest = tagVer // sorted oldest first
+		if tagVer.Major == ver.Major && tagVer.Minor == ver.Minor {
+			latestOnLine = tagVer
+		}
+	}
+
+	newest := latestOnLine
+	if newest == nil {
+		newest = latest
+	}
+	if newest == nil || version.Compare(ver, newest) > 0 {
+		w.log.Success("Version is newer than published releases")
+		return nil
+	}
+
+	newestTag := w.component.Tag(newest.String())
+	if w.config.AllowRegression {
+		w.log.Error("WARNING: releasing %s although %s is already published (-allow-regression)", w.tag.Full(), newestTag)
+		return nil
+	}
+	w.log.Error("Tag %s is already published. Use -allow-regression for intentional backfills.", newestTag)
+	return fmt.Errorf("%w: %s <= %s", ErrVersionRegression, w.tag.Full(), newestTag)
+}
+
 // enforceRefPolicy validates the current ref against release type rules.
 func (w *Workflow) enforceRefPolicy(ctx context.Context) error {
 	w.log.Step("Enforcing ref policy")
@@ -256,6 +408,7 @@ func (w *Workflow) enforceRefPolicy(ctx context.Context) error {
 		return fmt.Errorf("get current branch: %w", err)
 	}
 	w.log.Detail("Current branch", currentBranch)
+	currentBranch = w.resolveDetachedHead(currentBranch)
 
 	if w.tag.Version.IsPrerelease {
 		return w.enforcePrereleasePolicy(currentBranch)
@@ -264,6 +417,57 @@ func (w *Workflow) enforceRefPolicy(ctx context.Context) error {
 	return w.enforceStablePolicy(ctx, currentBranch)
 }
 
+// resolveDetachedHead returns the configured base branch in place of a detached HEAD.
+// CI checkouts of a specific SHA are detached; the ref policy then checks the branch
+// the CI job was started for. Outside CI, or without a base branch, HEAD is kept as-is
+// so the policy rejects it.
+func (w *Workflow) resolveDetachedHead(currentBranch string) string {
+	if currentBranch != detachedHEAD || !w.config.CI || w.config.BaseBranch == "" {
+		return currentBranch
+	}
+	w.log.Info("Detached HEAD in CI; validating against base branch %s", w.config.BaseBranch)
+	return w.config.BaseBranch
+}
+
+// validateNotBehindRemote checks the branch being released has every commit of its
+// origin counterpart, so the tag does not land on a stale commit. Branches without
+// an origin counterpart (only reachable with -skip-branch-check) are not compared.
+func (w *Workflow) validateNotBehindRemote(ctx context.Context) error {
+	w.log.Step("Checking branch is up to date with origin")
+
+	branch, err := w.git.CurrentBranch(ctx)
+	if err != nil {
+		return fmt.Errorf("get current branch: %w", err)
+	}
+	branch = w.resolveDetachedHead(branch)
+	if branch == detachedHEAD {
+		w.log.Info("Detached HEAD; skipping comparison with origin")
+		return nil
+	}
+
+	exists, err := w.git.RemoteBranchExists(ctx, branch)
+	if err != nil {
+		return fmt.Errorf("check remote branch: %w", err)
+	}
+	if !exists {
+		w.log.Info("origin/%s does not exist; skipping comparison with origin", branch)
+		return nil
+	}
+	return ensureNotBehindRemote(ctx, w.git, w.log, branch, w.config.AllowBehind)
+}
+
+// resolveReleaseCommit pins the commit that passed the ref checks, so a tag created later
+// does not follow a branch that moved in the meantime.
+func (w *Workflow) resolveReleaseCommit(ctx context.Context) error {
+	commit, err := w.git.ResolveCommit(ctx, "HEAD")
+	if err != nil {
+		return fmt.Errorf("resolve release commit: %w", err)
+	}
+	w.commit = commit
+	w.log.Detail("Commit", commit)
+	return nil
+}
+
 func (w *Workflow) enforcePrereleasePolicy(currentBranch string) error {
 	if currentBranch != mainBranch {
 		if w.config.UnsafeSkipBranchCheck {
@@ -278,18 +482,74 @@ func (w *Workflow) enforcePrereleasePolicy(currentBranch string) error {
 }
 
 func (w *Workflow) validateWorkingTreeClean(ctx context.Context) error {
+	if w.config.AllowDirty {
+		w.log.Error("WARNING: -allow-dirty set; skipping clean working tree check. " +
+			"Uncommitted changes may end up in the build. Never publish this release.")
+		return nil
+	}
 	return ensureWorkingTreeClean(ctx, w.git, w.log)
 }
 
 func (w *Workflow) prepareOutputDir() error {
 	w.log.Step("Preparing output directory")
+	if err := w.confirmOutputDirClean(); err != nil {
+		return err
+	}
 	if err := EnsureCleanDir(w.config.OutputDir); err != nil {
 		return fmt.Errorf("clean output dir: %w", err)
 	}
+	// The marker tells the next run that everything here came from a build.
+	marker := filepath.Join(w.config.OutputDir, outputDirMarkerFile)
+	if err := os.WriteFile(marker, []byte(w.tag.Full()+"\n"), perm.FilePermDefault); err != nil {
+		return fmt.Errorf("write output dir marker: %w", err)
+	}
 	w.log.Success("Output directory is ready")
 	return nil
 }
 
+// confirmOutputDirClean guards against wiping a directory the workflow did not fill.
+// A missing or empty directory, or one holding the marker of an earlier build, is
+// cleaned without asking. Anything else is refused in CI or without a prompter and
+// needs confirmation otherwise.
+func (w *Workflow) confirmOutputDirClean() error {
+	entries, err := os.ReadDir(w.config.OutputDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("read output dir: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Name() == outputDirMarkerFile {
+			return nil
+		}
+		names = append(names, entry.Name())
+	}
+
+	const maxListed = 5
+	listed := strings.Join(names[:min(len(names), maxListed)], ", ")
+	if len(names) > maxListed {
+		listed += fmt.Sprintf(" and %d more", len(names)-maxListed)
+	}
+
+	if w.config.CI || w.config.Prompter == nil {
+		w.log.Error("Output directory %s contains files not written by a release build: %s", w.config.OutputDir, listed)
+		w.log.Error("Remove them or choose another output directory.")
+		return fmt.Errorf("%w: %s", ErrOutputDirNotManaged, w.config.OutputDir)
+	}
+	return confirmMutatingAction(
+		w.config.Prompter,
+		"remove existing files from output directory",
+		"Directory: "+w.config.OutputDir,
+		"Not written by a release build: "+listed,
+	)
+}
+
 func (w *Workflow) enforceStablePolicy(ctx context.Context, currentBranch string) error {
 	releaseBranch := w.tag.ReleaseBranch()
 	branchExists, err := w.git.RemoteBranchExists(ctx, releaseBranch)
@@ -341,6 +601,9 @@ func (w *Workflow) handleChangelog(_ context.Context) error {
 	}
 	//nolint:gosec // G304: changelog path is from config, not user input.
 	content, err := os.ReadFile(changelogFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return changelogFileMissingError(w.component, changelogFile, "", "")
+	}
 	if err != nil {
 		return fmt.Errorf("read changelog: %w", err)
 	}
@@ -364,6 +627,25 @@ func (w *Workflow) handleChangelog(_ context.Context) error {
 	return nil
 }
 
+// changelogPathHint tells users of commands with a -changelog-path flag how to use another file.
+const changelogPathHint = "pass -changelog-path to use a different file"
+
+// changelogFileMissingError reports a changelog that does not exist at path, naming the
+// component's default location and, when not empty, hint. Only commands that take
+// -changelog-path pass changelogPathHint.
+// ref is the git ref the file was read from, or empty for the working tree.
+func changelogFileMissingError(comp *Component, path, ref, hint string) error {
+	location := path
+	if ref != "" {
+		location = path + " (on " + ref + ")"
+	}
+	msg := fmt.Sprintf("%s; default for %s is %s", location, comp.Name, comp.ChangelogPath)
+	if hint != "" {
+		msg += ", " + hint
+	}
+	return fmt.Errorf("%w: %s", ErrChangelogFileMissing, msg)
+}
+
 func (w *Workflow) buildArtifacts(ctx context.Context) error {
 	w.log.Step("Building release artifacts")
 
@@ -394,29 +676,16 @@ func (w *Workflow) createGitHubRelease(ctx context.Context) error {
 
 	verStr := w.tag.Version.String()
 
-	w.log.Info("Extracting release notes...")
-	notes, err := w.parsedChangelog.ExtractNotes(verStr)
+	notes, err := w.releaseNotes()
 	if err != nil {
-		return fmt.Errorf("extract release notes: %w", err)
-	}
-	w.log.Info("Release notes:")
-	for line := range strings.SplitSeq(notes, "\n") {
-		w.log.Info("  %s", line)
-	}
-
-	if dirErr := EnsureDir(w.config.OutputDir); dirErr != nil {
-		return fmt.Errorf("ensure output dir: %w", dirErr)
-	}
-
-	notesFile := filepath.Join(w.config.OutputDir, releaseNotesFile)
-	if writeErr := os.WriteFile(notesFile, []byte(notes), perm.FilePermDefault); writeErr != nil {
-		return fmt.Errorf("write release notes: %w", writeErr)
+		return err
 	}
 
 	assets, err := w.collectAssets()
 	if err != nil {
 		return fmt.Errorf("collect assets: %w", err)
 	}
+	w.assets = assets
 
 	target := w.determineTargetBranch()
 	tagFull := w.tag.Full()
@@ -426,17 +695,30 @@ func (w *Workflow) createGitHubRelease(ctx context.Context) error {
 	w.log.Detail("Target branch", target)
 
 	if w.config.DryRun {
-		w.log.Info("(dry-run) Would create release:")
-		w.log.Detail("Tag", tagFull)
-		w.log.Detail("Title", title)
-		w.log.Detail("Draft", strconv.FormatBool(w.config.Draft))
-		w.log.Detail("Prerelease", strconv.FormatBool(w.tag.Version.IsPrerelease))
-		for _, asset := range assets {
-			w.log.Info("  Asset: %s", filepath.Base(asset))
-		}
+		w.logDryRunRelease(target, title, assets)
 		return nil
 	}
 
+	w.log.Info("Release notes:")
+	for line := range strings.SplitSeq(notes, "\n") {
+		w.log.Info("  %s", line)
+	}
+
+	if dirErr := EnsureDir(w.config.OutputDir); dirErr != nil {
+		return fmt.Errorf("ensure output dir: %w", dirErr)
+	}
+
+	notesFile := filepath.Join(w.config.OutputDir, releaseNotesFile)
+	if writeErr := os.WriteFile(notesFile, []byte(notes), perm.FilePermDefault); writeErr != nil {
+		return fmt.Errorf("write release notes: %w", writeErr)
+	}
+
+	if w.config.AnnotatedTag {
+		if err := w.createAnnotatedTag(ctx, title, notes); err != nil {
+			return err
+		}
+	}
+
 	opts := Options{
 		Tag:             tagFull,
 		Title:           title,
@@ -446,19 +728,237 @@ func (w *Workflow) createGitHubRelease(ctx context.Context) error {
 		Draft:           w.config.Draft,
 		Prerelease:      w.tag.Version.IsPrerelease,
 		FailOnNoCommits: true,
+		VerifyTag:       w.config.AnnotatedTag,
 	}
 
 	// gh CLI needs to run from repo root
 	w.gh.SetWorkdir(w.config.RepoRoot)
 
-	if err := w.gh.CreateRelease(ctx, opts); err != nil {
+	policy := retry.DefaultPolicy()
+	policy.OnRetry = func(attempt int, delay time.Duration, err error) {
+		w.log.Info("Create release attempt %d failed (%v); retrying in %s", attempt, err, delay.Round(time.Millisecond))
+	}
+	attempts := 0
+	releaseURL, err := retry.Do(ctx, policy, isTransientGHError, func(ctx context.Context) (string, error) {
+		// Creating a release is not idempotent: a create that timed out may have gone through.
+		if attempts++; attempts > 1 {
+			if url, found, err := findRelease(ctx, w.gh, tagFull); err != nil || found {
+				return url, err
+			}
+		}
+		return w.gh.CreateRelease(ctx, opts)
+	})
+	if err != nil {
+		w.deleteAnnotatedTag(ctx)
 		return fmt.Errorf("create release: %w", err)
 	}
+	w.releaseURL = releaseURL
 
 	w.log.Success("GitHub release created")
 	return nil
 }
 
+// releaseNotes returns the notes published with the release, rendered in the
+// configured notes style with category headers decorated when configured.
+func (w *Workflow) releaseNotes() (string, error) {
+	if w.config.NotesFile != "" {
+		return w.releaseNotesFromFile()
+	}
+
+	w.log.Detail("Notes source", fmt.Sprintf("changelog section [%s]", w.tag.Version.String()))
+	cl := w.parsedChangelog
+	if filter := w.config.SincePrerelease; filter != "" && filter != changelog.PrereleaseFilterInclude {
+		filtered, err := cl.FilterPrereleaseEntries(w.tag.Version.String(), filter)
+		if err != nil {
+			return "", fmt.Errorf("filter prerelease entries: %w", err)
+		}
+		cl = filtered
+	}
+	notes, err := cl.ExtractNotesStyled(
+		w.tag.Version.String(),
+		w.config.NotesStyle,
+		w.config.CategoryHeaders,
+	)
+	if err != nil {
+		return "", fmt.Errorf("extract release notes: %w", err)
+	}
+	return notes, nil
+}
+
+// releaseNotesFromFile returns the curated notes from NotesFile. The changelog
+// section has already been validated, so the changelog still decides the version.
+func (w *Workflow) releaseNotesFromFile() (string, error) {
+	w.log.Detail("Notes source", w.config.NotesFile+" (overrides the changelog section)")
+
+	content, err := os.ReadFile(w.config.NotesFile)
+	if err != nil {
+		return "", fmt.Errorf("read notes file: %w", err)
+	}
+	notes := strings.TrimSpace(string(content))
+	if notes == "" {
+		return "", fmt.Errorf("%w: %s", ErrNotesFileEmpty, w.config.NotesFile)
+	}
+	return notes, nil
+}
+
+// previewReleaseNotes prints the notes that would be published for the release
+// version, so dry runs surface empty or wrong sections before building anything.
+func (w *Workflow) previewReleaseNotes() error {
+	if !w.config.DryRun {
+		return nil
+	}
+
+	w.log.Step("Previewing release notes")
+
+	verStr := w.tag.Version.String()
+	notes, err := w.releaseNotes()
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(notes) == "" {
+		w.log.Error("Changelog section [%s] has no notes", verStr)
+		return nil
+	}
+
+	w.log.Info("Release notes for %s:", verStr)
+	for line := range strings.SplitSeq(notes, "\n") {
+		w.log.Info("  %s", line)
+	}
+	return nil
+}
+
+func (w *Workflow) logDryRunRelease(target, title string, assets []string) {
+	w.log.Info("(dry-run) Would create release:")
+	w.log.Detail("Tag", w.tag.Full())
+	w.log.Detail("Title", title)
+	w.log.Detail("Draft", strconv.FormatBool(w.config.Draft))
+	w.log.Detail("Prerelease", strconv.FormatBool(w.tag.Version.IsPrerelease))
+	for _, asset := range assets {
+		w.log.Info("  Asset: %s", filepath.Base(asset))
+	}
+	if w.config.AnnotatedTag {
+		w.log.Info("(dry-run) Would create annotated tag %s at %s", w.tag.Full(), w.commit)
+	}
+}
+
+// createAnnotatedTag creates the release tag as an annotated tag carrying the
+// release title and notes, so consumers resolving the tag directly see them too.
+func (w *Workflow) createAnnotatedTag(ctx context.Context, title, notes string) error {
+	tagFull := w.tag.Full()
+	w.log.Info("Creating annotated tag %s at %s", tagFull, w.commit)
+
+	opts := AnnotatedTagOptions{
+		Name:    tagFull,
+		Target:  w.commit,
+		Message: annotatedTagMessage(title, notes),
+		Sign:    w.config.SignTag,
+	}
+	if err := w.git.CreateAnnotatedTag(ctx, opts); err != nil {
+		return fmt.Errorf("create annotated tag: %w", err)
+	}
+
+	w.log.Success("Annotated tag created")
+	return nil
+}
+
+// deleteAnnotatedTag removes the tag pushed for a release that could not be created,
+// so a rerun does not fail on an existing tag.
+func (w *Workflow) deleteAnnotatedTag(ctx context.Context) {
+	if !w.config.AnnotatedTag {
+		return
+	}
+	if err := w.git.DeleteTag(ctx, w.tag.Full()); err != nil {
+		w.log.Error("WARNING: could not delete tag %s after the failed release: %v", w.tag.Full(), err)
+		return
+	}
+	w.log.Info("Deleted tag %s after the failed release", w.tag.Full())
+}
+
+func annotatedTagMessage(title, notes string) string {
+	notes = strings.TrimSpace(notes)
+	if notes == "" {
+		return title
+	}
+	return title + "\n\n" + notes
+}
+
+// verifyRelease checks that every collected asset was uploaded to the created release.
+func (w *Workflow) verifyRelease(ctx context.Context) error {
+	if w.config.DryRun {
+		return nil
+	}
+	if w.config.SkipVerifyRelease {
+		w.log.Info("Skipping release verification")
+		return nil
+	}
+
+	w.log.Step("Verifying GitHub release")
+
+	uploaded, err := w.gh.ReleaseAssets(ctx, w.tag.Full())
+	if err != nil {
+		return fmt.Errorf("fetch release assets: %w", err)
+	}
+
+	uploadedNames := make(map[string]bool, len(uploaded))
+	for _, name := range uploaded {
+		uploadedNames[name] = true
+	}
+
+	var missing []string
+	for _, asset := range w.assets {
+		name := filepath.Base(asset)
+		if !uploadedNames[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		for _, name := range missing {
+			w.log.Error("  - %s", name)
+		}
+		return fmt.Errorf(
+			"%w: %d of %d missing: %s",
+			ErrReleaseAssetsMissing,
+			len(missing),
+			len(w.assets),
+			strings.Join(missing, ", "),
+		)
+	}
+
+	w.log.Success(fmt.Sprintf("All %d assets present on release", len(w.assets)))
+	return nil
+}
+
+// checkUnreleasedEmpty warns when [Unreleased] on the released branch still has entries.
+// The prepare PR moves them into the version section, so leftovers usually mean a merge
+// re-added promoted entries. It never fails the release, which is already published.
+func (w *Workflow) checkUnreleasedEmpty() {
+	if !w.config.CheckUnreleasedEmpty {
+		return
+	}
+
+	w.log.Step("Checking [Unreleased] is empty")
+
+	unreleased := w.parsedChangelog.Unreleased
+	var leftover []string
+	if unreleased != nil {
+		for _, cat := range unreleased.Categories {
+			for _, text := range cat.Entries {
+				leftover = append(leftover, cat.Name+": "+text)
+			}
+		}
+	}
+	if len(leftover) == 0 {
+		w.log.Success("[Unreleased] is empty")
+		return
+	}
+
+	w.log.Error("WARNING: [Unreleased] on %s is not empty after releasing %s; check the prepare PR merge:",
+		w.determineTargetBranch(), w.tag.Full())
+	for _, entry := range leftover {
+		w.log.Error("  - %s", entry)
+	}
+}
+
 // determineTargetBranch returns the branch where the tag should be created.
 func (w *Workflow) determineTargetBranch() string {
 	if w.tag.Version.IsPrerelease {
@@ -478,7 +978,7 @@ func (w *Workflow) collectAssets() ([]string, error) {
 		if entry.IsDir() {
 			continue
 		}
-		if entry.Name() == releaseNotesFile {
+		if entry.Name() == releaseNotesFile || entry.Name() == releaseSummaryFile || entry.Name() == outputDirMarkerFile {
 			continue
 		}
 		assets = append(assets, filepath.Join(w.config.OutputDir, entry.Name()))
@@ -3,15 +3,32 @@ package internal
 import (
 	"context"
 	"fmt"
+	"maps"
+
+	"altinn.studio/releaser/internal/changelog"
 )
 
 // WorkflowRequest describes the inputs for the release workflow.
 type WorkflowRequest struct {
-	Component             string // Component name (e.g., "studioctl")
-	BaseBranch            string // Derive version from changelog for this base branch
+	Prompter              ConfirmationPrompter // Confirms cleaning an output dir with foreign files; nil refuses
+	CategoryHeaders       map[string]string    // Release note header per category; overrides DefaultCategoryHeaders
+	Component             string               // Component name (e.g., "studioctl")
+	BaseBranch            string               // Derive version from changelog for this base branch
+	NotesFile             string               // Publish this markdown file as the release notes instead of the changelog section
+	NotesStyle            string               // Release notes style: github (default), plain or compact
+	SincePrerelease       string               // Entries already shipped in prereleases: include (default), annotate or exclude
 	DryRun                bool
 	Draft                 bool
 	UnsafeSkipBranchCheck bool
+	SkipVerifyRelease     bool
+	AnnotatedTag          bool
+	SignTag               bool
+	DecorateNotes         bool // Decorate release note category headers with DefaultCategoryHeaders
+	CI                    bool // Running in CI; a detached HEAD is validated as BaseBranch
+	AllowDirty            bool // Skip the clean working tree check; refused when CI is set
+	AllowRegression       bool // Allow releasing a version older than the latest published tag
+	AllowBehind           bool // Only warn when the branch is behind its origin counterpart
+	CheckUnreleasedEmpty  bool // Warn after the release when [Unreleased] still has entries
 }
 
 type workflowRunDeps struct {
@@ -35,6 +52,14 @@ func RunWorkflow(ctx context.Context, req WorkflowRequest, log Logger) error {
 	if req.BaseBranch == "" {
 		return errBaseBranchRequired
 	}
+	notesStyle, err := changelog.ParseNotesStyle(req.NotesStyle)
+	if err != nil {
+		return fmt.Errorf("parse notes style: %w", err)
+	}
+	sincePrerelease, err := changelog.ParsePrereleaseFilter(req.SincePrerelease)
+	if err != nil {
+		return fmt.Errorf("parse prerelease filter: %w", err)
+	}
 
 	deps, err := buildWorkflowRunDeps(ctx, req, log)
 	if err != nil {
@@ -47,14 +72,28 @@ func RunWorkflow(ctx context.Context, req WorkflowRequest, log Logger) error {
 	}
 
 	cfg := WorkflowConfig{
+		Prompter:              req.Prompter,
 		Component:             req.Component,
 		Version:               version,
 		ChangelogPath:         "",
 		OutputDir:             "",
 		RepoRoot:              deps.repoRoot,
+		BaseBranch:            req.BaseBranch,
+		CI:                    req.CI,
+		AllowDirty:            req.AllowDirty,
+		AllowRegression:       req.AllowRegression,
+		AllowBehind:           req.AllowBehind,
+		CheckUnreleasedEmpty:  req.CheckUnreleasedEmpty,
 		DryRun:                req.DryRun,
 		Draft:                 req.Draft,
 		UnsafeSkipBranchCheck: req.UnsafeSkipBranchCheck,
+		SkipVerifyRelease:     req.SkipVerifyRelease,
+		AnnotatedTag:          req.AnnotatedTag,
+		SignTag:               req.SignTag,
+		CategoryHeaders:       categoryHeaders(req),
+		NotesFile:             req.NotesFile,
+		NotesStyle:            notesStyle,
+		SincePrerelease:       sincePrerelease,
 	}
 	workflow, err := NewWorkflow(ctx, cfg, deps.git, deps.gh, nil, log)
 	if err != nil {
@@ -66,6 +105,23 @@ func RunWorkflow(ctx context.Context, req WorkflowRequest, log Logger) error {
 	return nil
 }
 
+// categoryHeaders returns the release-note header table for req: the defaults when
+// DecorateNotes is set, overridden or extended by req.CategoryHeaders.
+func categoryHeaders(req WorkflowRequest) map[string]string {
+	var headers map[string]string
+	if req.DecorateNotes {
+		headers = DefaultCategoryHeaders()
+	}
+	if len(req.CategoryHeaders) == 0 {
+		return headers
+	}
+	if headers == nil {
+		headers = make(map[string]string, len(req.CategoryHeaders))
+	}
+	maps.Copy(headers, req.CategoryHeaders)
+	return headers
+}
+
 func buildWorkflowRunDeps(ctx context.Context, req WorkflowRequest, log Logger) (workflowRunDeps, error) {
 	component, err := GetComponent(req.Component)
 	if err != nil {
@@ -76,9 +132,12 @@ func buildWorkflowRunDeps(ctx context.Context, req WorkflowRequest, log Logger)
 		WithDryRun(req.DryRun),
 		WithLogger(log),
 	)
-	gh := NewGitHubCLI(
-		WithGHDryRun(req.DryRun),
-		WithGHLogger(log),
+	gh := NewThrottledGitHub(
+		NewGitHubCLI(
+			WithGHDryRun(req.DryRun),
+			WithGHLogger(log),
+		),
+		WithThrottleLogger(log),
 	)
 
 	repoRoot, err := git.RepoRoot(ctx)
@@ -0,0 +1,60 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"altinn.studio/releaser/internal/perm"
+)
+
+// ReleaseSummary is the machine-readable result of a workflow run, written to
+// release-summary.json in the output directory for downstream CI steps.
+type ReleaseSummary struct {
+	Component  string   `json:"component"`
+	Version    string   `json:"version"`
+	Tag        string   `json:"tag"`
+	Target     string   `json:"target"`
+	URL        string   `json:"url,omitempty"` // empty for dry runs
+	Assets     []string `json:"assets"`        // asset file names
+	Prerelease bool     `json:"prerelease"`
+	Draft      bool     `json:"draft"`
+	DryRun     bool     `json:"dryRun"`
+}
+
+func (w *Workflow) summary() ReleaseSummary {
+	assets := make([]string, 0, len(w.assets))
+	for _, asset := range w.assets {
+		assets = append(assets, filepath.Base(asset))
+	}
+	return ReleaseSummary{
+		Component:  w.component.Name,
+		Version:    w.tag.Version.String(),
+		Tag:        w.tag.Full(),
+		Target:     w.determineTargetBranch(),
+		URL:        w.releaseURL,
+		Assets:     assets,
+		Prerelease: w.tag.Version.IsPrerelease,
+		Draft:      w.config.Draft,
+		DryRun:     w.config.DryRun,
+	}
+}
+
+// writeSummaryArtifact writes release-summary.json. It is never uploaded as a release asset.
+func (w *Workflow) writeSummaryArtifact() error {
+	content, err := json.MarshalIndent(w.summary(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal release summary: %w", err)
+	}
+	if err := EnsureDir(w.config.OutputDir); err != nil {
+		return fmt.Errorf("ensure output dir: %w", err)
+	}
+
+	summaryFile := filepath.Join(w.config.OutputDir, releaseSummaryFile)
+	if err := os.WriteFile(summaryFile, append(content, '\n'), perm.FilePermDefault); err != nil {
+		return fmt.Errorf("write release summary: %w", err)
+	}
+	w.log.Detail("Release summary", summaryFile)
+	return nil
+}
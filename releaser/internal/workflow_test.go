@@ -1,14 +1,19 @@
 package internal_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	"altinn.studio/releaser/internal"
+	"altinn.studio/releaser/internal/changelog"
 	"altinn.studio/releaser/internal/version"
 )
 
@@ -74,6 +79,98 @@ func TestWorkflow_Run_PreviewMustBeOnMain(t *testing.T) {
 	}
 }
 
+func TestWorkflow_Run_DetachedHead(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		wantErr       error
+		name          string
+		version       string
+		baseBranch    string
+		wantCheckouts int
+		ci            bool
+	}{
+		{
+			name:       "prerelease in CI with base branch",
+			version:    "v1.2.3-preview.1",
+			baseBranch: "main",
+			ci:         true,
+		},
+		{
+			name:       "stable in CI with release base branch",
+			version:    "v1.2.3",
+			baseBranch: "release/studioctl/v1.2",
+			ci:         true,
+		},
+		{
+			name:       "prerelease outside CI",
+			version:    "v1.2.3-preview.1",
+			baseBranch: "main",
+			ci:         false,
+			wantErr:    internal.ErrNotOnMain,
+		},
+		{
+			name:    "prerelease in CI without base branch",
+			version: "v1.2.3-preview.1",
+			ci:      true,
+			wantErr: internal.ErrNotOnMain,
+		},
+		{
+			name:          "stable in CI with mismatched base branch",
+			version:       "v1.2.3",
+			baseBranch:    "main",
+			ci:            true,
+			wantCheckouts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			changelogPath := writeChangelog(t, fmt.Sprintf(`# Changelog
+
+## [Unreleased]
+
+## [%s] - 2025-01-01
+
+### Added
+
+- Test entry
+`, strings.TrimPrefix(tt.version, "v")))
+			git := &fakeGit{currentBranch: "HEAD", remoteBranchExists: true, workingTreeClean: true}
+
+			workflow, err := internal.NewWorkflow(t.Context(), internal.WorkflowConfig{
+				Component:     "studioctl",
+				Version:       tt.version,
+				ChangelogPath: changelogPath,
+				BaseBranch:    tt.baseBranch,
+				CI:            tt.ci,
+				DryRun:        true,
+				OutputDir:     t.TempDir(),
+				RepoRoot:      os.TempDir(),
+			}, git, &fakeGH{}, &fakeBuilder{}, internal.NopLogger{})
+			if err != nil {
+				t.Fatalf("NewWorkflow() error: %v", err)
+			}
+			err = workflow.Run(t.Context())
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("workflow.Run() error: %v", err)
+			}
+			if git.checkoutCount != tt.wantCheckouts {
+				t.Fatalf("checkoutCount = %d, want %d", git.checkoutCount, tt.wantCheckouts)
+			}
+		})
+	}
+}
+
 func TestWorkflow_Run_ChangelogMissing(t *testing.T) {
 	t.Parallel()
 
@@ -92,33 +189,705 @@ func TestWorkflow_Run_ChangelogMissing(t *testing.T) {
 		RepoRoot:      os.TempDir(),
 	}
 
-	workflow, err := internal.NewWorkflow(t.Context(),
-		cfg,
-		&fakeGit{currentBranch: "main", workingTreeClean: true},
-		&fakeGH{},
-		builder,
-		internal.NopLogger{},
-	)
+	workflow, err := internal.NewWorkflow(t.Context(),
+		cfg,
+		&fakeGit{currentBranch: "main", workingTreeClean: true},
+		&fakeGH{},
+		builder,
+		internal.NopLogger{},
+	)
+	if err != nil {
+		t.Fatalf("NewWorkflow() error: %v", err)
+	}
+	err = workflow.Run(t.Context())
+
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+	if !errors.Is(err, internal.ErrChangelogMissing) {
+		t.Fatalf("error = %v, want %v", err, internal.ErrChangelogMissing)
+	}
+	if builder.called {
+		t.Fatalf("expected no build calls, got build called")
+	}
+}
+
+func TestWorkflow_Run_ChangelogFileMissing(t *testing.T) {
+	t.Parallel()
+
+	repoRoot := t.TempDir()
+	cfg := internal.WorkflowConfig{
+		Component:     "studioctl",
+		Version:       "v1.2.3-preview.1",
+		ChangelogPath: "docs/CHANGELOG.md",
+		DryRun:        true,
+		OutputDir:     filepath.Join(repoRoot, "build"),
+		RepoRoot:      repoRoot,
+	}
+
+	workflow, err := internal.NewWorkflow(t.Context(),
+		cfg,
+		&fakeGit{currentBranch: "main", workingTreeClean: true},
+		&fakeGH{},
+		&fakeBuilder{},
+		internal.NopLogger{},
+	)
+	if err != nil {
+		t.Fatalf("NewWorkflow() error: %v", err)
+	}
+	err = workflow.Run(t.Context())
+
+	if !errors.Is(err, internal.ErrChangelogFileMissing) {
+		t.Fatalf("error = %v, want %v", err, internal.ErrChangelogFileMissing)
+	}
+	wantPath := filepath.Join(repoRoot, "docs", "CHANGELOG.md")
+	for _, want := range []string{wantPath, "src/cli/CHANGELOG.md"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error = %v, want message containing %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "-changelog-path") {
+		t.Errorf("error = %v, want no -changelog-path hint: the command has no such flag", err)
+	}
+}
+
+func TestWorkflow_Run_StableChecksOutReleaseBranch(t *testing.T) {
+	t.Parallel()
+
+	changelogPath := writeChangelog(t, `# Changelog
+
+## [Unreleased]
+
+## [v1.2.3] - 2025-01-01
+
+### Added
+
+- Test entry
+`)
+
+	outputDir := t.TempDir()
+	builder := &fakeBuilder{}
+	gh := &fakeGH{}
+	git := &fakeGit{
+		currentBranch:      "main",
+		remoteBranchExists: true,
+		workingTreeClean:   true,
+	}
+
+	cfg := internal.WorkflowConfig{
+		Component:     "studioctl",
+		Version:       "v1.2.3",
+		ChangelogPath: changelogPath,
+		OutputDir:     outputDir,
+		DryRun:        false,
+		Draft:         true,
+		RepoRoot:      os.TempDir(),
+	}
+
+	workflow, err := internal.NewWorkflow(t.Context(), cfg, git, gh, builder, internal.NopLogger{})
+	if err != nil {
+		t.Fatalf("NewWorkflow() error: %v", err)
+	}
+	if err := workflow.Run(t.Context()); err != nil {
+		t.Fatalf("workflow.Run() error: %v", err)
+	}
+
+	if git.checkoutCount != 1 {
+		t.Fatalf("expected checkout to be called once, got %d", git.checkoutCount)
+	}
+	if git.pullCount != 1 {
+		t.Fatalf("expected pull to be called once, got %d", git.pullCount)
+	}
+	if gh.target != "release/studioctl/v1.2" {
+		t.Fatalf("target = %s, want release/studioctl/v1.2", gh.target)
+	}
+}
+
+func TestWorkflow_Run_CleansOutputDirBeforeCollectingAssets(t *testing.T) {
+	t.Parallel()
+
+	changelogPath := writeChangelog(t, `# Changelog
+
+## [Unreleased]
+
+## [v1.2.3] - 2025-01-01
+
+### Added
+
+- Test entry
+`)
+
+	outputDir := t.TempDir()
+	staleAsset := filepath.Join(outputDir, "stale.bin")
+	if err := os.WriteFile(staleAsset, []byte("stale"), 0o644); err != nil {
+		t.Fatalf("write stale asset: %v", err)
+	}
+	// Left behind by an earlier build, so the directory may be wiped without asking.
+	if err := os.WriteFile(filepath.Join(outputDir, ".releaser-output"), []byte("studioctl/v1.2.2\n"), 0o644); err != nil {
+		t.Fatalf("write output marker: %v", err)
+	}
+
+	builder := &fakeBuilder{}
+	gh := &fakeGH{}
+	git := &fakeGit{
+		currentBranch:      "main",
+		remoteBranchExists: true,
+		workingTreeClean:   true,
+	}
+
+	cfg := internal.WorkflowConfig{
+		Component:     "studioctl",
+		Version:       "v1.2.3",
+		ChangelogPath: changelogPath,
+		OutputDir:     outputDir,
+		DryRun:        false,
+		Draft:         true,
+		RepoRoot:      os.TempDir(),
+	}
+
+	workflow, err := internal.NewWorkflow(t.Context(), cfg, git, gh, builder, internal.NopLogger{})
+	if err != nil {
+		t.Fatalf("NewWorkflow() error: %v", err)
+	}
+	if err := workflow.Run(t.Context()); err != nil {
+		t.Fatalf("workflow.Run() error: %v", err)
+	}
+
+	for _, asset := range gh.assets {
+		if name := filepath.Base(asset); name == "stale.bin" || name == ".releaser-output" {
+			t.Fatalf("unexpected asset was uploaded: %s", asset)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, ".releaser-output")); err != nil {
+		t.Fatalf("output marker missing after build: %v", err)
+	}
+}
+
+func TestWorkflow_Run_GuardsForeignOutputDir(t *testing.T) {
+	t.Parallel()
+
+	changelogPath := writeChangelog(t, `# Changelog
+
+## [Unreleased]
+
+## [1.2.3-preview.1] - 2025-01-01
+
+### Added
+
+- Test entry
+`)
+
+	tests := []struct {
+		prompter  *scriptedPrompter
+		wantErr   error
+		name      string
+		ci        bool
+		wantClean bool
+	}{
+		{name: "refused in CI", ci: true, prompter: &scriptedPrompter{answers: []bool{true}}, wantErr: internal.ErrOutputDirNotManaged},
+		{name: "refused without prompter", prompter: nil, wantErr: internal.ErrOutputDirNotManaged},
+		{name: "declined", prompter: &scriptedPrompter{answers: []bool{false}}, wantErr: internal.ErrActionNotConfirmed},
+		{name: "confirmed", prompter: &scriptedPrompter{answers: []bool{true}}, wantClean: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			outputDir := t.TempDir()
+			foreign := filepath.Join(outputDir, "notes.txt")
+			if err := os.WriteFile(foreign, []byte("keep me"), 0o644); err != nil {
+				t.Fatalf("write foreign file: %v", err)
+			}
+
+			cfg := internal.WorkflowConfig{
+				Component:     "studioctl",
+				Version:       "v1.2.3-preview.1",
+				ChangelogPath: changelogPath,
+				OutputDir:     outputDir,
+				Draft:         true,
+				RepoRoot:      os.TempDir(),
+				CI:            tc.ci,
+			}
+			if tc.prompter != nil {
+				cfg.Prompter = tc.prompter
+			}
+			workflow, err := internal.NewWorkflow(t.Context(),
+				cfg,
+				&fakeGit{currentBranch: "main", workingTreeClean: true},
+				&fakeGH{},
+				&fakeBuilder{},
+				internal.NopLogger{},
+			)
+			if err != nil {
+				t.Fatalf("NewWorkflow() error: %v", err)
+			}
+
+			err = workflow.Run(t.Context())
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("workflow.Run() error = %v, want %v", err, tc.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("workflow.Run() error: %v", err)
+			}
+
+			_, statErr := os.Stat(foreign)
+			if cleaned := errors.Is(statErr, os.ErrNotExist); cleaned != tc.wantClean {
+				t.Errorf("foreign file removed = %v, want %v", cleaned, tc.wantClean)
+			}
+			if tc.ci && len(tc.prompter.calls) != 0 {
+				t.Errorf("prompted %d time(s) in CI, want none", len(tc.prompter.calls))
+			}
+		})
+	}
+}
+
+func TestWorkflow_Run_WritesReleaseSummary(t *testing.T) {
+	t.Parallel()
+
+	changelogPath := writeChangelog(t, `# Changelog
+
+## [Unreleased]
+
+## [1.2.3-preview.1] - 2025-01-01
+
+### Added
+
+- Test entry
+`)
+
+	outputDir := t.TempDir()
+	gh := &fakeGH{}
+	cfg := internal.WorkflowConfig{
+		Component:     "studioctl",
+		Version:       "v1.2.3-preview.1",
+		ChangelogPath: changelogPath,
+		OutputDir:     outputDir,
+		DryRun:        false,
+		Draft:         true,
+		RepoRoot:      os.TempDir(),
+	}
+
+	workflow, err := internal.NewWorkflow(t.Context(),
+		cfg,
+		&fakeGit{currentBranch: "main", workingTreeClean: true},
+		gh,
+		&fakeBuilder{},
+		internal.NopLogger{},
+	)
+	if err != nil {
+		t.Fatalf("NewWorkflow() error: %v", err)
+	}
+	if err := workflow.Run(t.Context()); err != nil {
+		t.Fatalf("workflow.Run() error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "release-summary.json"))
+	if err != nil {
+		t.Fatalf("read release summary: %v", err)
+	}
+	var got internal.ReleaseSummary
+	if err := json.Unmarshal(content, &got); err != nil {
+		t.Fatalf("unmarshal release summary: %v", err)
+	}
+
+	const wantTag = "studioctl/v1.2.3-preview.1"
+	if got.Component != "studioctl" || got.Version != "v1.2.3-preview.1" || got.Tag != wantTag {
+		t.Fatalf("summary identity = %s %s %s, want studioctl v1.2.3-preview.1 %s",
+			got.Component, got.Version, got.Tag, wantTag)
+	}
+	if got.Target != gh.target || got.Target != "main" {
+		t.Fatalf("summary target = %q, want main (release target %q)", got.Target, gh.target)
+	}
+	if !got.Prerelease || !got.Draft || got.DryRun {
+		t.Fatalf("summary flags prerelease=%v draft=%v dryRun=%v, want true true false",
+			got.Prerelease, got.Draft, got.DryRun)
+	}
+	if !slices.Equal(got.Assets, []string{"dummy-asset"}) {
+		t.Fatalf("summary assets = %v, want [dummy-asset]", got.Assets)
+	}
+	if got.URL != fakeReleaseURL+wantTag {
+		t.Fatalf("summary url = %q, want %q", got.URL, fakeReleaseURL+wantTag)
+	}
+	for _, asset := range gh.assets {
+		if filepath.Base(asset) == "release-summary.json" {
+			t.Fatalf("release summary was uploaded as an asset: %s", asset)
+		}
+	}
+}
+
+func TestWorkflow_Run_CreateReleaseTimeoutDoesNotDuplicate(t *testing.T) {
+	t.Parallel()
+
+	changelogPath := writeChangelog(t, "# Changelog\n\n## [Unreleased]\n\n"+
+		"## [v1.2.3] - 2025-01-01\n\n### Added\n\n- Test entry\n")
+	gh := &fakeGH{createTimesOut: true}
+	cfg := internal.WorkflowConfig{
+		Component:     "studioctl",
+		Version:       "v1.2.3",
+		ChangelogPath: changelogPath,
+		OutputDir:     t.TempDir(),
+		RepoRoot:      os.TempDir(),
+		Draft:         true,
+	}
+	git := &fakeGit{currentBranch: "main", remoteBranchExists: true, workingTreeClean: true}
+	workflow, err := internal.NewWorkflow(t.Context(), cfg, git, gh, &fakeBuilder{}, internal.NopLogger{})
+	if err != nil {
+		t.Fatalf("NewWorkflow() error: %v", err)
+	}
+
+	if err := workflow.Run(t.Context()); err != nil {
+		t.Fatalf("workflow.Run() error: %v", err)
+	}
+	if gh.createCalls != 1 {
+		t.Fatalf("CreateRelease called %d times, want 1 (the retry should find the created release)", gh.createCalls)
+	}
+}
+
+func TestWorkflow_Run_VerifyReleaseDetectsMissingAsset(t *testing.T) {
+	t.Parallel()
+
+	changelogPath := writeChangelog(t, `# Changelog
+
+## [Unreleased]
+
+## [v1.2.3] - 2025-01-01
+
+### Added
+
+- Test entry
+`)
+
+	tests := []struct {
+		name       string
+		skipVerify bool
+		wantErr    bool
+	}{
+		{name: "verification fails on missing asset", skipVerify: false, wantErr: true},
+		{name: "verification skipped", skipVerify: true, wantErr: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gh := &fakeGH{droppedAsset: "dummy-asset"}
+			git := &fakeGit{
+				currentBranch:      "main",
+				remoteBranchExists: true,
+				workingTreeClean:   true,
+			}
+
+			cfg := internal.WorkflowConfig{
+				Component:         "studioctl",
+				Version:           "v1.2.3",
+				ChangelogPath:     changelogPath,
+				OutputDir:         t.TempDir(),
+				DryRun:            false,
+				Draft:             true,
+				RepoRoot:          os.TempDir(),
+				SkipVerifyRelease: tc.skipVerify,
+			}
+
+			workflow, err := internal.NewWorkflow(t.Context(), cfg, git, gh, &fakeBuilder{}, internal.NopLogger{})
+			if err != nil {
+				t.Fatalf("NewWorkflow() error: %v", err)
+			}
+			err = workflow.Run(t.Context())
+
+			if !tc.wantErr {
+				if err != nil {
+					t.Fatalf("workflow.Run() error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, internal.ErrReleaseAssetsMissing) {
+				t.Fatalf("error = %v, want %v", err, internal.ErrReleaseAssetsMissing)
+			}
+			if !strings.Contains(err.Error(), "dummy-asset") {
+				t.Fatalf("error = %v, want missing asset name in message", err)
+			}
+		})
+	}
+}
+
+func TestWorkflow_Run_AnnotatedTag(t *testing.T) {
+	t.Parallel()
+
+	changelogPath := writeChangelog(t, `# Changelog
+
+## [Unreleased]
+
+## [v1.2.3] - 2025-01-01
+
+### Added
+
+- Test entry
+`)
+
+	tests := []struct {
+		createErr error
+		name      string
+		tagExists bool
+		sign      bool
+	}{
+		{name: "annotated tag created at release commit", tagExists: false, sign: false},
+		{name: "sign implies annotated", tagExists: false, sign: true},
+		{name: "existing tag is rejected", tagExists: true, sign: false},
+		{name: "tag deleted when release fails", createErr: errors.New("HTTP 422"), sign: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gh := &fakeGH{createErr: tc.createErr}
+			git := &fakeGit{
+				currentBranch:      "main",
+				remoteBranchExists: true,
+				workingTreeClean:   true,
+				tagExists:          tc.tagExists,
+			}
+
+			cfg := internal.WorkflowConfig{
+				Component:     "studioctl",
+				Version:       "v1.2.3",
+				ChangelogPath: changelogPath,
+				OutputDir:     t.TempDir(),
+				RepoRoot:      os.TempDir(),
+				Draft:         true,
+				AnnotatedTag:  !tc.sign,
+				SignTag:       tc.sign,
+			}
+
+			workflow, err := internal.NewWorkflow(t.Context(), cfg, git, gh, &fakeBuilder{}, internal.NopLogger{})
+			if err != nil {
+				t.Fatalf("NewWorkflow() error: %v", err)
+			}
+			err = workflow.Run(t.Context())
+
+			if tc.tagExists {
+				if !errors.Is(err, internal.ErrTagExists) {
+					t.Fatalf("error = %v, want %v", err, internal.ErrTagExists)
+				}
+				if git.annotatedTag != nil {
+					t.Fatalf("annotated tag created despite existing tag")
+				}
+				return
+			}
+			if tc.createErr != nil {
+				if !errors.Is(err, tc.createErr) {
+					t.Fatalf("error = %v, want %v", err, tc.createErr)
+				}
+				if git.deletedTag != "studioctl/v1.2.3" {
+					t.Fatalf("deleted tag = %q, want studioctl/v1.2.3", git.deletedTag)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("workflow.Run() error: %v", err)
+			}
+			if git.deletedTag != "" {
+				t.Fatalf("tag %s deleted after a successful release", git.deletedTag)
+			}
+
+			if git.annotatedTag == nil {
+				t.Fatalf("expected annotated tag to be created")
+			}
+			want := internal.AnnotatedTagOptions{
+				Name:    "studioctl/v1.2.3",
+				Target:  fakeCommit,
+				Message: "studioctl v1.2.3\n\n### Added\n\n- Test entry",
+				Sign:    tc.sign,
+			}
+			if *git.annotatedTag != want {
+				t.Fatalf("annotated tag = %+v, want %+v", *git.annotatedTag, want)
+			}
+			if !gh.opts.VerifyTag {
+				t.Fatalf("expected release to reference the existing tag")
+			}
+		})
+	}
+}
+
+func TestWorkflow_Run_DryRunPreviewsReleaseNotes(t *testing.T) {
+	t.Parallel()
+
+	changelogPath := writeChangelog(t, `# Changelog
+
+## [Unreleased]
+
+## [v1.2.3] - 2025-01-01
+
+### Added
+
+- Preview entry
+`)
+
+	outputDir := t.TempDir()
+	var logs strings.Builder
+	log := internal.NewConsoleLogger(internal.WithWriters(&logs, &logs))
+	gh := &fakeGH{}
+
+	cfg := internal.WorkflowConfig{
+		Component:     "studioctl",
+		Version:       "v1.2.3",
+		ChangelogPath: changelogPath,
+		OutputDir:     outputDir,
+		DryRun:        true,
+		RepoRoot:      os.TempDir(),
+	}
+
+	workflow, err := internal.NewWorkflow(
+		t.Context(),
+		cfg,
+		&fakeGit{currentBranch: "main", remoteBranchExists: true, workingTreeClean: true},
+		gh,
+		&fakeBuilder{},
+		log,
+	)
+	if err != nil {
+		t.Fatalf("NewWorkflow() error: %v", err)
+	}
+	if err := workflow.Run(t.Context()); err != nil {
+		t.Fatalf("workflow.Run() error: %v", err)
+	}
+
+	output := logs.String()
+	for _, want := range []string{"Release notes for v1.2.3:", "### Added", "- Preview entry"} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("dry-run log missing %q:\n%s", want, output)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "release-notes.md")); !os.IsNotExist(err) {
+		t.Fatalf("release notes file written during dry run (stat error: %v)", err)
+	}
+	if gh.called {
+		t.Fatalf("expected no release to be created during dry run")
+	}
+}
+
+func TestWorkflow_Run_DecoratesReleaseNotesOnly(t *testing.T) {
+	t.Parallel()
+
+	const content = `# Changelog
+
+## [Unreleased]
+
+## [v1.2.3] - 2025-01-01
+
+### Added
+
+- Decorated entry
+
+### Fixed
+
+- Plain entry
+`
+	changelogPath := writeChangelog(t, content)
+	outputDir := t.TempDir()
+
+	cfg := internal.WorkflowConfig{
+		Component:       "studioctl",
+		Version:         "v1.2.3",
+		ChangelogPath:   changelogPath,
+		OutputDir:       outputDir,
+		RepoRoot:        os.TempDir(),
+		Draft:           true,
+		CategoryHeaders: map[string]string{"Added": "✨ Added"},
+	}
+
+	git := &fakeGit{currentBranch: "main", remoteBranchExists: true, workingTreeClean: true}
+	workflow, err := internal.NewWorkflow(t.Context(), cfg, git, &fakeGH{}, &fakeBuilder{}, internal.NopLogger{})
+	if err != nil {
+		t.Fatalf("NewWorkflow() error: %v", err)
+	}
+	if err := workflow.Run(t.Context()); err != nil {
+		t.Fatalf("workflow.Run() error: %v", err)
+	}
+
+	notes, err := os.ReadFile(filepath.Join(outputDir, "release-notes.md"))
+	if err != nil {
+		t.Fatalf("read release notes: %v", err)
+	}
+	if !strings.Contains(string(notes), "### ✨ Added") || !strings.Contains(string(notes), "### Fixed") {
+		t.Fatalf("release notes missing decorated headers:\n%s", notes)
+	}
+
+	committed, err := os.ReadFile(changelogPath)
+	if err != nil {
+		t.Fatalf("read changelog: %v", err)
+	}
+	if string(committed) != content {
+		t.Fatalf("changelog was modified:\n%s", committed)
+	}
+
+	cl, err := changelog.Parse(string(committed))
 	if err != nil {
-		t.Fatalf("NewWorkflow() error: %v", err)
+		t.Fatalf("parse changelog: %v", err)
 	}
-	err = workflow.Run(t.Context())
+	rendered := cl.String()
+	if strings.Contains(rendered, "✨") || !strings.Contains(rendered, "### Added") {
+		t.Fatalf("rendered changelog should stay plain:\n%s", rendered)
+	}
+}
 
-	if err == nil {
-		t.Fatalf("expected error, got nil")
+func TestDefaultCategoryHeaders_ReturnsFreshMap(t *testing.T) {
+	t.Parallel()
+
+	headers := internal.DefaultCategoryHeaders()
+	headers["Added"] = "Changed by caller"
+	if got := internal.DefaultCategoryHeaders()["Added"]; got != "✨ Added" {
+		t.Fatalf("DefaultCategoryHeaders()[Added] = %q after caller modification, want %q", got, "✨ Added")
 	}
-	if !errors.Is(err, internal.ErrChangelogMissing) {
-		t.Fatalf("error = %v, want %v", err, internal.ErrChangelogMissing)
+}
+
+func TestParseCategoryHeader(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		raw          string
+		wantCategory string
+		wantHeader   string
+		wantErr      bool
+	}{
+		{name: "simple", raw: "Fixed=Bug fixes", wantCategory: "Fixed", wantHeader: "Bug fixes"},
+		{name: "header keeps equals", raw: "Added=a=b", wantCategory: "Added", wantHeader: "a=b"},
+		{name: "trims spaces", raw: " Fixed = 🐛 Fixed ", wantCategory: "Fixed", wantHeader: "🐛 Fixed"},
+		{name: "missing equals", raw: "Fixed", wantErr: true},
+		{name: "empty header", raw: "Fixed=", wantErr: true},
+		{name: "empty category", raw: "=Bug fixes", wantErr: true},
 	}
-	if builder.called {
-		t.Fatalf("expected no build calls, got build called")
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			category, header, err := internal.ParseCategoryHeader(tc.raw)
+			if tc.wantErr {
+				if !errors.Is(err, internal.ErrInvalidCategoryHeader) {
+					t.Fatalf("ParseCategoryHeader(%q) error = %v, want %v",
+						tc.raw, err, internal.ErrInvalidCategoryHeader)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCategoryHeader(%q) error = %v", tc.raw, err)
+			}
+			if category != tc.wantCategory || header != tc.wantHeader {
+				t.Fatalf("ParseCategoryHeader(%q) = %q, %q, want %q, %q",
+					tc.raw, category, header, tc.wantCategory, tc.wantHeader)
+			}
+		})
 	}
 }
 
-func TestWorkflow_Run_StableChecksOutReleaseBranch(t *testing.T) {
+func TestWorkflow_Run_NotesStyle(t *testing.T) {
 	t.Parallel()
 
-	changelogPath := writeChangelog(t, `# Changelog
+	const content = `# Changelog
 
 ## [Unreleased]
 
@@ -126,29 +895,27 @@ func TestWorkflow_Run_StableChecksOutReleaseBranch(t *testing.T) {
 
 ### Added
 
-- Test entry
-`)
+- New entry
 
+### Fixed
+
+- Fixed entry
+`
+	changelogPath := writeChangelog(t, content)
 	outputDir := t.TempDir()
-	builder := &fakeBuilder{}
-	gh := &fakeGH{}
-	git := &fakeGit{
-		currentBranch:      "main",
-		remoteBranchExists: true,
-		workingTreeClean:   true,
-	}
 
 	cfg := internal.WorkflowConfig{
 		Component:     "studioctl",
 		Version:       "v1.2.3",
 		ChangelogPath: changelogPath,
 		OutputDir:     outputDir,
-		DryRun:        false,
-		Draft:         true,
 		RepoRoot:      os.TempDir(),
+		Draft:         true,
+		NotesStyle:    changelog.NotesStyleCompact,
 	}
 
-	workflow, err := internal.NewWorkflow(t.Context(), cfg, git, gh, builder, internal.NopLogger{})
+	git := &fakeGit{currentBranch: "main", remoteBranchExists: true, workingTreeClean: true}
+	workflow, err := internal.NewWorkflow(t.Context(), cfg, git, &fakeGH{}, &fakeBuilder{}, internal.NopLogger{})
 	if err != nil {
 		t.Fatalf("NewWorkflow() error: %v", err)
 	}
@@ -156,56 +923,119 @@ func TestWorkflow_Run_StableChecksOutReleaseBranch(t *testing.T) {
 		t.Fatalf("workflow.Run() error: %v", err)
 	}
 
-	if git.checkoutCount != 1 {
-		t.Fatalf("expected checkout to be called once, got %d", git.checkoutCount)
+	notes, err := os.ReadFile(filepath.Join(outputDir, "release-notes.md"))
+	if err != nil {
+		t.Fatalf("read release notes: %v", err)
 	}
-	if git.pullCount != 1 {
-		t.Fatalf("expected pull to be called once, got %d", git.pullCount)
+	if want := "- [Added] New entry\n- [Fixed] Fixed entry"; string(notes) != want {
+		t.Fatalf("release notes =\n%s\nwant:\n%s", notes, want)
 	}
-	if gh.target != "release/studioctl/v1.2" {
-		t.Fatalf("target = %s, want release/studioctl/v1.2", gh.target)
+
+	cfg.NotesStyle = "html"
+	if _, err := internal.NewWorkflow(t.Context(), cfg, git, &fakeGH{}, &fakeBuilder{}, internal.NopLogger{}); !errors.Is(
+		err,
+		changelog.ErrInvalidNotesStyle,
+	) {
+		t.Fatalf("NewWorkflow(html) error = %v, want %v", err, changelog.ErrInvalidNotesStyle)
 	}
 }
 
-func TestWorkflow_Run_CleansOutputDirBeforeCollectingAssets(t *testing.T) {
+func TestWorkflow_Run_SincePrerelease(t *testing.T) {
 	t.Parallel()
 
-	changelogPath := writeChangelog(t, `# Changelog
+	const content = `# Changelog
 
 ## [Unreleased]
 
-## [v1.2.3] - 2025-01-01
+## [v1.3.0] - 2025-03-01
 
 ### Added
 
-- Test entry
-`)
+- Preview feature
+- Stable feature
 
-	outputDir := t.TempDir()
-	staleAsset := filepath.Join(outputDir, "stale.bin")
-	if err := os.WriteFile(staleAsset, []byte("stale"), 0o644); err != nil {
-		t.Fatalf("write stale asset: %v", err)
+## [v1.3.0-preview.1] - 2025-02-01
+
+### Added
+
+- Preview feature
+`
+	tests := []struct {
+		name   string
+		filter changelog.PrereleaseFilter
+		want   string
+	}{
+		{name: "default includes", filter: "", want: "### Added\n\n- Preview feature\n- Stable feature"},
+		{name: "exclude", filter: changelog.PrereleaseFilterExclude, want: "### Added\n\n- Stable feature"},
 	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
 
-	builder := &fakeBuilder{}
-	gh := &fakeGH{}
-	git := &fakeGit{
-		currentBranch:      "main",
-		remoteBranchExists: true,
-		workingTreeClean:   true,
+			outputDir := t.TempDir()
+			cfg := internal.WorkflowConfig{
+				Component:       "studioctl",
+				Version:         "v1.3.0",
+				ChangelogPath:   writeChangelog(t, content),
+				OutputDir:       outputDir,
+				RepoRoot:        os.TempDir(),
+				Draft:           true,
+				SincePrerelease: tt.filter,
+			}
+
+			git := &fakeGit{currentBranch: "release/studioctl/v1.3", remoteBranchExists: true, workingTreeClean: true}
+			workflow, err := internal.NewWorkflow(t.Context(), cfg, git, &fakeGH{}, &fakeBuilder{}, internal.NopLogger{})
+			if err != nil {
+				t.Fatalf("NewWorkflow() error: %v", err)
+			}
+			if err := workflow.Run(t.Context()); err != nil {
+				t.Fatalf("workflow.Run() error: %v", err)
+			}
+
+			notes, err := os.ReadFile(filepath.Join(outputDir, "release-notes.md"))
+			if err != nil {
+				t.Fatalf("read release notes: %v", err)
+			}
+			if strings.TrimSpace(string(notes)) != tt.want {
+				t.Fatalf("release notes =\n%s\nwant:\n%s", notes, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkflow_Run_NotesFile(t *testing.T) {
+	t.Parallel()
+
+	const content = `# Changelog
+
+## [Unreleased]
+
+## [v1.2.3] - 2025-01-01
+
+### Added
+
+- Changelog entry
+`
+	const curated = "## Highlights\n\nHand-written notes for v1.2.3.\n"
+	notesPath := filepath.Join(t.TempDir(), "notes.md")
+	if err := os.WriteFile(notesPath, []byte(curated), 0o600); err != nil {
+		t.Fatalf("write notes file: %v", err)
 	}
 
+	outputDir := t.TempDir()
 	cfg := internal.WorkflowConfig{
 		Component:     "studioctl",
 		Version:       "v1.2.3",
-		ChangelogPath: changelogPath,
+		ChangelogPath: writeChangelog(t, content),
 		OutputDir:     outputDir,
-		DryRun:        false,
-		Draft:         true,
 		RepoRoot:      os.TempDir(),
+		Draft:         true,
+		NotesFile:     notesPath,
 	}
 
-	workflow, err := internal.NewWorkflow(t.Context(), cfg, git, gh, builder, internal.NopLogger{})
+	git := &fakeGit{currentBranch: "main", remoteBranchExists: true, workingTreeClean: true}
+	gh := &fakeGH{}
+	workflow, err := internal.NewWorkflow(t.Context(), cfg, git, gh, &fakeBuilder{}, internal.NopLogger{})
 	if err != nil {
 		t.Fatalf("NewWorkflow() error: %v", err)
 	}
@@ -213,10 +1043,241 @@ func TestWorkflow_Run_CleansOutputDirBeforeCollectingAssets(t *testing.T) {
 		t.Fatalf("workflow.Run() error: %v", err)
 	}
 
-	for _, asset := range gh.assets {
-		if filepath.Base(asset) == "stale.bin" {
-			t.Fatalf("stale asset was uploaded: %s", asset)
-		}
+	uploaded, err := os.ReadFile(gh.opts.NotesFile)
+	if err != nil {
+		t.Fatalf("read uploaded notes: %v", err)
+	}
+	if want := strings.TrimSpace(curated); string(uploaded) != want {
+		t.Fatalf("uploaded notes =\n%s\nwant:\n%s", uploaded, want)
+	}
+
+	// The changelog section is still required.
+	cfg.Version = "v1.2.4"
+	cfg.OutputDir = t.TempDir()
+	workflow, err = internal.NewWorkflow(t.Context(), cfg, git, &fakeGH{}, &fakeBuilder{}, internal.NopLogger{})
+	if err != nil {
+		t.Fatalf("NewWorkflow() error: %v", err)
+	}
+	if err := workflow.Run(t.Context()); !errors.Is(err, internal.ErrChangelogMissing) {
+		t.Fatalf("workflow.Run() without changelog section error = %v, want %v", err, internal.ErrChangelogMissing)
+	}
+}
+
+func TestWorkflow_Run_GitHubNotAuthenticated(t *testing.T) {
+	t.Parallel()
+
+	const content = `# Changelog
+
+## [Unreleased]
+
+## [v1.2.3] - 2025-01-01
+
+### Added
+
+- Entry
+`
+	authErr := fmt.Errorf("%w: gh auth status: You are not logged into any GitHub hosts", internal.ErrGitHubNotAuthenticated)
+	for _, dryRun := range []bool{false, true} {
+		t.Run(fmt.Sprintf("dry-run=%t", dryRun), func(t *testing.T) {
+			t.Parallel()
+
+			cfg := internal.WorkflowConfig{
+				Component:     "studioctl",
+				Version:       "v1.2.3",
+				ChangelogPath: writeChangelog(t, content),
+				OutputDir:     t.TempDir(),
+				RepoRoot:      os.TempDir(),
+				DryRun:        dryRun,
+				Draft:         true,
+			}
+			git := &fakeGit{currentBranch: "main", remoteBranchExists: true, workingTreeClean: true}
+			gh := &fakeGH{authErr: authErr}
+			builder := &fakeBuilder{}
+			throttled := internal.NewThrottledGitHub(gh)
+			workflow, err := internal.NewWorkflow(t.Context(), cfg, git, throttled, builder, internal.NopLogger{})
+			if err != nil {
+				t.Fatalf("NewWorkflow() error: %v", err)
+			}
+			err = workflow.Run(t.Context())
+
+			if dryRun {
+				if err != nil {
+					t.Fatalf("workflow.Run() dry-run error = %v, want only a warning", err)
+				}
+				return
+			}
+			if !errors.Is(err, internal.ErrGitHubNotAuthenticated) {
+				t.Fatalf("workflow.Run() error = %v, want %v", err, internal.ErrGitHubNotAuthenticated)
+			}
+			if strings.Count(err.Error(), "check gh authentication") != 1 {
+				t.Errorf("workflow.Run() error = %q, want the auth check prefix once", err)
+			}
+			if builder.called || gh.called {
+				t.Fatal("workflow should stop before building or creating the release")
+			}
+		})
+	}
+}
+
+func TestWorkflow_Run_VersionRegression(t *testing.T) {
+	t.Parallel()
+
+	published := []string{"studioctl/v1.1.2", "studioctl/v1.2.0", "studioctl/v1.3.0-preview.1", "other/v9.0.0"}
+	tests := []struct {
+		wantErr         error
+		name            string
+		version         string
+		allowRegression bool
+	}{
+		{name: "older line", version: "v1.1.0", wantErr: internal.ErrVersionRegression},
+		{name: "older prerelease", version: "v1.3.0-preview.0", wantErr: internal.ErrVersionRegression},
+		{name: "backfill allowed", version: "v1.1.0", allowRegression: true},
+		{name: "patch on older line", version: "v1.1.3"},
+		{name: "next prerelease", version: "v1.3.0-preview.2"},
+		{name: "new line", version: "v1.4.0-preview.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			changelogPath := writeChangelog(t, fmt.Sprintf(`# Changelog
+
+## [Unreleased]
+
+## [%s] - 2025-01-01
+
+### Added
+
+- Entry
+`, tt.version))
+
+			cfg := internal.WorkflowConfig{
+				Component:       "studioctl",
+				Version:         tt.version,
+				ChangelogPath:   changelogPath,
+				OutputDir:       t.TempDir(),
+				RepoRoot:        os.TempDir(),
+				DryRun:          true,
+				AllowRegression: tt.allowRegression,
+			}
+			git := &fakeGit{currentBranch: "main", remoteBranchExists: true, workingTreeClean: true, tags: published}
+			workflow, err := internal.NewWorkflow(t.Context(), cfg, git, &fakeGH{}, &fakeBuilder{}, internal.NopLogger{})
+			if err != nil {
+				t.Fatalf("NewWorkflow() error: %v", err)
+			}
+
+			err = workflow.Run(t.Context())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("workflow.Run() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWorkflow_Run_BranchBehindRemote(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		wantErr       error
+		name          string
+		commitsBehind int
+		allowBehind   bool
+	}{
+		{name: "behind origin", commitsBehind: 2, wantErr: internal.ErrBranchBehindRemote},
+		{name: "behind allowed", commitsBehind: 2, allowBehind: true},
+		{name: "up to date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			changelogPath := writeChangelog(t, `# Changelog
+
+## [Unreleased]
+
+## [v1.3.0-preview.1] - 2025-01-01
+
+### Added
+
+- Entry
+`)
+			cfg := internal.WorkflowConfig{
+				Component:     "studioctl",
+				Version:       "v1.3.0-preview.1",
+				ChangelogPath: changelogPath,
+				OutputDir:     t.TempDir(),
+				RepoRoot:      os.TempDir(),
+				DryRun:        true,
+				AllowBehind:   tt.allowBehind,
+			}
+			git := &fakeGit{
+				currentBranch:      "main",
+				remoteBranchExists: true,
+				workingTreeClean:   true,
+				commitsBehind:      tt.commitsBehind,
+			}
+			builder := &fakeBuilder{}
+			workflow, err := internal.NewWorkflow(t.Context(), cfg, git, &fakeGH{}, builder, internal.NopLogger{})
+			if err != nil {
+				t.Fatalf("NewWorkflow() error: %v", err)
+			}
+
+			err = workflow.Run(t.Context())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("workflow.Run() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil && builder.called {
+				t.Fatal("workflow should stop before building")
+			}
+		})
+	}
+}
+
+func TestWorkflow_Run_CheckUnreleasedEmpty(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		unreleased string
+		wantWarn   bool
+	}{
+		{name: "emptied by promotion", unreleased: ""},
+		{name: "entries left behind", unreleased: "### Fixed\n\n- Merged back by mistake\n\n", wantWarn: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			changelogPath := writeChangelog(t, "# Changelog\n\n## [Unreleased]\n\n"+tt.unreleased+
+				"## [v1.3.0-preview.1] - 2025-01-01\n\n### Added\n\n- Entry\n")
+			cfg := internal.WorkflowConfig{
+				Component:            "studioctl",
+				Version:              "v1.3.0-preview.1",
+				ChangelogPath:        changelogPath,
+				OutputDir:            t.TempDir(),
+				RepoRoot:             os.TempDir(),
+				DryRun:               true,
+				CheckUnreleasedEmpty: true,
+			}
+			git := &fakeGit{currentBranch: "main", remoteBranchExists: true, workingTreeClean: true}
+			buf := &bytes.Buffer{}
+			log := internal.NewConsoleLogger(internal.WithWriters(buf, buf), internal.WithColor(false))
+			workflow, err := internal.NewWorkflow(t.Context(), cfg, git, &fakeGH{}, &fakeBuilder{}, log)
+			if err != nil {
+				t.Fatalf("NewWorkflow() error: %v", err)
+			}
+
+			if err := workflow.Run(t.Context()); err != nil {
+				t.Fatalf("workflow.Run() error = %v", err)
+			}
+			out := buf.String()
+			if got := strings.Contains(out, "WARNING: [Unreleased] on main is not empty"); got != tt.wantWarn {
+				t.Fatalf("warning logged = %v, want %v; output:\n%s", got, tt.wantWarn, out)
+			}
+			if tt.wantWarn && !strings.Contains(out, "Fixed: Merged back by mistake") {
+				t.Errorf("output does not list the leftover entry:\n%s", out)
+			}
+		})
 	}
 }
 
@@ -270,6 +1331,69 @@ func TestWorkflow_Run_DirtyWorkingTree(t *testing.T) {
 	}
 }
 
+func TestWorkflow_Run_AllowDirty(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		wantErr error
+		name    string
+		ci      bool
+	}{
+		{name: "honored locally", ci: false, wantErr: nil},
+		{name: "refused in CI", ci: true, wantErr: internal.ErrAllowDirtyInCI},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			changelogPath := writeChangelog(t, `# Changelog
+
+## [Unreleased]
+
+## [v1.2.3] - 2025-01-01
+
+### Added
+
+- Test entry
+`)
+			git := &fakeGit{
+				currentBranch:      "main",
+				remoteBranchExists: true,
+				workingTreeClean:   false,
+			}
+			cfg := internal.WorkflowConfig{
+				Component:     "studioctl",
+				Version:       "v1.2.3",
+				ChangelogPath: changelogPath,
+				OutputDir:     t.TempDir(),
+				DryRun:        true,
+				Draft:         true,
+				RepoRoot:      os.TempDir(),
+				CI:            tt.ci,
+				AllowDirty:    true,
+			}
+
+			workflow, err := internal.NewWorkflow(t.Context(), cfg, git, &fakeGH{}, &fakeBuilder{}, internal.NopLogger{})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("NewWorkflow() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewWorkflow() error: %v", err)
+			}
+			if err := workflow.Run(t.Context()); err != nil {
+				t.Fatalf("workflow.Run() error = %v, want dirty tree allowed", err)
+			}
+			if git.checkoutCount != 1 {
+				t.Fatalf("expected checkout to be called once, got %d", git.checkoutCount)
+			}
+		})
+	}
+}
+
 func TestNewWorkflow_InvalidComponent(t *testing.T) {
 	t.Parallel()
 
@@ -411,12 +1535,18 @@ func TestNewWorkflow_OutputDirSafety_RejectsSymlinkEscape(t *testing.T) {
 	}
 }
 
+const fakeCommit = "0123456789abcdef0123456789abcdef01234567"
+
 type fakeGit struct {
+	annotatedTag       *internal.AnnotatedTagOptions
+	deletedTag         string
 	currentBranch      string
 	lastCheckout       string
 	lastPull           string
+	tags               []string
 	checkoutCount      int
 	pullCount          int
+	commitsBehind      int
 	tagExists          bool
 	remoteBranchExists bool
 	workingTreeClean   bool
@@ -426,6 +1556,25 @@ func (g *fakeGit) TagExists(_ context.Context, _ string) (bool, error) {
 	return g.tagExists, nil
 }
 
+func (g *fakeGit) CommitsBehind(_ context.Context, _ string) (int, error) {
+	return g.commitsBehind, nil
+}
+
+func (g *fakeGit) ListTags(_ context.Context, prefix string) ([]*version.Version, error) {
+	var versions []*version.Version
+	for _, tag := range g.tags {
+		rest, ok := strings.CutPrefix(tag, prefix)
+		if !ok {
+			continue
+		}
+		if ver, err := version.Parse(rest); err == nil {
+			versions = append(versions, ver)
+		}
+	}
+	slices.SortFunc(versions, version.Compare)
+	return versions, nil
+}
+
 func (g *fakeGit) CurrentBranch(_ context.Context) (string, error) {
 	if g.currentBranch == "" {
 		return "main", nil
@@ -461,6 +1610,20 @@ func (g *fakeGit) RepoRoot(_ context.Context) (string, error) {
 	return ".", nil
 }
 
+func (g *fakeGit) CreateAnnotatedTag(_ context.Context, opts internal.AnnotatedTagOptions) error {
+	g.annotatedTag = &opts
+	return nil
+}
+
+func (g *fakeGit) DeleteTag(_ context.Context, name string) error {
+	g.deletedTag = name
+	return nil
+}
+
+func (g *fakeGit) ResolveCommit(_ context.Context, _ string) (string, error) {
+	return fakeCommit, nil
+}
+
 func (g *fakeGit) WorkingTreeClean(_ context.Context) (bool, error) {
 	if !g.workingTreeClean {
 		return false, nil
@@ -468,23 +1631,34 @@ func (g *fakeGit) WorkingTreeClean(_ context.Context) (bool, error) {
 	return true, nil
 }
 
+const fakeReleaseURL = "https://example.test/releases/tag/"
+
 type fakeGH struct {
+	authErr         error
+	createErr       error
+	droppedAsset    string
 	tag             string
 	target          string
 	prBase          string
 	prTitle         string
 	prBody          string
-	prLabel         string
+	prLabels        []string
 	assets          []string
+	opts            internal.Options
 	assetCount      int
+	createCalls     int
 	prerelease      bool
+	released        bool
+	createTimesOut  bool // the release is created but gh reports a gateway timeout
 	hasReleaseNotes bool
 	called          bool
 	prCreated       bool
 }
 
-func (g *fakeGH) CreateRelease(_ context.Context, opts internal.Options) error {
+func (g *fakeGH) CreateRelease(_ context.Context, opts internal.Options) (string, error) {
 	g.called = true
+	g.createCalls++
+	g.opts = opts
 	g.tag = opts.Tag
 	g.target = opts.Target
 	g.prerelease = opts.Prerelease
@@ -496,7 +1670,14 @@ func (g *fakeGH) CreateRelease(_ context.Context, opts internal.Options) error {
 			break
 		}
 	}
-	return nil
+	if g.createErr != nil {
+		return "", g.createErr
+	}
+	g.released = true
+	if g.createTimesOut {
+		return "", fmt.Errorf("%w: release create: HTTP 504", internal.ErrGHCommandFailed)
+	}
+	return fakeReleaseURL + opts.Tag, nil
 }
 
 func (g *fakeGH) CreatePR(_ context.Context, opts internal.PullRequestOptions) (string, error) {
@@ -504,10 +1685,33 @@ func (g *fakeGH) CreatePR(_ context.Context, opts internal.PullRequestOptions) (
 	g.prBase = opts.Base
 	g.prTitle = opts.Title
 	g.prBody = opts.Body
-	g.prLabel = opts.Label
+	g.prLabels = opts.Labels
 	return "https://example.test/pr/1", nil
 }
 
+func (g *fakeGH) ReleaseAssets(_ context.Context, _ string) ([]string, error) {
+	names := make([]string, 0, len(g.assets))
+	for _, asset := range g.assets {
+		name := filepath.Base(asset)
+		if name == g.droppedAsset {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func (g *fakeGH) ReleaseURL(_ context.Context, tag string) (string, error) {
+	if !g.released {
+		return "", internal.ErrReleaseNotFound
+	}
+	return fakeReleaseURL + tag, nil
+}
+
+func (g *fakeGH) EnsureAuthenticated(_ context.Context) error {
+	return g.authErr
+}
+
 func (g *fakeGH) SetWorkdir(_ string) {}
 
 type fakeBuilder struct {
@@ -83,24 +83,20 @@ func TestRunWorkflow_SelectsLatestPrereleaseForMain(t *testing.T) {
 `)
 	t.Chdir(repo)
 
+	var logs strings.Builder
 	err := internal.RunWorkflow(t.Context(), internal.WorkflowRequest{
 		Component:             "studioctl",
 		BaseBranch:            "main",
 		DryRun:                true,
 		Draft:                 true,
 		UnsafeSkipBranchCheck: true,
-	}, internal.NopLogger{})
+	}, internal.NewConsoleLogger(internal.WithWriters(&logs, &logs)))
 	if err != nil {
 		t.Fatalf("RunWorkflow() error = %v", err)
 	}
 
-	notesPath := filepath.Join(repo, "build", "release", "release-notes.md")
-	content, readErr := os.ReadFile(notesPath)
-	if readErr != nil {
-		t.Fatalf("read release notes: %v", readErr)
-	}
-	if !strings.Contains(string(content), "Latest preview notes") {
-		t.Fatalf("release notes did not use latest prerelease:\n%s", string(content))
+	if !strings.Contains(logs.String(), "Latest preview notes") || strings.Contains(logs.String(), "Old preview notes") {
+		t.Fatalf("release notes preview did not use latest prerelease:\n%s", logs.String())
 	}
 }
 
@@ -130,24 +126,20 @@ func TestRunWorkflow_SelectsLatestStableForReleaseLine(t *testing.T) {
 	createReleaseBranch(t, repo, "release/studioctl/v1.0")
 	t.Chdir(repo)
 
+	var logs strings.Builder
 	err := internal.RunWorkflow(t.Context(), internal.WorkflowRequest{
 		Component:             "studioctl",
 		BaseBranch:            "release/studioctl/v1.0",
 		DryRun:                true,
 		Draft:                 true,
 		UnsafeSkipBranchCheck: true,
-	}, internal.NopLogger{})
+	}, internal.NewConsoleLogger(internal.WithWriters(&logs, &logs)))
 	if err != nil {
 		t.Fatalf("RunWorkflow() error = %v", err)
 	}
 
-	notesPath := filepath.Join(repo, "build", "release", "release-notes.md")
-	content, readErr := os.ReadFile(notesPath)
-	if readErr != nil {
-		t.Fatalf("read release notes: %v", readErr)
-	}
-	if !strings.Contains(string(content), "Latest patch notes") {
-		t.Fatalf("release notes did not use latest stable patch:\n%s", string(content))
+	if !strings.Contains(logs.String(), "Latest patch notes") || strings.Contains(logs.String(), "Old patch notes") {
+		t.Fatalf("release notes preview did not use latest stable patch:\n%s", logs.String())
 	}
 }
 
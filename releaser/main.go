@@ -5,70 +5,162 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"time"
 
 	"altinn.studio/releaser/internal"
+	"altinn.studio/releaser/internal/perm"
+	"altinn.studio/releaser/internal/version"
 )
 
+// exitStatusRequiresCI is the exit status for non-dry-run workflow runs outside CI.
+const exitStatusRequiresCI = 3
+
+// Commit identity defaults for prepare and backport, used when -author-name or
+// -author-email is omitted.
+const (
+	authorNameEnv  = "RELEASER_AUTHOR_NAME"
+	authorEmailEnv = "RELEASER_AUTHOR_EMAIL"
+)
+
+// releaseVersionEnv is read by prepare when -version is omitted, so CI steps can
+// pass along a version computed earlier in the job.
+const releaseVersionEnv = "RELEASE_VERSION"
+
 var (
-	errComponentRequired           = errors.New("component is required")
-	errBaseBranchRequired          = errors.New("base-branch is required")
-	errReleaseVersionRequired      = errors.New("version is required")
-	errReleaseCommitBranchRequired = errors.New("commit and branch are required")
-	errBaseHeadRequired            = errors.New("base and head are required")
-	errWorkflowRequiresCI          = errors.New(
+	errComponentRequired           = invalidArgument("component is required")
+	errBaseBranchRequired          = invalidArgument("base-branch is required")
+	errReleaseVersionRequired      = invalidArgument("version is required")
+	errReleaseCommitBranchRequired = invalidArgument("commit and branch are required")
+	errBackportCommitsAndBranches  = invalidArgument("backport several commits or several branches, not both")
+	errBaseHeadRequired            = invalidArgument("base and head are required")
+	errCategoryMessageRequired     = invalidArgument("category and message are required")
+	errChangelogSubcommand         = invalidArgument("changelog requires a subcommand: add, init, promote or stats")
+	errWorkflowRequiresCI          = internal.NewCodedError("CI_REQUIRED", exitStatusRequiresCI, errors.New(
 		"workflow command may only run in CI; use -dry-run for local validation",
-	)
+	))
 )
 
+func invalidArgument(msg string) *internal.CodedError {
+	return internal.NewCodedError(internal.CodeInvalidArguments, internal.ExitStatusInvalidArguments, errors.New(msg))
+}
+
 func main() {
-	if len(os.Args) < 2 {
+	jsonErrors, args := parseGlobalFlags(os.Args[1:])
+	if len(args) < 1 {
 		printUsage()
 		os.Exit(1)
 	}
 
 	var err error
-	switch os.Args[1] {
+	switch args[0] {
 	case "workflow":
-		err = runWorkflow(os.Args[2:])
+		err = runWorkflow(args[1:])
 	case "prepare":
-		err = runPrepare(os.Args[2:])
+		err = runPrepare(args[1:])
 	case "backport":
-		err = runBackport(os.Args[2:])
+		err = runBackport(args[1:])
 	case "validate-changelog":
-		err = runValidateChangelog(os.Args[2:])
+		err = runValidateChangelog(args[1:])
+	case "changelog":
+		err = runChangelog(args[1:])
+	case "init-changelog":
+		err = runChangelogInit(args[1:])
+	case "audit":
+		err = runAudit(args[1:])
 	case "help", "-h", "--help":
 		printUsage()
 		return
 	default:
-		fmt.Fprintf(os.Stderr, "unknown command: %s\n\n", os.Args[1])
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n\n", args[0])
 		printUsage()
 		os.Exit(1)
 	}
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "error: %v\n", err)
-		os.Exit(1)
+		coded := internal.ClassifyError(err)
+		if jsonErrors {
+			fmt.Fprintln(os.Stderr, formatJSONError(coded))
+		} else {
+			fmt.Fprintln(os.Stderr, formatError(coded))
+		}
+		os.Exit(coded.Status)
 	}
 }
 
+// parseGlobalFlags consumes the flags given before the command and returns
+// whether failures are printed as JSON, and the remaining arguments.
+func parseGlobalFlags(args []string) (bool, []string) {
+	jsonErrors := false
+	for len(args) > 0 {
+		switch args[0] {
+		case "-json-errors", "--json-errors":
+			jsonErrors = true
+		default:
+			return jsonErrors, args
+		}
+		args = args[1:]
+	}
+	return jsonErrors, args
+}
+
+// formatError renders an error with its stable code for CI log parsing.
+func formatError(coded *internal.CodedError) string {
+	return fmt.Sprintf("error [%s]: %v", coded.Code, coded.Err)
+}
+
+type jsonErrorReport struct {
+	Error jsonErrorDetail `json:"error"`
+}
+
+type jsonErrorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Kind    string `json:"kind"`
+}
+
+// formatJSONError renders an error as a single-line JSON object for -json-errors.
+func formatJSONError(coded *internal.CodedError) string {
+	report := jsonErrorReport{Error: jsonErrorDetail{
+		Code:    coded.Code,
+		Message: coded.Err.Error(),
+		Kind:    coded.Kind(),
+	}}
+	content, err := json.Marshal(report)
+	if err != nil {
+		return formatError(coded)
+	}
+	return string(content)
+}
+
 func printUsage() {
 	fmt.Print(`releaser - Release tooling for Altinn Studio components
 
-Usage: releaser <command> [options]
+Usage: releaser [-json-errors] <command> [options]
 
 Commands:
   workflow            Run the complete release workflow (for CI)
   prepare             Create a changelog promotion PR for release
   backport            Cherry-pick a commit to a release branch with changelog handling
   validate-changelog  Validate changelog was modified and release-ready
+  changelog add       Add an entry to a component's [Unreleased] section
+  changelog init      Scaffold a new component changelog (alias: init-changelog)
+  changelog promote   Print the changelog as promoted to a version, without touching git
+  changelog stats     Report entry counts and days between releases
+  audit               Lint every component's working-tree changelog and write a combined report
 
 Notes:
   - workflow resolves the release version from CHANGELOG.md using -base-branch
+  - prepare falls back to RELEASE_VERSION when -version is omitted
   - non-dry-run workflow is CI-only (requires CI=true)
+  - errors are printed as 'error [CODE]: message' and exit with a code-specific status;
+    with -json-errors they are printed as {"error":{"code":...,"message":...,"kind":...}}
 
 Run 'releaser <command> -h' for command-specific help.
 `)
@@ -80,6 +172,25 @@ func runWorkflow(args []string) error {
 	baseBranch := fs.String("base-branch", "", "Base branch (main or release/<component>/vX.Y)")
 	dryRun := fs.Bool("dry-run", false, "Validate without creating tags/releases")
 	skipBranchCheck := fs.Bool("skip-branch-check", false, "Skip branch requirement (unsafe)")
+	noVerifyRelease := fs.Bool("no-verify-release", false, "Skip checking uploaded assets after creating the release")
+	annotatedTag := fs.Bool("annotated-tag", false, "Create an annotated tag with the release notes before the release")
+	signTag := fs.Bool("sign-tag", false, "Sign the annotated tag (implies -annotated-tag)")
+	decorateNotes := fs.Bool("decorate-notes", false, "Prefix release note category headers with emoji (changelog stays plain)")
+	var categoryHeaders stringList
+	fs.Var(&categoryHeaders, "category-header",
+		"Release note header as Category=Header, e.g. Fixed=Bug fixes (repeatable; overrides -decorate-notes)")
+	allowRegression := fs.Bool("allow-regression", false,
+		"Allow releasing a version older than the latest published tag (intentional backfills)")
+	notesStyle := fs.String("notes-style", "github",
+		"Release notes style: github (### headers), plain (**bold** headers) or compact (single [Category] list)")
+	notesFile := fs.String("notes-file", "",
+		"Publish this markdown file as the release notes instead of the changelog section (the section must still exist)")
+	sincePrerelease := fs.String("since-prerelease", "include",
+		"Stable release notes entries already shipped in a prerelease of the same version: include, annotate or exclude")
+	allowDirty := fs.Bool("allow-dirty", false, "Skip the clean working tree check (local debugging only; refused in CI)")
+	allowBehind := fs.Bool("allow-behind", false, "Only warn when the branch is behind origin instead of failing")
+	checkUnreleasedEmpty := fs.Bool("check-unreleased-empty", false,
+		"After releasing, warn if [Unreleased] on the released branch still has entries")
 	fs.Usage = func() {
 		fmt.Print(`Usage: releaser workflow [options]
 
@@ -88,10 +199,23 @@ Runs the complete release workflow. Version is derived from changelog:
   - base-branch=release/<component>/vX.Y -> latest stable on that line
 
 Then it:
-  1. Enforces ref policy (prerelease from main, stable from release branch)
-  2. Validates changelog has version section (use 'prepare' first)
-  3. Builds release artifacts (if component has a builder)
-  4. Creates GitHub release (tag created automatically)
+  1. Enforces ref policy (prerelease from main, stable from release branch;
+     in CI a detached HEAD is treated as -base-branch)
+  2. Refuses a version older than the latest published tag on its line
+     (or overall for a new line) unless -allow-regression is set, and a
+     branch behind its origin counterpart unless -allow-behind is set
+  3. Validates changelog has version section (use 'prepare' first), even when
+     -notes-file supplies the release notes
+  4. Builds release artifacts (if component has a builder)
+  5. Creates GitHub release (tag created automatically unless -annotated-tag)
+  6. Verifies all built assets were uploaded (skip with -no-verify-release)
+  7. With -check-unreleased-empty, warns if [Unreleased] on the released branch
+     still has entries (the prepare PR should have emptied it)
+  8. Writes release-summary.json to the output directory for later CI steps
+
+The output directory is only wiped when it is empty or was written by an
+earlier build (it holds a .releaser-output marker). Otherwise the workflow
+fails in CI and asks for confirmation when run interactively.
 
 Options:
 `)
@@ -117,13 +241,36 @@ Examples:
 	if err := validateWorkflowExecutionContext(*dryRun); err != nil {
 		return fmt.Errorf("validate workflow execution context: %w", err)
 	}
+	headers, err := parseCategoryHeaders(categoryHeaders)
+	if err != nil {
+		return err
+	}
+
+	var prompter internal.ConfirmationPrompter
+	if !isCIEnvironment() && isInteractiveInput(os.Stdin) {
+		prompter = internal.NewConsolePrompter()
+	}
 
 	req := internal.WorkflowRequest{
+		Prompter:              prompter,
 		Component:             *component,
 		BaseBranch:            *baseBranch,
 		DryRun:                *dryRun,
 		Draft:                 true,
 		UnsafeSkipBranchCheck: *skipBranchCheck,
+		SkipVerifyRelease:     *noVerifyRelease,
+		AnnotatedTag:          *annotatedTag,
+		SignTag:               *signTag,
+		DecorateNotes:         *decorateNotes,
+		CategoryHeaders:       headers,
+		NotesFile:             *notesFile,
+		NotesStyle:            *notesStyle,
+		SincePrerelease:       *sincePrerelease,
+		CI:                    isCIEnvironment(),
+		AllowDirty:            *allowDirty,
+		AllowRegression:       *allowRegression,
+		AllowBehind:           *allowBehind,
+		CheckUnreleasedEmpty:  *checkUnreleasedEmpty,
 	}
 	if err := internal.RunWorkflow(context.Background(), req, internal.NewConsoleLogger()); err != nil {
 		return fmt.Errorf("workflow: %w", err)
@@ -134,29 +281,77 @@ Examples:
 func runPrepare(args []string) error {
 	fs := flag.NewFlagSet("prepare", flag.ExitOnError)
 	component := fs.String("component", "", "Component name (required, e.g., studioctl)")
-	version := fs.String("version", "", "Version to release (required, e.g., v1.2.3)")
+	versionFlag := fs.String("version", "",
+		"Version to release (required unless "+releaseVersionEnv+" is set, e.g., v1.2.3)")
 	dryRun := fs.Bool("dry-run", false, "Show what would be done without making changes")
 	yes := fs.Bool("yes", false, "Skip confirmation prompts")
 	yesShort := fs.Bool("y", false, "Alias for -yes")
 	open := fs.Bool("open", false, "Open created PR in browser")
+	noPush := fs.Bool("no-push", false, "Commit locally and stop before pushing and creating the PR")
+	resume := fs.Bool("resume", false, "Recreate local branches left over from an interrupted prepare")
+	promotePrerelease := fs.Bool(
+		"promote-prerelease",
+		false,
+		"Build stable -version from its prerelease sections only, leaving [Unreleased] untouched",
+	)
+	local := fs.Bool("local", false, "With -dry-run, preview from the working-tree changelog without fetching origin")
+	allowBehind := fs.Bool("allow-behind", false, "Only warn when the checked-out base branch is behind origin")
+	worktree := fs.Bool("worktree", false, "Prepare in a temporary git worktree, leaving your checkout untouched")
+	author := addAuthorFlags(fs)
+	var labels, trailers stringList
+	fs.Var(&labels, "label", "Additional PR label (repeatable)")
+	fs.Var(&trailers, "trailer", "Commit trailer as key=value, e.g. Release-Component=studioctl (repeatable)")
+	commitTemplate := fs.String("commit-template", internal.DefaultCommitTemplate,
+		"Release commit message; placeholders: {component}, {version}, {tag}, {title}")
 	fs.Usage = func() {
 		fmt.Print(`Usage: releaser prepare -component <name> -version <version> [options]
 
 Creates a PR to promote [Unreleased] changelog entries to the specified version.
 After merging the PR, CI can run the release workflow if configured.
+When -version is omitted, the RELEASE_VERSION environment variable is used.
 
 Version behavior:
   - vX.Y.Z-preview.N: prep PR targets main
+  - vX.Y.Z-preview: numbered as the next vX.Y.Z-preview.N in the changelog
   - vX.Y.0: creates release/<component>/vX.Y if missing, prep PR targets it
   - vX.Y.Z (Z>0): prep PR targets existing release/<component>/vX.Y
 
+With -promote-prerelease, a stable version is promoted from the notes of its
+existing prereleases (e.g., v1.3.0 from v1.3.0-preview.2) even when [Unreleased]
+is empty. Branch targeting follows the rules above.
+
 Steps performed:
   1. Creates branch 'release-prep/<component>-<version>'
   2. Promotes [Unreleased] to [<version>] in CHANGELOG.md
   3. Prompts before commit/push/PR actions (unless -y/-yes)
   4. Commits the change
   5. Pushes the branch
-  6. Creates PR with 'release/<component>' label
+  6. Creates PR with 'release/<component>' label (plus any -label values)
+
+If an earlier prepare was interrupted, its local branches make the run fail with
+PREP_BRANCH_EXISTS. Pass -resume to recreate them (only while not on origin), or
+delete them with the printed 'git branch -D' command.
+
+With -no-push, steps 5 and 6 are skipped (a new release branch is not pushed
+either) and the push commands are printed instead.
+
+The release commit is made as the git config user unless -author-name and
+-author-email (or RELEASER_AUTHOR_NAME and RELEASER_AUTHOR_EMAIL) are set.
+Its message is -commit-template, e.g. 'chore(release): {component} {version}' for
+conventional commits. Each -trailer key=value is appended as a "Key: value" trailer.
+
+With -dry-run -local, the working-tree changelog is promoted instead of the one
+on origin and release branches are assumed to exist as the version requires, so
+the preview works offline. -local is rejected without -dry-run.
+
+When the checked-out branch is the one prepare branches from (the base branch,
+or main for a new release branch), it must not be behind origin; the run fails
+with BRANCH_BEHIND_REMOTE unless -allow-behind is set.
+
+Prepare normally switches your checkout to the new branches, so the working tree
+must be clean. With -worktree the branches are created and committed in a
+temporary git worktree instead: your branch and uncommitted changes are left as
+they are, and only the prep (and new release) branches are pushed.
 
 Options:
 `)
@@ -169,9 +364,16 @@ Options:
 		fs.Usage()
 		return errComponentRequired
 	}
-	if *version == "" {
+	releaseVersion, err := resolveReleaseVersion(*versionFlag)
+	if errors.Is(err, errReleaseVersionRequired) {
 		fs.Usage()
-		return errReleaseVersionRequired
+	}
+	if err != nil {
+		return err
+	}
+	parsedTrailers, err := parseTrailers(trailers)
+	if err != nil {
+		return err
 	}
 
 	assumeYes := *yes || *yesShort
@@ -181,12 +383,23 @@ Options:
 	}
 
 	req := internal.PrepareRequest{
-		Component:     *component,
-		Version:       *version,
-		ChangelogPath: "",
-		Open:          *open,
-		DryRun:        *dryRun,
-		Prompter:      prompter,
+		Component:         *component,
+		Version:           releaseVersion,
+		ChangelogPath:     "",
+		Labels:            labels,
+		Open:              *open,
+		DryRun:            *dryRun,
+		NoPush:            *noPush,
+		Resume:            *resume,
+		Prompter:          prompter,
+		PromotePrerelease: *promotePrerelease,
+		Local:             *local,
+		AllowBehind:       *allowBehind,
+		Worktree:          *worktree,
+		Clock:             nil,
+		Author:            author(),
+		Trailers:          parsedTrailers,
+		CommitTemplate:    *commitTemplate,
 	}
 	if err := internal.RunPrepare(context.Background(), req, internal.NewConsoleLogger()); err != nil {
 		return fmt.Errorf("prepare: %w", err)
@@ -197,12 +410,19 @@ Options:
 func runBackport(args []string) error {
 	fs := flag.NewFlagSet("backport", flag.ExitOnError)
 	component := fs.String("component", "", "Component name (required, e.g., studioctl)")
-	commit := fs.String("commit", "", "Commit SHA to backport (required)")
-	branch := fs.String("branch", "", "Release branch version (required, e.g., v1.0)")
+	commit := fs.String("commit", "", "Commit SHA to backport (required; comma-separate several)")
+	branch := fs.String("branch", "", "Release branch version (required, e.g., v1.0; comma-separate several)")
 	dryRun := fs.Bool("dry-run", false, "Show what would be done without making changes")
 	yes := fs.Bool("yes", false, "Skip confirmation prompts")
 	yesShort := fs.Bool("y", false, "Alias for -yes")
 	open := fs.Bool("open", false, "Open created PR in browser")
+	keepGoing := fs.Bool("keep-going", false, "With several commits, attempt all of them and report a summary")
+	noPush := fs.Bool("no-push", false, "Commit locally and stop before pushing and creating the PR")
+	parallel := fs.Int("parallel", 1, "With several branches, backport up to this many release lines at once")
+	author := addAuthorFlags(fs)
+	var labels, trailers stringList
+	fs.Var(&labels, "label", "Additional PR label (repeatable)")
+	fs.Var(&trailers, "trailer", "Commit trailer as key=value, added after the cherry-pick line (repeatable)")
 	fs.Usage = func() {
 		fmt.Print(`Usage: releaser backport -component <name> -commit <sha> -branch <version> [options]
 
@@ -218,7 +438,24 @@ Steps performed:
   7. Inserts extracted entries into [Unreleased] section
   8. Creates commit referencing original SHA
   9. Pushes the backport branch
- 10. Creates a PR targeting the release branch (label: backport)
+ 10. Creates a PR targeting the release branch (label: backport, plus any -label values)
+
+With -no-push, steps 9 and 10 are skipped and the push command is printed instead.
+
+Commits are made as the git config user unless -author-name and -author-email
+(or RELEASER_AUTHOR_NAME and RELEASER_AUTHOR_EMAIL) are set. Each -trailer
+key=value is added as a "Key: value" trailer below the cherry-pick line.
+
+Several commits (-commit a,b,c) are backported one at a time, each on its own
+branch and PR. A failed commit is rolled back and you are returned to the starting
+branch; the batch stops there unless -keep-going is set. A summary lists every
+attempted commit, and the command fails if any of them failed.
+
+Several branches (-branch v1.0,v1.1) backport one commit to each release line,
+each on its own branch and PR. Every line is built in a temporary git worktree,
+so your checkout is left alone and the working tree need not be clean. With
+-parallel N up to N lines run at once. After a failure no further lines are
+started unless -keep-going is set; a summary lists every attempted line.
 
 After merging the backport PR, use 'releaser prepare -component <name> -version vX.Y.Z'
 to create the release PR (then CI can run the release workflow if configured).
@@ -238,6 +475,10 @@ Options:
 		fs.Usage()
 		return errReleaseCommitBranchRequired
 	}
+	parsedTrailers, err := parseTrailers(trailers)
+	if err != nil {
+		return err
+	}
 
 	assumeYes := *yes || *yesShort
 	var prompter internal.ConfirmationPrompter
@@ -245,14 +486,46 @@ Options:
 		prompter = internal.NewConsolePrompter()
 	}
 
+	commits := splitList(*commit)
+	branches := splitList(*branch)
+	if len(commits) > 1 && len(branches) > 1 {
+		return errBackportCommitsAndBranches
+	}
 	req := internal.BackportRequest{
 		Component:     *component,
 		Commit:        *commit,
 		Branch:        *branch,
 		ChangelogPath: "",
+		Labels:        labels,
+		Commits:       nil,
+		Branches:      nil,
+		Parallel:      *parallel,
 		Open:          *open,
 		DryRun:        *dryRun,
+		NoPush:        *noPush,
+		KeepGoing:     *keepGoing,
 		Prompter:      prompter,
+		Author:        author(),
+		Trailers:      parsedTrailers,
+	}
+	if len(commits) == 1 {
+		req.Commit = commits[0]
+	}
+	if len(branches) > 1 {
+		req.Branch = ""
+		req.Branches = branches
+		if _, err := internal.RunBackportLines(context.Background(), req, internal.NewConsoleLogger()); err != nil {
+			return fmt.Errorf("backport: %w", err)
+		}
+		return nil
+	}
+	if len(commits) > 1 || *keepGoing {
+		req.Commit = ""
+		req.Commits = commits
+		if _, err := internal.RunBackportBatch(context.Background(), req, internal.NewConsoleLogger()); err != nil {
+			return fmt.Errorf("backport: %w", err)
+		}
+		return nil
 	}
 	if err := internal.RunBackport(context.Background(), req, internal.NewConsoleLogger()); err != nil {
 		return fmt.Errorf("backport: %w", err)
@@ -262,11 +535,16 @@ Options:
 
 func runValidateChangelog(args []string) error {
 	fs := flag.NewFlagSet("validate-changelog", flag.ExitOnError)
-	component := fs.String("component", "", "Component name (required, e.g., studioctl)")
+	component := fs.String("component", "", "Component name (required, e.g., studioctl, or 'all')")
 	base := fs.String("base", "", "Base commit SHA (required)")
 	head := fs.String("head", "", "Head commit SHA (required)")
+	changelogPath := fs.String("changelog-path", "", "Changelog path relative to repo root (default: component changelog)")
+	requirePreamble := fs.Bool("require-preamble", false, "Fail unless the changelog starts with a \"# \" title")
+	warnDuplicates := fs.Bool("warn-duplicates", false,
+		"Warn about [Unreleased] entries that repeat an entry of the most recent release")
+	format := fs.String("format", "text", "Output format: text, or sarif for code scanning (printed to stdout)")
 	fs.Usage = func() {
-		fmt.Print(`Usage: releaser validate-changelog -component <name> -base <sha> -head <sha>
+		fmt.Print(`Usage: releaser validate-changelog -component <name> -base <sha> -head <sha> [options]
 
 Validates that the changelog was modified and has content in the [Unreleased] section.
 Used in CI to enforce changelog updates in PRs.
@@ -275,6 +553,19 @@ Checks performed:
   1. Verifies changelog file was modified between base and head
   2. Validates [Unreleased] has at least one category and entry OR this is a release-promotion PR
   3. Validates released sections (if present) have no duplicates and are semver-descending
+  4. Rejects entries added under an already released version section
+  5. Rejects edits to or removal of version sections already released at base
+  6. With -require-preamble, requires a "# " title before the first section
+
+With -warn-duplicates, [Unreleased] entries that exactly match an entry in the
+same category of the most recent release are reported as warnings on stderr.
+Duplicates never fail validation.
+
+With -format sarif, a SARIF 2.1.0 report with one result per failure is printed
+to stdout for code scanning upload. The exit status is still non-zero on failures.
+
+With -component all, every registered component is validated and a per-component
+report is printed; the command fails if any component fails.
 
 Options:
 `)
@@ -291,12 +582,23 @@ Options:
 		fs.Usage()
 		return errBaseHeadRequired
 	}
+	if *format != "text" && *format != "sarif" {
+		return invalidArgument(fmt.Sprintf("invalid -format %q: want text or sarif", *format))
+	}
 
 	req := internal.ValidationRequest{
-		Component:     *component,
-		Base:          *base,
-		Head:          *head,
-		ChangelogPath: "",
+		Component:       *component,
+		Base:            *base,
+		Head:            *head,
+		ChangelogPath:   *changelogPath,
+		RequirePreamble: *requirePreamble,
+		WarnDuplicates:  *warnDuplicates,
+	}
+	if *format == "sarif" {
+		return runValidateChangelogSARIF(req)
+	}
+	if *component == internal.AllComponents {
+		return runValidateAllChangelogs(req)
 	}
 	if err := internal.RunValidation(context.Background(), req, internal.NewConsoleLogger()); err != nil {
 		return fmt.Errorf("validate changelog: %w", err)
@@ -306,6 +608,477 @@ Options:
 	return nil
 }
 
+func runAudit(args []string) error {
+	fs := flag.NewFlagSet("audit", flag.ExitOnError)
+	format := fs.String("format", "markdown", "Report format: markdown or json")
+	output := fs.String("o", "-", "Output file, or - for stdout")
+	emptyCategories := fs.Bool("empty-categories", false, "Warn about category headers without entries")
+	patchGaps := fs.Bool("patch-gaps", false, "Warn about skipped patch versions within a vX.Y line")
+	requirePreamble := fs.Bool("require-preamble", false, "Report an error unless the changelog starts with a \"# \" title")
+	failOnWarnings := fs.Bool("fail-on-warnings", false, "Exit non-zero on warnings as well as errors")
+	fs.Usage = func() {
+		fmt.Print(`Usage: releaser audit [options]
+
+Lints the working-tree changelog of every registered component and writes one
+combined report, e.g. for a nightly job that posts it as an issue.
+
+Structural problems (unparseable file, invalid category, duplicate or misordered
+versions, prerelease conflicts, missing file) are always reported as errors.
+Optional checks:
+  -empty-categories  warning for each category header without entries
+  -patch-gaps        warning when a vX.Y line skips a patch version
+  -require-preamble  error unless the changelog starts with a "# " title
+
+The report is always written. The command exits with AUDIT_FAILED when any
+error is reported, or any warning with -fail-on-warnings.
+
+Options:
+`)
+		fs.PrintDefaults()
+		fmt.Print(`
+Examples:
+  releaser audit -empty-categories -patch-gaps
+  releaser audit -format json -o audit.json
+`)
+	}
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+	if *format != "markdown" && *format != "json" {
+		return invalidArgument(fmt.Sprintf("invalid -format %q: want markdown or json", *format))
+	}
+
+	req := internal.AuditRequest{
+		EmptyCategories: *emptyCategories,
+		PatchGaps:       *patchGaps,
+		RequirePreamble: *requirePreamble,
+		FailOnWarnings:  *failOnWarnings,
+	}
+	// Logs go to stderr so the report on stdout stays clean.
+	log := internal.NewConsoleLogger(internal.WithWriters(os.Stderr, os.Stderr))
+	report, auditErr := internal.RunAudit(context.Background(), req, log)
+	if auditErr != nil && !errors.Is(auditErr, internal.ErrAuditFailed) {
+		return fmt.Errorf("audit: %w", auditErr)
+	}
+
+	content := internal.AuditMarkdown(report)
+	if *format == "json" {
+		encoded, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal audit report: %w", err)
+		}
+		content = string(encoded) + "\n"
+	}
+	if *output == "-" {
+		fmt.Print(content)
+	} else if err := os.WriteFile(*output, []byte(content), perm.FilePermDefault); err != nil {
+		return fmt.Errorf("write %s: %w", *output, err)
+	}
+	if auditErr != nil {
+		return fmt.Errorf("audit: %w", auditErr)
+	}
+	return nil
+}
+
+func runChangelog(args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "add":
+			return runChangelogAdd(args[1:])
+		case "init":
+			return runChangelogInit(args[1:])
+		case "promote":
+			return runChangelogPromote(args[1:])
+		case "stats":
+			return runChangelogStats(args[1:])
+		}
+	}
+	fmt.Fprint(os.Stderr, `Usage:
+  releaser changelog add -component <name> -category <category> -message <text>
+  releaser changelog init -component <name> [-force]
+  releaser changelog promote -component <name> -version <version> [-o <file>]
+  releaser changelog stats -component <name> [-json]
+`)
+	return errChangelogSubcommand
+}
+
+func runChangelogStats(args []string) error {
+	fs := flag.NewFlagSet("changelog stats", flag.ExitOnError)
+	component := fs.String("component", "", "Component name (required, e.g., studioctl)")
+	changelogPath := fs.String("changelog-path", "", "Changelog path relative to repo root (default: component changelog)")
+	jsonOutput := fs.Bool("json", false, "Print the stats as JSON")
+	fs.Usage = func() {
+		fmt.Print(`Usage: releaser changelog stats -component <name> [options]
+
+Reports release velocity from the working-tree changelog: entry counts per
+category for each released version, days since the previous release, the
+average entries per release and the median days between releases.
+
+Options:
+`)
+		fs.PrintDefaults()
+		fmt.Print(`
+Examples:
+  releaser changelog stats -component studioctl
+  releaser changelog stats -component studioctl -json
+`)
+	}
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+	if *component == "" {
+		fs.Usage()
+		return errComponentRequired
+	}
+
+	req := internal.ChangelogStatsRequest{Component: *component, ChangelogPath: *changelogPath}
+	// Logs go to stderr so -json output stays parseable.
+	log := internal.NewConsoleLogger(internal.WithWriters(os.Stderr, os.Stderr))
+	stats, err := internal.RunChangelogStats(context.Background(), req, log)
+	if err != nil {
+		return fmt.Errorf("changelog stats: %w", err)
+	}
+	if *jsonOutput {
+		content, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal changelog stats: %w", err)
+		}
+		fmt.Println(string(content))
+		return nil
+	}
+	printChangelogStats(os.Stdout, stats)
+	return nil
+}
+
+func printChangelogStats(w io.Writer, stats internal.ChangelogStats) {
+	for _, release := range stats.Releases {
+		counts := make([]string, 0, len(release.Categories))
+		for _, cat := range release.Categories {
+			counts = append(counts, fmt.Sprintf("%s %d", cat.Name, cat.Entries))
+		}
+		since := ""
+		if release.DaysSincePrevious != nil {
+			since = fmt.Sprintf("  +%dd", *release.DaysSincePrevious)
+		}
+		//nolint:errcheck // report output errors are non-critical
+		fmt.Fprintf(w, "%-20s %-10s %3d entries (%s)%s\n",
+			release.Version, release.Date, release.Entries, strings.Join(counts, ", "), since)
+	}
+	//nolint:errcheck // report output errors are non-critical
+	fmt.Fprintf(w, "\nReleases: %d\nAvg entries per release: %.1f\nMedian days between releases: %.1f\n",
+		len(stats.Releases), stats.AvgEntriesPerRelease, stats.MedianDaysBetweenReleases)
+}
+
+func runChangelogPromote(args []string) error {
+	fs := flag.NewFlagSet("changelog promote", flag.ExitOnError)
+	component := fs.String("component", "", "Component name (required, e.g., studioctl)")
+	version := fs.String("version", "", "Version to promote [Unreleased] to (required, e.g., v1.2.0)")
+	changelogPath := fs.String("changelog-path", "", "Changelog path relative to repo root (default: component changelog)")
+	output := fs.String("o", "-", "Output file, or - for stdout")
+	date := fs.String("date", "", "Release date YYYY-MM-DD for the new section (default: today)")
+	format := fs.String("format", "keepachangelog",
+		"Output format: keepachangelog, or common for Common Changelog")
+	promotePrerelease := fs.Bool(
+		"promote-prerelease",
+		false,
+		"Build stable -version from its prerelease sections only, leaving [Unreleased] untouched",
+	)
+	fs.Usage = func() {
+		fmt.Print(`Usage: releaser changelog promote -component <name> -version <version> [options]
+
+Prints the working-tree changelog as 'prepare' would promote it, without fetching,
+branching, committing or creating a PR. The changelog file itself is not modified.
+
+With -format common, the result is printed in Common Changelog style: [Unreleased]
+is left out and entries are regrouped into Changed, Added, Removed and Fixed
+(Migration and Deprecated under Changed, Security under Fixed). Migration entries
+come first with a **Breaking:** prefix.
+
+Options:
+`)
+		fs.PrintDefaults()
+		fmt.Print(`
+Examples:
+  releaser changelog promote -component studioctl -version v1.2.0
+  releaser changelog promote -component studioctl -version v1.2.0 -o /tmp/CHANGELOG.md
+`)
+	}
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+	if *component == "" {
+		fs.Usage()
+		return errComponentRequired
+	}
+	if *version == "" {
+		fs.Usage()
+		return errReleaseVersionRequired
+	}
+
+	req := internal.ChangelogPromoteRequest{
+		Date:              time.Time{},
+		Component:         *component,
+		Version:           *version,
+		ChangelogPath:     *changelogPath,
+		PromotePrerelease: *promotePrerelease,
+		Format:            *format,
+	}
+	if *date != "" {
+		parsed, err := time.Parse(time.DateOnly, *date)
+		if err != nil {
+			return invalidArgument(fmt.Sprintf("invalid -date %q: want YYYY-MM-DD", *date))
+		}
+		req.Date = parsed
+	}
+
+	promoted, err := internal.RunChangelogPromote(context.Background(), req, internal.NewConsoleLogger())
+	if err != nil {
+		return fmt.Errorf("changelog promote: %w", err)
+	}
+	if *output == "-" {
+		fmt.Print(promoted)
+		return nil
+	}
+	if err := os.WriteFile(*output, []byte(promoted), perm.FilePermDefault); err != nil {
+		return fmt.Errorf("write %s: %w", *output, err)
+	}
+	fmt.Printf("wrote promoted changelog to %s\n", *output)
+	return nil
+}
+
+func runChangelogInit(args []string) error {
+	fs := flag.NewFlagSet("changelog init", flag.ExitOnError)
+	component := fs.String("component", "", "Component name (required, e.g., studioctl)")
+	changelogPath := fs.String("changelog-path", "", "Changelog path relative to repo root (default: component changelog)")
+	force := fs.Bool("force", false, "Overwrite an existing changelog")
+	fs.Usage = func() {
+		fmt.Print(`Usage: releaser changelog init -component <name> [options]
+
+Writes a new changelog at the component's changelog path: the "# Changelog"
+title and intro, and an empty [Unreleased] section with the categories listed
+in a comment. The file passes validate-changelog -require-preamble and audit.
+An existing changelog is never overwritten unless -force is set.
+
+Also available as 'releaser init-changelog'.
+
+Options:
+`)
+		fs.PrintDefaults()
+		fmt.Print(`
+Examples:
+  releaser changelog init -component studioctl
+  releaser init-changelog -component studioctl -changelog-path docs/CHANGELOG.md
+`)
+	}
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+	if *component == "" {
+		fs.Usage()
+		return errComponentRequired
+	}
+
+	req := internal.ChangelogInitRequest{
+		Component:     *component,
+		ChangelogPath: *changelogPath,
+		Force:         *force,
+	}
+	path, err := internal.RunChangelogInit(context.Background(), req, internal.NewConsoleLogger())
+	if err != nil {
+		return fmt.Errorf("changelog init: %w", err)
+	}
+	fmt.Printf("wrote changelog skeleton to %s\n", path)
+	return nil
+}
+
+func runChangelogAdd(args []string) error {
+	fs := flag.NewFlagSet("changelog add", flag.ExitOnError)
+	component := fs.String("component", "", "Component name (required, e.g., studioctl)")
+	category := fs.String(
+		"category",
+		"",
+		"Changelog category (required: Migration, Added, Changed, Fixed, Removed, Security, Deprecated)",
+	)
+	message := fs.String("message", "", "Entry text (required, e.g., \"Fix X (#123)\")")
+	changelogPath := fs.String("changelog-path", "", "Changelog path relative to repo root (default: component changelog)")
+	dryRun := fs.Bool("dry-run", false, "Print the resulting [Unreleased] section without writing")
+	stage := fs.Bool("stage", false, "Stage the changelog with git add after writing")
+	fs.Usage = func() {
+		fmt.Print(`Usage: releaser changelog add -component <name> -category <category> -message <text> [options]
+
+Adds an entry to the [Unreleased] section of the component changelog.
+The category header is created if the section does not have it yet.
+
+Options:
+`)
+		fs.PrintDefaults()
+		fmt.Print(`
+Examples:
+  releaser changelog add -component studioctl -category Fixed -message "Fix X (#123)"
+  releaser changelog add -component studioctl -category Added -message "Add Y" -dry-run
+`)
+	}
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+	if *component == "" {
+		fs.Usage()
+		return errComponentRequired
+	}
+	if *category == "" || *message == "" {
+		fs.Usage()
+		return errCategoryMessageRequired
+	}
+
+	req := internal.ChangelogAddRequest{
+		Component:     *component,
+		Category:      *category,
+		Message:       *message,
+		ChangelogPath: *changelogPath,
+		DryRun:        *dryRun,
+		Stage:         *stage,
+	}
+	result, err := internal.RunChangelogAdd(context.Background(), req, internal.NewConsoleLogger())
+	if err != nil {
+		return fmt.Errorf("changelog add: %w", err)
+	}
+
+	if *dryRun {
+		fmt.Print(result.Unreleased)
+		return nil
+	}
+	fmt.Printf("added %s entry to %s\n", *category, result.Path)
+	return nil
+}
+
+// runValidateChangelogSARIF prints a SARIF report to stdout; logs go to stderr to keep it parseable.
+func runValidateChangelogSARIF(req internal.ValidationRequest) error {
+	log := internal.NewConsoleLogger(internal.WithWriters(os.Stderr, os.Stderr))
+	git := internal.NewGitCLI(internal.WithLogger(log))
+	results, err := internal.RunValidationResults(context.Background(), req, git)
+	report, reportErr := internal.ValidationSARIF(results)
+	if reportErr != nil {
+		return fmt.Errorf("validate changelog: %w", reportErr)
+	}
+	fmt.Println(string(report))
+	if err != nil {
+		return fmt.Errorf("validate changelog: %w", err)
+	}
+	return nil
+}
+
+func runValidateAllChangelogs(req internal.ValidationRequest) error {
+	git := internal.NewGitCLI(internal.WithLogger(internal.NewConsoleLogger()))
+	results, err := internal.RunValidationAll(context.Background(), req, git)
+	printValidationReport(os.Stdout, results)
+	if err != nil {
+		return fmt.Errorf("validate changelog: %w", err)
+	}
+	fmt.Println("all changelogs validated")
+	return nil
+}
+
+func printValidationReport(w io.Writer, results []internal.ComponentValidationResult) {
+	for _, result := range results {
+		if result.Err != nil {
+			//nolint:errcheck // report output errors are non-critical
+			fmt.Fprintf(w, "FAIL %s: %v\n", result.Component, result.Err)
+			continue
+		}
+		if result.Skipped {
+			//nolint:errcheck // report output errors are non-critical
+			fmt.Fprintf(w, "skip %s: not modified\n", result.Component)
+			continue
+		}
+		//nolint:errcheck // report output errors are non-critical
+		fmt.Fprintf(w, "ok   %s\n", result.Component)
+	}
+}
+
+// splitList splits a comma-separated flag value, dropping empty items.
+func splitList(value string) []string {
+	var items []string
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// stringList is a repeatable string flag.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// addAuthorFlags registers -author-name and -author-email on fs. The returned
+// function builds the commit identity after parsing, falling back to the
+// RELEASER_AUTHOR_* environment variables for omitted flags.
+func addAuthorFlags(fs *flag.FlagSet) func() internal.CommitIdentity {
+	name := fs.String("author-name", "", "Commit author name (default: $"+authorNameEnv+" or git config user.name)")
+	email := fs.String("author-email", "", "Commit author email (default: $"+authorEmailEnv+" or git config user.email)")
+	return func() internal.CommitIdentity {
+		id := internal.CommitIdentity{Name: *name, Email: *email}
+		if id.Name == "" {
+			id.Name = os.Getenv(authorNameEnv)
+		}
+		if id.Email == "" {
+			id.Email = os.Getenv(authorEmailEnv)
+		}
+		return id
+	}
+}
+
+// parseTrailers parses repeated -trailer key=value flags.
+func parseTrailers(values []string) ([]internal.Trailer, error) {
+	trailers := make([]internal.Trailer, 0, len(values))
+	for _, value := range values {
+		trailer, err := internal.ParseTrailer(value)
+		if err != nil {
+			return nil, fmt.Errorf("-trailer: %w", err)
+		}
+		trailers = append(trailers, trailer)
+	}
+	return trailers, nil
+}
+
+// parseCategoryHeaders parses repeated -category-header Category=Header flags.
+func parseCategoryHeaders(values []string) (map[string]string, error) {
+	headers := make(map[string]string, len(values))
+	for _, value := range values {
+		category, header, err := internal.ParseCategoryHeader(value)
+		if err != nil {
+			return nil, fmt.Errorf("-category-header: %w", err)
+		}
+		headers[category] = header
+	}
+	return headers, nil
+}
+
+// resolveReleaseVersion returns the -version flag value, falling back to
+// RELEASE_VERSION when the flag is empty. The result must parse as a version.
+func resolveReleaseVersion(flagValue string) (string, error) {
+	value, source := flagValue, "-version"
+	if value == "" {
+		value, source = os.Getenv(releaseVersionEnv), releaseVersionEnv
+	}
+	if strings.TrimSpace(value) == "" {
+		return "", errReleaseVersionRequired
+	}
+	parsed, err := version.Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", source, err)
+	}
+	return parsed.Full, nil
+}
+
 func shouldPromptPrepare(dryRun, assumeYes, interactive bool) bool {
 	return !dryRun && !assumeYes && interactive
 }
@@ -1,8 +1,14 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
+
+	"altinn.studio/releaser/internal"
+	"altinn.studio/releaser/internal/version"
 )
 
 func TestValidateWorkflowExecutionContext(t *testing.T) {
@@ -57,6 +63,8 @@ func TestCLIArgValidation(t *testing.T) {
 	})
 
 	t.Run("prepare requires version", func(t *testing.T) {
+		t.Setenv(releaseVersionEnv, "")
+
 		err := runPrepare([]string{"-component", "studioctl"})
 		if !errors.Is(err, errReleaseVersionRequired) {
 			t.Fatalf("runPrepare() error = %v, want %v", err, errReleaseVersionRequired)
@@ -88,6 +96,50 @@ func TestWorkflowCommandRequiresCI(t *testing.T) {
 	}
 }
 
+func TestResolveReleaseVersion(t *testing.T) {
+	t.Run("flag wins over env", func(t *testing.T) {
+		t.Setenv(releaseVersionEnv, "v9.9.9")
+
+		got, err := resolveReleaseVersion("v1.2.3")
+		if err != nil {
+			t.Fatalf("resolveReleaseVersion() error = %v", err)
+		}
+		if got != "v1.2.3" {
+			t.Fatalf("resolveReleaseVersion() = %q, want %q", got, "v1.2.3")
+		}
+	})
+	t.Run("falls back to env", func(t *testing.T) {
+		t.Setenv(releaseVersionEnv, " v1.3.0-preview.1\n")
+
+		got, err := resolveReleaseVersion("")
+		if err != nil {
+			t.Fatalf("resolveReleaseVersion() error = %v", err)
+		}
+		if got != "v1.3.0-preview.1" {
+			t.Fatalf("resolveReleaseVersion() = %q, want %q", got, "v1.3.0-preview.1")
+		}
+	})
+	t.Run("neither set", func(t *testing.T) {
+		t.Setenv(releaseVersionEnv, "")
+
+		_, err := resolveReleaseVersion("")
+		if !errors.Is(err, errReleaseVersionRequired) {
+			t.Fatalf("resolveReleaseVersion() error = %v, want %v", err, errReleaseVersionRequired)
+		}
+	})
+	t.Run("invalid env value", func(t *testing.T) {
+		t.Setenv(releaseVersionEnv, "1.2")
+
+		_, err := resolveReleaseVersion("")
+		if !errors.Is(err, version.ErrInvalidFormat) {
+			t.Fatalf("resolveReleaseVersion() error = %v, want %v", err, version.ErrInvalidFormat)
+		}
+		if !strings.Contains(err.Error(), releaseVersionEnv) {
+			t.Fatalf("resolveReleaseVersion() error = %v, want it to name %s", err, releaseVersionEnv)
+		}
+	})
+}
+
 func TestShouldPromptPrepare(t *testing.T) {
 	t.Parallel()
 
@@ -138,3 +190,112 @@ func TestShouldPromptPrepare(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatJSONError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err  error
+		want jsonErrorDetail
+	}{
+		{
+			err: fmt.Errorf("handle changelog: %w", internal.ErrChangelogMissing),
+			want: jsonErrorDetail{
+				Code:    "CHANGELOG_MISSING",
+				Message: "handle changelog: changelog version section not found",
+				Kind:    internal.KindPrecondition,
+			},
+		},
+		{
+			err: fmt.Errorf("create release: %w", internal.ErrGitHubNotAuthenticated),
+			want: jsonErrorDetail{
+				Code:    "GH_NOT_AUTHENTICATED",
+				Message: "create release: " + internal.ErrGitHubNotAuthenticated.Error(),
+				Kind:    internal.KindEnvironment,
+			},
+		},
+		{
+			err:  errComponentRequired,
+			want: jsonErrorDetail{Code: "INVALID_ARGUMENTS", Message: "component is required", Kind: "invalid_arguments"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.want.Code, func(t *testing.T) {
+			t.Parallel()
+
+			line := formatJSONError(internal.ClassifyError(tc.err))
+			var got map[string]map[string]string
+			if err := json.Unmarshal([]byte(line), &got); err != nil {
+				t.Fatalf("formatJSONError() = %q is not JSON: %v", line, err)
+			}
+			want := map[string]map[string]string{"error": {
+				"code":    tc.want.Code,
+				"message": tc.want.Message,
+				"kind":    tc.want.Kind,
+			}}
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Fatalf("formatJSONError() = %v, want %v", got, want)
+			}
+			if strings.Contains(line, "\n") {
+				t.Fatalf("formatJSONError() spans lines: %q", line)
+			}
+		})
+	}
+}
+
+func TestParseGlobalFlags(t *testing.T) {
+	t.Parallel()
+
+	jsonErrors, rest := parseGlobalFlags([]string{"-json-errors", "prepare", "-json-errors"})
+	if !jsonErrors || strings.Join(rest, " ") != "prepare -json-errors" {
+		t.Fatalf("parseGlobalFlags() = %v, %q; want true, [prepare -json-errors]", jsonErrors, rest)
+	}
+	jsonErrors, rest = parseGlobalFlags([]string{"prepare"})
+	if jsonErrors || strings.Join(rest, " ") != "prepare" {
+		t.Fatalf("parseGlobalFlags() = %v, %q; want false, [prepare]", jsonErrors, rest)
+	}
+}
+
+func TestFormatError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		err        error
+		want       string
+		wantStatus int
+	}{
+		{
+			name:       "mapped sentinel",
+			err:        fmt.Errorf("handle changelog: %w", internal.ErrChangelogMissing),
+			want:       "error [CHANGELOG_MISSING]: handle changelog: changelog version section not found",
+			wantStatus: 20,
+		},
+		{
+			name:       "missing argument",
+			err:        errComponentRequired,
+			want:       "error [INVALID_ARGUMENTS]: component is required",
+			wantStatus: internal.ExitStatusInvalidArguments,
+		},
+		{
+			name:       "workflow outside ci",
+			err:        fmt.Errorf("validate workflow execution context: %w", errWorkflowRequiresCI),
+			want:       "error [CI_REQUIRED]: validate workflow execution context: workflow command may only run in CI; use -dry-run for local validation",
+			wantStatus: exitStatusRequiresCI,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			coded := internal.ClassifyError(tc.err)
+			if got := formatError(coded); got != tc.want {
+				t.Fatalf("formatError() = %q, want %q", got, tc.want)
+			}
+			if coded.Status != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", coded.Status, tc.wantStatus)
+			}
+		})
+	}
+}
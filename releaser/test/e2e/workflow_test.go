@@ -48,6 +48,11 @@ func (v releaseFlow) nextPrerelease(t *testing.T, expected int) (releaseFlow, st
 	return v, version
 }
 
+// preview returns the unnumbered preview version that prepare numbers itself.
+func (v releaseFlow) preview() string {
+	return fmt.Sprintf("v%d.%d.%d-preview", v.major, v.minor, v.patch)
+}
+
 func (v releaseFlow) stabilize() string {
 	return v.stable()
 }
@@ -158,8 +163,8 @@ func TestReleaseWorkflow_LocalRepo(t *testing.T) {
 	)
 	bugfixSHA := s.headSHA()
 
-	// 5) Release v1.1.0-preview.2 on main.
-	promoted = s.prepareAndMerge(previewV110p2, mainBranchName)
+	// 5) Release v1.1.0-preview.2 on main, letting prepare pick the preview number.
+	promoted = s.prepareAndMerge(v110.preview(), mainBranchName)
 	assertVersionOrder(t, promoted, previewV110p2, previewV110p1)
 	assertVersionOrder(t, promoted, previewV110p1, stableV100)
 	s.release(previewV110p2, mainBranchName, true)
@@ -225,6 +230,19 @@ func TestWorkflowRun_ErrorCases(t *testing.T) {
 			},
 			expectErr: internal.ErrTagExists,
 		},
+		{
+			name:      "version_regression_on_origin",
+			version:   previewVersion,
+			changelog: changelogPreview,
+			setupRepo: func(t *testing.T, repo *repoFixture, _ internal.Logger) {
+				t.Helper()
+				newer := component + "/v1.3.0-preview.1"
+				runGit(t, nil, repo.dir, "tag", newer)
+				runGit(t, nil, repo.dir, "push", "origin", newer)
+				runGit(t, nil, repo.dir, "tag", "-d", newer)
+			},
+			expectErr: internal.ErrVersionRegression,
+		},
 	}
 
 	for _, tt := range tests {
@@ -300,10 +318,10 @@ func TestRunWorkflow_StudioctlLikeRepo_LocalRepo(t *testing.T) {
 		"studioctl-windows-amd64.exe",
 		"studioctl-windows-arm64.exe",
 		"localtest-resources.tar.gz",
+		"localtest-resources.manifest",
 		"install.sh",
 		"install.ps1",
 		"SHA256SUMS",
-		"release-notes.md",
 	}
 	for _, name := range expectedArtifacts {
 		path := filepath.Join(outputDir, name)
@@ -316,12 +334,11 @@ func TestRunWorkflow_StudioctlLikeRepo_LocalRepo(t *testing.T) {
 		}
 	}
 
-	notes, readErr := os.ReadFile(filepath.Join(outputDir, "release-notes.md"))
-	if readErr != nil {
-		t.Fatalf("read release notes: %v", readErr)
+	if _, statErr := os.Stat(filepath.Join(outputDir, "release-notes.md")); !os.IsNotExist(statErr) {
+		t.Fatalf("release notes file written during dry run (stat error: %v)", statErr)
 	}
-	if !strings.Contains(string(notes), "Latest studioctl preview") {
-		t.Fatalf("release notes missing latest prerelease notes:\n%s", string(notes))
+	if !strings.Contains(logger.buf.String(), "Latest studioctl preview") {
+		t.Fatalf("dry-run log missing latest prerelease notes:\n%s", logger.buf.String())
 	}
 
 	checksums, readErr := os.ReadFile(filepath.Join(outputDir, "SHA256SUMS"))
@@ -329,12 +346,22 @@ func TestRunWorkflow_StudioctlLikeRepo_LocalRepo(t *testing.T) {
 		t.Fatalf("read SHA256SUMS: %v", readErr)
 	}
 	lines := strings.Split(strings.TrimSpace(string(checksums)), "\n")
-	if len(lines) != 9 {
-		t.Fatalf("SHA256SUMS line count = %d, want 9", len(lines))
+	if len(lines) != 10 {
+		t.Fatalf("SHA256SUMS line count = %d, want 10", len(lines))
 	}
 	if !strings.Contains(string(checksums), "localtest-resources.tar.gz") {
 		t.Fatalf("SHA256SUMS missing localtest-resources.tar.gz entry:\n%s", string(checksums))
 	}
+	manifest, readErr := os.ReadFile(filepath.Join(outputDir, "localtest-resources.manifest"))
+	if readErr != nil {
+		t.Fatalf("read resources manifest: %v", readErr)
+	}
+	for line := range strings.SplitSeq(strings.TrimSpace(string(manifest)), "\n") {
+		sum, path, ok := strings.Cut(line, "  ")
+		if !ok || len(sum) != 64 || (!strings.HasPrefix(path, "testdata/") && !strings.HasPrefix(path, "infra/")) {
+			t.Fatalf("resources manifest line %q is not \"<sha256>  <path>\"", line)
+		}
+	}
 
 	expectedTag := "studioctl/v1.2.0-preview.2"
 	for _, scriptName := range []string{"install.sh", "install.ps1"} {
@@ -887,16 +914,21 @@ type fakeGH struct {
 	releaseTag        string
 	releaseTarget     string
 	prBase            string
+	releaseAssets     []string
 	releasePrerelease bool
 	releaseCreated    bool
 	prCreated         bool
 }
 
-func (g *fakeGH) CreateRelease(_ context.Context, opts internal.Options) error {
+func (g *fakeGH) CreateRelease(_ context.Context, opts internal.Options) (string, error) {
 	g.releaseCreated = true
 	g.releaseTag = opts.Tag
 	g.releaseTarget = opts.Target
 	g.releasePrerelease = opts.Prerelease
+	g.releaseAssets = g.releaseAssets[:0]
+	for _, asset := range opts.Assets {
+		g.releaseAssets = append(g.releaseAssets, filepath.Base(asset))
+	}
 	if g.log != nil {
 		g.log.Info(
 			"gh create release: tag=%s target=%s prerelease=%v assets=%d",
@@ -906,7 +938,7 @@ func (g *fakeGH) CreateRelease(_ context.Context, opts internal.Options) error {
 			len(opts.Assets),
 		)
 	}
-	return nil
+	return "https://example.test/releases/tag/" + opts.Tag, nil
 }
 
 func (g *fakeGH) CreatePR(_ context.Context, opts internal.PullRequestOptions) (string, error) {
@@ -918,12 +950,28 @@ func (g *fakeGH) CreatePR(_ context.Context, opts internal.PullRequestOptions) (
 	return "https://example.test/pr/1", nil
 }
 
+func (g *fakeGH) ReleaseAssets(_ context.Context, _ string) ([]string, error) {
+	return append([]string(nil), g.releaseAssets...), nil
+}
+
+func (g *fakeGH) ReleaseURL(_ context.Context, tag string) (string, error) {
+	if !g.releaseCreated || g.releaseTag != tag {
+		return "", internal.ErrReleaseNotFound
+	}
+	return "https://example.test/releases/tag/" + tag, nil
+}
+
+func (g *fakeGH) EnsureAuthenticated(_ context.Context) error {
+	return nil
+}
+
 func (g *fakeGH) SetWorkdir(_ string) {}
 
 func (g *fakeGH) reset() {
 	g.releaseTag = ""
 	g.releaseTarget = ""
 	g.releasePrerelease = false
+	g.releaseAssets = nil
 	g.releaseCreated = false
 	g.prBase = ""
 	g.prCreated = false
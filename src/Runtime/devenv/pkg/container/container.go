@@ -32,6 +32,7 @@ package container
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -97,6 +98,9 @@ var ErrNetworkNotFound = types.ErrNetworkNotFound
 // ErrImageNotFound is returned when an image does not exist.
 var ErrImageNotFound = types.ErrImageNotFound
 
+// ErrNoRuntime is returned by Detect when neither Docker nor Podman is reachable.
+var ErrNoRuntime = errors.New("no container runtime found")
+
 const (
 	InstallationUnknown        = types.InstallationUnknown
 	InstallationDocker         = types.InstallationDocker
@@ -154,6 +158,10 @@ type ContainerClient interface {
 	// If force is true, the container is killed before removal.
 	ContainerRemove(ctx context.Context, nameOrID string, force bool) error
 
+	// ContainerRename changes the name of a container.
+	// Returns ErrContainerNotFound if the container does not exist.
+	ContainerRename(ctx context.Context, nameOrID, newName string) error
+
 	// NetworkCreate creates a new network.
 	// Returns the network ID.
 	NetworkCreate(ctx context.Context, cfg types.NetworkConfig) (string, error)
@@ -232,7 +240,8 @@ func detectRuntime(ctx context.Context) (runtimeType, string, error) {
 	}
 
 	// Try Docker Engine API (checks DOCKER_HOST or default /var/run/docker.sock)
-	if cli, err := dockerapi.New(ctx); err == nil {
+	cli, dockerErr := dockerapi.New(ctx)
+	if dockerErr == nil {
 		_ = cli.Close()
 		// Check if docker CLI is available
 		if _, lookErr := exec.LookPath("docker"); lookErr == nil {
@@ -257,7 +266,11 @@ func detectRuntime(ctx context.Context) (runtimeType, string, error) {
 		return runtimePodmanCLI, "", nil
 	}
 
-	return runtimeUnknown, "", fmt.Errorf("no container runtime found (tried Docker API, Podman socket, Podman CLI)")
+	// A socket we may not use is a setup problem, not a missing runtime.
+	if errors.Is(dockerErr, os.ErrPermission) {
+		return runtimeUnknown, "", fmt.Errorf("connect to Docker API: %w", dockerErr)
+	}
+	return runtimeUnknown, "", fmt.Errorf("%w (tried Docker API, Podman socket, Podman CLI)", ErrNoRuntime)
 }
 
 // newClientForType creates a new client based on the detected runtime type
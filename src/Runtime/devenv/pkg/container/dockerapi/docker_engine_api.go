@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"altinn.studio/devenv/pkg/container/types"
 
@@ -225,6 +226,13 @@ func (c *Client) CreateContainer(ctx context.Context, cfg types.ContainerConfig)
 		Labels:       cfg.Labels,
 		User:         cfg.User,
 	}
+	if cfg.Healthcheck != nil {
+		containerCfg.Healthcheck = &container.HealthConfig{
+			Test:     append([]string{"CMD"}, cfg.Healthcheck.Command...),
+			Interval: cfg.Healthcheck.Interval,
+			Retries:  cfg.Healthcheck.Retries,
+		}
+	}
 
 	// Determine primary network and additional networks
 	var primaryNetwork string
@@ -250,6 +258,10 @@ func (c *Client) CreateContainer(ctx context.Context, cfg types.ContainerConfig)
 		RestartPolicy: restartPolicy,
 		NetworkMode:   container.NetworkMode(primaryNetwork),
 		CapAdd:        capAdd,
+		Resources: container.Resources{
+			Memory:   cfg.Resources.MemoryBytes,
+			NanoCPUs: cfg.Resources.NanoCPUs,
+		},
 	}
 
 	// Create the container
@@ -289,11 +301,22 @@ func (c *Client) ContainerState(ctx context.Context, nameOrID string) (types.Con
 		}
 		return types.ContainerState{}, fmt.Errorf("failed to inspect container: %w", err)
 	}
+	var health string
+	if info.State.Health != nil {
+		health = string(info.State.Health.Status)
+	}
+	// Docker reports "0001-01-01T00:00:00Z" for containers that never started, which parses to zero.
+	startedAt, err := time.Parse(time.RFC3339Nano, info.State.StartedAt)
+	if err != nil {
+		startedAt = time.Time{}
+	}
 	return types.ContainerState{
-		Status:   string(info.State.Status),
-		Running:  info.State.Running,
-		Paused:   info.State.Paused,
-		ExitCode: info.State.ExitCode,
+		StartedAt: startedAt,
+		Status:    string(info.State.Status),
+		Health:    health,
+		Running:   info.State.Running,
+		Paused:    info.State.Paused,
+		ExitCode:  info.State.ExitCode,
 	}, nil
 }
 
@@ -476,6 +499,18 @@ func (c *Client) ContainerRemove(ctx context.Context, nameOrID string, force boo
 	return nil
 }
 
+// ContainerRename changes the name of a container.
+// Returns ErrContainerNotFound if the container does not exist.
+func (c *Client) ContainerRename(ctx context.Context, nameOrID, newName string) error {
+	if err := c.cli.ContainerRename(ctx, nameOrID, newName); err != nil {
+		if cerrdefs.IsNotFound(err) {
+			return types.ErrContainerNotFound
+		}
+		return fmt.Errorf("failed to rename container: %w", err)
+	}
+	return nil
+}
+
 // NetworkCreate creates a new network
 func (c *Client) NetworkCreate(ctx context.Context, cfg types.NetworkConfig) (string, error) {
 	driver := cfg.Driver
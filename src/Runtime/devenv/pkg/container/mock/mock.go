@@ -29,6 +29,7 @@ type Client struct {
 	ContainerStartFunc    func(ctx context.Context, nameOrID string) error
 	ContainerStopFunc     func(ctx context.Context, nameOrID string, timeout *int) error
 	ContainerRemoveFunc   func(ctx context.Context, nameOrID string, force bool) error
+	ContainerRenameFunc   func(ctx context.Context, nameOrID, newName string) error
 	NetworkCreateFunc     func(ctx context.Context, cfg types.NetworkConfig) (string, error)
 	NetworkInspectFunc    func(ctx context.Context, nameOrID string) (types.NetworkInfo, error)
 	NetworkRemoveFunc     func(ctx context.Context, nameOrID string) error
@@ -183,6 +184,15 @@ func (c *Client) ContainerRemove(ctx context.Context, nameOrID string, force boo
 	return nil
 }
 
+// ContainerRename implements ContainerClient.
+func (c *Client) ContainerRename(ctx context.Context, nameOrID, newName string) error {
+	c.recordCall("ContainerRename", nameOrID, newName)
+	if c.ContainerRenameFunc != nil {
+		return c.ContainerRenameFunc(ctx, nameOrID, newName)
+	}
+	return nil
+}
+
 // NetworkCreate implements ContainerClient.
 func (c *Client) NetworkCreate(ctx context.Context, cfg types.NetworkConfig) (string, error) {
 	c.recordCall("NetworkCreate", cfg)
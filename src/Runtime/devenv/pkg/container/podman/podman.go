@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"io"
 	"os/exec"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"altinn.studio/devenv/pkg/container/types"
 )
@@ -130,6 +132,25 @@ func (c *Client) CreateContainer(ctx context.Context, cfg types.ContainerConfig)
 		args = append(args, "--user", cfg.User)
 	}
 
+	if cfg.Healthcheck != nil {
+		healthCmd, err := json.Marshal(cfg.Healthcheck.Command)
+		if err != nil {
+			return "", fmt.Errorf("encode health command: %w", err)
+		}
+		args = append(args,
+			"--health-cmd", string(healthCmd),
+			"--health-interval", cfg.Healthcheck.Interval.String(),
+			"--health-retries", strconv.Itoa(cfg.Healthcheck.Retries),
+		)
+	}
+
+	if cfg.Resources.MemoryBytes > 0 {
+		args = append(args, "--memory", strconv.FormatInt(cfg.Resources.MemoryBytes, 10))
+	}
+	if cfg.Resources.NanoCPUs > 0 {
+		args = append(args, "--cpus", strconv.FormatFloat(float64(cfg.Resources.NanoCPUs)/1e9, 'f', -1, 64))
+	}
+
 	// Add capabilities (merge defaults with any explicit ones)
 	caps := types.MergeCapabilities(types.DefaultPodmanCapabilities(), cfg.CapAdd)
 	for _, cap := range caps {
@@ -177,20 +198,26 @@ func (c *Client) ContainerState(ctx context.Context, nameOrID string) (types.Con
 	}
 
 	var state struct {
-		Status   string `json:"Status"`
-		Running  bool   `json:"Running"`
-		Paused   bool   `json:"Paused"`
-		ExitCode int    `json:"ExitCode"`
+		Health struct {
+			Status string `json:"Status"`
+		} `json:"Health"`
+		StartedAt time.Time `json:"StartedAt"`
+		Status    string    `json:"Status"`
+		Running   bool      `json:"Running"`
+		Paused    bool      `json:"Paused"`
+		ExitCode  int       `json:"ExitCode"`
 	}
 	if err := json.Unmarshal(bytes.TrimSpace(output), &state); err != nil {
 		return types.ContainerState{}, fmt.Errorf("failed to parse container state: %w", err)
 	}
 
 	return types.ContainerState{
-		Status:   state.Status,
-		Running:  state.Running,
-		Paused:   state.Paused,
-		ExitCode: state.ExitCode,
+		StartedAt: state.StartedAt,
+		Status:    state.Status,
+		Health:    state.Health.Status,
+		Running:   state.Running,
+		Paused:    state.Paused,
+		ExitCode:  state.ExitCode,
 	}, nil
 }
 
@@ -414,6 +441,19 @@ func (c *Client) ContainerRemove(ctx context.Context, nameOrID string, force boo
 	return nil
 }
 
+// ContainerRename changes the name of a container
+func (c *Client) ContainerRename(ctx context.Context, nameOrID, newName string) error {
+	cmd := exec.CommandContext(ctx, "podman", "rename", nameOrID, newName)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if isContainerNotFoundOutput(output) {
+			return types.ErrContainerNotFound
+		}
+		return fmt.Errorf("podman rename failed: %w\nOutput: %s", err, string(output))
+	}
+	return nil
+}
+
 func isContainerNotFoundOutput(output []byte) bool {
 	lower := strings.ToLower(string(output))
 	return strings.Contains(lower, "no such container") ||
@@ -1,6 +1,9 @@
 package types
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 // ErrContainerNotFound is returned when a container does not exist.
 var ErrContainerNotFound = errors.New("container not found")
@@ -86,6 +89,27 @@ type VolumeMount struct {
 	ReadOnly      bool
 }
 
+// ResourceLimits caps the memory and CPU a container may use.
+// Zero values mean unlimited.
+type ResourceLimits struct {
+	MemoryBytes int64 // hard memory limit in bytes
+	NanoCPUs    int64 // CPU quota in units of 1e-9 CPUs
+}
+
+// Healthcheck defines how the runtime probes a container's health.
+type Healthcheck struct {
+	Command  []string      // exec-form command; exit code 0 means healthy
+	Interval time.Duration // time between probes
+	Retries  int           // consecutive failures before the container is unhealthy
+}
+
+// Health states reported in ContainerState.Health.
+const (
+	HealthStarting  = "starting"
+	HealthHealthy   = "healthy"
+	HealthUnhealthy = "unhealthy"
+)
+
 // ContainerConfig defines options for creating a container
 type ContainerConfig struct {
 	Name          string
@@ -99,8 +123,10 @@ type ContainerConfig struct {
 	RestartPolicy string   // "no", "always", "on-failure", "unless-stopped"
 	Detach        bool
 	Labels        map[string]string
-	User          string   // "uid:gid" to run as (e.g., "1000:1000")
-	CapAdd        []string // Linux capabilities to add (e.g., "NET_RAW", "MKNOD")
+	User          string       // "uid:gid" to run as (e.g., "1000:1000")
+	CapAdd        []string     // Linux capabilities to add (e.g., "NET_RAW", "MKNOD")
+	Healthcheck   *Healthcheck // optional; nil keeps the image default
+	Resources     ResourceLimits
 }
 
 // ImageInfo contains metadata about an image
@@ -111,10 +137,12 @@ type ImageInfo struct {
 
 // ContainerState represents the state of a container.
 type ContainerState struct {
-	Status   string // "created", "running", "paused", "restarting", "removing", "exited", "dead"
-	Running  bool
-	Paused   bool
-	ExitCode int
+	StartedAt time.Time // when the container last started; zero if it never started
+	Status    string    // "created", "running", "paused", "restarting", "removing", "exited", "dead"
+	Health    string    // HealthStarting, HealthHealthy, HealthUnhealthy, or empty without a healthcheck
+	Running   bool
+	Paused    bool
+	ExitCode  int
 }
 
 // ContainerInfo contains detailed information about a container.
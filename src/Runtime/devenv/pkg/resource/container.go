@@ -20,6 +20,8 @@ type Container struct {
 	ExtraHosts    []string // "hostname:ip" pairs
 	RestartPolicy string   // "no", "always", "on-failure", "unless-stopped"
 	User          string   // "uid:gid" to run as (e.g., "1000:1000")
+	Healthcheck   *types.Healthcheck
+	Resources     types.ResourceLimits
 }
 
 // ID returns the unique identifier for this container.
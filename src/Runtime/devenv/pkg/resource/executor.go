@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"time"
 
 	"altinn.studio/devenv/pkg/container"
 	"altinn.studio/devenv/pkg/container/types"
@@ -17,11 +18,16 @@ import (
 // Executor applies resources to infrastructure using a container client.
 // It maintains resolved state (e.g., image IDs) across resource applications.
 type Executor struct {
-	client   container.ContainerClient
-	observer Observer
-	resolved *resolvedMap // Stores resolved state (image IDs, etc.)
+	client      container.ContainerClient
+	observer    Observer
+	resolved    *resolvedMap        // Stores resolved state (image IDs, etc.)
+	recreate    map[ResourceID]bool // Containers replaced even when they match the desired state
+	pullTimeout time.Duration       // Bounds each image pull; zero means no limit
 }
 
+// ErrPullTimeout is returned when an image pull does not finish within the pull timeout.
+var ErrPullTimeout = errors.New("image pull timed out")
+
 const containerSpecHashLabel = "altinn.studio/devenv-spec-hash"
 const networkResourceIDPrefix = "network:"
 
@@ -38,6 +44,21 @@ func (e *Executor) SetObserver(o Observer) {
 	e.observer = o
 }
 
+// SetPullTimeout bounds each remote image pull. A pull that runs longer is cancelled
+// and fails with ErrPullTimeout. Zero disables the limit.
+func (e *Executor) SetPullTimeout(d time.Duration) {
+	e.pullTimeout = d
+}
+
+// SetRecreate marks containers to be stopped, removed and created again on Apply,
+// even when the existing container already matches the desired state.
+func (e *Executor) SetRecreate(ids ...ResourceID) {
+	e.recreate = make(map[ResourceID]bool, len(ids))
+	for _, id := range ids {
+		e.recreate[id] = true
+	}
+}
+
 // Apply creates/updates all resources in the graph in dependency order.
 // Resources at the same dependency level are applied in parallel.
 func (e *Executor) Apply(ctx context.Context, g *Graph) error {
@@ -168,13 +189,13 @@ func (e *Executor) applyRemoteImage(ctx context.Context, img *RemoteImage) error
 
 	switch img.PullPolicy {
 	case PullAlways:
-		if err := e.client.ImagePull(ctx, img.Ref); err != nil {
-			return fmt.Errorf("pull image %s: %w", img.Ref, err)
+		if err := e.pullImage(ctx, img.Ref); err != nil {
+			return err
 		}
 	case PullIfNotPresent:
 		if !imageExists {
-			if err := e.client.ImagePull(ctx, img.Ref); err != nil {
-				return fmt.Errorf("pull image %s: %w", img.Ref, err)
+			if err := e.pullImage(ctx, img.Ref); err != nil {
+				return err
 			}
 		}
 	case PullNever:
@@ -193,6 +214,27 @@ func (e *Executor) applyRemoteImage(ctx context.Context, img *RemoteImage) error
 	return nil
 }
 
+// pullImage pulls ref, cancelling the pull when it exceeds the pull timeout.
+func (e *Executor) pullImage(ctx context.Context, ref string) error {
+	if e.pullTimeout <= 0 {
+		if err := e.client.ImagePull(ctx, ref); err != nil {
+			return fmt.Errorf("pull image %s: %w", ref, err)
+		}
+		return nil
+	}
+
+	pullCtx, cancel := context.WithTimeout(ctx, e.pullTimeout)
+	defer cancel()
+	if err := e.client.ImagePull(pullCtx, ref); err != nil {
+		// Only our own timeout is reported as such; a cancelled parent context keeps its error.
+		if ctx.Err() == nil && errors.Is(pullCtx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("pull image %s: %w after %s", ref, ErrPullTimeout, e.pullTimeout)
+		}
+		return fmt.Errorf("pull image %s: %w", ref, err)
+	}
+	return nil
+}
+
 func (e *Executor) applyLocalImage(ctx context.Context, img *LocalImage) error {
 	dockerfile := img.Dockerfile
 	if dockerfile == "" {
@@ -312,10 +354,11 @@ func (e *Executor) applyContainer(ctx context.Context, c *Container) error {
 		}
 
 		// Container exists - check if matches desired state
-		if info.ImageID != imageID ||
+		if e.recreate[c.ID()] ||
+			info.ImageID != imageID ||
 			!labelsMatch(desiredLabels, info.Labels) ||
 			!networksMatch(networks, actualNetworks) {
-			// Mismatch - recreate
+			// Mismatch or forced - recreate
 			if err := e.stopAndRemoveContainer(ctx, c.Name); err != nil {
 				return err
 			}
@@ -344,6 +387,8 @@ func (e *Executor) applyContainer(ctx context.Context, c *Container) error {
 		Labels:        desiredLabels,
 		Detach:        true,
 		User:          c.User,
+		Healthcheck:   c.Healthcheck,
+		Resources:     c.Resources,
 	}
 
 	cfg.Networks = networks
@@ -483,6 +528,14 @@ func containerSpecHash(c *Container, imageID string, networks []string) string {
 	b.WriteString(c.RestartPolicy)
 	b.WriteByte('\n')
 
+	// Optional settings are only hashed when set so older containers are not recreated.
+	if hc := c.Healthcheck; hc != nil {
+		fmt.Fprintf(&b, "healthcheck=%s|%s|%d\n", strings.Join(hc.Command, "\x00"), hc.Interval, hc.Retries)
+	}
+	if c.Resources != (types.ResourceLimits{}) {
+		fmt.Fprintf(&b, "resources=%d|%d\n", c.Resources.MemoryBytes, c.Resources.NanoCPUs)
+	}
+
 	portEntries := make([]string, 0, len(c.Ports))
 	for _, p := range c.Ports {
 		portEntries = append(
@@ -3,8 +3,10 @@ package resource
 import (
 	"context"
 	"errors"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	containermock "altinn.studio/devenv/pkg/container/mock"
 	"altinn.studio/devenv/pkg/container/types"
@@ -147,3 +149,107 @@ func TestExecutor_StopAndRemoveContainer_IgnoresContainerNotFound(t *testing.T)
 		t.Fatalf("stopAndRemoveContainer() error = %v, want nil", err)
 	}
 }
+
+func TestExecutor_Apply_PullTimeout(t *testing.T) {
+	t.Parallel()
+
+	client := containermock.New()
+	client.ImageInspectFunc = func(_ context.Context, image string) (types.ImageInfo, error) {
+		if image == "registry.example/stalled:1" {
+			return types.ImageInfo{}, types.ErrImageNotFound
+		}
+		return types.ImageInfo{ID: "sha256:fine"}, nil
+	}
+	client.ImagePullFunc = func(ctx context.Context, _ string) error {
+		<-ctx.Done() // a stalled registry never answers
+		return ctx.Err()
+	}
+
+	g := NewGraph()
+	for _, ref := range []string{"registry.example/stalled:1", "registry.example/fine:1"} {
+		if err := g.Add(&RemoteImage{Ref: ref, PullPolicy: PullIfNotPresent}); err != nil {
+			t.Fatalf("Add(%s) error = %v", ref, err)
+		}
+	}
+
+	exec := NewExecutor(client)
+	exec.SetPullTimeout(20 * time.Millisecond)
+	err := exec.Apply(t.Context(), g)
+	if !errors.Is(err, ErrPullTimeout) {
+		t.Fatalf("Apply() error = %v, want ErrPullTimeout", err)
+	}
+	if !strings.Contains(err.Error(), "registry.example/stalled:1") {
+		t.Fatalf("Apply() error = %v, want the stalled image named", err)
+	}
+}
+
+func TestExecutor_Apply_Recreate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		recreate []ResourceID
+		want     []string
+	}{
+		"matching container is kept": {
+			recreate: nil,
+			want:     nil,
+		},
+		"recreate replaces matching container": {
+			recreate: []ResourceID{"container:app"},
+			want:     []string{"ContainerStop", "ContainerRemove", "CreateContainer"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			img := &RemoteImage{Ref: "registry.example/app:1", PullPolicy: PullIfNotPresent}
+			net := &Network{Name: "appnet"}
+			app := &Container{Name: "app", Image: Ref(img), Networks: Deps(net)}
+			labels := normalizedContainerLabels(app, "sha256:app", []string{"appnet"})
+
+			client := containermock.New()
+			client.ImageInspectFunc = func(context.Context, string) (types.ImageInfo, error) {
+				return types.ImageInfo{ID: "sha256:app"}, nil
+			}
+			client.NetworkInspectFunc = func(context.Context, string) (types.NetworkInfo, error) {
+				return types.NetworkInfo{}, nil
+			}
+			client.ContainerInspectFunc = func(context.Context, string) (types.ContainerInfo, error) {
+				return types.ContainerInfo{
+					ImageID: "sha256:app",
+					Labels:  labels,
+					State:   types.ContainerState{Status: "running", Running: true},
+				}, nil
+			}
+			client.ContainerNetworksFunc = func(context.Context, string) ([]string, error) {
+				return []string{"appnet"}, nil
+			}
+
+			g := NewGraph()
+			for _, r := range []Resource{img, net, app} {
+				if err := g.Add(r); err != nil {
+					t.Fatalf("Add(%s) error = %v", r.ID(), err)
+				}
+			}
+
+			exec := NewExecutor(client)
+			exec.SetRecreate(tt.recreate...)
+			if err := exec.Apply(t.Context(), g); err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+
+			var got []string
+			for _, call := range client.Calls {
+				switch call.Method {
+				case "ContainerStop", "ContainerRemove", "CreateContainer":
+					got = append(got, call.Method)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("container calls = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
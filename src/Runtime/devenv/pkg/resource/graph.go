@@ -4,9 +4,16 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strings"
 	"sync"
 )
 
+// Graph validation errors.
+var (
+	ErrMissingDependency = errors.New("missing dependency")
+	ErrDependencyCycle   = errors.New("dependency cycle detected")
+)
+
 // Graph manages a DAG of resources with dependency tracking.
 // Graph is a pure data structure - use Executor to apply resources.
 type Graph struct {
@@ -70,11 +77,13 @@ func (g *Graph) Validate() error {
 	defer g.mu.RUnlock()
 
 	// Check all dependencies exist
-	for id, r := range g.resources {
-		for _, ref := range r.Dependencies() {
+	for _, id := range g.sortedIDs() {
+		for _, ref := range g.resources[id].Dependencies() {
 			depID := ref.ID()
 			if _, exists := g.resources[depID]; !exists {
-				return fmt.Errorf("resource %q depends on non-existent resource %q", id, depID)
+				return fmt.Errorf(
+					"%w: resource %q depends on non-existent resource %q", ErrMissingDependency, id, depID,
+				)
 			}
 		}
 	}
@@ -150,7 +159,9 @@ func (g *Graph) detectCycle() error {
 
 			dep, exists := g.resources[depID]
 			if !exists {
-				return fmt.Errorf("resource %q depends on non-existent resource %q", id, depID)
+				return fmt.Errorf(
+					"%w: resource %q depends on non-existent resource %q", ErrMissingDependency, id, depID,
+				)
 			}
 			if dep == nil {
 				return fmt.Errorf("resource %q depends on nil resource %q", id, depID)
@@ -164,7 +175,7 @@ func (g *Graph) detectCycle() error {
 				cycle := make([]ResourceID, len(cyclePath)+1)
 				copy(cycle, cyclePath)
 				cycle[len(cyclePath)] = depID
-				return fmt.Errorf("dependency cycle detected: %v", cycle)
+				return fmt.Errorf("%w: %s", ErrDependencyCycle, formatIDPath(cycle))
 			case white:
 				if err := visit(depID); err != nil {
 					return err
@@ -177,7 +188,7 @@ func (g *Graph) detectCycle() error {
 		return nil
 	}
 
-	for id := range g.resources {
+	for _, id := range g.sortedIDs() {
 		if colors[id] == white {
 			if err := visit(id); err != nil {
 				return err
@@ -187,6 +198,26 @@ func (g *Graph) detectCycle() error {
 	return nil
 }
 
+// sortedIDs returns the resource IDs in sorted order, so validation errors are stable.
+// Caller must hold read lock.
+func (g *Graph) sortedIDs() []ResourceID {
+	ids := make([]ResourceID, 0, len(g.resources))
+	for id := range g.resources {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// formatIDPath renders a dependency path as "a -> b -> c".
+func formatIDPath(path []ResourceID) string {
+	parts := make([]string, len(path))
+	for i, id := range path {
+		parts[i] = string(id)
+	}
+	return strings.Join(parts, " -> ")
+}
+
 // topologicalLevels returns resources grouped by dependency level.
 // Level 0 has no dependencies, level 1 depends only on level 0, etc.
 // Caller must hold read lock.
@@ -1,6 +1,7 @@
 package resource
 
 import (
+	"errors"
 	"strings"
 	"testing"
 )
@@ -86,10 +87,11 @@ func TestGraph_All(t *testing.T) {
 
 func TestGraph_Validate(t *testing.T) {
 	tests := []struct {
+		target    error
 		name      string
+		errMsg    string
 		resources []Resource
 		wantErr   bool
-		errMsg    string
 	}{
 		{
 			name: "valid graph no deps",
@@ -113,6 +115,7 @@ func TestGraph_Validate(t *testing.T) {
 				&mockResource{id: "a", deps: DepIDs("nonexistent")},
 			},
 			wantErr: true,
+			target:  ErrMissingDependency,
 			errMsg:  "non-existent resource",
 		},
 		{
@@ -121,7 +124,8 @@ func TestGraph_Validate(t *testing.T) {
 				&mockResource{id: "a", deps: DepIDs("a")},
 			},
 			wantErr: true,
-			errMsg:  "cycle",
+			target:  ErrDependencyCycle,
+			errMsg:  "cycle detected: a -> a",
 		},
 		{
 			name: "two node cycle",
@@ -130,7 +134,8 @@ func TestGraph_Validate(t *testing.T) {
 				&mockResource{id: "b", deps: DepIDs("a")},
 			},
 			wantErr: true,
-			errMsg:  "cycle",
+			target:  ErrDependencyCycle,
+			errMsg:  "cycle detected: a -> b -> a",
 		},
 		{
 			name: "three node cycle",
@@ -140,7 +145,8 @@ func TestGraph_Validate(t *testing.T) {
 				&mockResource{id: "c", deps: DepIDs("b")},
 			},
 			wantErr: true,
-			errMsg:  "cycle",
+			target:  ErrDependencyCycle,
+			errMsg:  "cycle detected: a -> c -> b -> a",
 		},
 	}
 
@@ -154,6 +160,9 @@ func TestGraph_Validate(t *testing.T) {
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if tt.target != nil && !errors.Is(err, tt.target) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.target)
+			}
 			if tt.wantErr && tt.errMsg != "" {
 				if err == nil {
 					t.Fatalf("Validate() expected error containing %q, got nil", tt.errMsg)
@@ -0,0 +1,115 @@
+// Package retry runs operations with capped exponential backoff and jitter.
+// It is shared by studioctl and the releaser.
+package retry
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	defaultAttempts = 3
+	defaultInitial  = time.Second
+	defaultMax      = 30 * time.Second
+	defaultJitter   = 0.5
+)
+
+// Policy configures how often and how long Do waits between attempts.
+//
+// The delay before retry n (0-based) is Initial*2^n capped at Max, reduced by a
+// random fraction of up to Jitter, so it lies in [base*(1-Jitter), base].
+type Policy struct {
+	// Sleep waits for d or until ctx is done; nil uses a timer.
+	Sleep func(ctx context.Context, d time.Duration) error
+	// Rand returns a value in [0, 1); nil uses math/rand.
+	Rand func() float64
+	// OnRetry is called before each wait; nil disables it.
+	OnRetry  func(attempt int, delay time.Duration, err error)
+	Initial  time.Duration
+	Max      time.Duration
+	Attempts int     // total attempts including the first; values below 1 mean 1
+	Jitter   float64 // fraction of the delay that is randomized, clamped to [0, 1]
+}
+
+// DefaultPolicy returns three attempts starting at one second, capped at 30 seconds, with 50% jitter.
+func DefaultPolicy() Policy {
+	return Policy{
+		Sleep:    nil,
+		Rand:     nil,
+		OnRetry:  nil,
+		Initial:  defaultInitial,
+		Max:      defaultMax,
+		Attempts: defaultAttempts,
+		Jitter:   defaultJitter,
+	}
+}
+
+// Delay returns the jittered wait before retry n (0-based).
+func (p Policy) Delay(n int) time.Duration {
+	base := p.Initial
+	for i := 0; i < n && base < p.Max; i++ {
+		base *= 2
+	}
+	if p.Max > 0 {
+		base = min(base, p.Max)
+	}
+
+	jitter := min(max(p.Jitter, 0), 1)
+	if jitter == 0 || base <= 0 {
+		return base
+	}
+	random := p.Rand
+	if random == nil {
+		random = rand.Float64 //nolint:gosec // jitter does not need cryptographic randomness
+	}
+	return base - time.Duration(float64(base)*jitter*random())
+}
+
+// Do calls fn until it succeeds, retryable reports false for its error, the
+// attempts are used up, or ctx is done. It returns the last result and error.
+// A nil retryable retries every error.
+func Do[T any](
+	ctx context.Context,
+	p Policy,
+	retryable func(error) bool,
+	fn func(ctx context.Context) (T, error),
+) (T, error) {
+	sleep := p.Sleep
+	if sleep == nil {
+		sleep = sleepContext
+	}
+	attempts := max(p.Attempts, 1)
+
+	var zero T
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return zero, fmt.Errorf("retry: %w", err)
+		}
+
+		result, err := fn(ctx)
+		if err == nil || attempt >= attempts || (retryable != nil && !retryable(err)) {
+			return result, err
+		}
+
+		delay := p.Delay(attempt - 1)
+		if p.OnRetry != nil {
+			p.OnRetry(attempt, delay, err)
+		}
+		if sleepErr := sleep(ctx, delay); sleepErr != nil {
+			return zero, fmt.Errorf("%w (retry aborted: %w)", err, sleepErr)
+		}
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("sleep interrupted: %w", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
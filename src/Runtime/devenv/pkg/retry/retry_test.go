@@ -0,0 +1,172 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"altinn.studio/devenv/pkg/retry"
+)
+
+var (
+	errTransient = errors.New("transient")
+	errPermanent = errors.New("permanent")
+)
+
+func TestPolicy_DelaySchedule(t *testing.T) {
+	t.Parallel()
+
+	p := retry.Policy{
+		Sleep:    nil,
+		Rand:     nil,
+		OnRetry:  nil,
+		Initial:  100 * time.Millisecond,
+		Max:      time.Second,
+		Attempts: 6,
+		Jitter:   0,
+	}
+	want := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	}
+	for n, w := range want {
+		if got := p.Delay(n); got != w {
+			t.Errorf("Delay(%d) = %s, want %s", n, got, w)
+		}
+	}
+}
+
+func TestPolicy_DelayJitterBounds(t *testing.T) {
+	t.Parallel()
+
+	base := 400 * time.Millisecond
+	for _, r := range []float64{0, 0.25, 0.5, 0.999} {
+		p := retry.Policy{
+			Sleep:    nil,
+			Rand:     func() float64 { return r },
+			OnRetry:  nil,
+			Initial:  base,
+			Max:      time.Second,
+			Attempts: 2,
+			Jitter:   0.5,
+		}
+		got := p.Delay(0)
+		if got < base/2 || got > base {
+			t.Errorf("Delay(0) with rand %v = %s, want within [%s, %s]", r, got, base/2, base)
+		}
+	}
+
+	// The default random source stays within the same bounds.
+	p := retry.DefaultPolicy()
+	p.Initial = base
+	for range 100 {
+		if got := p.Delay(0); got < base/2 || got > base {
+			t.Fatalf("Delay(0) = %s, want within [%s, %s]", got, base/2, base)
+		}
+	}
+}
+
+func TestDo_RetriesUntilSuccess(t *testing.T) {
+	t.Parallel()
+
+	var waits []time.Duration
+	p := retry.Policy{
+		Sleep: func(_ context.Context, d time.Duration) error {
+			waits = append(waits, d)
+			return nil
+		},
+		Rand:     nil,
+		OnRetry:  nil,
+		Initial:  10 * time.Millisecond,
+		Max:      time.Second,
+		Attempts: 4,
+		Jitter:   0,
+	}
+
+	calls := 0
+	got, err := retry.Do(t.Context(), p, nil, func(context.Context) (string, error) {
+		calls++
+		if calls < 3 {
+			return "", errTransient
+		}
+		return "ok", nil
+	})
+	if err != nil || got != "ok" {
+		t.Fatalf("Do() = %q, %v; want ok, nil", got, err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+	if len(waits) != 2 || waits[0] != 10*time.Millisecond || waits[1] != 20*time.Millisecond {
+		t.Errorf("waits = %v, want [10ms 20ms]", waits)
+	}
+}
+
+func TestDo_StopsOnPermanentErrorAndExhaustion(t *testing.T) {
+	t.Parallel()
+
+	p := retry.Policy{
+		Sleep:    func(context.Context, time.Duration) error { return nil },
+		Rand:     nil,
+		OnRetry:  nil,
+		Initial:  time.Millisecond,
+		Max:      time.Millisecond,
+		Attempts: 3,
+		Jitter:   0,
+	}
+	retryable := func(err error) bool { return errors.Is(err, errTransient) }
+
+	calls := 0
+	_, err := retry.Do(t.Context(), p, retryable, func(context.Context) (int, error) {
+		calls++
+		return 0, errPermanent
+	})
+	if !errors.Is(err, errPermanent) || calls != 1 {
+		t.Errorf("permanent: err = %v after %d calls, want errPermanent after 1", err, calls)
+	}
+
+	calls = 0
+	_, err = retry.Do(t.Context(), p, retryable, func(context.Context) (int, error) {
+		calls++
+		return 0, errTransient
+	})
+	if !errors.Is(err, errTransient) || calls != 3 {
+		t.Errorf("exhausted: err = %v after %d calls, want errTransient after 3", err, calls)
+	}
+}
+
+func TestDo_ContextCancellation(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	p := retry.DefaultPolicy()
+	p.Initial = time.Hour
+	p.Max = time.Hour
+	p.OnRetry = func(int, time.Duration, error) { cancel() }
+
+	calls := 0
+	_, err := retry.Do(ctx, p, nil, func(context.Context) (int, error) {
+		calls++
+		return 0, errTransient
+	})
+	if !errors.Is(err, context.Canceled) || !errors.Is(err, errTransient) {
+		t.Errorf("Do() error = %v, want both context.Canceled and the last error", err)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+
+	calls = 0
+	_, err = retry.Do(ctx, p, nil, func(context.Context) (int, error) {
+		calls++
+		return 0, nil
+	})
+	if !errors.Is(err, context.Canceled) || calls != 0 {
+		t.Errorf("cancelled context: err = %v after %d calls, want context.Canceled without calling fn", err, calls)
+	}
+}
@@ -5,6 +5,7 @@ go 1.25.7
 require (
 	altinn.studio/devenv v0.0.0
 	github.com/charmbracelet/lipgloss v1.1.0
+	golang.org/x/net v0.49.0
 	golang.org/x/sys v0.41.0
 	golang.org/x/term v0.40.0
 	gopkg.in/yaml.v3 v3.0.1
@@ -50,6 +51,7 @@ require (
 	go.opentelemetry.io/otel/sdk/metric v1.39.0 // indirect
 	go.opentelemetry.io/otel/trace v1.39.0 // indirect
 	golang.org/x/sync v0.19.0 // indirect
+	golang.org/x/text v0.33.0 // indirect
 )
 
 replace altinn.studio/devenv => ../Runtime/devenv
@@ -0,0 +1,197 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ExportVersion is the current version of the credentials export format.
+	ExportVersion = 1
+
+	exportKDF        = "pbkdf2-sha256"
+	exportCipher     = "aes-256-gcm"
+	exportIterations = 600_000
+	exportKeyLen     = 32
+	exportSaltLen    = 16
+)
+
+// Sentinel errors for credentials export and import.
+var (
+	// ErrPassphraseRequired is returned when exporting or importing without a passphrase.
+	// Credentials are never exported in plaintext.
+	ErrPassphraseRequired = errors.New("passphrase is required")
+
+	// ErrUnsupportedExportVersion is returned when an export was written by a newer studioctl.
+	ErrUnsupportedExportVersion = errors.New("unsupported credentials export version")
+
+	// ErrInvalidExport is returned when an export is malformed.
+	ErrInvalidExport = errors.New("invalid credentials export")
+
+	// ErrDecryptFailed is returned when an export cannot be decrypted (wrong passphrase or corrupted file).
+	ErrDecryptFailed = errors.New("decrypt credentials export: wrong passphrase or corrupted file")
+)
+
+// exportEnvelope is the on-disk credentials export format.
+// Version is checked first so newer formats fail with a clear error instead of a decrypt failure.
+type exportEnvelope struct {
+	KDF        string `json:"kdf"`
+	Cipher     string `json:"cipher"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+	Version    int    `json:"version"`
+	Iterations int    `json:"iterations"`
+}
+
+// EncryptCredentials serializes creds and encrypts them with a key derived from passphrase.
+func EncryptCredentials(creds *Credentials, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, ErrPassphraseRequired
+	}
+
+	plaintext, err := yaml.Marshal(creds)
+	if err != nil {
+		return nil, fmt.Errorf("marshal credentials: %w", err)
+	}
+
+	salt := make([]byte, exportSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("generate salt: %w", err)
+	}
+	gcm, err := newExportCipher(passphrase, salt, exportIterations)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+
+	envelope := exportEnvelope{
+		KDF:        exportKDF,
+		Cipher:     exportCipher,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, nil),
+		Version:    ExportVersion,
+		Iterations: exportIterations,
+	}
+	data, err := json.MarshalIndent(envelope, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal credentials export: %w", err)
+	}
+	return data, nil
+}
+
+// DecryptCredentials decrypts an export written by EncryptCredentials.
+func DecryptCredentials(data []byte, passphrase string) (*Credentials, error) {
+	if passphrase == "" {
+		return nil, ErrPassphraseRequired
+	}
+
+	var envelope exportEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidExport, err)
+	}
+	if envelope.Version > ExportVersion {
+		return nil, fmt.Errorf(
+			"%w: %d (this studioctl supports up to %d)",
+			ErrUnsupportedExportVersion, envelope.Version, ExportVersion,
+		)
+	}
+	if envelope.Version < 1 || envelope.KDF != exportKDF || envelope.Cipher != exportCipher ||
+		envelope.Iterations <= 0 || len(envelope.Salt) == 0 {
+		return nil, fmt.Errorf("%w: unexpected header", ErrInvalidExport)
+	}
+
+	gcm, err := newExportCipher(passphrase, envelope.Salt, envelope.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	if len(envelope.Nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("%w: bad nonce length", ErrInvalidExport)
+	}
+	plaintext, err := gcm.Open(nil, envelope.Nonce, envelope.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrDecryptFailed
+	}
+
+	creds := &Credentials{Envs: make(map[string]EnvCredentials)}
+	if err := yaml.Unmarshal(plaintext, creds); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidExport, err)
+	}
+	if creds.Envs == nil {
+		creds.Envs = make(map[string]EnvCredentials)
+	}
+	return creds, nil
+}
+
+//nolint:ireturn // cipher.AEAD is the standard library's interface for GCM
+func newExportCipher(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, exportKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create gcm: %w", err)
+	}
+	return gcm, nil
+}
+
+// MergeResult lists the outcome of merging imported credentials, sorted by environment name.
+type MergeResult struct {
+	Added       []string // environments that were not stored before
+	Overwritten []string // conflicting environments replaced by the import
+	Kept        []string // conflicting environments where the existing credentials were kept
+	Unchanged   []string // environments identical in both
+}
+
+// Merge adds the environments from incoming to c. When an environment exists with different
+// credentials, overwrite decides whether the incoming credentials replace the existing ones.
+func (c *Credentials) Merge(
+	incoming *Credentials,
+	overwrite func(env string, existing, imported EnvCredentials) (bool, error),
+) (MergeResult, error) {
+	result := MergeResult{Added: []string{}, Overwritten: []string{}, Kept: []string{}, Unchanged: []string{}}
+
+	envs := incoming.EnvNames()
+	slices.Sort(envs)
+	for _, env := range envs {
+		imported := incoming.Envs[env]
+		existing, err := c.Get(env)
+		switch {
+		case err != nil:
+			c.Set(env, imported)
+			result.Added = append(result.Added, env)
+		case *existing == imported:
+			result.Unchanged = append(result.Unchanged, env)
+		default:
+			replace, err := overwrite(env, *existing, imported)
+			if err != nil {
+				return result, err
+			}
+			if replace {
+				c.Set(env, imported)
+				result.Overwritten = append(result.Overwritten, env)
+			} else {
+				result.Kept = append(result.Kept, env)
+			}
+		}
+	}
+	return result, nil
+}
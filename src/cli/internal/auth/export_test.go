@@ -0,0 +1,176 @@
+package auth_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"altinn.studio/studioctl/internal/auth"
+)
+
+func TestEncryptDecryptCredentials_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	creds := &auth.Credentials{Envs: map[string]auth.EnvCredentials{
+		"prod": {Host: "altinn.studio", Token: "secret-prod-token", Username: "alice"},
+		"dev":  {Host: "dev.altinn.studio", Token: "secret-dev-token", Username: "alice"},
+	}}
+
+	data, err := auth.EncryptCredentials(creds, "correct horse")
+	if err != nil {
+		t.Fatalf("EncryptCredentials() error = %v", err)
+	}
+	if bytes.Contains(data, []byte("secret-prod-token")) || bytes.Contains(data, []byte("alice")) {
+		t.Fatalf("export contains plaintext credentials: %s", data)
+	}
+
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil || header.Version != auth.ExportVersion {
+		t.Fatalf("export header version = %d (err %v), want %d", header.Version, err, auth.ExportVersion)
+	}
+
+	got, err := auth.DecryptCredentials(data, "correct horse")
+	if err != nil {
+		t.Fatalf("DecryptCredentials() error = %v", err)
+	}
+	if len(got.Envs) != len(creds.Envs) {
+		t.Fatalf("DecryptCredentials() envs = %v, want %v", got.Envs, creds.Envs)
+	}
+	for env, want := range creds.Envs {
+		if got.Envs[env] != want {
+			t.Errorf("DecryptCredentials() %s = %+v, want %+v", env, got.Envs[env], want)
+		}
+	}
+
+	if _, err := auth.DecryptCredentials(data, "wrong"); !errors.Is(err, auth.ErrDecryptFailed) {
+		t.Errorf("DecryptCredentials(wrong passphrase) error = %v, want ErrDecryptFailed", err)
+	}
+}
+
+func TestEncryptCredentials_RefusesPlaintext(t *testing.T) {
+	t.Parallel()
+
+	creds := &auth.Credentials{Envs: map[string]auth.EnvCredentials{
+		"prod": {Host: "altinn.studio", Token: "token", Username: "alice"},
+	}}
+	if _, err := auth.EncryptCredentials(creds, ""); !errors.Is(err, auth.ErrPassphraseRequired) {
+		t.Fatalf("EncryptCredentials(\"\") error = %v, want ErrPassphraseRequired", err)
+	}
+}
+
+func TestDecryptCredentials_NewerVersion(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`{"version": 99, "kdf": "future-kdf"}`)
+	if _, err := auth.DecryptCredentials(data, "pass"); !errors.Is(err, auth.ErrUnsupportedExportVersion) {
+		t.Fatalf("DecryptCredentials() error = %v, want ErrUnsupportedExportVersion", err)
+	}
+}
+
+func TestCredentialsMerge(t *testing.T) {
+	t.Parallel()
+
+	prod := auth.EnvCredentials{Host: "altinn.studio", Token: "old", Username: "alice"}
+	dev := auth.EnvCredentials{Host: "dev.altinn.studio", Token: "dev", Username: "alice"}
+	newStaging := auth.EnvCredentials{Host: "staging.altinn.studio", Token: "new", Username: "bob"}
+	newProd := auth.EnvCredentials{Host: "altinn.studio", Token: "new", Username: "alice"}
+	newTT02 := auth.EnvCredentials{Host: "tt02.altinn.studio", Token: "new", Username: "alice"}
+
+	tests := []struct {
+		wantProd  auth.EnvCredentials
+		name      string
+		want      auth.MergeResult
+		overwrite bool
+	}{
+		{
+			name:      "conflict overwritten",
+			overwrite: true,
+			wantProd:  newProd,
+			want: auth.MergeResult{
+				Added:       []string{"staging"},
+				Overwritten: []string{"prod"},
+				Kept:        []string{},
+				Unchanged:   []string{"dev"},
+			},
+		},
+		{
+			name:      "conflict kept",
+			overwrite: false,
+			wantProd:  prod,
+			want: auth.MergeResult{
+				Added:       []string{"staging"},
+				Overwritten: []string{},
+				Kept:        []string{"prod"},
+				Unchanged:   []string{"dev"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			existing := &auth.Credentials{Envs: map[string]auth.EnvCredentials{"prod": prod, "dev": dev}}
+			incoming := &auth.Credentials{Envs: map[string]auth.EnvCredentials{
+				"prod": newProd, "dev": dev, "staging": newStaging,
+			}}
+
+			var asked []string
+			got, err := existing.Merge(incoming, func(env string, _, _ auth.EnvCredentials) (bool, error) {
+				asked = append(asked, env)
+				return tt.overwrite, nil
+			})
+			if err != nil {
+				t.Fatalf("Merge() error = %v", err)
+			}
+
+			if !slices.Equal(asked, []string{"prod"}) {
+				t.Errorf("Merge() asked about %v, want only the conflicting env", asked)
+			}
+			assertMergeResult(t, got, tt.want)
+			if existing.Envs["prod"] != tt.wantProd {
+				t.Errorf("prod = %+v, want %+v", existing.Envs["prod"], tt.wantProd)
+			}
+			if existing.Envs["staging"] != newStaging {
+				t.Errorf("staging = %+v, want %+v", existing.Envs["staging"], newStaging)
+			}
+		})
+	}
+
+	t.Run("prompt error aborts", func(t *testing.T) {
+		t.Parallel()
+
+		errAbort := errors.New("interrupted")
+		existing := &auth.Credentials{Envs: map[string]auth.EnvCredentials{"prod": prod}}
+		incoming := &auth.Credentials{Envs: map[string]auth.EnvCredentials{"prod": newProd, "tt02": newTT02}}
+		_, err := existing.Merge(incoming, func(string, auth.EnvCredentials, auth.EnvCredentials) (bool, error) {
+			return false, errAbort
+		})
+		if !errors.Is(err, errAbort) {
+			t.Fatalf("Merge() error = %v, want %v", err, errAbort)
+		}
+		if existing.Envs["prod"] != prod {
+			t.Errorf("prod changed after aborted merge: %+v", existing.Envs["prod"])
+		}
+	})
+}
+
+func assertMergeResult(t *testing.T, got, want auth.MergeResult) {
+	t.Helper()
+
+	fields := map[string][2][]string{
+		"Added":       {got.Added, want.Added},
+		"Overwritten": {got.Overwritten, want.Overwritten},
+		"Kept":        {got.Kept, want.Kept},
+		"Unchanged":   {got.Unchanged, want.Unchanged},
+	}
+	for name, pair := range fields {
+		if !slices.Equal(pair[0], pair[1]) {
+			t.Errorf("Merge() %s = %v, want %v", name, pair[0], pair[1])
+		}
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"slices"
 	"strings"
 
 	authstore "altinn.studio/studioctl/internal/auth"
@@ -21,10 +23,14 @@ type authStatusFlags struct {
 	jsonOutput bool
 }
 
-var errLoginCancelled = errors.New("login cancelled")
+var (
+	errLoginCancelled     = errors.New("login cancelled")
+	errPassphraseMismatch = errors.New("passphrases do not match")
+)
 
 // AuthCommand implements the 'auth' subcommand.
 type AuthCommand struct {
+	in      io.Reader // confirmation prompt input
 	out     *ui.Output
 	service *authsvc.Service
 }
@@ -32,6 +38,7 @@ type AuthCommand struct {
 // NewAuthCommand creates a new auth command.
 func NewAuthCommand(cfg *config.Config, out *ui.Output) *AuthCommand {
 	return &AuthCommand{
+		in:      os.Stdin,
 		out:     out,
 		service: authsvc.NewService(cfg.Home),
 	}
@@ -53,7 +60,12 @@ Subcommands:
   login     Authenticate with Altinn Studio using a Personal Access Token
             (requires 'read:user' and 'repo' scopes)
   status    Show authentication status
-  logout    Clear stored credentials
+  logout    Clear stored credentials (--all asks for confirmation unless -y;
+            --dry-run lists what would be removed)
+  export    Write stored credentials to a passphrase-encrypted file
+            (--out FILE; for moving to another machine)
+  import    Merge credentials from an exported file (--in FILE),
+            asking before replacing different stored credentials
 
 Run '%s auth <subcommand> --help' for more information.
 `, osutil.CurrentBin(), osutil.CurrentBin())
@@ -76,6 +88,10 @@ func (c *AuthCommand) Run(ctx context.Context, args []string) error {
 		return c.runStatus(ctx, subArgs)
 	case "logout":
 		return c.runLogout(ctx, subArgs)
+	case "export":
+		return c.runExport(ctx, subArgs)
+	case "import":
+		return c.runImport(ctx, subArgs)
 	case "-h", flagHelp, helpSubcmd:
 		c.out.Print(c.Usage())
 		return nil
@@ -325,12 +341,22 @@ func (c *AuthCommand) printAuthStatusJSON(status authsvc.StatusResult) error {
 	return nil
 }
 
-func (c *AuthCommand) runLogout(_ context.Context, args []string) error {
+// logoutFlags holds parsed flags for the auth logout command.
+type logoutFlags struct {
+	env    string
+	all    bool
+	dryRun bool
+	yes    bool
+}
+
+func (c *AuthCommand) runLogout(ctx context.Context, args []string) error {
 	fs := flag.NewFlagSet("auth logout", flag.ContinueOnError)
-	var env string
-	var all bool
-	fs.StringVar(&env, "env", authstore.DefaultEnv, "Environment to logout from")
-	fs.BoolVar(&all, "all", false, "Logout from all environments")
+	var f logoutFlags
+	fs.StringVar(&f.env, "env", authstore.DefaultEnv, "Environment to logout from")
+	fs.BoolVar(&f.all, "all", false, "Logout from all environments")
+	fs.BoolVar(&f.dryRun, "dry-run", false, "List the environments that would be logged out without removing them")
+	fs.BoolVar(&f.yes, "y", false, "Skip the --all confirmation prompt")
+	fs.BoolVar(&f.yes, "yes", false, "Skip the --all confirmation prompt")
 
 	if err := fs.Parse(args); err != nil {
 		if errors.Is(err, flag.ErrHelp) {
@@ -339,15 +365,38 @@ func (c *AuthCommand) runLogout(_ context.Context, args []string) error {
 		return fmt.Errorf("parsing flags: %w", err)
 	}
 
-	result, err := c.service.Logout(authsvc.LogoutRequest{
-		Env: env,
-		All: all,
-	})
+	if f.dryRun || (f.all && !f.yes) {
+		preview, err := c.service.Logout(authsvc.LogoutRequest{Env: f.env, All: f.all, DryRun: true})
+		if err != nil {
+			return fmt.Errorf("logout: %w", err)
+		}
+		if len(preview.Environments) == 0 {
+			c.out.Println("Not logged in to any matching environment")
+			return nil
+		}
+		c.out.Println("Would log out from:")
+		c.renderLogoutEnvironments(preview.Environments)
+		if f.dryRun {
+			return nil
+		}
+
+		c.out.Print("Log out from all of these environments? [y/N]: ")
+		confirmed, err := c.readConfirmation(ctx)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			c.out.Println("Logout cancelled")
+			return nil
+		}
+	}
+
+	result, err := c.service.Logout(authsvc.LogoutRequest{Env: f.env, All: f.all, DryRun: false})
 	if err != nil {
 		return fmt.Errorf("logout: %w", err)
 	}
 
-	if all {
+	if f.all {
 		c.out.Success("Logged out from all environments")
 		return nil
 	}
@@ -356,15 +405,28 @@ func (c *AuthCommand) runLogout(_ context.Context, args []string) error {
 		return nil
 	}
 
-	c.out.Successf("Logged out from %s", env)
+	c.out.Successf("Logged out from %s", f.env)
 	return nil
 }
 
+func (c *AuthCommand) renderLogoutEnvironments(envs []authsvc.StatusEnvironment) {
+	rows := [][]string{{"ENV", "HOST", "USERNAME"}}
+	for _, env := range envs {
+		rows = append(rows, []string{env.Env, env.Host, env.Username})
+	}
+	c.out.Table(rows)
+}
+
 // confirmOverwrite prompts the user to confirm overwriting existing credentials.
 // Returns (confirmed, error) where error is ui.ErrInterrupted on Ctrl+C.
 func (c *AuthCommand) confirmOverwrite(ctx context.Context) (bool, error) {
 	c.out.Print("Overwrite existing credentials? [y/N]: ")
-	response, err := ui.ReadLine(ctx, os.Stdin)
+	return c.readConfirmation(ctx)
+}
+
+// readConfirmation reads a y/N answer from the prompt input.
+func (c *AuthCommand) readConfirmation(ctx context.Context) (bool, error) {
+	response, err := ui.ReadLine(ctx, c.in)
 	if err != nil {
 		c.out.Println("")
 		return false, fmt.Errorf("read confirmation: %w", err)
@@ -372,3 +434,99 @@ func (c *AuthCommand) confirmOverwrite(ctx context.Context) (bool, error) {
 	answer := strings.TrimSpace(strings.ToLower(string(response)))
 	return answer == "y" || answer == "yes", nil
 }
+
+func (c *AuthCommand) runExport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("auth export", flag.ContinueOnError)
+	var out string
+	fs.StringVar(&out, "out", "", "File to write the encrypted credentials to")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return fmt.Errorf("parsing flags: %w", err)
+	}
+	if out == "" {
+		return fmt.Errorf("%w: --out", ErrMissingArgument)
+	}
+
+	passphrase, err := c.promptPassphrase(ctx, "Passphrase to encrypt the export: ")
+	if err != nil {
+		return err
+	}
+	confirm, err := c.promptPassphrase(ctx, "Repeat passphrase: ")
+	if err != nil {
+		return err
+	}
+	if passphrase != confirm {
+		return errPassphraseMismatch
+	}
+
+	result, err := c.service.Export(authsvc.ExportRequest{Path: out, Passphrase: passphrase})
+	if err != nil {
+		return fmt.Errorf("export credentials: %w", err)
+	}
+
+	c.out.Successf("Exported credentials for %s to %s", strings.Join(result.Envs, ", "), out)
+	c.out.Printf("Run '%s auth import --in %s' on the other machine, then delete the file.\n", osutil.CurrentBin(), out)
+	return nil
+}
+
+func (c *AuthCommand) runImport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("auth import", flag.ContinueOnError)
+	var in string
+	fs.StringVar(&in, "in", "", "Encrypted credentials file written by 'auth export'")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return fmt.Errorf("parsing flags: %w", err)
+	}
+	if in == "" {
+		return fmt.Errorf("%w: --in", ErrMissingArgument)
+	}
+
+	passphrase, err := c.promptPassphrase(ctx, "Passphrase for the export: ")
+	if err != nil {
+		return err
+	}
+
+	result, err := c.service.Import(authsvc.ImportRequest{
+		Overwrite: func(env string, existing, imported authstore.EnvCredentials) (bool, error) {
+			c.out.Warningf(
+				"%s is already stored as %s@%s; the import has %s@%s",
+				env, existing.Username, existing.Host, imported.Username, imported.Host,
+			)
+			return c.confirmOverwrite(ctx)
+		},
+		Path:       in,
+		Passphrase: passphrase,
+	})
+	if err != nil {
+		return fmt.Errorf("import credentials: %w", err)
+	}
+
+	imported := slices.Concat(result.Added, result.Overwritten)
+	slices.Sort(imported)
+	if len(imported) == 0 {
+		c.out.Println("No credentials imported")
+		return nil
+	}
+	c.out.Successf("Imported credentials for %s", strings.Join(imported, ", "))
+	return nil
+}
+
+// promptPassphrase reads a passphrase without echo. An empty passphrase is rejected.
+func (c *AuthCommand) promptPassphrase(ctx context.Context, prompt string) (string, error) {
+	c.out.Print(prompt)
+	passphrase, err := ui.ReadPassword(ctx, c.out)
+	c.out.Println("")
+	if err != nil {
+		return "", fmt.Errorf("read passphrase: %w", err)
+	}
+	if len(passphrase) == 0 {
+		return "", authstore.ErrPassphraseRequired
+	}
+	return string(passphrase), nil
+}
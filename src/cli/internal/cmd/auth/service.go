@@ -5,9 +5,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"sort"
 
 	authstore "altinn.studio/studioctl/internal/auth"
+	"altinn.studio/studioctl/internal/osutil"
 	"altinn.studio/studioctl/internal/studio"
 )
 
@@ -84,7 +86,10 @@ func (s *Service) Login(ctx context.Context, req LoginRequest) (LoginResult, err
 		}
 	}
 
-	client := studio.NewClientWithHTTP(req.Host, req.Token, "", nil)
+	client, err := studio.NewClient(&authstore.EnvCredentials{Host: req.Host, Token: req.Token, Username: ""})
+	if err != nil {
+		return LoginResult{}, fmt.Errorf("create studio client: %w", err)
+	}
 	user, err := client.GetUser(ctx)
 	if err != nil {
 		if errors.Is(err, studio.ErrUnauthorized) {
@@ -150,6 +155,10 @@ func (s *Service) Status(ctx context.Context, req StatusRequest) (StatusResult,
 			return StatusResult{}, fmt.Errorf("get credentials for %s: %w", req.Env, err)
 		}
 
+		status, err := validateToken(ctx, envCreds)
+		if err != nil {
+			return StatusResult{}, err
+		}
 		return StatusResult{
 			MissingEnv: "",
 			Environments: []StatusEnvironment{
@@ -157,7 +166,7 @@ func (s *Service) Status(ctx context.Context, req StatusRequest) (StatusResult,
 					Env:      req.Env,
 					Host:     envCreds.Host,
 					Username: envCreds.Username,
-					Status:   validateToken(ctx, envCreds),
+					Status:   status,
 				},
 			},
 		}, nil
@@ -172,11 +181,15 @@ func (s *Service) Status(ctx context.Context, req StatusRequest) (StatusResult,
 			continue
 		}
 
+		status, err := validateToken(ctx, envCreds)
+		if err != nil {
+			return StatusResult{}, err
+		}
 		envs = append(envs, StatusEnvironment{
 			Env:      envName,
 			Host:     envCreds.Host,
 			Username: envCreds.Username,
-			Status:   validateToken(ctx, envCreds),
+			Status:   status,
 		})
 	}
 
@@ -188,13 +201,16 @@ func (s *Service) Status(ctx context.Context, req StatusRequest) (StatusResult,
 
 // LogoutRequest contains logout inputs.
 type LogoutRequest struct {
-	Env string
-	All bool
+	Env    string
+	All    bool
+	DryRun bool // report what would be removed without removing it
 }
 
 // LogoutResult contains logout output details.
 type LogoutResult struct {
-	Removed bool
+	// Environments lists the removed (or, for a dry run, removable) environments without token status.
+	Environments []StatusEnvironment
+	Removed      bool
 }
 
 // Logout clears credentials for one/all environments.
@@ -205,36 +221,167 @@ func (s *Service) Logout(req LogoutRequest) (LogoutResult, error) {
 	}
 
 	if req.All {
+		envs := storedEnvironments(creds)
+		if req.DryRun {
+			return LogoutResult{Environments: envs, Removed: false}, nil
+		}
 		creds.DeleteAll()
 		if err := authstore.SaveCredentials(s.credentialsHome, creds); err != nil {
 			return LogoutResult{}, fmt.Errorf("save credentials: %w", err)
 		}
-		return LogoutResult{Removed: true}, nil
+		return LogoutResult{Environments: envs, Removed: true}, nil
 	}
 
-	if _, err := creds.Get(req.Env); err != nil {
+	envCreds, err := creds.Get(req.Env)
+	if err != nil {
 		if errors.Is(err, authstore.ErrNotLoggedIn) {
-			return LogoutResult{Removed: false}, nil
+			return LogoutResult{Environments: []StatusEnvironment{}, Removed: false}, nil
 		}
 		return LogoutResult{}, fmt.Errorf("get credentials for %s: %w", req.Env, err)
 	}
+	envs := []StatusEnvironment{{Env: req.Env, Host: envCreds.Host, Username: envCreds.Username, Status: ""}}
+	if req.DryRun {
+		return LogoutResult{Environments: envs, Removed: false}, nil
+	}
 
 	creds.Delete(req.Env)
 	if err := authstore.SaveCredentials(s.credentialsHome, creds); err != nil {
 		return LogoutResult{}, fmt.Errorf("save credentials: %w", err)
 	}
 
-	return LogoutResult{Removed: true}, nil
+	return LogoutResult{Environments: envs, Removed: true}, nil
+}
+
+// storedEnvironments lists stored environments sorted by name, without validating tokens.
+func storedEnvironments(creds *authstore.Credentials) []StatusEnvironment {
+	envNames := creds.EnvNames()
+	sort.Strings(envNames)
+	envs := make([]StatusEnvironment, 0, len(envNames))
+	for _, envName := range envNames {
+		envCreds := creds.Envs[envName]
+		envs = append(envs, StatusEnvironment{
+			Env:      envName,
+			Host:     envCreds.Host,
+			Username: envCreds.Username,
+			Status:   "",
+		})
+	}
+	return envs
 }
 
-func validateToken(ctx context.Context, creds *authstore.EnvCredentials) string {
-	client := studio.NewClient(creds)
-	_, err := client.GetUser(ctx)
+// validateToken returns the token status. Errors are only returned when no request could be made.
+func validateToken(ctx context.Context, creds *authstore.EnvCredentials) (string, error) {
+	client, err := studio.NewClient(creds)
 	if err != nil {
+		return "", fmt.Errorf("create studio client: %w", err)
+	}
+	if _, err := client.GetUser(ctx); err != nil {
 		if errors.Is(err, studio.ErrUnauthorized) {
-			return "invalid"
+			return "invalid", nil
+		}
+		return "error", nil
+	}
+	return "valid", nil
+}
+
+// ErrNoCredentialsToExport indicates there are no stored credentials to export.
+var ErrNoCredentialsToExport = errors.New("no stored credentials to export")
+
+// ExportRequest contains credentials export inputs.
+type ExportRequest struct {
+	Path       string
+	Passphrase string
+}
+
+// ExportResult contains credentials export output details.
+type ExportResult struct {
+	Envs []string
+}
+
+// Export writes all stored credentials to an encrypted file. An existing file is never overwritten.
+func (s *Service) Export(req ExportRequest) (ExportResult, error) {
+	creds, err := authstore.LoadCredentials(s.credentialsHome)
+	if err != nil {
+		return ExportResult{}, fmt.Errorf("load credentials: %w", err)
+	}
+	if !creds.HasCredentials() {
+		return ExportResult{}, ErrNoCredentialsToExport
+	}
+
+	data, err := authstore.EncryptCredentials(creds, req.Passphrase)
+	if err != nil {
+		return ExportResult{}, fmt.Errorf("encrypt credentials: %w", err)
+	}
+
+	if err := writeExportFile(req.Path, data); err != nil {
+		return ExportResult{}, err
+	}
+
+	envs := creds.EnvNames()
+	sort.Strings(envs)
+	return ExportResult{Envs: envs}, nil
+}
+
+// writeExportFile creates path, failing if it exists, and writes data to it.
+// The file is removed again if it cannot be written completely.
+func writeExportFile(path string, data []byte) (err error) {
+	//nolint:gosec // G304: export path is chosen by the user
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, osutil.FilePermOwnerOnly)
+	if err != nil {
+		return fmt.Errorf("create export file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(path) //nolint:errcheck,gosec // best-effort cleanup; err is the one worth reporting
 		}
-		return "error"
+	}()
+
+	if _, err := f.Write(data); err != nil {
+		f.Close() //nolint:errcheck,gosec // the write error is the one worth reporting
+		return fmt.Errorf("write export file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close export file: %w", err)
+	}
+	if err := osutil.SecureFile(path); err != nil {
+		return fmt.Errorf("secure export file: %w", err)
+	}
+	return nil
+}
+
+// ImportRequest contains credentials import inputs.
+type ImportRequest struct {
+	// Overwrite decides conflicts where an environment is already stored with different credentials.
+	Overwrite  func(env string, existing, imported authstore.EnvCredentials) (bool, error)
+	Path       string
+	Passphrase string
+}
+
+// Import decrypts an export and merges it into the stored credentials.
+func (s *Service) Import(req ImportRequest) (authstore.MergeResult, error) {
+	//nolint:gosec // G304: import path is chosen by the user
+	data, err := os.ReadFile(req.Path)
+	if err != nil {
+		return authstore.MergeResult{}, fmt.Errorf("read export file: %w", err)
+	}
+	incoming, err := authstore.DecryptCredentials(data, req.Passphrase)
+	if err != nil {
+		return authstore.MergeResult{}, fmt.Errorf("decrypt credentials: %w", err)
+	}
+
+	creds, err := authstore.LoadCredentials(s.credentialsHome)
+	if err != nil {
+		return authstore.MergeResult{}, fmt.Errorf("load credentials: %w", err)
+	}
+	result, err := creds.Merge(incoming, req.Overwrite)
+	if err != nil {
+		return result, fmt.Errorf("merge credentials: %w", err)
+	}
+	if len(result.Added) == 0 && len(result.Overwritten) == 0 {
+		return result, nil
+	}
+	if err := authstore.SaveCredentials(s.credentialsHome, creds); err != nil {
+		return result, fmt.Errorf("save credentials: %w", err)
 	}
-	return "valid"
+	return result, nil
 }
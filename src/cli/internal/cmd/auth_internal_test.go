@@ -0,0 +1,91 @@
+//nolint:testpackage // testing the unexported confirmation input
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	authstore "altinn.studio/studioctl/internal/auth"
+	"altinn.studio/studioctl/internal/config"
+	"altinn.studio/studioctl/internal/ui"
+)
+
+func TestAuthCommand_LogoutAll(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		input       string
+		wantOutput  string
+		args        []string
+		wantRemoved bool
+	}{
+		{
+			name:        "dry run removes nothing",
+			input:       "",
+			wantOutput:  "Would log out from:",
+			args:        []string{"logout", "--all", "--dry-run"},
+			wantRemoved: false,
+		},
+		{
+			name:        "declined confirmation removes nothing",
+			input:       "n\n",
+			wantOutput:  "Logout cancelled",
+			args:        []string{"logout", "--all"},
+			wantRemoved: false,
+		},
+		{
+			name:        "confirmed removes all",
+			input:       "y\n",
+			wantOutput:  "Logged out from all environments",
+			args:        []string{"logout", "--all"},
+			wantRemoved: true,
+		},
+		{
+			name:        "yes flag skips confirmation",
+			input:       "",
+			wantOutput:  "Logged out from all environments",
+			args:        []string{"logout", "--all", "-y"},
+			wantRemoved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			home := t.TempDir()
+			creds := &authstore.Credentials{Envs: map[string]authstore.EnvCredentials{
+				"prod": {Host: "altinn.studio", Token: "token", Username: "alice"},
+				"dev":  {Host: "dev.altinn.studio", Token: "token", Username: "alice"},
+			}}
+			if err := authstore.SaveCredentials(home, creds); err != nil {
+				t.Fatalf("SaveCredentials() error = %v", err)
+			}
+
+			var stdout bytes.Buffer
+			command := NewAuthCommand(
+				&config.Config{Home: home},
+				ui.NewOutput(&stdout, &stdout, false),
+			)
+			command.in = strings.NewReader(tt.input)
+
+			if err := command.Run(context.Background(), tt.args); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if !strings.Contains(stdout.String(), tt.wantOutput) {
+				t.Errorf("Run() output = %q, want %q", stdout.String(), tt.wantOutput)
+			}
+
+			stored, err := authstore.LoadCredentials(home)
+			if err != nil {
+				t.Fatalf("LoadCredentials() error = %v", err)
+			}
+			if removed := !stored.HasCredentials(); removed != tt.wantRemoved {
+				t.Errorf("credentials removed = %v, want %v (remaining %v)", removed, tt.wantRemoved, stored.EnvNames())
+			}
+		})
+	}
+}
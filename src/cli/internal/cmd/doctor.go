@@ -7,6 +7,7 @@ import (
 	"flag"
 	"fmt"
 	"strconv"
+	"strings"
 
 	doctorsvc "altinn.studio/studioctl/internal/cmd/doctor"
 	"altinn.studio/studioctl/internal/config"
@@ -44,10 +45,12 @@ func (c *DoctorCommand) Usage() string {
 Diagnose the development environment and show any issues.
 
 Options:
-  -c, --checks   Run active checks (probe host gateway, validate connectivity)
+  -c, --checks   Run active checks (probe host gateway, validate connectivity, verify resources)
+  --only LIST    Run only these sections (comma-separated: %s)
+  --explain      Add a remediation hint for each finding that is not OK
   --json         Output as JSON
   -h             Show this help
-`, osutil.CurrentBin())
+`, osutil.CurrentBin(), strings.Join(doctorsvc.Sections(), ", "))
 }
 
 // Run executes the command.
@@ -55,9 +58,13 @@ func (c *DoctorCommand) Run(ctx context.Context, args []string) error {
 	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
 	var jsonOutput bool
 	var runChecks bool
+	var only string
+	var explain bool
 	fs.BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	fs.BoolVar(&explain, "explain", false, "Add remediation hints for findings")
 	fs.BoolVar(&runChecks, "checks", false, "Run active checks")
 	fs.BoolVar(&runChecks, "c", false, "Run active checks")
+	fs.StringVar(&only, "only", "", "Run only these sections (comma-separated)")
 
 	if err := fs.Parse(args); err != nil {
 		if errors.Is(err, flag.ErrHelp) {
@@ -66,30 +73,37 @@ func (c *DoctorCommand) Run(ctx context.Context, args []string) error {
 		}
 		return fmt.Errorf("parsing flags: %w", err)
 	}
+	sections, err := doctorsvc.ParseSections(only)
+	if err != nil {
+		return fmt.Errorf("%w: --only: %w", ErrInvalidFlagValue, err)
+	}
 
 	service := doctorsvc.New(c.cfg, c.out.Verbosef)
-	report := service.BuildReport(ctx, runChecks)
+	report := service.BuildSections(ctx, runChecks, sections)
 	issues := service.HasIssues(report)
 
+	var explanations []doctorsvc.Explanation
+	if explain {
+		explanations = doctorsvc.Explain(report)
+	}
+
 	if jsonOutput {
-		payload, err := json.Marshal(map[string]any{
-			"hasIssues":     issues,
-			"cli":           report.CLI,
-			"system":        report.System,
-			"prerequisites": report.Prerequisites,
-			"network":       report.Network,
-			"auth":          report.Auth,
-			"app":           report.App,
-			"disk":          report.Disk,
-		})
+		payload := doctorJSON(report, issues)
+		if explain {
+			payload["explanations"] = explanations
+		}
+		data, err := json.Marshal(payload)
 		if err != nil {
 			return fmt.Errorf("marshal doctor json: %w", err)
 		}
-		c.out.Printf("%s\n", payload)
+		c.out.Printf("%s\n", data)
 		return nil
 	}
 
 	c.renderDoctorText(report)
+	if explain {
+		c.renderDoctorExplanations(explanations)
+	}
 	if issues {
 		c.out.Warning("Some issues were found. See above for details.")
 		return nil
@@ -99,18 +113,68 @@ func (c *DoctorCommand) Run(ctx context.Context, args []string) error {
 	return nil
 }
 
+// doctorJSON returns the JSON payload with only the sections the report was built for.
+func doctorJSON(report doctorsvc.Report, issues bool) map[string]any {
+	all := map[string]any{
+		doctorsvc.SectionCLI:           report.CLI,
+		doctorsvc.SectionSystem:        report.System,
+		doctorsvc.SectionPrerequisites: report.Prerequisites,
+		doctorsvc.SectionNetwork:       report.Network,
+		doctorsvc.SectionAuth:          report.Auth,
+		doctorsvc.SectionApp:           report.App,
+		doctorsvc.SectionDisk:          report.Disk,
+	}
+	payload := map[string]any{"hasIssues": issues}
+	for _, name := range doctorsvc.Sections() {
+		if report.Includes(name) {
+			payload[name] = all[name]
+		}
+	}
+	return payload
+}
+
 func (c *DoctorCommand) renderDoctorText(report doctorsvc.Report) {
 	c.out.Printf("%s doctor\n", osutil.CurrentBin())
 	c.out.Println("")
 
-	sec := c.out.NewSection(doctorKeyWidth)
-	c.renderDoctorCLISection(sec, report.CLI)
-	c.renderDoctorSystemSection(sec, report.System)
-	c.renderDoctorPrerequisitesSection(sec, report.Prerequisites)
-	c.renderDoctorNetworkSection(sec, report.Network)
-	c.renderDoctorAuthSection(sec, report.Auth)
-	c.renderDoctorDiskSection(sec, report.Disk)
-	c.renderDoctorAppSection(sec, report.App)
+	sec := c.out.NewRedactedSection(doctorKeyWidth)
+	renderers := []struct {
+		render func()
+		name   string
+	}{
+		{name: doctorsvc.SectionCLI, render: func() { c.renderDoctorCLISection(sec, report.CLI) }},
+		{name: doctorsvc.SectionSystem, render: func() { c.renderDoctorSystemSection(sec, report.System) }},
+		{
+			name:   doctorsvc.SectionPrerequisites,
+			render: func() { c.renderDoctorPrerequisitesSection(sec, report.Prerequisites) },
+		},
+		{name: doctorsvc.SectionNetwork, render: func() { c.renderDoctorNetworkSection(sec, report.Network) }},
+		{name: doctorsvc.SectionAuth, render: func() { c.renderDoctorAuthSection(sec, report.Auth) }},
+		{name: doctorsvc.SectionDisk, render: func() { c.renderDoctorDiskSection(sec, report.Disk) }},
+		{name: doctorsvc.SectionApp, render: func() { c.renderDoctorAppSection(sec, report.App) }},
+	}
+	for _, r := range renderers {
+		if report.Includes(r.name) {
+			r.render()
+		}
+	}
+}
+
+func (c *DoctorCommand) renderDoctorExplanations(explanations []doctorsvc.Explanation) {
+	if len(explanations) == 0 {
+		return
+	}
+	keyWidth := doctorKeyWidth
+	for _, explanation := range explanations {
+		keyWidth = max(keyWidth, len(explanation.ID))
+	}
+	sec := c.out.NewRedactedSection(keyWidth)
+	sec.Header("How to fix")
+	defer c.out.Println("")
+
+	for _, explanation := range explanations {
+		sec.KeyValue(explanation.ID, explanation.Hint)
+	}
 }
 
 func (c *DoctorCommand) renderDoctorCLISection(sec *ui.Section, cli *doctorsvc.CLI) {
@@ -119,8 +183,30 @@ func (c *DoctorCommand) renderDoctorCLISection(sec *ui.Section, cli *doctorsvc.C
 
 	if cli == nil {
 		sec.KeyValue("Version", unknownValue)
-	} else {
-		sec.KeyValue("Version", cli.Version)
+		return
+	}
+	sec.KeyValue("Version", cli.Version)
+	c.renderDoctorBinary(sec, cli.Binary)
+}
+
+func (c *DoctorCommand) renderDoctorBinary(sec *ui.Section, binary *doctorsvc.Binary) {
+	switch {
+	case binary == nil:
+		return
+	case binary.Error != "":
+		sec.KeyValueStatus(false, "Binary", binary.Error)
+		return
+	}
+
+	sec.KeyValue("Binary", binary.Running)
+	switch {
+	case binary.OnPath == "":
+		sec.KeyValue("On PATH", "not found (alias or full path in use)")
+	case binary.Mismatch:
+		sec.KeyValueStatus(false, "On PATH",
+			"WARN: "+binary.OnPath+" ("+binary.OnPathVersion+") is not the running binary")
+	default:
+		sec.KeyValueStatus(true, "On PATH", "same binary")
 	}
 }
 
@@ -224,10 +310,13 @@ func (c *DoctorCommand) renderDoctorNetworkSection(sec *ui.Section, network *doc
 
 	if network.Mode == "cached" {
 		c.renderDoctorCachedNetworkSection(sec, network)
-		return
+	} else {
+		c.renderDoctorActiveNetworkSection(sec, network)
 	}
 
-	c.renderDoctorActiveNetworkSection(sec, network)
+	if network.HTTPSProxy != "" {
+		sec.KeyValue("HTTPS Proxy", network.HTTPSProxy)
+	}
 }
 
 func (c *DoctorCommand) renderDoctorCachedNetworkSection(sec *ui.Section, network *doctorsvc.Network) {
@@ -329,8 +418,8 @@ func (c *DoctorCommand) renderDoctorDiskSection(sec *ui.Section, disk *doctorsvc
 			diskKeyWidth = idLen
 		}
 	}
-	diskSec := c.out.NewSection(diskKeyWidth)
-	diskInfoSec := c.out.NewSection(diskKeyWidth + 2)
+	diskSec := c.out.NewRedactedSection(diskKeyWidth)
+	diskInfoSec := c.out.NewRedactedSection(diskKeyWidth + 2)
 
 	for _, check := range disk.Checks {
 		value := check.Message
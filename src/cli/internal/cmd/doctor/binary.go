@@ -0,0 +1,106 @@
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	shellsvc "altinn.studio/studioctl/internal/cmd/shell"
+)
+
+const (
+	// binaryName is the command users type; PATH is searched for it.
+	binaryName = "studioctl"
+
+	// binaryVersionTimeout bounds running the PATH binary to read its version.
+	binaryVersionTimeout = 5 * time.Second
+)
+
+// Binary compares the running studioctl with the one found on PATH.
+type Binary struct {
+	Running       string `json:"running"`
+	OnPath        string `json:"onPath,omitempty"`
+	OnPathVersion string `json:"onPathVersion,omitempty"`
+	Error         string `json:"error,omitempty"`
+	// Mismatch is set when PATH resolves to another binary than the running one.
+	Mismatch bool `json:"mismatch"`
+}
+
+// binaryProbe holds the lookups buildBinary uses, so tests can fake them.
+type binaryProbe struct {
+	running  func() (string, error)
+	lookPath func(file string) (string, error)
+	resolve  func(path string) (string, error)
+	version  func(ctx context.Context, path string) (string, error)
+}
+
+func defaultBinaryProbe() binaryProbe {
+	return binaryProbe{
+		running:  shellsvc.BinaryPath,
+		lookPath: exec.LookPath,
+		resolve:  shellsvc.ResolveBinaryPath,
+		version:  probeBinaryVersion,
+	}
+}
+
+// buildBinary resolves the studioctl on PATH and compares it to the running binary.
+// A studioctl missing from PATH is not a mismatch: it may be reached through an alias.
+func (s *Service) buildBinary(ctx context.Context) *Binary {
+	var binary Binary
+
+	running, err := s.binary.running()
+	if err != nil {
+		binary.Error = fmt.Sprintf("resolving running binary: %v", err)
+		return &binary
+	}
+	binary.Running = running
+
+	found, err := s.binary.lookPath(binaryName)
+	if err != nil {
+		s.debugf("%s not found on PATH: %v", binaryName, err)
+		return &binary
+	}
+	onPath, err := s.binary.resolve(found)
+	if err != nil {
+		binary.Error = fmt.Sprintf("resolving %s: %v", found, err)
+		return &binary
+	}
+	binary.OnPath = onPath
+	if onPath == running {
+		binary.OnPathVersion = s.cfg.Version
+		return &binary
+	}
+
+	binary.Mismatch = true
+	version, err := s.binary.version(ctx, onPath)
+	if err != nil {
+		s.debugf("reading version of %s failed: %v", onPath, err)
+		version = unknownValue
+	}
+	binary.OnPathVersion = version
+	return &binary
+}
+
+// probeBinaryVersion runs "<path> version --json" and returns the reported version.
+func probeBinaryVersion(ctx context.Context, path string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, binaryVersionTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, path, "version", "--json").Output()
+	if err != nil {
+		return "", fmt.Errorf("running %s version: %w", path, err)
+	}
+	var payload struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(output, &payload); err != nil {
+		return "", fmt.Errorf("parsing %s version output: %w", path, err)
+	}
+	if strings.TrimSpace(payload.Version) == "" {
+		return "", fmt.Errorf("parsing %s version output: %w", path, errEmptyVersion)
+	}
+	return payload.Version, nil
+}
@@ -0,0 +1,126 @@
+//nolint:testpackage // testing the PATH binary check with fake lookups
+package doctor
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+
+	"altinn.studio/studioctl/internal/config"
+)
+
+const runningBinary = "/home/user/.altinn-studio/bin/studioctl"
+
+var errNoVersion = errors.New("exit status 2")
+
+func TestBuildBinary(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		lookPathErr   error
+		versionErr    error
+		name          string
+		onPath        string
+		want          Binary
+		wantIssues    bool
+		wantVersionOf bool
+	}{
+		{
+			name:   "same binary through symlink",
+			onPath: "/usr/local/bin/studioctl",
+			want: Binary{
+				Running:       runningBinary,
+				OnPath:        runningBinary,
+				OnPathVersion: "v1.2.0",
+				Error:         "",
+				Mismatch:      false,
+			},
+		},
+		{
+			name:   "other install on PATH",
+			onPath: "/opt/studioctl/studioctl",
+			want: Binary{
+				Running:       runningBinary,
+				OnPath:        "/opt/studioctl/studioctl",
+				OnPathVersion: "v0.9.0",
+				Error:         "",
+				Mismatch:      true,
+			},
+			wantIssues:    true,
+			wantVersionOf: true,
+		},
+		{
+			name:       "other install without readable version",
+			onPath:     "/opt/studioctl/studioctl",
+			versionErr: errNoVersion,
+			want: Binary{
+				Running:       runningBinary,
+				OnPath:        "/opt/studioctl/studioctl",
+				OnPathVersion: unknownValue,
+				Error:         "",
+				Mismatch:      true,
+			},
+			wantIssues:    true,
+			wantVersionOf: true,
+		},
+		{
+			name:        "not on PATH",
+			lookPathErr: exec.ErrNotFound,
+			want: Binary{
+				Running:       runningBinary,
+				OnPath:        "",
+				OnPathVersion: "",
+				Error:         "",
+				Mismatch:      false,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			versionProbed := false
+			service := New(&config.Config{Version: "v1.2.0"}, nil)
+			service.binary = binaryProbe{
+				running: func() (string, error) { return runningBinary, nil },
+				lookPath: func(file string) (string, error) {
+					if file != binaryName {
+						t.Errorf("lookPath(%q), want %q", file, binaryName)
+					}
+					return tt.onPath, tt.lookPathErr
+				},
+				resolve: func(path string) (string, error) {
+					if path == "/usr/local/bin/studioctl" {
+						return runningBinary, nil
+					}
+					return path, nil
+				},
+				version: func(_ context.Context, path string) (string, error) {
+					versionProbed = true
+					if path != tt.onPath {
+						t.Errorf("version(%q), want %q", path, tt.onPath)
+					}
+					if tt.versionErr != nil {
+						return "", tt.versionErr
+					}
+					return "v0.9.0", nil
+				},
+			}
+
+			report := service.BuildSections(t.Context(), false, []string{SectionCLI})
+			if report.CLI == nil || report.CLI.Binary == nil {
+				t.Fatalf("report.CLI = %+v, want binary check", report.CLI)
+			}
+			if got := *report.CLI.Binary; got != tt.want {
+				t.Fatalf("Binary = %+v, want %+v", got, tt.want)
+			}
+			if versionProbed != tt.wantVersionOf {
+				t.Errorf("version probed = %v, want %v", versionProbed, tt.wantVersionOf)
+			}
+			if got := service.HasIssues(report); got != tt.wantIssues {
+				t.Errorf("HasIssues() = %v, want %v", got, tt.wantIssues)
+			}
+		})
+	}
+}
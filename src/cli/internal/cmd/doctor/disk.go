@@ -2,12 +2,14 @@ package doctor
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 
@@ -17,7 +19,7 @@ import (
 	"altinn.studio/studioctl/internal/networking"
 )
 
-func (s *Service) buildDisk() *Disk {
+func (s *Service) buildDisk(runChecks bool) *Disk {
 	checks := []DiskCheck{
 		s.checkDirState("home_dir", s.cfg.Home, true),
 		s.checkDirState("socket_dir", s.cfg.SocketDir, true),
@@ -31,6 +33,9 @@ func (s *Service) buildDisk() *Disk {
 		s.checkAppManagerBinaryState(),
 		s.checkAppManagerRuntimeState(),
 	}
+	if runChecks {
+		checks = append(checks, s.checkResourcesIntegrity())
+	}
 
 	hasIssues := false
 	for _, check := range checks {
@@ -298,11 +303,63 @@ func (s *Service) checkResourcesState() DiskCheck {
 		return *check
 	}
 
+	message := "installed for current CLI version"
+	if report, err := install.ReadReport(s.cfg.DataDir); err == nil {
+		source := report.Source
+		if report.RestampedFrom != "" {
+			source += ", restamped from " + report.RestampedFrom
+		}
+		message += fmt.Sprintf(
+			" (%d files, %s, %s)",
+			report.FileCount,
+			report.InstalledAt.Local().Format(time.DateTime),
+			source,
+		)
+	}
 	return DiskCheck{
 		ID:      "resources",
 		Level:   diskLevelOK,
 		Path:    s.cfg.DataDir,
-		Message: "installed for current CLI version",
+		Message: message,
+	}
+}
+
+// checkResourcesIntegrity verifies installed resources against the install manifest.
+func (s *Service) checkResourcesIntegrity() DiskCheck {
+	result, err := install.Verify(s.cfg.DataDir)
+	if errors.Is(err, install.ErrManifestNotFound) {
+		return DiskCheck{
+			ID:      "resources_integrity",
+			Level:   diskLevelInfo,
+			Path:    s.cfg.DataDir,
+			Message: "no manifest (resources not installed by this CLI version)",
+		}
+	}
+	if err != nil {
+		return DiskCheck{
+			ID:      "resources_integrity",
+			Level:   diskLevelError,
+			Path:    s.cfg.DataDir,
+			Message: "verify failed: " + err.Error(),
+		}
+	}
+	if !result.OK() {
+		return DiskCheck{
+			ID:    "resources_integrity",
+			Level: diskLevelWarn,
+			Path:  s.cfg.DataDir,
+			Message: fmt.Sprintf(
+				"%d missing, %d modified (run 'studioctl install' to repair)",
+				len(result.Missing),
+				len(result.Modified),
+			),
+		}
+	}
+	return DiskCheck{
+		ID:      "resources_integrity",
+		Level:   diskLevelOK,
+		Path:    s.cfg.DataDir,
+		Message: "matches manifest",
 	}
 }
 
@@ -676,7 +733,7 @@ func ownerOnlyPermissionsWarning(mode os.FileMode) string {
 		return ""
 	}
 	if mode.Perm()&0o077 != 0 {
-		return fmt.Sprintf("permissions %03o are broader than owner-only", mode.Perm())
+		return fmt.Sprintf(permissionsWarningPrefix+"%03o are broader than owner-only", mode.Perm())
 	}
 	return ""
 }
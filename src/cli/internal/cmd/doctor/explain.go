@@ -0,0 +1,151 @@
+package doctor
+
+import (
+	"strings"
+
+	"altinn.studio/studioctl/internal/networking"
+)
+
+// Finding IDs for checks outside the disk section. Disk findings use their DiskCheck.ID.
+const (
+	FindingCLIBinary    = "cli_binary"
+	FindingDotnet       = "dotnet"
+	FindingContainer    = "container"
+	FindingWindows      = "windows"
+	FindingHostGateway  = "host_gateway"
+	FindingConnectivity = "connectivity"
+	FindingHostDNS      = "host_dns"
+	FindingContainerDNS = "container_dns"
+	FindingAuth         = "auth"
+	FindingApp          = "app"
+
+	// findingPermissions explains any file check that failed only on permissions.
+	findingPermissions = "permissions"
+)
+
+// permissionsWarningPrefix starts the message of checks that failed on file permissions.
+const permissionsWarningPrefix = "permissions "
+
+// remediations holds the remediation hint for each finding ID.
+//
+//nolint:gochecknoglobals // read-only lookup table
+var remediations = map[string]string{
+	FindingCLIBinary: "Several studioctl installs are present. Remove the stale one or put the bin dir first " +
+		"with 'studioctl shell path'",
+	FindingDotnet:       "Install the .NET SDK 8.0 or newer from https://dotnet.microsoft.com/download",
+	FindingContainer:    "Install Docker or Podman and make sure the daemon is running ('docker info')",
+	FindingWindows:      "Update to Windows 10 version 1803 or newer, which adds Unix domain socket support",
+	FindingHostGateway:  "Start the container runtime, then rerun 'studioctl doctor -c'",
+	FindingConnectivity: "Check that no firewall or VPN blocks traffic between containers and the host",
+	FindingHostDNS: "Run 'studioctl env up' to refresh the network cache, then check that " +
+		networking.LocalDomain + " resolves",
+	FindingContainerDNS: "Restart the container runtime so containers pick up the host DNS settings",
+	FindingAuth:         "Log in again with 'studioctl auth login' to rewrite the credentials file",
+	FindingApp:          "Run studioctl from inside an app repository, or check that App/ is readable",
+	findingPermissions:  "Restrict the file to your user with 'chmod 600 <path>'",
+
+	"home_dir":            "Create the directory or point --home at a writable location",
+	"socket_dir":          "Create the directory or point --socket-dir at a writable location",
+	"log_dir":             "Create the directory; studioctl writes logs there when it exists",
+	"data_dir":            "Run 'studioctl install', which creates the directory",
+	"bin_dir":             "Rerun 'studioctl self install' to recreate the bin directory",
+	"config_file":         "Fix or remove the config file; embedded defaults apply when it is missing",
+	"credentials_file":    "Log in again with 'studioctl auth login', or remove the file and log in from scratch",
+	"network_cache":       "Run 'studioctl env up' or 'studioctl doctor -c' to re-probe the host network",
+	"resources":           "Run 'studioctl install' to reinstall resources for this studioctl version",
+	"resources_integrity": "Run 'studioctl install' to restore missing or modified resource files",
+	"appmgr_binary":       "Run 'studioctl install' to reinstall the app manager",
+	"appmgr_state":        "Run 'studioctl servers down' to stop the app manager and clear its pid and socket files",
+}
+
+// Explanation is a remediation hint for one non-OK finding.
+type Explanation struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+	Hint    string `json:"hint"`
+}
+
+// Remediation returns the hint for a finding ID, or "" when there is none.
+func Remediation(id string) string {
+	return remediations[id]
+}
+
+// Explain returns a remediation hint for every non-OK finding of the selected
+// sections, in render order. Findings without a known hint are left out.
+func Explain(report Report) []Explanation {
+	e := explainer{explanations: []Explanation{}}
+	if report.CLI != nil && report.CLI.Binary != nil && report.CLI.Binary.Mismatch {
+		e.add(FindingCLIBinary, "PATH resolves to "+report.CLI.Binary.OnPath)
+	}
+	if p := report.Prerequisites; p != nil {
+		if !p.Dotnet.OK {
+			e.add(FindingDotnet, p.Dotnet.Error)
+		}
+		if !p.Container.OK {
+			e.add(FindingContainer, p.Container.Error)
+		}
+		if p.Windows != nil && !p.Windows.OK {
+			e.add(FindingWindows, p.Windows.Error)
+		}
+	}
+	e.network(report.Network)
+	if report.Auth != nil && report.Auth.Error != "" {
+		e.add(FindingAuth, report.Auth.Error)
+	}
+	if report.Disk != nil {
+		for _, check := range report.Disk.Checks {
+			if check.Level == diskLevelWarn || check.Level == diskLevelError {
+				e.disk(check)
+			}
+		}
+	}
+	if report.App != nil && report.App.Error != "" {
+		e.add(FindingApp, report.App.Error)
+	}
+	return e.explanations
+}
+
+// explainer collects explanations in the order findings are added.
+type explainer struct {
+	explanations []Explanation
+}
+
+func (e *explainer) add(id, message string) {
+	e.addHint(id, message, Remediation(id))
+}
+
+func (e *explainer) addHint(id, message, hint string) {
+	if hint == "" {
+		return
+	}
+	e.explanations = append(e.explanations, Explanation{ID: id, Message: message, Hint: hint})
+}
+
+// network explains active network checks; cached results are covered by the network_cache disk check.
+func (e *explainer) network(network *Network) {
+	if network == nil || network.Mode != networkModeChecks {
+		return
+	}
+	if network.Error != "" {
+		e.add(FindingHostGateway, network.Error)
+		return
+	}
+	if network.PingOK == nil || !*network.PingOK {
+		e.add(FindingConnectivity, "ping failed")
+	}
+	if network.HostDNS == "" {
+		e.add(FindingHostDNS, "host DNS unresolvable")
+	}
+	if network.ContainerDNS == "" {
+		e.add(FindingContainerDNS, "container DNS unresolvable")
+	}
+}
+
+// disk explains a disk check, using the permissions hint when only file permissions are wrong.
+func (e *explainer) disk(check DiskCheck) {
+	if !strings.HasPrefix(check.Message, permissionsWarningPrefix) {
+		e.add(check.ID, check.Message)
+		return
+	}
+	e.addHint(check.ID, check.Message, strings.Replace(Remediation(findingPermissions), "<path>", check.Path, 1))
+}
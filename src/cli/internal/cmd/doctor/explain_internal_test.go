@@ -0,0 +1,89 @@
+//nolint:testpackage // testing remediation hints against the unexported lookup table
+package doctor
+
+import (
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	pingOK := true
+	report := Report{
+		CLI: &CLI{Binary: &Binary{Running: "/a/studioctl", OnPath: "/b/studioctl", Mismatch: true}, Version: "v1"},
+		Prerequisites: &Prerequisites{
+			Dotnet:    Check{Error: "dotnet version too old", OK: false},
+			Container: Check{OK: true},
+		},
+		Network: &Network{Mode: networkModeChecks, HostGateway: "10.0.0.1", PingOK: &pingOK, HostDNS: "10.0.0.1"},
+		Disk: &Disk{Checks: []DiskCheck{
+			{ID: "home_dir", Level: diskLevelOK, Path: "/home/u/.altinn-studio", Message: "ready"},
+			{ID: "credentials_file", Level: diskLevelInfo, Message: "missing (not logged in)"},
+			{ID: "network_cache", Level: diskLevelWarn, Message: "stale cache (3d old)"},
+			{
+				ID:      "credentials_file",
+				Level:   diskLevelWarn,
+				Path:    "/home/u/.altinn-studio/credentials.yaml",
+				Message: ownerOnlyWarningForTest(),
+			},
+			{ID: "unknown_check", Level: diskLevelError, Message: "broken"},
+		}},
+		App: &App{Error: "detecting app: permission denied"},
+	}
+
+	got := Explain(report)
+	ids := make([]string, 0, len(got))
+	for _, explanation := range got {
+		ids = append(ids, explanation.ID)
+	}
+	want := []string{
+		FindingCLIBinary, FindingDotnet, FindingContainerDNS, "network_cache", "credentials_file", FindingApp,
+	}
+	if !slices.Equal(ids, want) {
+		t.Fatalf("Explain() IDs = %v, want %v", ids, want)
+	}
+
+	hints := map[string]string{}
+	for _, explanation := range got {
+		hints[explanation.ID] = explanation.Hint
+	}
+	if !strings.Contains(hints["network_cache"], "env up") {
+		t.Errorf("network_cache hint = %q, want it to suggest env up", hints["network_cache"])
+	}
+	if want := "chmod 600 /home/u/.altinn-studio/credentials.yaml"; !strings.Contains(hints["credentials_file"], want) {
+		t.Errorf("credentials_file hint = %q, want %q", hints["credentials_file"], want)
+	}
+}
+
+func TestExplain_NoFindings(t *testing.T) {
+	t.Parallel()
+
+	got := Explain(Report{Disk: &Disk{Checks: []DiskCheck{{ID: "home_dir", Level: diskLevelOK}}}})
+	if got == nil || len(got) != 0 {
+		t.Fatalf("Explain() = %#v, want an empty list", got)
+	}
+}
+
+func TestRemediation_CoversDiskChecks(t *testing.T) {
+	t.Parallel()
+
+	for _, id := range []string{
+		"home_dir", "socket_dir", "log_dir", "data_dir", "bin_dir", "config_file", "credentials_file",
+		"network_cache", "resources", "resources_integrity", "appmgr_binary", "appmgr_state",
+	} {
+		if Remediation(id) == "" {
+			t.Errorf("Remediation(%q) is empty", id)
+		}
+	}
+}
+
+// ownerOnlyWarningForTest returns the permissions message disk checks produce for a 0644 file.
+func ownerOnlyWarningForTest() string {
+	if runtime.GOOS == osWindows {
+		return permissionsWarningPrefix + "644 are broader than owner-only"
+	}
+	return ownerOnlyPermissionsWarning(0o644)
+}
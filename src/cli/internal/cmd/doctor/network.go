@@ -7,11 +7,13 @@ import (
 	"time"
 
 	"altinn.studio/devenv/pkg/container"
+	"altinn.studio/studioctl/internal/httpclient"
 	"altinn.studio/studioctl/internal/networking"
 )
 
 func (s *Service) buildNetwork(ctx context.Context, runChecks bool) *Network {
 	var network Network
+	network.HTTPSProxy = httpsProxy()
 
 	if !runChecks {
 		status := networking.GetCacheStatus(s.cfg.Home)
@@ -55,6 +57,18 @@ func (s *Service) buildNetwork(ctx context.Context, runChecks bool) *Network {
 	return &network
 }
 
+// proxyProbeURL is a representative outbound HTTPS target (resource downloads are served from GitHub).
+const proxyProbeURL = "https://github.com"
+
+// httpsProxy reports the proxy studioctl uses for outbound HTTPS, or "direct".
+func httpsProxy() string {
+	proxy, err := httpclient.EffectiveProxy(proxyProbeURL)
+	if err != nil {
+		return fmt.Sprintf("invalid: %v", err)
+	}
+	return proxy
+}
+
 // formatDuration formats a duration in human-readable form.
 func formatDuration(d time.Duration) string {
 	if d >= hoursPerDay*time.Hour {
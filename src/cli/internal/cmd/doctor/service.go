@@ -5,7 +5,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
+	"strings"
 
 	"altinn.studio/studioctl/internal/auth"
 	"altinn.studio/studioctl/internal/config"
@@ -40,8 +42,23 @@ const (
 	networkModeChecks = "checks"
 )
 
+// Report section names, as accepted by ParseSections and used as JSON keys.
+const (
+	SectionCLI           = "cli"
+	SectionSystem        = "system"
+	SectionPrerequisites = "prerequisites"
+	SectionNetwork       = "network"
+	SectionAuth          = "auth"
+	SectionDisk          = "disk"
+	SectionApp           = "app"
+)
+
+// ErrUnknownSection is returned when a requested doctor section does not exist.
+var ErrUnknownSection = errors.New("unknown doctor section")
+
 var (
 	errDotnetVersionTooOld = errors.New("dotnet version too old")
+	errEmptyVersion        = errors.New("empty version")
 	errNoContainerRuntime  = errors.New("no container runtime found")
 	errWindowsVersionOld   = errors.New("windows version too old")
 	errWindowsVersionUnk   = errors.New("windows version unknown")
@@ -60,9 +77,11 @@ const (
 type Service struct {
 	cfg    *config.Config
 	debugf func(format string, args ...any)
+	binary binaryProbe
 }
 
 // Report is the doctor application-layer output model.
+// Sections left out by BuildSections are nil.
 type Report struct {
 	CLI           *CLI           `json:"cli"`
 	Prerequisites *Prerequisites `json:"prerequisites"`
@@ -71,11 +90,54 @@ type Report struct {
 	App           *App           `json:"app"`
 	Disk          *Disk          `json:"disk"`
 	System        *System        `json:"system"`
+	// sections is the selection the report was built for; nil means all sections.
+	sections []string
+}
+
+// Includes reports whether section was selected when the report was built.
+func (r Report) Includes(section string) bool {
+	return r.sections == nil || slices.Contains(r.sections, section)
+}
+
+// Sections returns all report section names in render order.
+func Sections() []string {
+	return []string{
+		SectionCLI,
+		SectionSystem,
+		SectionPrerequisites,
+		SectionNetwork,
+		SectionAuth,
+		SectionDisk,
+		SectionApp,
+	}
+}
+
+// ParseSections parses a comma-separated list of section names.
+// An empty list selects every section and returns nil.
+func ParseSections(list string) ([]string, error) {
+	if strings.TrimSpace(list) == "" {
+		return nil, nil
+	}
+	var sections []string
+	for name := range strings.SplitSeq(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(Sections(), name) {
+			return nil, fmt.Errorf("%w: %q (valid: %s)", ErrUnknownSection, name, strings.Join(Sections(), ", "))
+		}
+		if !slices.Contains(sections, name) {
+			sections = append(sections, name)
+		}
+	}
+	return sections, nil
 }
 
 // CLI contains CLI version metadata for doctor output.
 type CLI struct {
-	Version string `json:"version"`
+	Binary  *Binary `json:"binary,omitempty"`
+	Version string  `json:"version"`
 }
 
 // System contains environment and terminal metadata for doctor output.
@@ -116,6 +178,7 @@ type Network struct {
 	CacheFresh   *bool  `json:"cacheFresh,omitempty"`
 	CacheAge     string `json:"cacheAge,omitempty"`
 	Error        string `json:"error,omitempty"`
+	HTTPSProxy   string `json:"httpsProxy,omitempty"`
 }
 
 // Auth contains authentication status summary for configured environments.
@@ -159,40 +222,73 @@ func New(cfg *config.Config, debugf func(format string, args ...any)) *Service {
 	if debugf == nil {
 		debugf = func(string, ...any) {}
 	}
-	return &Service{cfg: cfg, debugf: debugf}
+	return &Service{cfg: cfg, debugf: debugf, binary: defaultBinaryProbe()}
 }
 
 // BuildReport builds a doctor report from system state.
 func (s *Service) BuildReport(ctx context.Context, runChecks bool) Report {
-	return Report{
-		CLI:           &CLI{Version: s.cfg.Version},
-		System:        buildSystem(ctx),
-		Prerequisites: s.collectPrerequisites(ctx),
-		Network:       s.buildNetwork(ctx, runChecks),
-		Auth:          s.buildAuth(),
-		App:           s.buildApp(ctx),
-		Disk:          s.buildDisk(),
-	}
+	return s.BuildSections(ctx, runChecks, nil)
 }
 
-// HasIssues reports whether the report indicates actionable problems.
-func (s *Service) HasIssues(report Report) bool {
-	if report.Prerequisites == nil {
-		return true
+// BuildSections builds only the given report sections (see ParseSections);
+// nil builds all of them. Sections that are not selected are not probed at all.
+func (s *Service) BuildSections(ctx context.Context, runChecks bool, sections []string) Report {
+	report := Report{
+		CLI:           nil,
+		Prerequisites: nil,
+		Network:       nil,
+		Auth:          nil,
+		App:           nil,
+		Disk:          nil,
+		System:        nil,
+		sections:      sections,
 	}
-	if !report.Prerequisites.Dotnet.OK || !report.Prerequisites.Container.OK {
-		return true
+	if report.Includes(SectionCLI) {
+		report.CLI = &CLI{Binary: s.buildBinary(ctx), Version: s.cfg.Version}
 	}
-	if report.Prerequisites.Windows != nil && !report.Prerequisites.Windows.OK {
-		return true
+	if report.Includes(SectionSystem) {
+		report.System = buildSystem(ctx, s.cfg.NoColor)
 	}
-	if report.App == nil {
-		return true
+	if report.Includes(SectionPrerequisites) {
+		report.Prerequisites = s.collectPrerequisites(ctx)
+	}
+	if report.Includes(SectionNetwork) {
+		report.Network = s.buildNetwork(ctx, runChecks)
+	}
+	if report.Includes(SectionAuth) {
+		report.Auth = s.buildAuth()
+	}
+	if report.Includes(SectionApp) {
+		report.App = s.buildApp(ctx)
+	}
+	if report.Includes(SectionDisk) {
+		report.Disk = s.buildDisk(runChecks)
 	}
-	if report.App.Error != "" {
+	return report
+}
+
+// HasIssues reports whether the selected sections of the report indicate actionable problems.
+func (s *Service) HasIssues(report Report) bool {
+	if report.Includes(SectionCLI) && report.CLI != nil && report.CLI.Binary != nil && report.CLI.Binary.Mismatch {
 		return true
 	}
-	return report.Disk != nil && report.Disk.HasIssues
+	if report.Includes(SectionPrerequisites) {
+		if report.Prerequisites == nil {
+			return true
+		}
+		if !report.Prerequisites.Dotnet.OK || !report.Prerequisites.Container.OK {
+			return true
+		}
+		if report.Prerequisites.Windows != nil && !report.Prerequisites.Windows.OK {
+			return true
+		}
+	}
+	if report.Includes(SectionApp) {
+		if report.App == nil || report.App.Error != "" {
+			return true
+		}
+	}
+	return report.Includes(SectionDisk) && report.Disk != nil && report.Disk.HasIssues
 }
 
 func (s *Service) buildAuth() *Auth {
@@ -8,16 +8,18 @@ import (
 	"strings"
 
 	"golang.org/x/term"
+
+	"altinn.studio/studioctl/internal/ui"
 )
 
-func buildSystem(ctx context.Context) *System {
+func buildSystem(ctx context.Context, noColor bool) *System {
 	system := &System{
 		OS:           runtime.GOOS,
 		Architecture: runtime.GOARCH,
 		OSName:       "",
 		OSVersion:    "",
 		Terminal:     os.Getenv("TERM"),
-		ColorEnabled: os.Getenv("NO_COLOR") == "",
+		ColorEnabled: ui.ColorsEnabled(noColor),
 		TTY:          term.IsTerminal(int(os.Stdout.Fd())),
 	}
 
@@ -0,0 +1,159 @@
+//nolint:testpackage // testing doctor section selection without probing the host
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	doctorsvc "altinn.studio/studioctl/internal/cmd/doctor"
+	"altinn.studio/studioctl/internal/config"
+	"altinn.studio/studioctl/internal/ui"
+)
+
+func TestDoctorCommand_Only(t *testing.T) {
+	t.Parallel()
+
+	t.Run("json includes only selected sections", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := runDoctor(t, "--only", "auth, CLI", "--json")
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		var payload map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(out), &payload); err != nil {
+			t.Fatalf("unmarshal output %q: %v", out, err)
+		}
+		keys := make([]string, 0, len(payload))
+		for key := range payload {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		if want := []string{"auth", "cli", "hasIssues"}; !slices.Equal(keys, want) {
+			t.Fatalf("keys = %v, want %v", keys, want)
+		}
+	})
+
+	t.Run("text renders only selected sections", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := runDoctor(t, "--only", "auth")
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if !strings.Contains(out, "Auth") {
+			t.Fatalf("output missing Auth section:\n%s", out)
+		}
+		for _, header := range []string{"System", "Prerequisites", "Network", "Disk", "App"} {
+			if strings.Contains(out, header) {
+				t.Fatalf("output contains unselected %s section:\n%s", header, out)
+			}
+		}
+	})
+
+	t.Run("unknown section", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := runDoctor(t, "--only", "auth,dns")
+		if !errors.Is(err, ErrInvalidFlagValue) || !errors.Is(err, doctorsvc.ErrUnknownSection) {
+			t.Fatalf("Run() error = %v, want ErrInvalidFlagValue and ErrUnknownSection", err)
+		}
+	})
+}
+
+func TestDoctorCommand_Explain(t *testing.T) {
+	t.Parallel()
+
+	t.Run("json lists hints for failed checks", func(t *testing.T) {
+		t.Parallel()
+
+		// The test config has no socket or data dir, so those disk checks fail.
+		out, err := runDoctor(t, "--only", "disk", "--explain", "--json")
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		var payload struct {
+			Explanations []doctorsvc.Explanation `json:"explanations"`
+		}
+		if err := json.Unmarshal([]byte(out), &payload); err != nil {
+			t.Fatalf("unmarshal output %q: %v", out, err)
+		}
+		hints := map[string]string{}
+		for _, explanation := range payload.Explanations {
+			hints[explanation.ID] = explanation.Hint
+		}
+		for _, id := range []string{"socket_dir", "data_dir"} {
+			if hints[id] != doctorsvc.Remediation(id) {
+				t.Errorf("hint for %s = %q, want %q", id, hints[id], doctorsvc.Remediation(id))
+			}
+		}
+		if _, ok := hints["home_dir"]; ok {
+			t.Errorf("home_dir is ready but was explained: %v", payload.Explanations)
+		}
+	})
+
+	t.Run("text renders hints", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := runDoctor(t, "--only", "disk", "--explain")
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if !strings.Contains(out, "How to fix") || !strings.Contains(out, doctorsvc.Remediation("socket_dir")) {
+			t.Fatalf("output missing remediation hints:\n%s", out)
+		}
+	})
+
+	t.Run("hints are off by default", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := runDoctor(t, "--only", "disk")
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if strings.Contains(out, "How to fix") {
+			t.Fatalf("output has remediation hints without --explain:\n%s", out)
+		}
+	})
+}
+
+func TestParseSections(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		list string
+		want []string
+	}{
+		{name: "empty selects all", list: "", want: nil},
+		{name: "trims and lowercases", list: " Network ,disk", want: []string{"network", "disk"}},
+		{name: "deduplicates", list: "app,app,,cli", want: []string{"app", "cli"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := doctorsvc.ParseSections(tt.list)
+			if err != nil {
+				t.Fatalf("ParseSections() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("ParseSections() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func runDoctor(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	cfg := &config.Config{Home: t.TempDir(), Version: "v1.0.0"}
+	err := NewDoctorCommand(cfg, ui.NewOutput(&buf, &buf, false)).Run(context.Background(), args)
+	return buf.String(), err
+}
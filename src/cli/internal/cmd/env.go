@@ -6,6 +6,11 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
 
 	"altinn.studio/devenv/pkg/container"
 	envtypes "altinn.studio/studioctl/internal/cmd/env"
@@ -17,17 +22,59 @@ import (
 
 var errInvalidPort = errors.New("invalid port")
 
-const runtimeLocaltest = "localtest"
+const (
+	runtimeLocaltest = "localtest"
+
+	defaultWatchInterval = 2 * time.Second
+)
+
+// runtimeCLIs are the container runtime binaries checked when no runtime is reachable.
+func runtimeCLIs() []string {
+	return []string{"docker", "podman"}
+}
 
 // EnvCommand implements the 'env' subcommand.
 type EnvCommand struct {
-	cfg *config.Config
-	out *ui.Output
+	cfg      *config.Config
+	out      *ui.Output
+	detect   func(ctx context.Context) (container.ContainerClient, error)
+	lookPath func(file string) (string, error)
 }
 
 // NewEnvCommand creates a new env command.
 func NewEnvCommand(cfg *config.Config, out *ui.Output) *EnvCommand {
-	return &EnvCommand{cfg: cfg, out: out}
+	return &EnvCommand{cfg: cfg, out: out, detect: container.Detect, lookPath: exec.LookPath}
+}
+
+// runtimeUnavailableError is returned by withContainerClient when container.Detect finds no runtime.
+type runtimeUnavailableError struct {
+	err       error
+	installed string // runtime CLI found on PATH, empty if none
+}
+
+// Error returns a message including why the runtime is unavailable.
+func (e *runtimeUnavailableError) Error() string {
+	return "connect to container runtime: " + e.reason() + ": " + e.err.Error()
+}
+
+// Unwrap returns the detection error.
+func (e *runtimeUnavailableError) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether target is ErrNoContainerRuntime or ErrContainerRuntimeNotRunning.
+func (e *runtimeUnavailableError) Is(target error) bool {
+	if e.installed == "" {
+		return target == ErrNoContainerRuntime
+	}
+	return target == ErrContainerRuntimeNotRunning
+}
+
+func (e *runtimeUnavailableError) reason() string {
+	if e.installed != "" {
+		return e.installed + " is installed but not running"
+	}
+	return "no container runtime installed (install Docker or Podman)"
 }
 
 // Name returns the command name.
@@ -53,13 +100,64 @@ Common options:
   -r, --runtime    Runtime to use (default: localtest)
 
 Options for 'env up':
-  -p, --port       Loadbalancer port (default: %d)
+  -p, --port       Loadbalancer port (default: %d, or the next free port if taken)
+  --strict-port    Fail instead of picking another port when the default is taken
   -d, --detach     Run in background (default: true)
   --monitoring     Start monitoring stack
   --open           Open localtest in browser after starting
+  --mem-limit MEM  Memory limit per monitoring container (e.g. 512m, 2g)
+  --cpu-limit CPUS CPU limit per monitoring container (e.g. 0.5)
+  --add-host HOST:IP
+                   Add a host mapping to every container; repeatable
+                   (e.g. --add-host api.example.test:10.0.0.5)
+  --volume HOST:CONTAINER[:ro]
+                   Bind-mount a host path into the localtest container; repeatable
+                   (e.g. --volume ./App:/app:ro)
+  --pull-timeout DUR
+                   Give up on an image pull after DUR (default: %s, 0 for no limit);
+                   use the global --deadline to bound the whole startup
+  --teardown-timeout DUR
+                   How long stopping the environment may take when run in the foreground
+                   or after a failed start (default: teardownTimeout in config or %s;
+                   minimum %s)
+  --recreate       Replace running containers instead of reusing them, e.g. after
+                   changing env vars or image pins (monitoring too with --monitoring)
+  --migrate        Stop legacy localtest containers (not started by this CLI) first and keep
+                   them under a -legacy name suffix
+  -y, --yes        Skip the --migrate confirmation prompt
+  --timing         Print how long each startup phase took
+  --json           Print the --timing report as JSON on stdout; all other output goes to
+                   stderr
+
+Options for 'env down':
+  --only NAMES     Stop only these containers (comma-separated, e.g. pdf3,grafana)
+                   and any containers that depend on them
+
+Options for 'env logs':
+  -c, --container NAME
+                   Stream only this container; repeat to interleave several
+                   (e.g. --container localtest --container pdf3)
+  -f, --follow     Follow log output (default: true)
+  --level LEVEL    Show only lines at or above LEVEL (trace, debug, info, warn, error, fatal);
+                   lines without a detectable level are shown too
+  --hide-unclassified
+                   With --level, also hide lines without a detectable level
+
+Options for 'env status':
+  --json           Output as JSON
+  --watch [INTERVAL]
+                   Refresh status until Ctrl+C (default interval: 2s); with --json,
+                   print one JSON object per line per refresh
 
 Run '%s env <subcommand> --help' for more information.
-`, osutil.CurrentBin(), defaultPort, osutil.CurrentBin())
+`,
+		osutil.CurrentBin(),
+		defaultPort,
+		envlocaltest.DefaultPullTimeout,
+		envlocaltest.DefaultTeardownTimeout,
+		envlocaltest.MinTeardownTimeout,
+		osutil.CurrentBin(),
+	)
 }
 
 // Run executes the command.
@@ -108,10 +206,20 @@ func (c *EnvCommand) withContainerClient(
 	ctx context.Context,
 	run func(client container.ContainerClient) error,
 ) error {
-	client, err := container.Detect(ctx)
-	if err != nil {
+	client, err := c.detect(ctx)
+	if err != nil && !errors.Is(err, container.ErrNoRuntime) {
 		return fmt.Errorf("connect to container runtime: %w", err)
 	}
+	if err != nil {
+		unavailable := &runtimeUnavailableError{err: err, installed: ""}
+		for _, bin := range runtimeCLIs() {
+			if _, lookErr := c.lookPath(bin); lookErr == nil {
+				unavailable.installed = bin
+				break
+			}
+		}
+		return unavailable
+	}
 	defer func() {
 		if cerr := client.Close(); cerr != nil {
 			c.out.Verbosef("failed to close container client: %v", cerr)
@@ -123,16 +231,28 @@ func (c *EnvCommand) withContainerClient(
 
 // envUpFlags holds parsed flags for the env up command.
 type envUpFlags struct {
-	runtime     string
-	port        int
-	detach      bool
-	monitoring  bool
-	openBrowser bool
+	runtime         string
+	memLimit        string
+	cpuLimit        string
+	addHosts        stringList
+	volumes         stringList
+	pullTimeout     time.Duration
+	teardownTimeout time.Duration
+	port            int
+	strictPort      bool
+	detach          bool
+	monitoring      bool
+	openBrowser     bool
+	recreate        bool
+	migrate         bool
+	yes             bool
+	timing          bool
+	jsonOutput      bool
 }
 
 func (c *EnvCommand) parseUpFlags(args []string) (envUpFlags, bool, error) {
-	fs := flag.NewFlagSet("env up", flag.ContinueOnError)
 	var f envUpFlags
+	fs := flag.NewFlagSet("env up", flag.ContinueOnError)
 	fs.StringVar(&f.runtime, "r", runtimeLocaltest, "Runtime to use")
 	fs.StringVar(&f.runtime, "runtime", runtimeLocaltest, "Runtime to use")
 	fs.BoolVar(&f.detach, "d", true, "Run in background")
@@ -141,7 +261,24 @@ func (c *EnvCommand) parseUpFlags(args []string) (envUpFlags, bool, error) {
 	portHelp := fmt.Sprintf("Loadbalancer port (default: %d)", envlocaltest.DefaultLoadBalancerPort)
 	fs.IntVar(&f.port, "p", 0, portHelp)
 	fs.IntVar(&f.port, "port", 0, portHelp)
+	fs.BoolVar(&f.strictPort, "strict-port", false, "Fail if the default port is taken")
 	fs.BoolVar(&f.openBrowser, "open", false, "Open localtest in browser after starting")
+	fs.StringVar(&f.memLimit, "mem-limit", "", "Memory limit per monitoring container (e.g. 512m)")
+	fs.StringVar(&f.cpuLimit, "cpu-limit", "", "CPU limit per monitoring container (e.g. 0.5)")
+	fs.Var(&f.addHosts, "add-host", "Add a host:ip mapping to every container (repeatable)")
+	fs.DurationVar(
+		&f.pullTimeout, "pull-timeout", envlocaltest.DefaultPullTimeout, "Give up on an image pull after this long",
+	)
+	fs.DurationVar(
+		&f.teardownTimeout, "teardown-timeout", envlocaltest.DefaultTeardownTimeout, "How long stopping may take",
+	)
+	fs.Var(&f.volumes, "volume", "Bind-mount host:container[:ro] into the localtest container (repeatable)")
+	fs.BoolVar(&f.recreate, "recreate", false, "Replace existing containers instead of reusing them")
+	fs.BoolVar(&f.migrate, "migrate", false, "Stop legacy localtest containers before starting")
+	fs.BoolVar(&f.yes, "y", false, "Skip confirmation prompts")
+	fs.BoolVar(&f.yes, "yes", false, "Skip confirmation prompts")
+	fs.BoolVar(&f.timing, "timing", false, "Print how long each startup phase took")
+	fs.BoolVar(&f.jsonOutput, "json", false, "Print the --timing report as JSON on stdout")
 
 	if err := fs.Parse(args); err != nil {
 		if errors.Is(err, flag.ErrHelp) {
@@ -153,10 +290,51 @@ func (c *EnvCommand) parseUpFlags(args []string) (envUpFlags, bool, error) {
 	if f.port != 0 && (f.port < 1 || f.port > 65535) {
 		return f, false, fmt.Errorf("%w: %d (must be 1-65535)", errInvalidPort, f.port)
 	}
+	if _, err := envlocaltest.ParseResourceLimits(f.memLimit, f.cpuLimit); err != nil {
+		return f, false, fmt.Errorf("%w: %w", ErrInvalidFlagValue, err)
+	}
+	if _, err := envlocaltest.ParseExtraHosts(f.addHosts); err != nil {
+		return f, false, fmt.Errorf("%w: %w", ErrInvalidFlagValue, err)
+	}
+	if _, err := envlocaltest.ParseVolumes(f.volumes); err != nil {
+		return f, false, fmt.Errorf("%w: %w", ErrInvalidFlagValue, err)
+	}
+	if f.pullTimeout < 0 {
+		return f, false, fmt.Errorf("%w: --pull-timeout must not be negative", ErrInvalidFlagValue)
+	}
+	if err := c.resolveTeardownTimeout(fs, &f); err != nil {
+		return f, false, err
+	}
+	if f.jsonOutput && !f.timing {
+		return f, false, fmt.Errorf("%w: --json requires --timing", ErrInvalidFlagValue)
+	}
 
 	return f, false, nil
 }
 
+// resolveTeardownTimeout validates --teardown-timeout when given and otherwise falls back
+// to teardownTimeout from config, so a bad config value only matters when it is used.
+func (c *EnvCommand) resolveTeardownTimeout(fs *flag.FlagSet, f *envUpFlags) error {
+	flagSet := false
+	fs.Visit(func(fl *flag.Flag) {
+		if fl.Name == "teardown-timeout" {
+			flagSet = true
+		}
+	})
+	if flagSet {
+		if err := envlocaltest.ValidateTeardownTimeout(f.teardownTimeout); err != nil {
+			return fmt.Errorf("%w: --teardown-timeout: %w", ErrInvalidFlagValue, err)
+		}
+		return nil
+	}
+	teardownTimeout, err := envlocaltest.ParseTeardownTimeout(c.cfg.TeardownTimeout)
+	if err != nil {
+		return fmt.Errorf("config teardownTimeout: %w", err)
+	}
+	f.teardownTimeout = teardownTimeout
+	return nil
+}
+
 func (c *EnvCommand) runUp(ctx context.Context, args []string) error {
 	flags, helpShown, err := c.parseUpFlags(args)
 	if err != nil {
@@ -181,9 +359,31 @@ func (c *EnvCommand) runLocaltestUp(
 	client container.ContainerClient,
 	flags envUpFlags,
 ) error {
-	env := envlocaltest.NewEnv(c.cfg, c.out, client)
+	out := c.out
+	var timingJSON io.Writer
+	if flags.jsonOutput {
+		// Keep stdout for the JSON timing report; everything else goes to stderr.
+		out, timingJSON = c.out.ToStderr(), c.out.Stdout()
+	}
+	env := envlocaltest.NewEnv(c.cfg, out, client)
+
+	if flags.migrate {
+		confirm := func() (bool, error) { return confirmMigration(ctx, out) }
+		if flags.yes {
+			confirm = func() (bool, error) { return true, nil }
+		}
+		if err := env.MigrateLegacy(ctx, confirm); err != nil {
+			return fmt.Errorf("migrate legacy localtest: %w", err)
+		}
+	}
 
 	preflightErr := env.Preflight(ctx)
+	if errors.Is(preflightErr, envlocaltest.ErrLegacyLocaltestRunning) {
+		return fmt.Errorf(
+			"preflight check: %w (run '%s env up --migrate' to stop it)",
+			preflightErr, osutil.CurrentBin(),
+		)
+	}
 	if preflightErr != nil {
 		return fmt.Errorf("preflight check: %w", preflightErr)
 	}
@@ -192,25 +392,56 @@ func (c *EnvCommand) runLocaltestUp(
 	if err != nil {
 		return fmt.Errorf("get status: %w", err)
 	}
-	if status.Running {
-		c.out.Printf("%s already running.\n", runtimeLocaltest)
+	if status.Running && !flags.recreate {
+		out.Printf("%s already running.\n", runtimeLocaltest)
 		return nil
 	}
 
-	if err := env.Up(ctx, envtypes.UpOptions{
-		Port:        flags.port,
-		Detach:      flags.detach,
-		Monitoring:  flags.monitoring,
-		OpenBrowser: flags.openBrowser,
-	}); err != nil {
+	result, err := env.Up(ctx, envtypes.UpOptions{
+		Port:            flags.port,
+		StrictPort:      flags.strictPort,
+		Detach:          flags.detach,
+		Monitoring:      flags.monitoring,
+		OpenBrowser:     flags.openBrowser,
+		Recreate:        flags.recreate,
+		MemLimit:        flags.memLimit,
+		CPULimit:        flags.cpuLimit,
+		Volumes:         flags.volumes,
+		ExtraHosts:      flags.addHosts,
+		PullTimeout:     flags.pullTimeout,
+		TeardownTimeout: flags.teardownTimeout,
+		Timing:          flags.timing,
+		TimingJSON:      timingJSON,
+	})
+	if err != nil {
 		return fmt.Errorf("env up: %w", err)
 	}
+	if flags.detach {
+		out.Println("\nLocaltest started in background.")
+		out.Printf("Access the platform at: %s\n", result.URL)
+		out.Printf("Use '%s env logs' to view logs.\n", osutil.CurrentBin())
+		out.Printf("Use '%s env down' to stop.\n", osutil.CurrentBin())
+	}
 	return nil
 }
 
+// confirmMigration prompts the user to confirm stopping legacy localtest containers.
+// Returns (confirmed, error) where error is ui.ErrInterrupted on Ctrl+C.
+func confirmMigration(ctx context.Context, out *ui.Output) (bool, error) {
+	out.Print("Stop these containers? [y/N]: ")
+	response, err := ui.ReadLine(ctx, os.Stdin)
+	if err != nil {
+		out.Println("")
+		return false, fmt.Errorf("read confirmation: %w", err)
+	}
+	answer := strings.TrimSpace(strings.ToLower(string(response)))
+	return answer == "y" || answer == "yes", nil
+}
+
 // envDownFlags holds parsed flags for the env down command.
 type envDownFlags struct {
 	runtime string
+	only    []string
 }
 
 func (c *EnvCommand) parseDownFlags(args []string) (envDownFlags, bool, error) {
@@ -218,6 +449,8 @@ func (c *EnvCommand) parseDownFlags(args []string) (envDownFlags, bool, error) {
 	var f envDownFlags
 	fs.StringVar(&f.runtime, "r", runtimeLocaltest, "Runtime to use")
 	fs.StringVar(&f.runtime, "runtime", runtimeLocaltest, "Runtime to use")
+	var only string
+	fs.StringVar(&only, "only", "", "Comma-separated containers to stop")
 
 	if err := fs.Parse(args); err != nil {
 		if errors.Is(err, flag.ErrHelp) {
@@ -226,6 +459,15 @@ func (c *EnvCommand) parseDownFlags(args []string) (envDownFlags, bool, error) {
 		return f, false, fmt.Errorf("parsing flags: %w", err)
 	}
 
+	for name := range strings.SplitSeq(only, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			f.only = append(f.only, name)
+		}
+	}
+	if only != "" && len(f.only) == 0 {
+		return f, false, fmt.Errorf("%w: only %q (e.g. pdf3,grafana)", ErrInvalidFlagValue, only)
+	}
+
 	return f, false, nil
 }
 
@@ -238,34 +480,83 @@ func (c *EnvCommand) runDown(ctx context.Context, args []string) error {
 		return nil
 	}
 
-	return c.withContainerClient(ctx, func(client container.ContainerClient) error {
+	err = c.withContainerClient(ctx, func(client container.ContainerClient) error {
 		env, err := c.getEnv(flags.runtime, client)
 		if err != nil {
 			return err
 		}
-		if err := env.Down(ctx); err != nil {
+		result, err := env.Down(ctx, envtypes.DownOptions{Only: flags.only})
+		if err != nil {
 			if errors.Is(err, envtypes.ErrAlreadyStopped) {
 				c.out.Printf("%s is already stopped.\n", flags.runtime)
 				return nil
 			}
 			return fmt.Errorf("env down: %w", err)
 		}
+		c.out.Verbosef("Stopped containers: %s", strings.Join(result.Stopped, ", "))
 		return nil
 	})
+	// Nothing can be running without a runtime, so there is nothing to stop.
+	if unavailable, ok := asRuntimeUnavailable(err); ok {
+		c.out.Printf("%s is already stopped (%s).\n", flags.runtime, unavailable.reason())
+		return nil
+	}
+	return err
+}
+
+// asRuntimeUnavailable reports whether err means no container runtime is reachable.
+func asRuntimeUnavailable(err error) (*runtimeUnavailableError, bool) {
+	var unavailable *runtimeUnavailableError
+	if errors.As(err, &unavailable) {
+		return unavailable, true
+	}
+	return nil, false
 }
 
 // envStatusFlags holds parsed flags for the env status command.
 type envStatusFlags struct {
 	runtime    string
+	watch      watchInterval
 	jsonOutput bool
 }
 
+// watchInterval is a boolean-style flag with an optional duration:
+// "--watch" uses defaultWatchInterval, "--watch=5s" or "--watch 5s" sets it.
+// Zero means watching is disabled.
+type watchInterval time.Duration
+
+func (w *watchInterval) String() string {
+	if w == nil || *w == 0 {
+		return ""
+	}
+	return time.Duration(*w).String()
+}
+
+func (w *watchInterval) Set(value string) error {
+	switch value {
+	case "true":
+		*w = watchInterval(defaultWatchInterval)
+	case "false":
+		*w = 0
+	default:
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			return fmt.Errorf("%w: watch interval %q (e.g. 2s, 500ms)", ErrInvalidFlagValue, value)
+		}
+		*w = watchInterval(interval)
+	}
+	return nil
+}
+
+func (w *watchInterval) IsBoolFlag() bool { return true }
+
 func (c *EnvCommand) parseStatusFlags(args []string) (envStatusFlags, bool, error) {
 	fs := flag.NewFlagSet("env status", flag.ContinueOnError)
 	var f envStatusFlags
 	fs.StringVar(&f.runtime, "r", runtimeLocaltest, "Runtime to use")
 	fs.StringVar(&f.runtime, "runtime", runtimeLocaltest, "Runtime to use")
 	fs.BoolVar(&f.jsonOutput, "json", false, "Output as JSON")
+	fs.Var(&f.watch, "watch", "Refresh status every INTERVAL until Ctrl+C (default 2s)")
 
 	if err := fs.Parse(args); err != nil {
 		if errors.Is(err, flag.ErrHelp) {
@@ -274,6 +565,13 @@ func (c *EnvCommand) parseStatusFlags(args []string) (envStatusFlags, bool, erro
 		return f, false, fmt.Errorf("parsing flags: %w", err)
 	}
 
+	// Boolean-style flags cannot take a separate value, so accept "--watch 5s" here.
+	if f.watch != 0 && fs.NArg() == 1 {
+		if err := f.watch.Set(fs.Arg(0)); err != nil {
+			return f, false, err
+		}
+	}
+
 	return f, false, nil
 }
 
@@ -286,22 +584,56 @@ func (c *EnvCommand) runStatus(ctx context.Context, args []string) error {
 		return nil
 	}
 
-	return c.withContainerClient(ctx, func(client container.ContainerClient) error {
+	err = c.withContainerClient(ctx, func(client container.ContainerClient) error {
 		switch flags.runtime {
 		case runtimeLocaltest:
-			return c.runLocaltestStatus(ctx, client, flags.jsonOutput)
+			env := envlocaltest.NewEnv(c.cfg, c.out, client)
+			render := func(ctx context.Context) error {
+				return c.runLocaltestStatus(ctx, env, flags.jsonOutput)
+			}
+			switch {
+			case flags.watch != 0 && flags.jsonOutput:
+				// One JSON document per line, so the stream can be read line by line.
+				return ui.Poll(ctx, time.Duration(flags.watch), render)
+			case flags.watch != 0:
+				return ui.Watch(ctx, c.out, time.Duration(flags.watch), render)
+			default:
+				return render(ctx)
+			}
 		default:
 			return fmt.Errorf("%w: %s", ErrUnsupportedRuntime, flags.runtime)
 		}
 	})
+	if unavailable, ok := asRuntimeUnavailable(err); ok {
+		return c.renderRuntimeUnavailableStatus(flags, unavailable)
+	}
+	return err
+}
+
+// renderRuntimeUnavailableStatus reports the environment as not running when no container runtime is reachable.
+func (c *EnvCommand) renderRuntimeUnavailableStatus(flags envStatusFlags, unavailable *runtimeUnavailableError) error {
+	if flags.jsonOutput {
+		payload, err := json.Marshal(envlocaltest.Status{
+			Health:     envlocaltest.HealthStopped,
+			Containers: []envlocaltest.ContainerStatus{},
+			Running:    false,
+			AnyRunning: false,
+		})
+		if err != nil {
+			return fmt.Errorf("marshal status json: %w", err)
+		}
+		c.out.Printf("%s\n", payload)
+		return nil
+	}
+	c.out.Printf("%s is not running (%s).\n", flags.runtime, unavailable.reason())
+	return nil
 }
 
 func (c *EnvCommand) runLocaltestStatus(
 	ctx context.Context,
-	client container.ContainerClient,
+	env *envlocaltest.Env,
 	jsonOutput bool,
 ) error {
-	env := envlocaltest.NewEnv(c.cfg, c.out, client)
 	status, err := env.Status(ctx)
 	if err != nil {
 		return fmt.Errorf("get status: %w", err)
@@ -321,7 +653,7 @@ func (c *EnvCommand) runLocaltestStatus(
 		return nil
 	}
 
-	if !status.Running {
+	if !status.Running || status.Unhealthy() {
 		c.out.Printf("%s is running with issues.\n", runtimeLocaltest)
 		c.out.Println("")
 		c.renderLocaltestStatus(status)
@@ -337,19 +669,53 @@ func (c *EnvCommand) runLocaltestStatus(
 
 func (c *EnvCommand) renderLocaltestStatus(status *envlocaltest.Status) {
 	rows := make([][]string, 1, len(status.Containers)+1)
-	rows[0] = []string{"Container", "Status"}
+	rows[0] = []string{"Container", "Status", "Health", "Uptime"}
 
 	for _, ctr := range status.Containers {
-		rows = append(rows, []string{ctr.Name, ctr.Status})
+		health := ctr.Health
+		if health == "" {
+			health = "-"
+		}
+		uptime := ctr.Uptime
+		if uptime == "" {
+			uptime = "-"
+		}
+		rows = append(rows, []string{ctr.Name, ctr.Status, health, uptime})
 	}
 	c.out.Table(rows)
 }
 
 // envLogsFlags holds parsed flags for the env logs command.
 type envLogsFlags struct {
-	runtime   string
-	component string
-	follow    bool
+	runtime          string
+	level            string
+	containers       stringList
+	follow           bool
+	hideUnclassified bool
+}
+
+// stringList is a repeatable flag; each occurrence may also hold comma-separated values.
+type stringList []string
+
+func (l *stringList) String() string {
+	if l == nil {
+		return ""
+	}
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	added := false
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*l = append(*l, item)
+			added = true
+		}
+	}
+	if !added {
+		return fmt.Errorf("%w: empty value %q", ErrInvalidFlagValue, value)
+	}
+	return nil
 }
 
 func (c *EnvCommand) parseLogsFlags(args []string) (envLogsFlags, bool, error) {
@@ -357,10 +723,13 @@ func (c *EnvCommand) parseLogsFlags(args []string) (envLogsFlags, bool, error) {
 	var f envLogsFlags
 	fs.StringVar(&f.runtime, "r", runtimeLocaltest, "Runtime to use")
 	fs.StringVar(&f.runtime, "runtime", runtimeLocaltest, "Runtime to use")
-	fs.StringVar(&f.component, "c", "", "Filter by component")
-	fs.StringVar(&f.component, "component", "", "Filter by component")
+	fs.Var(&f.containers, "c", "Stream logs from this container (repeatable)")
+	fs.Var(&f.containers, "container", "Stream logs from this container (repeatable)")
+	fs.Var(&f.containers, "component", "Alias for --container")
 	fs.BoolVar(&f.follow, "f", true, "Follow log output")
 	fs.BoolVar(&f.follow, "follow", true, "Follow log output")
+	fs.StringVar(&f.level, "level", "", "Show only lines at or above this level")
+	fs.BoolVar(&f.hideUnclassified, "hide-unclassified", false, "With --level, hide lines without a detectable level")
 
 	if err := fs.Parse(args); err != nil {
 		if errors.Is(err, flag.ErrHelp) {
@@ -369,6 +738,15 @@ func (c *EnvCommand) parseLogsFlags(args []string) (envLogsFlags, bool, error) {
 		return f, false, fmt.Errorf("parsing flags: %w", err)
 	}
 
+	if f.level != "" {
+		if _, err := envlocaltest.ParseLogLevel(f.level); err != nil {
+			return f, false, fmt.Errorf("%w: %w", ErrInvalidFlagValue, err)
+		}
+	}
+	if f.hideUnclassified && f.level == "" {
+		return f, false, fmt.Errorf("%w: --hide-unclassified requires --level", ErrInvalidFlagValue)
+	}
+
 	return f, false, nil
 }
 
@@ -388,8 +766,10 @@ func (c *EnvCommand) runLogs(ctx context.Context, args []string) error {
 		}
 
 		if err := env.Logs(ctx, envtypes.LogsOptions{
-			Component: flags.component,
-			Follow:    flags.follow,
+			Level:            flags.level,
+			Components:       flags.containers,
+			DropUnclassified: flags.hideUnclassified,
+			Follow:           flags.follow,
 		}); err != nil {
 			return fmt.Errorf("env logs: %w", err)
 		}
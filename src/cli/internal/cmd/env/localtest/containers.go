@@ -1,5 +1,14 @@
 package localtest
 
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"altinn.studio/devenv/pkg/container"
+	"altinn.studio/studioctl/internal/config"
+)
+
 // Container name constants - single source of truth for all container names.
 const (
 	// ContainerLocaltest is the main localtest container.
@@ -52,3 +61,77 @@ func AllContainerNames(includeMonitoring bool) []string {
 	result = append(result, monitoring...)
 	return result
 }
+
+// containerNamePrefixes are stripped when matching short container names such as "pdf3" or "grafana".
+func containerNamePrefixes() []string {
+	return []string{"localtest-", "monitoring_"}
+}
+
+// containerDependencies maps each container name to the containers it depends on.
+func containerDependencies() map[string][]string {
+	cfg := RuntimeConfig{
+		HostGateway:      "", // not used for dependency lookup
+		LoadBalancerPort: "", // not used for dependency lookup
+		User:             "", // not used for dependency lookup
+		Installation:     container.InstallationUnknown,
+	}
+	noDashboards := config.DashboardsSpec{Include: nil, Extra: nil} // not used for dependency lookup
+	specs := slices.Concat(coreContainers("", cfg, nil), monitoringContainers("", cfg, noDashboards))
+	deps := make(map[string][]string, len(specs))
+	for _, spec := range specs {
+		deps[spec.Name] = spec.Dependencies
+	}
+	return deps
+}
+
+// resolveContainerName maps a full or short container name to its container name.
+func resolveContainerName(name string) (string, bool) {
+	all := AllContainerNames(true)
+	if slices.Contains(all, name) {
+		return name, true
+	}
+	for _, candidate := range all {
+		for _, prefix := range containerNamePrefixes() {
+			if strings.TrimPrefix(candidate, prefix) == name {
+				return candidate, true
+			}
+		}
+	}
+	return "", false
+}
+
+// SelectContainers resolves the given container names and adds every container that
+// depends on them, so a partial teardown never leaves a dependent running without its dependency.
+// The result is in AllContainerNames order.
+func SelectContainers(names []string) ([]string, error) {
+	selected := make(map[string]bool, len(names))
+	for _, name := range names {
+		resolved, ok := resolveContainerName(strings.TrimSpace(name))
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownComponent, name)
+		}
+		selected[resolved] = true
+	}
+
+	deps := containerDependencies()
+	for changed := true; changed; {
+		changed = false
+		for name, nameDeps := range deps {
+			if selected[name] {
+				continue
+			}
+			if slices.ContainsFunc(nameDeps, func(dep string) bool { return selected[dep] }) {
+				selected[name] = true
+				changed = true
+			}
+		}
+	}
+
+	result := make([]string, 0, len(selected))
+	for _, name := range AllContainerNames(true) {
+		if selected[name] {
+			result = append(result, name)
+		}
+	}
+	return result, nil
+}
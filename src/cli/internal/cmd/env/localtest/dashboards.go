@@ -0,0 +1,93 @@
+package localtest
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"altinn.studio/devenv/pkg/container/types"
+	"altinn.studio/studioctl/internal/config"
+)
+
+// ErrInvalidDashboard is returned when a configured Grafana dashboard entry is malformed.
+var ErrInvalidDashboard = errors.New("invalid grafana dashboard")
+
+const (
+	grafanaDashboardsDir  = "grafana-dashboards"
+	grafanaDashboardsPath = "/var/lib/grafana/dashboards"
+
+	// Folders used once dashboards are mounted individually. Grafana derives
+	// dashboard folders from this layout (foldersFromFilesStructure).
+	builtinDashboardsFolder = "Altinn"
+	extraDashboardsFolder   = "Extra"
+
+	dashboardExt = ".json"
+)
+
+// validateDashboards checks that included dashboards are plain JSON file names and
+// extra dashboards are absolute JSON file paths with distinct names.
+func validateDashboards(spec config.DashboardsSpec) error {
+	for _, name := range spec.Include {
+		if name == "" || name != filepath.Base(name) || strings.ContainsAny(name, `/\`) {
+			return fmt.Errorf("%w: include %q must be a file name under %s", ErrInvalidDashboard, name, grafanaDashboardsDir)
+		}
+		if filepath.Ext(name) != dashboardExt {
+			return fmt.Errorf("%w: include %q must be a %s file", ErrInvalidDashboard, name, dashboardExt)
+		}
+	}
+
+	seen := make(map[string]struct{}, len(spec.Extra))
+	for _, hostPath := range spec.Extra {
+		if !filepath.IsAbs(hostPath) {
+			return fmt.Errorf("%w: extra %q must be an absolute path", ErrInvalidDashboard, hostPath)
+		}
+		name := filepath.Base(hostPath)
+		if filepath.Ext(name) != dashboardExt {
+			return fmt.Errorf("%w: extra %q must be a %s file", ErrInvalidDashboard, hostPath, dashboardExt)
+		}
+		if _, ok := seen[name]; ok {
+			return fmt.Errorf("%w: more than one extra dashboard named %q", ErrInvalidDashboard, name)
+		}
+		seen[name] = struct{}{}
+	}
+	return nil
+}
+
+// grafanaDashboardVolumes returns the dashboard mounts for the Grafana container.
+//
+// Without configuration the built-in directory is mounted as is. Otherwise each
+// dashboard is mounted as its own file so the host directory never receives mount
+// points: included built-ins at the top level, or the whole built-in directory in
+// the Altinn folder when only extras are configured, and extras in the Extra folder.
+func grafanaDashboardVolumes(infraDir string, spec config.DashboardsSpec) []types.VolumeMount {
+	builtinDir := filepath.Join(infraDir, grafanaDashboardsDir)
+	if len(spec.Include) == 0 && len(spec.Extra) == 0 {
+		return []types.VolumeMount{newVolume(builtinDir, grafanaDashboardsPath)}
+	}
+
+	volumes := make([]types.VolumeMount, 0, len(spec.Include)+len(spec.Extra)+1)
+	if len(spec.Include) == 0 {
+		volumes = append(volumes, newVolume(builtinDir, path.Join(grafanaDashboardsPath, builtinDashboardsFolder)))
+	}
+	for _, name := range slices.Compact(slices.Sorted(slices.Values(spec.Include))) {
+		volumes = append(volumes, newVolume(filepath.Join(builtinDir, name), path.Join(grafanaDashboardsPath, name)))
+	}
+	for _, hostPath := range spec.Extra {
+		target := path.Join(grafanaDashboardsPath, extraDashboardsFolder, filepath.Base(hostPath))
+		volumes = append(volumes, newVolume(hostPath, target))
+	}
+	return volumes
+}
+
+// extraDashboardExpectations lists the extra dashboard files, which are not part of
+// the installed resources and so are checked before install.
+func extraDashboardExpectations(spec config.DashboardsSpec) []hostPathExpectation {
+	result := make([]hostPathExpectation, 0, len(spec.Extra))
+	for _, hostPath := range spec.Extra {
+		result = append(result, hostPathExpectation{hostPath: hostPath, expectDir: false, anyType: false})
+	}
+	return result
+}
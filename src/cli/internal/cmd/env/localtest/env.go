@@ -2,9 +2,13 @@ package localtest
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"altinn.studio/devenv/pkg/container"
@@ -29,10 +33,18 @@ var (
 
 	// ErrLegacyLocaltestRunning is returned when legacy localtest containers are detected.
 	ErrLegacyLocaltestRunning = errors.New("legacy localtest is running (started outside this CLI)")
+
+	// ErrMigrationNotConfirmed is returned when the user declines stopping legacy containers.
+	ErrMigrationNotConfirmed = errors.New("legacy localtest migration not confirmed")
 )
 
-// teardownTimeout is the maximum time to wait for environment teardown.
-const teardownTimeout = 30 * time.Second
+const (
+	// timingKeyWidth fits the longest startup phase name.
+	timingKeyWidth = len(PhaseContainerStart)
+
+	// DefaultPullTimeout bounds each image pull during 'env up' (--pull-timeout).
+	DefaultPullTimeout = 10 * time.Minute
+)
 
 // Env implements envtypes.Env for the localtest runtime.
 type Env struct {
@@ -41,6 +53,7 @@ type Env struct {
 	client        container.ContainerClient
 	runtimeConfig *runtimeConfigResolver
 	logs          *logStreamer
+	now           func() time.Time
 }
 
 // NewEnv creates a new localtest environment manager.
@@ -49,8 +62,9 @@ func NewEnv(cfg *config.Config, out *ui.Output, client container.ContainerClient
 		cfg:           cfg,
 		out:           out,
 		client:        client,
-		runtimeConfig: newRuntimeConfigResolver(cfg, client, out.Verbosef),
+		runtimeConfig: newRuntimeConfigResolver(cfg, client, out.Verbosef, out.Warningf),
 		logs:          newLogStreamer(client, out),
+		now:           time.Now,
 	}
 }
 
@@ -59,77 +73,155 @@ func (e *Env) Preflight(ctx context.Context) error {
 	return CheckForLegacyLocaltest(ctx, e.client)
 }
 
-// Up starts the localtest environment.
-func (e *Env) Up(ctx context.Context, opts envtypes.UpOptions) error {
-	e.out.Verbosef("Using container runtime: %s", e.client.Name())
-
-	runtimeCfg, err := e.runtimeConfig.Build(ctx, opts.Port)
+// MigrateLegacy stops running legacy localtest containers so the CLI-managed stack can start.
+// Because these containers were not created by studioctl, confirm is asked before stopping them.
+func (e *Env) MigrateLegacy(ctx context.Context, confirm func() (bool, error)) error {
+	legacy, err := FindLegacyLocaltest(ctx, e.client)
 	if err != nil {
 		return err
 	}
-	e.out.Verbosef("Host gateway IP: %s", runtimeCfg.HostGateway)
+	if len(legacy) == 0 {
+		return nil
+	}
 
-	buildOpts, err := e.buildResourceOptions(ctx, runtimeCfg, opts.Monitoring)
+	e.out.Warningf("Found localtest containers not created by %s: %s", osutil.CurrentBin(), strings.Join(legacy, ", "))
+	e.out.Warningf(
+		"They will be stopped and renamed with a %q suffix (not removed) before starting the CLI-managed environment.",
+		legacyNameSuffix,
+	)
+	confirmed, err := confirm()
 	if err != nil {
 		return err
 	}
-	e.out.Verbosef("Image mode: %s", buildOpts.ImageMode)
+	if !confirmed {
+		return ErrMigrationNotConfirmed
+	}
 
-	if err := e.ensureResources(ctx, buildOpts); err != nil {
+	if err := StopLegacyLocaltest(ctx, e.client, legacy); err != nil {
 		return err
 	}
+	renamed := make([]string, 0, len(legacy))
+	for _, name := range legacy {
+		renamed = append(renamed, LegacyName(name))
+	}
+	e.out.Successf("Stopped legacy containers, kept as: %s", strings.Join(renamed, ", "))
+	return nil
+}
 
-	if err := e.applyResources(ctx, buildOpts); err != nil {
-		return err
+// Up starts the localtest environment. With opts.Detach unset it streams logs
+// until ctx is cancelled and tears the environment down before returning.
+func (e *Env) Up(ctx context.Context, opts envtypes.UpOptions) (envtypes.UpResult, error) {
+	e.out.Verbosef("Using container runtime: %s", e.client.Name())
+
+	var timer *startupTimer
+	if opts.Timing {
+		timer = newStartupTimer(time.Now)
 	}
 
-	localtestURL := FormatLocaltestURL(runtimeCfg.LoadBalancerPort)
+	runtimeCfg, err := e.runtimeConfig.Build(ctx, opts.Port, opts.StrictPort)
+	if err != nil {
+		return envtypes.UpResult{}, err
+	}
+	e.out.Verbosef("Host gateway IP: %s", runtimeCfg.HostGateway)
+	port, err := strconv.Atoi(runtimeCfg.LoadBalancerPort)
+	if err != nil {
+		return envtypes.UpResult{}, fmt.Errorf("parse load balancer port: %w", err)
+	}
+
+	var buildOpts ResourceBuildOptions
+	if err := timer.track(PhaseValidate, func() error {
+		var buildErr error
+		buildOpts, buildErr = e.buildResourceOptions(ctx, runtimeCfg, opts)
+		return buildErr
+	}); err != nil {
+		return envtypes.UpResult{}, err
+	}
+	e.out.Verbosef("Image mode: %s", buildOpts.ImageMode)
+
+	if err := e.ensureResources(ctx, buildOpts, timer); err != nil {
+		return envtypes.UpResult{}, err
+	}
+
+	applyOpts := applyOptions{pullTimeout: opts.PullTimeout, recreate: opts.Recreate}
+	if err := e.applyResourcesOrReinstall(ctx, buildOpts, applyOpts, timer); err != nil {
+		// A start cut short by a deadline or a stalled pull would otherwise leave a partial environment behind.
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) || errors.Is(err, resource.ErrPullTimeout) {
+			e.out.Println("\nStartup timed out, stopping localtest environment...")
+			//nolint:contextcheck // ctx has expired; teardown needs its own context
+			if teardownErr := e.teardown(teardownTimeout(opts)); teardownErr != nil {
+				e.out.Warningf("Failed to stop environment cleanly: %v", teardownErr)
+			} else {
+				e.out.Println("Environment stopped.")
+			}
+		}
+		return envtypes.UpResult{}, err
+	}
+
+	if timer != nil {
+		if err := e.printTiming(timer.report(), opts.TimingJSON); err != nil {
+			return envtypes.UpResult{}, err
+		}
+	}
+
+	result := envtypes.UpResult{
+		URL:        FormatLocaltestURL(runtimeCfg.LoadBalancerPort),
+		Containers: e.containerStatuses(ctx, containerNames(BuildResources(buildOpts))),
+		Port:       port,
+	}
 
 	if opts.OpenBrowser {
-		e.out.Verbosef("Opening browser to: %s\n", localtestURL)
-		if err := osutil.OpenContext(ctx, localtestURL); err != nil {
+		e.out.Verbosef("Opening browser to: %s\n", result.URL)
+		if err := osutil.OpenContext(ctx, result.URL); err != nil {
 			e.out.Warningf("Failed to open browser: %v", err)
 		}
 	}
 
 	if !opts.Detach {
-		return e.runForeground(ctx, localtestURL)
+		return result, e.runForeground(ctx, result.URL, teardownTimeout(opts))
 	}
-
-	e.out.Println("\nLocaltest started in background.")
-	e.out.Printf("Access the platform at: %s\n", localtestURL)
-	e.out.Printf("Use '%s env logs' to view logs.\n", osutil.CurrentBin())
-	e.out.Printf("Use '%s env down' to stop.\n", osutil.CurrentBin())
-
-	return nil
+	return result, nil
 }
 
-// Down stops the localtest environment.
-func (e *Env) Down(ctx context.Context) error {
+// Down stops the localtest environment, or only the containers selected in opts.
+func (e *Env) Down(ctx context.Context, downOpts envtypes.DownOptions) (envtypes.DownResult, error) {
 	e.out.Verbosef("Using container runtime: %s", e.client.Name())
 
-	hasResources, err := e.hasManagedResources(ctx)
+	opts := e.buildDestroyOptions()
+	spinnerMsg := "Stopping localtest environment..."
+	if len(downOpts.Only) > 0 {
+		only, err := SelectContainers(downOpts.Only)
+		if err != nil {
+			return envtypes.DownResult{}, err
+		}
+		opts.Only = only
+		spinnerMsg = "Stopping " + strings.Join(only, ", ") + "..."
+	}
+
+	existing, hasResources, err := e.managedResources(ctx, opts)
 	if err != nil {
-		return err
+		return envtypes.DownResult{}, err
 	}
 	if !hasResources {
-		return envtypes.ErrAlreadyStopped
+		return envtypes.DownResult{}, envtypes.ErrAlreadyStopped
 	}
 
-	opts := e.buildDestroyOptions()
-
-	spinner := ui.NewSpinner(e.out, "Stopping localtest environment...")
+	spinner := ui.NewSpinner(e.out, spinnerMsg)
 	if !e.cfg.Verbose {
 		spinner.Start()
 	}
 
 	if err := e.destroyResources(ctx, opts); err != nil {
 		spinner.StopWithError("Failed to stop environment")
-		return fmt.Errorf("stop environment: %w", err)
+		return envtypes.DownResult{}, fmt.Errorf("stop environment: %w", err)
 	}
 
+	result := envtypes.DownResult{Stopped: existing}
+	if len(opts.Only) > 0 {
+		spinner.StopWithSuccess("Stopped " + strings.Join(opts.Only, ", "))
+		return result, nil
+	}
 	spinner.StopWithSuccess("Environment stopped")
-	return nil
+	return result, nil
 }
 
 // Status returns the localtest environment status.
@@ -143,14 +235,13 @@ func (e *Env) Status(ctx context.Context) (*Status, error) {
 		state, err := e.client.ContainerState(ctx, name)
 		if err != nil {
 			if errors.Is(err, containertypes.ErrContainerNotFound) {
-				status.Containers = append(status.Containers, newContainerStatus(name, "not found"))
+				status.Containers = append(status.Containers, newContainerStatus(name, "not found", ""))
 				continue
 			}
 			return nil, fmt.Errorf("get state for container %q: %w", name, err)
 		}
 
-		info := newContainerStatus(name, state.Status)
-		status.Containers = append(status.Containers, info)
+		status.Containers = append(status.Containers, containerStatusFromState(name, state, e.now()))
 
 		if state.Running {
 			runningCoreContainers++
@@ -158,63 +249,112 @@ func (e *Env) Status(ctx context.Context) (*Status, error) {
 	}
 	status.Running = runningCoreContainers == len(containers)
 	status.AnyRunning = runningCoreContainers > 0
+	status.Health = status.rollupHealth()
 
 	return &status, nil
 }
 
 // Logs streams localtest environment logs.
 func (e *Env) Logs(ctx context.Context, opts envtypes.LogsOptions) error {
-	return e.logs.Stream(ctx, opts.Component, opts.Follow)
+	return e.logs.Stream(ctx, opts)
 }
 
-func (e *Env) hasManagedResources(ctx context.Context) (bool, error) {
-	graph, err := buildResourceGraph(BuildResourcesForDestroy(e.buildDestroyOptions()))
+// managedResources returns the containers selected by opts that exist, in build
+// order, and whether any selected container or network exists.
+func (e *Env) managedResources(ctx context.Context, opts ResourceDestroyOptions) ([]string, bool, error) {
+	resources := BuildResourcesForDestroy(opts)
+	graph, err := buildResourceGraph(resources)
 	if err != nil {
-		return false, fmt.Errorf("build resource graph: %w", err)
+		return nil, false, fmt.Errorf("build resource graph: %w", err)
 	}
 
 	executor := resource.NewExecutor(e.client)
 	statuses, err := executor.Status(ctx, graph)
 	if err != nil {
-		return false, fmt.Errorf("get resource status: %w", err)
+		return nil, false, fmt.Errorf("get resource status: %w", err)
 	}
 
-	for _, res := range graph.All() {
-		switch res.(type) {
-		case *resource.Container, *resource.Network:
-			status, ok := statuses[res.ID()]
-			if !ok {
-				return true, nil
-			}
-			if status != resource.StatusDestroyed {
-				return true, nil
-			}
+	var containers []string
+	found := false
+	for _, res := range resources {
+		var container string
+		switch r := res.(type) {
+		case *resource.Container:
+			container = r.Name
+		case *resource.Network:
+		default:
+			continue
+		}
+		if status, ok := statuses[res.ID()]; ok && status == resource.StatusDestroyed {
+			continue
+		}
+		found = true
+		if container != "" {
+			containers = append(containers, container)
+		}
+	}
+
+	return containers, found, nil
+}
+
+// containerNames returns the names of the containers among resources, in order.
+func containerNames(resources []resource.Resource) []string {
+	var names []string
+	for _, res := range resources {
+		if c, ok := res.(*resource.Container); ok {
+			names = append(names, c.Name)
 		}
 	}
+	return names
+}
 
-	return false, nil
+// containerIDs returns the resource IDs of the containers among resources.
+func containerIDs(resources []resource.Resource) []resource.ResourceID {
+	var ids []resource.ResourceID
+	for _, res := range resources {
+		if _, ok := res.(*resource.Container); ok {
+			ids = append(ids, res.ID())
+		}
+	}
+	return ids
+}
+
+// containerStatuses reports the current state of the named containers. States
+// that cannot be read are reported as "unknown" rather than failing the caller.
+func (e *Env) containerStatuses(ctx context.Context, names []string) []envtypes.ContainerStatus {
+	statuses := make([]envtypes.ContainerStatus, 0, len(names))
+	for _, name := range names {
+		state, err := e.client.ContainerState(ctx, name)
+		switch {
+		case errors.Is(err, containertypes.ErrContainerNotFound):
+			statuses = append(statuses, newContainerStatus(name, "not found", ""))
+		case err != nil:
+			e.out.Verbosef("get state for container %q: %v", name, err)
+			statuses = append(statuses, newContainerStatus(name, "unknown", ""))
+		default:
+			statuses = append(statuses, containerStatusFromState(name, state, e.now()))
+		}
+	}
+	return statuses
 }
 
 func (e *Env) runForeground(
 	ctx context.Context,
 	localtestURL string,
+	stopTimeout time.Duration,
 ) error {
 	e.out.Println("\nLocaltest is running. Press Ctrl+C to stop.")
 	e.out.Printf("Access the platform at: %s\n", localtestURL)
 
-	if err := e.logs.Stream(ctx, "", true); err != nil {
+	streamOpts := envtypes.LogsOptions{Components: nil, Level: "", DropUnclassified: false, Follow: true}
+	if err := e.logs.Stream(ctx, streamOpts); err != nil {
 		e.out.Verbosef("log streaming ended: %v", err)
 	}
 
 	e.out.Println("\nStopping localtest environment...")
 
-	teardownCtx, cancel := context.WithTimeout(context.Background(), teardownTimeout)
-	defer cancel()
-
-	destroyOpts := e.buildDestroyOptions()
-
 	//nolint:contextcheck // intentionally using new context for cleanup after cancellation
-	if err := e.destroyResources(teardownCtx, destroyOpts); err != nil {
+	if err := e.teardown(stopTimeout); err != nil {
 		e.out.Warningf("Failed to stop environment cleanly: %v", err)
 		return err
 	}
@@ -222,8 +362,54 @@ func (e *Env) runForeground(
 	return nil
 }
 
-func (e *Env) applyResources(ctx context.Context, opts ResourceBuildOptions) error {
-	graph, err := buildResourceGraph(BuildResources(opts))
+// teardown destroys the whole environment with a fresh context bounded by timeout, for use
+// after the caller's context was cancelled or timed out. When the timeout is hit, the error
+// names the containers that are still there.
+func (e *Env) teardown(timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	opts := e.buildDestroyOptions()
+	err := e.destroyResources(ctx, opts)
+	if err == nil || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+
+	checkCtx, checkCancel := context.WithTimeout(context.Background(), teardownCheckTimeout)
+	defer checkCancel()
+	remaining, _, checkErr := e.managedResources(checkCtx, opts)
+	if checkErr != nil || len(remaining) == 0 {
+		return fmt.Errorf("teardown timed out after %s: %w", timeout, err)
+	}
+	return fmt.Errorf(
+		"teardown timed out after %s, containers not stopped: %s "+
+			"(raise --teardown-timeout or remove them manually): %w",
+		timeout, strings.Join(remaining, ", "), err,
+	)
+}
+
+// teardownTimeout returns the teardown timeout requested in opts, or the default.
+func teardownTimeout(opts envtypes.UpOptions) time.Duration {
+	if opts.TeardownTimeout > 0 {
+		return opts.TeardownTimeout
+	}
+	return DefaultTeardownTimeout
+}
+
+// applyOptions tune how applyResources reconciles the environment.
+type applyOptions struct {
+	pullTimeout time.Duration // bounds each image pull; zero means no limit
+	recreate    bool          // replace existing containers even when they match
+}
+
+// applyResources starts the environment.
+func (e *Env) applyResources(
+	ctx context.Context,
+	opts ResourceBuildOptions,
+	applyOpts applyOptions,
+	timer *startupTimer,
+) error {
+	resources := BuildResources(opts)
+	graph, err := buildResourceGraph(resources)
 	if err != nil {
 		return err
 	}
@@ -239,6 +425,13 @@ func (e *Env) applyResources(ctx context.Context, opts ResourceBuildOptions) err
 	}
 
 	executor := resource.NewExecutor(e.client)
+	executor.SetPullTimeout(applyOpts.pullTimeout)
+	if applyOpts.recreate {
+		executor.SetRecreate(containerIDs(resources)...)
+	}
+	if timer != nil {
+		executor.SetObserver(timer)
+	}
 	if err := executor.Apply(ctx, graph); err != nil {
 		spinner.StopWithError("Failed to start environment")
 		return fmt.Errorf("start environment: %w", err)
@@ -266,32 +459,90 @@ func (e *Env) buildDestroyOptions() ResourceDestroyOptions {
 	return ResourceDestroyOptions{
 		DataDir:           e.cfg.DataDir,
 		Images:            e.cfg.Images,
+		Only:              nil,
 		IncludeMonitoring: true, // include all for cleanup
 		Installation:      e.client.Installation(),
 	}
 }
 
-func (e *Env) ensureResources(ctx context.Context, buildOpts ResourceBuildOptions) error {
+func (e *Env) ensureResources(ctx context.Context, buildOpts ResourceBuildOptions, timer *startupTimer) error {
+	validate := func() error { return ValidateResourceHostPaths(buildOpts) }
+
 	if !install.IsInstalled(e.cfg.DataDir, e.cfg.Version) {
-		if err := e.installResources(ctx, false); err != nil {
+		if err := timer.track(PhaseInstall, func() error { return e.installResources(ctx, false) }); err != nil {
 			return err
 		}
-		if err := ValidateResourceHostPaths(buildOpts); err != nil {
+		if err := timer.track(PhaseValidate, validate); err != nil {
 			return fmt.Errorf("validate resources: %w", err)
 		}
 		return nil
 	}
 
-	if err := ValidateResourceHostPaths(buildOpts); err != nil {
+	if err := timer.track(PhaseValidate, validate); err != nil {
 		e.out.Verbosef("Resource layout invalid, forcing reinstall: %v", err)
-		if installErr := e.installResources(ctx, true); installErr != nil {
-			return installErr
+		return e.reinstallResources(ctx, buildOpts, timer)
+	}
+
+	return nil
+}
+
+// reinstallResources force-reinstalls resources and validates the bind-mounted host paths again.
+func (e *Env) reinstallResources(ctx context.Context, buildOpts ResourceBuildOptions, timer *startupTimer) error {
+	if err := timer.track(PhaseInstall, func() error { return e.installResources(ctx, true) }); err != nil {
+		return err
+	}
+	if err := timer.track(PhaseValidate, func() error { return ValidateResourceHostPaths(buildOpts) }); err != nil {
+		return fmt.Errorf("validate resources after reinstall: %w", err)
+	}
+	return nil
+}
+
+// applyResourcesOrReinstall applies resources. If apply fails because a bind-mounted host path
+// went missing after ensureResources validated it, resources are reinstalled and apply is retried once.
+func (e *Env) applyResourcesOrReinstall(
+	ctx context.Context,
+	buildOpts ResourceBuildOptions,
+	applyOpts applyOptions,
+	timer *startupTimer,
+) error {
+	applyErr := e.applyResources(ctx, buildOpts, applyOpts, timer)
+	if applyErr == nil || ctx.Err() != nil {
+		return applyErr
+	}
+
+	layoutErr := timer.track(PhaseValidate, func() error { return ValidateResourceHostPaths(buildOpts) })
+	if !errors.Is(layoutErr, ErrInvalidResourceLayout) {
+		return applyErr
+	}
+
+	e.out.Warningf("Resource files changed during start, forcing reinstall: %v", layoutErr)
+	e.out.Verbosef("Start failed with: %v", applyErr)
+	if err := e.reinstallResources(ctx, buildOpts, timer); err != nil {
+		return err
+	}
+	return e.applyResources(ctx, buildOpts, applyOpts, timer)
+}
+
+// printTiming prints the startup phase breakdown requested with --timing.
+func (e *Env) printTiming(timing StartupTiming, jsonOut io.Writer) error {
+	if jsonOut != nil {
+		payload, err := json.Marshal(timing)
+		if err != nil {
+			return fmt.Errorf("marshal startup timing: %w", err)
 		}
-		if err := ValidateResourceHostPaths(buildOpts); err != nil {
-			return fmt.Errorf("validate resources after reinstall: %w", err)
+		if _, err := fmt.Fprintf(jsonOut, "%s\n", payload); err != nil {
+			return fmt.Errorf("write startup timing: %w", err)
 		}
+		return nil
 	}
 
+	sec := e.out.NewSection(timingKeyWidth)
+	e.out.Println("")
+	sec.Header("Startup timing")
+	for _, phase := range timing.Phases {
+		sec.KeyValue(phase.Name, formatPhaseDuration(phase.Duration))
+	}
+	sec.KeyValue("total", formatPhaseDuration(timing.Total))
 	return nil
 }
 
@@ -303,7 +554,7 @@ func buildResourceGraph(resources []resource.Resource) (*resource.Graph, error)
 		}
 	}
 	if err := graph.Validate(); err != nil {
-		return nil, fmt.Errorf("validate resource graph: %w", err)
+		return nil, resourceGraphError(graph, err)
 	}
 	return graph, nil
 }
@@ -311,9 +562,11 @@ func buildResourceGraph(resources []resource.Resource) (*resource.Graph, error)
 func (e *Env) installResources(ctx context.Context, force bool) error {
 	e.out.Println("Installing localtest resources...")
 	installOpts := install.Options{
-		DataDir: e.cfg.DataDir,
-		Version: e.cfg.Version,
-		Force:   force,
+		DataDir:        e.cfg.DataDir,
+		Version:        e.cfg.Version,
+		MaxArchiveSize: 0,
+		MaxFileSize:    0,
+		Force:          force,
 	}
 	if err := install.Install(ctx, installOpts); err != nil {
 		return fmt.Errorf("install resources: %w", err)
@@ -325,20 +578,54 @@ func (e *Env) installResources(ctx context.Context, force bool) error {
 func (e *Env) buildResourceOptions(
 	ctx context.Context,
 	runtimeCfg RuntimeConfig,
-	monitoring bool,
+	upOpts envtypes.UpOptions,
 ) (ResourceBuildOptions, error) {
 	cwd, err := os.Getwd()
 	if err != nil {
 		return ResourceBuildOptions{}, fmt.Errorf("get working directory: %w", err)
 	}
 
+	monitoringCap, err := ParseResourceLimits(upOpts.MemLimit, upOpts.CPULimit)
+	if err != nil {
+		return ResourceBuildOptions{}, err
+	}
+	limits, err := resolveResourceLimits(e.cfg.Limits, monitoringCap)
+	if err != nil {
+		return ResourceBuildOptions{}, err
+	}
+	extraHosts, err := ParseExtraHosts(upOpts.ExtraHosts)
+	if err != nil {
+		return ResourceBuildOptions{}, err
+	}
+	volumes, err := ParseVolumes(upOpts.Volumes)
+	if err != nil {
+		return ResourceBuildOptions{}, err
+	}
+	// Checked before ensureResources, which would take a missing path for a broken install.
+	if err := validateHostPaths(volumeExpectations(volumes)); err != nil {
+		return ResourceBuildOptions{}, fmt.Errorf("volumes: %w", err)
+	}
+
+	if upOpts.Monitoring {
+		if err := validateDashboards(e.cfg.Dashboards); err != nil {
+			return ResourceBuildOptions{}, err
+		}
+		if err := validateHostPaths(extraDashboardExpectations(e.cfg.Dashboards)); err != nil {
+			return ResourceBuildOptions{}, fmt.Errorf("extra grafana dashboards: %w", err)
+		}
+	}
+
 	imageMode, devConfig := detectImageMode(ctx, cwd)
 
 	return ResourceBuildOptions{
 		DataDir:           e.cfg.DataDir,
+		Dashboards:        e.cfg.Dashboards,
+		Volumes:           volumes,
+		ExtraHosts:        extraHosts,
 		RuntimeConfig:     runtimeCfg,
-		IncludeMonitoring: monitoring,
+		IncludeMonitoring: upOpts.Monitoring,
 		ImageMode:         imageMode,
+		Limits:            limits,
 		Images:            e.cfg.Images,
 		DevConfig:         devConfig,
 	}, nil
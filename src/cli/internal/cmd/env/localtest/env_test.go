@@ -1,26 +1,43 @@
 package localtest_test
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"altinn.studio/devenv/pkg/container"
 	"altinn.studio/devenv/pkg/container/mock"
 	"altinn.studio/devenv/pkg/container/types"
+	"altinn.studio/devenv/pkg/resource"
+	envtypes "altinn.studio/studioctl/internal/cmd/env"
 	"altinn.studio/studioctl/internal/cmd/env/localtest"
 	"altinn.studio/studioctl/internal/config"
 	"altinn.studio/studioctl/internal/ui"
 )
 
-var errStateUnavailable = errors.New("state unavailable")
+var (
+	errStateUnavailable  = errors.New("state unavailable")
+	errBindSourceMissing = errors.New("invalid mount config: bind source path does not exist")
+)
 
 func TestStatus_RunningRequiresAllCoreContainers(t *testing.T) {
 	t.Parallel()
 
 	tests := map[string]struct {
 		states         map[string]types.ContainerState
+		wantHealth     string
 		wantRunning    bool
 		wantAnyRunning bool
 	}{
@@ -29,6 +46,7 @@ func TestStatus_RunningRequiresAllCoreContainers(t *testing.T) {
 				localtest.ContainerLocaltest: {Status: "running", Running: true},
 				localtest.ContainerPDF3:      {Status: "running", Running: true},
 			},
+			wantHealth:     localtest.HealthHealthy,
 			wantRunning:    true,
 			wantAnyRunning: true,
 		},
@@ -37,6 +55,7 @@ func TestStatus_RunningRequiresAllCoreContainers(t *testing.T) {
 				localtest.ContainerLocaltest: {Status: "running", Running: true},
 				localtest.ContainerPDF3:      {Status: "exited", Running: false},
 			},
+			wantHealth:     localtest.HealthDegraded,
 			wantRunning:    false,
 			wantAnyRunning: true,
 		},
@@ -44,11 +63,13 @@ func TestStatus_RunningRequiresAllCoreContainers(t *testing.T) {
 			states: map[string]types.ContainerState{
 				localtest.ContainerLocaltest: {Status: "running", Running: true},
 			},
+			wantHealth:     localtest.HealthDegraded,
 			wantRunning:    false,
 			wantAnyRunning: true,
 		},
 		"none running": {
 			states:         map[string]types.ContainerState{},
+			wantHealth:     localtest.HealthStopped,
 			wantRunning:    false,
 			wantAnyRunning: false,
 		},
@@ -78,6 +99,9 @@ func TestStatus_RunningRequiresAllCoreContainers(t *testing.T) {
 			if status.AnyRunning != tt.wantAnyRunning {
 				t.Fatalf("Status().AnyRunning = %v, want %v", status.AnyRunning, tt.wantAnyRunning)
 			}
+			if status.Health != tt.wantHealth {
+				t.Fatalf("Status().Health = %q, want %q", status.Health, tt.wantHealth)
+			}
 		})
 	}
 }
@@ -100,6 +124,531 @@ func TestStatus_ReturnsErrorForNonNotFoundStateError(t *testing.T) {
 	}
 }
 
+func TestStatus_ReportsContainerHealth(t *testing.T) {
+	t.Parallel()
+
+	client := mock.New()
+	client.ContainerStateFunc = func(_ context.Context, nameOrID string) (types.ContainerState, error) {
+		if nameOrID == localtest.ContainerLocaltest {
+			return types.ContainerState{Status: "running", Health: types.HealthUnhealthy, Running: true}, nil
+		}
+		return types.ContainerState{Status: "running", Running: true}, nil
+	}
+
+	status, err := newTestEnv(client).Status(context.Background())
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+
+	want := []localtest.ContainerStatus{
+		{Name: localtest.ContainerLocaltest, Status: "running", Health: types.HealthUnhealthy},
+		{Name: localtest.ContainerPDF3, Status: "running", Health: ""},
+	}
+	if !slices.Equal(status.Containers, want) {
+		t.Fatalf("Status().Containers = %+v, want %+v", status.Containers, want)
+	}
+	if !status.Running || !status.Unhealthy() {
+		t.Fatalf("Status() Running = %v, Unhealthy() = %v, want both true", status.Running, status.Unhealthy())
+	}
+	if status.Health != localtest.HealthUnhealthy {
+		t.Fatalf("Status().Health = %q, want %q", status.Health, localtest.HealthUnhealthy)
+	}
+}
+
+func TestMigrateLegacy_StopsLegacyContainersBeforeStart(t *testing.T) {
+	t.Parallel()
+
+	client, stopped := newLegacyClient()
+	env := newTestEnv(client)
+
+	if err := env.Preflight(context.Background()); !errors.Is(err, localtest.ErrLegacyLocaltestRunning) {
+		t.Fatalf("Preflight() before migrate error = %v, want ErrLegacyLocaltestRunning", err)
+	}
+
+	err := env.MigrateLegacy(context.Background(), func() (bool, error) { return true, nil })
+	if err != nil {
+		t.Fatalf("MigrateLegacy() error = %v", err)
+	}
+	for _, name := range []string{localtest.ContainerLocaltest, localtest.ContainerPDF3} {
+		if !stopped[name] {
+			t.Fatalf("legacy container %q was not stopped", name)
+		}
+	}
+	var renamed []string
+	for _, call := range client.Calls {
+		switch call.Method {
+		case "ContainerRemove":
+			t.Fatalf("legacy container removed: %v", call.Args)
+		case "ContainerRename":
+			renamed = append(renamed, fmt.Sprintf("%v -> %v", call.Args...))
+		}
+	}
+	wantRenamed := []string{
+		localtest.ContainerLocaltest + " -> " + localtest.LegacyName(localtest.ContainerLocaltest),
+		localtest.ContainerPDF3 + " -> " + localtest.LegacyName(localtest.ContainerPDF3),
+	}
+	if !slices.Equal(renamed, wantRenamed) {
+		t.Fatalf("renamed containers = %q, want %q", renamed, wantRenamed)
+	}
+
+	if err := env.Preflight(context.Background()); err != nil {
+		t.Fatalf("Preflight() after migrate error = %v, want nil", err)
+	}
+}
+
+func TestMigrateLegacy_DeclinedLeavesContainersRunning(t *testing.T) {
+	t.Parallel()
+
+	client, stopped := newLegacyClient()
+	env := newTestEnv(client)
+
+	err := env.MigrateLegacy(context.Background(), func() (bool, error) { return false, nil })
+	if !errors.Is(err, localtest.ErrMigrationNotConfirmed) {
+		t.Fatalf("MigrateLegacy() error = %v, want ErrMigrationNotConfirmed", err)
+	}
+	if len(stopped) != 0 {
+		t.Fatalf("stopped containers = %v, want none", stopped)
+	}
+}
+
+func TestLogs_InterleavesSelectedContainers(t *testing.T) {
+	t.Parallel()
+
+	client := mock.New()
+	client.ContainerLogsFunc = func(_ context.Context, name string, _ bool, _ string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("hello from " + name + "\n")), nil
+	}
+
+	var stdout bytes.Buffer
+	env := localtest.NewEnv(&config.Config{}, ui.NewOutput(&stdout, io.Discard, false), client)
+	err := env.Logs(context.Background(), envtypes.LogsOptions{
+		Components: []string{localtest.ContainerLocaltest, "pdf3", localtest.ContainerLocaltest},
+		Follow:     false,
+	})
+	if err != nil {
+		t.Fatalf("Logs() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Logs() printed %d lines, want 2: %q", len(lines), stdout.String())
+	}
+	for _, name := range []string{localtest.ContainerLocaltest, localtest.ContainerPDF3} {
+		found := slices.ContainsFunc(lines, func(line string) bool {
+			return strings.Contains(line, name) && strings.HasSuffix(line, " | hello from "+name)
+		})
+		if !found {
+			t.Errorf("Logs() output missing prefixed line for %s: %q", name, stdout.String())
+		}
+	}
+}
+
+func TestLogs_FiltersByLevel(t *testing.T) {
+	t.Parallel()
+
+	client := mock.New()
+	client.ContainerLogsFunc = func(context.Context, string, bool, string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("info: started\nwarn: slow\n[ERROR] failed\nplain text\n")), nil
+	}
+
+	var stdout bytes.Buffer
+	env := localtest.NewEnv(&config.Config{}, ui.NewOutput(&stdout, io.Discard, false), client)
+	err := env.Logs(context.Background(), envtypes.LogsOptions{
+		Level:      "warn",
+		Components: []string{localtest.ContainerLocaltest},
+		Follow:     false,
+	})
+	if err != nil {
+		t.Fatalf("Logs() error = %v", err)
+	}
+
+	var got []string
+	for line := range strings.Lines(stdout.String()) {
+		_, text, _ := strings.Cut(strings.TrimSpace(line), " | ")
+		got = append(got, text)
+	}
+	if want := []string{"warn: slow", "[ERROR] failed", "plain text"}; !slices.Equal(got, want) {
+		t.Errorf("Logs() lines = %q, want %q", got, want)
+	}
+}
+
+func TestLogs_UnknownContainer(t *testing.T) {
+	t.Parallel()
+
+	err := newTestEnv(mock.New()).Logs(context.Background(), envtypes.LogsOptions{
+		Components: []string{localtest.ContainerLocaltest, "nope"},
+		Follow:     false,
+	})
+	if !errors.Is(err, localtest.ErrUnknownComponent) {
+		t.Fatalf("Logs() error = %v, want ErrUnknownComponent", err)
+	}
+}
+
+// newLegacyClient returns a client reporting running, unlabelled core containers until they are stopped.
+// Renamed containers are no longer found under their original name.
+func newLegacyClient() (*mock.Client, map[string]bool) {
+	stopped := map[string]bool{}
+	renamed := map[string]bool{}
+	client := mock.New()
+	client.ContainerInspectFunc = func(_ context.Context, name string) (types.ContainerInfo, error) {
+		if renamed[name] {
+			return types.ContainerInfo{}, types.ErrContainerNotFound
+		}
+		return types.ContainerInfo{
+			State:  types.ContainerState{Running: !stopped[name]},
+			Labels: map[string]string{},
+		}, nil
+	}
+	client.ContainerStopFunc = func(_ context.Context, name string, _ *int) error {
+		stopped[name] = true
+		return nil
+	}
+	client.ContainerRenameFunc = func(_ context.Context, name, _ string) error {
+		renamed[name] = true
+		return nil
+	}
+	return client, stopped
+}
+
 func newTestEnv(client container.ContainerClient) *localtest.Env {
 	return localtest.NewEnv(&config.Config{}, ui.NewOutput(io.Discard, io.Discard, false), client)
 }
+
+func TestUp_ReturnsResult(t *testing.T) {
+	t.Parallel()
+
+	cfg := newInstalledConfig(t)
+	client := mock.New()
+	env := localtest.NewEnv(cfg, ui.NewOutput(io.Discard, io.Discard, false), client)
+
+	result, err := env.Up(context.Background(), envtypes.UpOptions{Port: 8123, Detach: true})
+	if err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if result.Port != 8123 {
+		t.Errorf("Port = %d, want 8123", result.Port)
+	}
+	if want := localtest.FormatLocaltestURL("8123"); result.URL != want {
+		t.Errorf("URL = %q, want %q", result.URL, want)
+	}
+
+	names := make([]string, 0, len(result.Containers))
+	for _, c := range result.Containers {
+		names = append(names, c.Name)
+		if c.Status != "running" {
+			t.Errorf("container %s status = %q, want running", c.Name, c.Status)
+		}
+	}
+	for _, want := range []string{localtest.ContainerLocaltest, localtest.ContainerPDF3} {
+		if !slices.Contains(names, want) {
+			t.Errorf("Containers = %v, missing %s", names, want)
+		}
+	}
+}
+
+func TestDown_ReturnsStoppedContainers(t *testing.T) {
+	t.Parallel()
+
+	client := mock.New()
+	client.ContainerInspectFunc = func(_ context.Context, nameOrID string) (types.ContainerInfo, error) {
+		if nameOrID == localtest.ContainerPDF3 {
+			return types.ContainerInfo{}, types.ErrContainerNotFound
+		}
+		return types.ContainerInfo{
+			Name:   nameOrID,
+			Labels: map[string]string{localtest.LabelKey: localtest.LabelValue},
+			State:  types.ContainerState{Status: "running", Running: true},
+		}, nil
+	}
+
+	env := localtest.NewEnv(newInstalledConfig(t), ui.NewOutput(io.Discard, io.Discard, false), client)
+	result, err := env.Down(context.Background(), envtypes.DownOptions{})
+	if err != nil {
+		t.Fatalf("Down() error = %v", err)
+	}
+	if !slices.Contains(result.Stopped, localtest.ContainerLocaltest) {
+		t.Errorf("Stopped = %v, missing %s", result.Stopped, localtest.ContainerLocaltest)
+	}
+	if slices.Contains(result.Stopped, localtest.ContainerPDF3) {
+		t.Errorf("Stopped = %v, want %s left out since it did not exist", result.Stopped, localtest.ContainerPDF3)
+	}
+}
+
+// newInstalledConfig returns a default config whose localtest resources and
+// network metadata look installed and cached, so Up needs no downloads or probes.
+func newInstalledConfig(t *testing.T) *config.Config {
+	t.Helper()
+
+	const version = "v1.0.0"
+	cfg, err := config.New(config.Flags{Home: t.TempDir()}, version)
+	if err != nil {
+		t.Fatalf("config.New() error = %v", err)
+	}
+	files := map[string]string{
+		filepath.Join(cfg.DataDir, "testdata", "tenor.json"):       "{}",
+		filepath.Join(cfg.DataDir, ".version"):                     version,
+		filepath.Join(cfg.DataDir, ".source-marker"):               "tarball-sha256:test",
+		filepath.Join(cfg.Home, "network-metadata.yaml"):           "hostGateway: 172.17.0.1\n",
+		filepath.Join(cfg.DataDir, "AltinnPlatformLocal", ".keep"): "",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("create directory for %s: %v", path, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+	return cfg
+}
+
+func TestUp_TearsDownWhenDeadlineExceeded(t *testing.T) {
+	t.Parallel()
+
+	client := mock.New()
+	client.CreateContainerFunc = func(ctx context.Context, _ types.ContainerConfig) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	}
+	env := localtest.NewEnv(newInstalledConfig(t), ui.NewOutput(io.Discard, io.Discard, false), client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := env.Up(ctx, envtypes.UpOptions{Port: 8123, Detach: true})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Up() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	var removed []string
+	for _, call := range client.Calls {
+		if call.Method == "ContainerRemove" || call.Method == "NetworkRemove" {
+			removed = append(removed, call.Method)
+		}
+	}
+	if len(removed) == 0 {
+		t.Fatal("Up() did not tear down the partially started environment")
+	}
+}
+
+func TestUp_TearsDownWhenImagePullTimesOut(t *testing.T) {
+	t.Parallel()
+
+	client := mock.New()
+	client.ImageInspectFunc = func(context.Context, string) (types.ImageInfo, error) {
+		return types.ImageInfo{}, types.ErrImageNotFound
+	}
+	client.ImagePullFunc = func(ctx context.Context, _ string) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	env := localtest.NewEnv(newInstalledConfig(t), ui.NewOutput(io.Discard, io.Discard, false), client)
+
+	_, err := env.Up(context.Background(), envtypes.UpOptions{
+		Port:        8123,
+		Detach:      true,
+		PullTimeout: 50 * time.Millisecond,
+	})
+	if !errors.Is(err, resource.ErrPullTimeout) {
+		t.Fatalf("Up() error = %v, want %v", err, resource.ErrPullTimeout)
+	}
+	if !strings.Contains(err.Error(), "pull image ") {
+		t.Errorf("Up() error = %q, want it to name the image", err)
+	}
+
+	var tornDown bool
+	for _, call := range client.Calls {
+		if call.Method == "ContainerRemove" || call.Method == "NetworkRemove" {
+			tornDown = true
+		}
+	}
+	if !tornDown {
+		t.Fatal("Up() did not tear down the partially started environment")
+	}
+}
+
+func TestUp_RecreateReplacesMatchingContainers(t *testing.T) {
+	t.Parallel()
+
+	// The fake runtime remembers created containers so a second Up sees them as up to date.
+	var mu sync.Mutex
+	created := make(map[string]types.ContainerConfig)
+	client := mock.New()
+	client.CreateContainerFunc = func(_ context.Context, cfg types.ContainerConfig) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		created[cfg.Name] = cfg
+		return cfg.Name, nil
+	}
+	client.ContainerInspectFunc = func(_ context.Context, name string) (types.ContainerInfo, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		cfg, ok := created[name]
+		if !ok {
+			return types.ContainerInfo{}, types.ErrContainerNotFound
+		}
+		return types.ContainerInfo{
+			ImageID: cfg.Image,
+			Labels:  cfg.Labels,
+			State:   types.ContainerState{Status: "running", Running: true},
+		}, nil
+	}
+	client.ContainerNetworksFunc = func(_ context.Context, name string) ([]string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return created[name].Networks, nil
+	}
+	env := localtest.NewEnv(newInstalledConfig(t), ui.NewOutput(io.Discard, io.Discard, false), client)
+
+	up := func(recreate bool) []string {
+		t.Helper()
+		client.Reset()
+		if _, err := env.Up(context.Background(), envtypes.UpOptions{
+			Port:     8123,
+			Detach:   true,
+			Recreate: recreate,
+		}); err != nil {
+			t.Fatalf("Up(recreate=%t) error = %v", recreate, err)
+		}
+		var calls []string
+		for _, call := range client.Calls {
+			if name, ok := call.Args[0].(string); ok && call.Method == "ContainerRemove" {
+				calls = append(calls, "remove "+name)
+			}
+			if cfg, ok := call.Args[0].(types.ContainerConfig); ok && call.Method == "CreateContainer" {
+				calls = append(calls, "create "+cfg.Name)
+			}
+		}
+		return calls
+	}
+
+	up(false)
+	if calls := up(false); len(calls) != 0 {
+		t.Fatalf("Up() without --recreate = %v, want existing containers reused", calls)
+	}
+
+	calls := up(true)
+	for _, name := range []string{localtest.ContainerLocaltest, localtest.ContainerPDF3} {
+		removed := slices.Index(calls, "remove "+name)
+		recreated := slices.Index(calls, "create "+name)
+		if removed < 0 || recreated < removed {
+			t.Errorf("Up() with --recreate calls = %v, want %s removed then created", calls, name)
+		}
+	}
+}
+
+func TestUp_ForegroundTeardownUsesConfiguredTimeout(t *testing.T) {
+	t.Parallel()
+
+	const teardownTimeout = 2 * time.Minute
+	var (
+		mu        sync.Mutex
+		remaining []time.Duration
+	)
+	client := mock.New()
+	client.ContainerRemoveFunc = func(ctx context.Context, _ string, _ bool) error {
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			t.Error("teardown context has no deadline")
+			return nil
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		remaining = append(remaining, time.Until(deadline))
+		return nil
+	}
+	env := localtest.NewEnv(newInstalledConfig(t), ui.NewOutput(io.Discard, io.Discard, false), client)
+
+	// No container reports running, so log streaming ends at once and the foreground run tears down.
+	if _, err := env.Up(context.Background(), envtypes.UpOptions{
+		Port:            8123,
+		Detach:          false,
+		TeardownTimeout: teardownTimeout,
+	}); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	if len(remaining) == 0 {
+		t.Fatal("Up() did not tear down the environment")
+	}
+	for _, left := range remaining {
+		if left > teardownTimeout || left < teardownTimeout-time.Minute {
+			t.Fatalf("teardown deadline in %s, want about %s", left, teardownTimeout)
+		}
+	}
+}
+
+func TestUp_ReinstallsWhenHostPathVanishesDuringApply(t *testing.T) {
+	cfg := newInstalledConfig(t)
+	testdata := filepath.Join(cfg.DataDir, "testdata")
+	t.Setenv(config.EnvResourcesTarball, writeResourcesTarball(t, map[string]string{"testdata/tenor.json": "{}"}))
+
+	var failed atomic.Bool
+	client := mock.New()
+	client.CreateContainerFunc = func(_ context.Context, containerCfg types.ContainerConfig) (string, error) {
+		// The first container start finds its bind-mount source gone.
+		if failed.CompareAndSwap(false, true) {
+			if err := os.RemoveAll(testdata); err != nil {
+				t.Errorf("remove %s: %v", testdata, err)
+			}
+			return "", errBindSourceMissing
+		}
+		return containerCfg.Name, nil
+	}
+	env := localtest.NewEnv(cfg, ui.NewOutput(io.Discard, io.Discard, false), client)
+
+	if _, err := env.Up(context.Background(), envtypes.UpOptions{Port: 8123, Detach: true}); err != nil {
+		t.Fatalf("Up() error = %v, want success after reinstall", err)
+	}
+	if !failed.Load() {
+		t.Fatal("first apply did not fail")
+	}
+	if _, err := os.Stat(filepath.Join(testdata, "tenor.json")); err != nil {
+		t.Errorf("reinstall did not restore %s: %v", testdata, err)
+	}
+}
+
+func TestUp_DoesNotReinstallOnUnrelatedApplyError(t *testing.T) {
+	t.Parallel()
+
+	cfg := newInstalledConfig(t)
+	client := mock.New()
+	client.CreateContainerFunc = func(context.Context, types.ContainerConfig) (string, error) {
+		return "", errBindSourceMissing
+	}
+	env := localtest.NewEnv(cfg, ui.NewOutput(io.Discard, io.Discard, false), client)
+
+	_, err := env.Up(context.Background(), envtypes.UpOptions{Port: 8123, Detach: true})
+	if !errors.Is(err, errBindSourceMissing) {
+		t.Fatalf("Up() error = %v, want %v", err, errBindSourceMissing)
+	}
+}
+
+// writeResourcesTarball writes a gzipped resources tarball with files and returns its path.
+func writeResourcesTarball(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	for name, content := range files {
+		header := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("write tar header: %v", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("write tar content: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("close tar writer: %v", err)
+	}
+	if err := gzw.Close(); err != nil {
+		t.Fatalf("close gzip writer: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "resources.tar.gz")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("write tarball: %v", err)
+	}
+	return path
+}
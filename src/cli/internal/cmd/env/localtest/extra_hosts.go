@@ -0,0 +1,39 @@
+package localtest
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// ErrInvalidExtraHost is returned when an --add-host value is not a host:ip mapping.
+var ErrInvalidExtraHost = errors.New("invalid extra host")
+
+// ParseExtraHosts validates host:ip mappings (e.g. "api.example.test:10.0.0.5") and returns
+// them normalized for the container runtime. IPv6 addresses may be given in brackets.
+func ParseExtraHosts(values []string) ([]string, error) {
+	hosts := make([]string, 0, len(values))
+	for _, value := range values {
+		name, ip, ok := strings.Cut(strings.TrimSpace(value), ":")
+		ip = strings.TrimSuffix(strings.TrimPrefix(ip, "["), "]")
+		if !ok || !validHostName(name) || net.ParseIP(ip) == nil {
+			return nil, fmt.Errorf("%w: %q (want host:ip, e.g. api.example.test:10.0.0.5)", ErrInvalidExtraHost, value)
+		}
+		hosts = append(hosts, name+":"+ip)
+	}
+	return hosts, nil
+}
+
+// validHostName reports whether name is a hostname made of letters, digits, dots and hyphens.
+func validHostName(name string) bool {
+	if name == "" || strings.HasPrefix(name, "-") || strings.HasPrefix(name, ".") {
+		return false
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '.' && r != '-' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package localtest
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"altinn.studio/devenv/pkg/resource"
+)
+
+// ErrInvalidResourceGraph is returned when resource dependencies are missing or form a cycle.
+var ErrInvalidResourceGraph = errors.New("invalid localtest resource graph")
+
+// missingDependencyChains describes every dependency in graph that names a resource not in it,
+// with the chain of dependents leading to it, e.g. "container:a -> image:b -> network:c (missing)".
+func missingDependencyChains(graph *resource.Graph) []string {
+	resources := graph.All()
+	slices.SortFunc(resources, func(a, b resource.Resource) int { return cmp.Compare(a.ID(), b.ID()) })
+	dependents := make(map[resource.ResourceID][]resource.ResourceID, len(resources))
+	for _, res := range resources {
+		for _, ref := range res.Dependencies() {
+			dependents[ref.ID()] = append(dependents[ref.ID()], res.ID())
+		}
+	}
+
+	var chains []string
+	for _, res := range resources {
+		for _, ref := range res.Dependencies() {
+			if graph.Get(ref.ID()) != nil {
+				continue
+			}
+			chain := append(dependencyChain(res.ID(), dependents), ref.ID())
+			chains = append(chains, formatResourceChain(chain)+" (missing)")
+		}
+	}
+	return chains
+}
+
+// dependencyChain walks from id up through its first dependents to a resource nothing depends on,
+// returning the chain ordered from that root down to id.
+func dependencyChain(
+	id resource.ResourceID,
+	dependents map[resource.ResourceID][]resource.ResourceID,
+) []resource.ResourceID {
+	chain := []resource.ResourceID{id}
+	for {
+		parents := dependents[chain[0]]
+		if len(parents) == 0 || slices.Contains(chain, parents[0]) {
+			return chain
+		}
+		chain = slices.Insert(chain, 0, parents[0])
+	}
+}
+
+func formatResourceChain(chain []resource.ResourceID) string {
+	parts := make([]string, len(chain))
+	for i, id := range chain {
+		parts[i] = id.String()
+	}
+	return strings.Join(parts, " -> ")
+}
+
+// resourceGraphError wraps a graph validation failure. Missing dependencies and cycles also wrap
+// ErrInvalidResourceGraph, and missing dependencies are listed with the chain leading to them.
+func resourceGraphError(graph *resource.Graph, err error) error {
+	if !errors.Is(err, resource.ErrMissingDependency) && !errors.Is(err, resource.ErrDependencyCycle) {
+		return fmt.Errorf("validate resource graph: %w", err)
+	}
+	chains := missingDependencyChains(graph)
+	if len(chains) == 0 {
+		return fmt.Errorf("validate resource graph: %w: %w", ErrInvalidResourceGraph, err)
+	}
+	return fmt.Errorf("validate resource graph: %w: %w; %s", ErrInvalidResourceGraph, err, strings.Join(chains, "; "))
+}
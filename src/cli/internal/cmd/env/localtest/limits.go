@@ -0,0 +1,93 @@
+package localtest
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"altinn.studio/devenv/pkg/container/types"
+	"altinn.studio/studioctl/internal/config"
+)
+
+// ErrInvalidResourceLimit is returned when a memory or CPU limit cannot be parsed.
+var ErrInvalidResourceLimit = errors.New("invalid resource limit")
+
+const nanoCPUsPerCPU = 1e9
+
+// memoryUnits maps docker-style memory suffixes to their multiplier.
+func memoryUnits() map[string]int64 {
+	return map[string]int64{
+		"":  1,
+		"b": 1,
+		"k": 1 << 10,
+		"m": 1 << 20,
+		"g": 1 << 30,
+	}
+}
+
+// ParseResourceLimits parses a docker-style memory value (e.g. "512m", "2g") and a
+// decimal CPU count (e.g. "1.5"). Empty values mean unlimited.
+func ParseResourceLimits(memory, cpus string) (types.ResourceLimits, error) {
+	limits := types.ResourceLimits{MemoryBytes: 0, NanoCPUs: 0}
+
+	if memory != "" {
+		value := strings.ToLower(strings.TrimSpace(memory))
+		number := strings.TrimRight(value, "bkmg")
+		multiplier, ok := memoryUnits()[value[len(number):]]
+		n, err := strconv.ParseInt(number, 10, 64)
+		if !ok || err != nil || n <= 0 || n > math.MaxInt64/multiplier {
+			return limits, fmt.Errorf("%w: memory %q (e.g. 512m, 2g)", ErrInvalidResourceLimit, memory)
+		}
+		limits.MemoryBytes = n * multiplier
+	}
+
+	if cpus != "" {
+		n, err := strconv.ParseFloat(strings.TrimSpace(cpus), 64)
+		if err != nil || n <= 0 || math.IsInf(n, 0) || n*nanoCPUsPerCPU > math.MaxInt64 {
+			return limits, fmt.Errorf("%w: cpus %q (e.g. 0.5, 2)", ErrInvalidResourceLimit, cpus)
+		}
+		limits.NanoCPUs = int64(n * nanoCPUsPerCPU)
+	}
+
+	return limits, nil
+}
+
+// resolveResourceLimits parses configured per-container limits and applies monitoringCap
+// to every monitoring container. A cap only lowers limits; it never raises a configured one.
+func resolveResourceLimits(
+	configured map[string]config.ResourceLimitSpec,
+	monitoringCap types.ResourceLimits,
+) (map[string]types.ResourceLimits, error) {
+	limits := make(map[string]types.ResourceLimits, len(configured))
+	for _, name := range slices.Sorted(maps.Keys(configured)) {
+		resolved, ok := resolveContainerName(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown container %q in limits config", ErrInvalidResourceLimit, name)
+		}
+		spec := configured[name]
+		parsed, err := ParseResourceLimits(spec.Memory, spec.CPUs)
+		if err != nil {
+			return nil, fmt.Errorf("limits for %s: %w", name, err)
+		}
+		limits[resolved] = parsed
+	}
+
+	for _, name := range monitoringContainerNames() {
+		limits[name] = capResourceLimits(limits[name], monitoringCap)
+	}
+	return limits, nil
+}
+
+func capResourceLimits(limits, limitCap types.ResourceLimits) types.ResourceLimits {
+	if limitCap.MemoryBytes > 0 && (limits.MemoryBytes == 0 || limits.MemoryBytes > limitCap.MemoryBytes) {
+		limits.MemoryBytes = limitCap.MemoryBytes
+	}
+	if limitCap.NanoCPUs > 0 && (limits.NanoCPUs == 0 || limits.NanoCPUs > limitCap.NanoCPUs) {
+		limits.NanoCPUs = limitCap.NanoCPUs
+	}
+	return limits
+}
@@ -0,0 +1,114 @@
+package localtest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidLogLevel is returned when a log level name is not recognized.
+var ErrInvalidLogLevel = errors.New("invalid log level")
+
+// LogLevel is the severity of a log line. LogLevelUnknown marks lines that could not be classified.
+type LogLevel int
+
+// Log levels in increasing severity.
+const (
+	LogLevelUnknown LogLevel = iota
+	LogLevelTrace
+	LogLevelDebug
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+	LogLevelFatal
+)
+
+// logLevelNames maps level names and common abbreviations to levels.
+// Includes the four-letter .NET console names (dbug, fail, crit) and Serilog's (vrb, wrn, ftl).
+func logLevelNames() map[string]LogLevel {
+	return map[string]LogLevel{
+		"trace": LogLevelTrace, "trce": LogLevelTrace, "verbose": LogLevelTrace, "vrb": LogLevelTrace,
+		"debug": LogLevelDebug, "dbug": LogLevelDebug, "dbg": LogLevelDebug,
+		"info": LogLevelInfo, "information": LogLevelInfo, "inf": LogLevelInfo,
+		"warn": LogLevelWarn, "warning": LogLevelWarn, "wrn": LogLevelWarn,
+		"error": LogLevelError, "err": LogLevelError, "fail": LogLevelError, "eror": LogLevelError,
+		"fatal": LogLevelFatal, "critical": LogLevelFatal, "crit": LogLevelFatal, "panic": LogLevelFatal, "ftl": LogLevelFatal,
+	}
+}
+
+// ParseLogLevel parses a minimum level name such as "warn" or "error".
+func ParseLogLevel(name string) (LogLevel, error) {
+	level, ok := logLevelNames()[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return LogLevelUnknown, fmt.Errorf("%w: %q (trace, debug, info, warn, error, fatal)", ErrInvalidLogLevel, name)
+	}
+	return level, nil
+}
+
+// logMatcher classifies a log line, returning LogLevelUnknown when it cannot tell.
+type logMatcher func(line string) LogLevel
+
+//nolint:gochecknoglobals // compiled once, read-only
+var (
+	// keyValueLevelPattern matches level=warn and level="warn" (logfmt and similar).
+	keyValueLevelPattern = regexp.MustCompile(`(?i)\b(?:level|lvl|severity)="?([a-z]+)`)
+	// bracketLevelPattern matches [WARN] and levels ending a bracket, as in Serilog's [12:00:01 WRN].
+	bracketLevelPattern = regexp.MustCompile(`\[(?:[^\]]*\s)?([A-Za-z]+)\]`)
+	// prefixLevelPattern matches the .NET console format, e.g. "warn: Microsoft.Hosting[0]".
+	prefixLevelPattern = regexp.MustCompile(`^\s*([a-z]{4}):\s`)
+)
+
+// classifyLogLine detects the level of JSON lines with a level field, key=value levels,
+// bracketed levels and .NET console prefixes.
+func classifyLogLine(line string) LogLevel {
+	if level := jsonLogLevel(line); level != LogLevelUnknown {
+		return level
+	}
+	for _, pattern := range []*regexp.Regexp{keyValueLevelPattern, bracketLevelPattern, prefixLevelPattern} {
+		for _, match := range pattern.FindAllStringSubmatch(line, -1) {
+			if level, ok := logLevelNames()[strings.ToLower(match[1])]; ok {
+				return level
+			}
+		}
+	}
+	return LogLevelUnknown
+}
+
+// jsonLogLevel reads the level of a JSON log line from its level, severity or @l field.
+func jsonLogLevel(line string) LogLevel {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "{") {
+		return LogLevelUnknown
+	}
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(trimmed), &fields); err != nil {
+		return LogLevelUnknown
+	}
+	for _, key := range []string{"level", "Level", "severity", "Severity", "@l", "LogLevel"} {
+		if name, ok := fields[key].(string); ok {
+			return logLevelNames()[strings.ToLower(name)]
+		}
+	}
+	return LogLevelUnknown
+}
+
+// logFilter keeps log lines at or above a minimum level.
+type logFilter struct {
+	match logMatcher
+	min   LogLevel // LogLevelUnknown keeps every line
+	// dropUnclassified hides lines the matcher cannot classify; by default they are kept.
+	dropUnclassified bool
+}
+
+func (f logFilter) keep(line string) bool {
+	if f.min == LogLevelUnknown {
+		return true
+	}
+	level := f.match(line)
+	if level == LogLevelUnknown {
+		return !f.dropUnclassified
+	}
+	return level >= f.min
+}
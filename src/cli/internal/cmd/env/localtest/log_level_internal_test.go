@@ -0,0 +1,86 @@
+package localtest
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestClassifyLogLine(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		line string
+		want LogLevel
+	}{
+		{line: `{"level":"warn","msg":"slow query"}`, want: LogLevelWarn},
+		{line: `{"@t":"2026-10-17T10:00:00Z","@l":"Error","@m":"boom"}`, want: LogLevelError},
+		{line: `{"severity":"DEBUG","message":"tick"}`, want: LogLevelDebug},
+		{line: `ts=2026-10-17T10:00:00Z level=info msg="ready"`, want: LogLevelInfo},
+		{line: `level="error" caller=main.go:12`, want: LogLevelError},
+		{line: `[WARN] disk almost full`, want: LogLevelWarn},
+		{line: `[10:00:00 FTL] crashed`, want: LogLevelFatal},
+		{line: `fail: Microsoft.AspNetCore.Server.Kestrel[13]`, want: LogLevelError},
+		{line: `info: Microsoft.Hosting.Lifetime[14]`, want: LogLevelInfo},
+		{line: `      Now listening on: http://[::]:5101`, want: LogLevelUnknown},
+		{line: `{"msg":"no level here"}`, want: LogLevelUnknown},
+		{line: `GET /health [Microsoft] 200`, want: LogLevelUnknown},
+	}
+	for _, tt := range tests {
+		if got := classifyLogLine(tt.line); got != tt.want {
+			t.Errorf("classifyLogLine(%q) = %d, want %d", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestLogFilter_KeepsLinesAtOrAboveLevel(t *testing.T) {
+	t.Parallel()
+
+	lines := []string{
+		`{"level":"debug","msg":"cache miss"}`,
+		`info: Microsoft.Hosting.Lifetime[0]`,
+		`level=warn msg="retrying"`,
+		`[ERROR] request failed`,
+		`      continuation without level`,
+	}
+	tests := []struct {
+		name             string
+		want             []bool
+		min              LogLevel
+		dropUnclassified bool
+	}{
+		{name: "no level", min: LogLevelUnknown, want: []bool{true, true, true, true, true}},
+		{name: "warn keeps unclassified", min: LogLevelWarn, want: []bool{false, false, true, true, true}},
+		{
+			name:             "warn drops unclassified",
+			min:              LogLevelWarn,
+			dropUnclassified: true,
+			want:             []bool{false, false, true, true, false},
+		},
+		{name: "error", min: LogLevelError, want: []bool{false, false, false, true, true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			filter := logFilter{match: classifyLogLine, min: tt.min, dropUnclassified: tt.dropUnclassified}
+			for i, line := range lines {
+				if got := filter.keep(line); got != tt.want[i] {
+					t.Errorf("keep(%q) = %v, want %v", line, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseLogLevel(t *testing.T) {
+	t.Parallel()
+
+	for name, want := range map[string]LogLevel{"warn": LogLevelWarn, "WARNING": LogLevelWarn, "error": LogLevelError} {
+		if got, err := ParseLogLevel(name); err != nil || got != want {
+			t.Errorf("ParseLogLevel(%q) = %d, %v; want %d", name, got, err, want)
+		}
+	}
+	if _, err := ParseLogLevel("loud"); !errors.Is(err, ErrInvalidLogLevel) {
+		t.Errorf("ParseLogLevel(loud) error = %v, want ErrInvalidLogLevel", err)
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"slices"
 	"sync"
 
 	"altinn.studio/devenv/pkg/container"
+	envtypes "altinn.studio/studioctl/internal/cmd/env"
 	"altinn.studio/studioctl/internal/docker"
 	"altinn.studio/studioctl/internal/ui"
 )
@@ -20,37 +22,31 @@ const (
 type logStreamer struct {
 	client container.ContainerClient
 	out    *ui.Output
+	// match classifies lines for --level filtering.
+	match logMatcher
 }
 
 func newLogStreamer(client container.ContainerClient, out *ui.Output) *logStreamer {
 	return &logStreamer{
 		client: client,
 		out:    out,
+		match:  classifyLogLine,
 	}
 }
 
-func (s *logStreamer) Stream(ctx context.Context, component string, follow bool) error {
-	allContainers := AllContainerNames(true)
-
-	var containers []string
-	if component != "" {
-		for _, name := range allContainers {
-			if name == component {
-				containers = []string{name}
-				break
-			}
-		}
-		if len(containers) == 0 {
-			return fmt.Errorf(
-				"%w: %s (available: %s, %s, monitoring_*)",
-				ErrUnknownComponent,
-				component,
-				ContainerLocaltest,
-				ContainerPDF3,
-			)
+// Stream interleaves the logs of the selected containers, prefixing each line with its
+// color-coded source container. An empty opts.Components streams every container.
+// With opts.Level set, only lines at or above that level are printed.
+func (s *logStreamer) Stream(ctx context.Context, opts envtypes.LogsOptions) error {
+	containers, err := selectLogContainers(opts.Components)
+	if err != nil {
+		return err
+	}
+	filter := logFilter{match: s.match, min: LogLevelUnknown, dropUnclassified: opts.DropUnclassified}
+	if opts.Level != "" {
+		if filter.min, err = ParseLogLevel(opts.Level); err != nil {
+			return err
 		}
-	} else {
-		containers = allContainers
 	}
 
 	var runningContainers []string
@@ -72,26 +68,53 @@ func (s *logStreamer) Stream(ctx context.Context, component string, follow bool)
 
 	var wg sync.WaitGroup
 	for i, name := range runningContainers {
-		logs, err := s.client.ContainerLogs(ctx, name, follow, "100")
+		logs, err := s.client.ContainerLogs(ctx, name, opts.Follow, "100")
 		if err != nil {
 			s.out.Warningf("Failed to get logs for %s: %v", name, err)
 			continue
 		}
 
 		wg.Add(1)
-		go s.streamContainerLogs(ctx, &wg, logs, name, i)
+		go s.streamContainerLogs(ctx, &wg, logs, name, i, filter)
 	}
 
 	wg.Wait()
 	return nil
 }
 
+// selectLogContainers resolves full or short container names, dropping duplicates
+// while keeping the requested order so prefix colors follow the command line.
+func selectLogContainers(components []string) ([]string, error) {
+	if len(components) == 0 {
+		return AllContainerNames(true), nil
+	}
+
+	containers := make([]string, 0, len(components))
+	for _, component := range components {
+		name, ok := resolveContainerName(component)
+		if !ok {
+			return nil, fmt.Errorf(
+				"%w: %s (available: %s, %s, monitoring_*)",
+				ErrUnknownComponent,
+				component,
+				ContainerLocaltest,
+				ContainerPDF3,
+			)
+		}
+		if !slices.Contains(containers, name) {
+			containers = append(containers, name)
+		}
+	}
+	return containers, nil
+}
+
 func (s *logStreamer) streamContainerLogs(
 	ctx context.Context,
 	wg *sync.WaitGroup,
 	logs io.ReadCloser,
 	name string,
 	colorIdx int,
+	filter logFilter,
 ) {
 	defer wg.Done()
 	defer func() {
@@ -112,7 +135,9 @@ func (s *logStreamer) streamContainerLogs(
 			return
 		default:
 			line := docker.StripMultiplexedHeader(scanner.Text())
-			s.out.Println(prefix + line)
+			if filter.keep(line) {
+				s.out.Println(prefix + line)
+			}
 		}
 	}
 }
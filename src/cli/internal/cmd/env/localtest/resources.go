@@ -8,10 +8,12 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
 	"altinn.studio/devenv/pkg/container"
 	"altinn.studio/devenv/pkg/container/types"
 	"altinn.studio/devenv/pkg/resource"
+	envtypes "altinn.studio/studioctl/internal/cmd/env"
 	"altinn.studio/studioctl/internal/config"
 	"altinn.studio/studioctl/internal/networking"
 )
@@ -26,6 +28,9 @@ const (
 	// NetworkName is the name of the localtest network.
 	NetworkName = "altinntestlocal_network"
 
+	localtestHealthInterval = 5 * time.Second
+	localtestHealthRetries  = 3
+
 	devImageTagLocaltest = "localtest:dev"
 	devImageTagPDF3      = "localtest-pdf3:dev"
 )
@@ -43,28 +48,41 @@ type RuntimeConfig struct {
 
 // ContainerSpec defines a container to run.
 type ContainerSpec struct {
+	Environment  map[string]string
+	Healthcheck  *types.Healthcheck // nil keeps the image default; only applied in apply mode
 	Name         string
 	Ports        []types.PortMapping
-	Environment  map[string]string
 	Volumes      []types.VolumeMount
 	ExtraHosts   []string
 	Dependencies []string
 	Command      []string
+	Resources    types.ResourceLimits // zero means unlimited; only applied in apply mode
 }
 
 // ContainerStatus describes one localtest container.
-type ContainerStatus struct {
-	Name   string `json:"name"`
-	Status string `json:"status"`
-}
+type ContainerStatus = envtypes.ContainerStatus
 
 // Status is the localtest-specific runtime status payload.
 type Status struct {
+	// Health rolls up the containers: HealthStopped, HealthDegraded, HealthUnhealthy,
+	// HealthStarting or HealthHealthy, in that order of precedence.
+	Health     string            `json:"health"`
 	Containers []ContainerStatus `json:"containers"`
 	Running    bool              `json:"running"`
 	AnyRunning bool              `json:"anyRunning"`
 }
 
+// Overall localtest health reported in Status.Health.
+const (
+	HealthHealthy   = types.HealthHealthy
+	HealthStarting  = types.HealthStarting
+	HealthUnhealthy = types.HealthUnhealthy
+	// HealthDegraded means some but not all core containers are running.
+	HealthDegraded = "degraded"
+	// HealthStopped means no core container is running.
+	HealthStopped = "stopped"
+)
+
 func newPort(hostPort, containerPort string) types.PortMapping {
 	return types.PortMapping{
 		HostPort:      hostPort,
@@ -97,25 +115,69 @@ func newContainerSpec(
 		ExtraHosts:   extraHosts,
 		Dependencies: deps,
 		Command:      cmd,
+		Healthcheck:  nil,
+		Resources:    types.ResourceLimits{MemoryBytes: 0, NanoCPUs: 0},
 	}
 }
 
 func newStatus() Status {
 	return Status{
+		Health:     HealthStopped,
 		Containers: []ContainerStatus{},
 		Running:    false,
 		AnyRunning: false,
 	}
 }
 
-func newContainerStatus(name, status string) ContainerStatus {
+func newContainerStatus(name, status, health string) ContainerStatus {
 	return ContainerStatus{
-		Name:   name,
-		Status: status,
+		StartedAt: time.Time{},
+		Name:      name,
+		Status:    status,
+		Health:    health,
+		Uptime:    "",
 	}
 }
 
-func coreContainers(dataDir string, cfg RuntimeConfig) []ContainerSpec {
+// containerStatusFromState maps inspected container state to a ContainerStatus,
+// computing uptime from now for running containers.
+func containerStatusFromState(name string, state types.ContainerState, now time.Time) ContainerStatus {
+	status := newContainerStatus(name, state.Status, state.Health)
+	if state.Running && !state.StartedAt.IsZero() {
+		status.StartedAt = state.StartedAt
+		status.Uptime = max(now.Sub(state.StartedAt), 0).Round(time.Second).String()
+	}
+	return status
+}
+
+// rollupHealth derives the overall health from Running, AnyRunning and the container health checks.
+func (s *Status) rollupHealth() string {
+	switch {
+	case !s.AnyRunning:
+		return HealthStopped
+	case !s.Running:
+		return HealthDegraded
+	case s.Unhealthy():
+		return HealthUnhealthy
+	case slices.ContainsFunc(s.Containers, func(c ContainerStatus) bool { return c.Health == types.HealthStarting }):
+		return HealthStarting
+	default:
+		return HealthHealthy
+	}
+}
+
+// Unhealthy reports whether any container failed its healthcheck.
+func (s *Status) Unhealthy() bool {
+	for _, c := range s.Containers {
+		if c.Health == types.HealthUnhealthy {
+			return true
+		}
+	}
+	return false
+}
+
+// coreContainers returns the core container specs; volumes are extra bind mounts for the localtest container.
+func coreContainers(dataDir string, cfg RuntimeConfig, volumes []types.VolumeMount) []ContainerSpec {
 	extraHosts := []string{
 		"host.docker.internal:" + cfg.HostGateway,
 		"host.containers.internal:" + cfg.HostGateway,
@@ -124,26 +186,33 @@ func coreContainers(dataDir string, cfg RuntimeConfig) []ContainerSpec {
 
 	dotnetEnv := cfg.Installation.String()
 
+	localtest := newContainerSpec(
+		ContainerLocaltest,
+		[]types.PortMapping{
+			newPort(cfg.LoadBalancerPort, "5101"), // Main port
+			newPort("5101", "5101"),               // Internal port
+		},
+		map[string]string{
+			"DOTNET_ENVIRONMENT":        dotnetEnv,
+			"GeneralSettings__BaseUrl":  "http://" + networking.LocalDomain + ":" + cfg.LoadBalancerPort,
+			"GeneralSettings__HostName": networking.LocalDomain,
+		},
+		append([]types.VolumeMount{
+			newVolume(filepath.Join(dataDir, "testdata"), "/testdata"),
+			newVolume(filepath.Join(dataDir, "AltinnPlatformLocal"), "/AltinnPlatformLocal"),
+		}, volumes...),
+		extraHosts,
+		nil,
+		nil,
+	)
+	localtest.Healthcheck = &types.Healthcheck{
+		Command:  []string{"wget", "-nv", "-t1", "--spider", "http://localhost:5101/health"},
+		Interval: localtestHealthInterval,
+		Retries:  localtestHealthRetries,
+	}
+
 	return []ContainerSpec{
-		newContainerSpec(
-			ContainerLocaltest,
-			[]types.PortMapping{
-				newPort(cfg.LoadBalancerPort, "5101"), // Main port
-				newPort("5101", "5101"),               // Internal port
-			},
-			map[string]string{
-				"DOTNET_ENVIRONMENT":        dotnetEnv,
-				"GeneralSettings__BaseUrl":  "http://" + networking.LocalDomain + ":" + cfg.LoadBalancerPort,
-				"GeneralSettings__HostName": networking.LocalDomain,
-			},
-			[]types.VolumeMount{
-				newVolume(filepath.Join(dataDir, "testdata"), "/testdata"),
-				newVolume(filepath.Join(dataDir, "AltinnPlatformLocal"), "/AltinnPlatformLocal"),
-			},
-			extraHosts,
-			nil,
-			nil,
-		),
+		localtest,
 		newContainerSpec(
 			ContainerPDF3,
 			[]types.PortMapping{newPort("5300", "5031")},
@@ -162,7 +231,7 @@ func coreContainers(dataDir string, cfg RuntimeConfig) []ContainerSpec {
 }
 
 //nolint:funlen // Container spec list is more readable as a single function
-func monitoringContainers(dataDir string, cfg RuntimeConfig) []ContainerSpec {
+func monitoringContainers(dataDir string, cfg RuntimeConfig, dashboards config.DashboardsSpec) []ContainerSpec {
 	extraHosts := []string{
 		"host.docker.internal:" + cfg.HostGateway,
 		"host.containers.internal:" + cfg.HostGateway,
@@ -228,7 +297,7 @@ func monitoringContainers(dataDir string, cfg RuntimeConfig) []ContainerSpec {
 				"GF_SERVER_SERVE_FROM_SUB_PATH": "true",
 				"GF_SERVER_ROOT_URL":            "%(protocol)s://%(domain)s:%(http_port)s/grafana/",
 			},
-			[]types.VolumeMount{
+			append([]types.VolumeMount{
 				newVolume(
 					filepath.Join(infraDir, "grafana-datasources.yaml"),
 					"/etc/grafana/provisioning/datasources/datasources.yaml",
@@ -237,8 +306,7 @@ func monitoringContainers(dataDir string, cfg RuntimeConfig) []ContainerSpec {
 					filepath.Join(infraDir, "grafana-dashboards.yaml"),
 					"/etc/grafana/provisioning/dashboards/dashboards.yaml",
 				),
-				newVolume(filepath.Join(infraDir, "grafana-dashboards"), "/var/lib/grafana/dashboards"),
-			},
+			}, grafanaDashboardVolumes(infraDir, dashboards)...),
 			extraHosts,
 			[]string{
 				ContainerMonitoringOtelCollector,
@@ -263,9 +331,16 @@ func monitoringImageRefs(mon config.MonitoringImages) map[string]string {
 
 // ResourceBuildOptions holds options for building the resource graph.
 type ResourceBuildOptions struct {
-	DevConfig         *DevImageConfig
-	Images            config.ImagesConfig
-	DataDir           string
+	DevConfig *DevImageConfig
+	// Limits holds per-container resource limits keyed by container name.
+	Limits     map[string]types.ResourceLimits
+	Images     config.ImagesConfig
+	DataDir    string
+	Dashboards config.DashboardsSpec // Grafana dashboards to mount; zero mounts all built-ins
+	// Volumes holds extra bind mounts for the localtest container (see ParseVolumes).
+	Volumes []types.VolumeMount
+	// ExtraHosts holds validated host:ip mappings added to every container after the built-in ones.
+	ExtraHosts        []string
 	RuntimeConfig     RuntimeConfig
 	ImageMode         ImageMode
 	IncludeMonitoring bool
@@ -273,8 +348,11 @@ type ResourceBuildOptions struct {
 
 // ResourceDestroyOptions holds minimal options for destroying resources.
 type ResourceDestroyOptions struct {
-	DataDir           string
-	Images            config.ImagesConfig
+	DataDir string
+	Images  config.ImagesConfig
+	// Only limits destruction to these container names (see SelectContainers).
+	// The network is kept so the remaining containers stay connected. Empty means everything.
+	Only              []string
 	IncludeMonitoring bool
 	Installation      container.RuntimeInstallation
 }
@@ -293,8 +371,12 @@ func BuildResources(opts ResourceBuildOptions) []resource.Resource {
 		opts.DataDir,
 		opts.RuntimeConfig,
 		opts.IncludeMonitoring,
+		opts.Dashboards,
 		buildCoreImages(opts),
 		monitoringImageRefs(opts.Images.Monitoring),
+		opts.Limits,
+		opts.Volumes,
+		opts.ExtraHosts,
 		containerModeApply,
 	)
 }
@@ -308,14 +390,45 @@ func BuildResourcesForDestroy(opts ResourceDestroyOptions) []resource.Resource {
 		User:             "", // not used for destroy
 	}
 
-	return buildResourcesWithMode(
+	resources := buildResourcesWithMode(
 		opts.DataDir,
 		runtimeCfg,
 		opts.IncludeMonitoring,
+		config.DashboardsSpec{Include: nil, Extra: nil}, // volumes are not used for destroy
 		buildRemoteCoreImages(opts.Images.Core),
 		monitoringImageRefs(opts.Images.Monitoring),
+		nil,
+		nil,
+		nil,
 		containerModeDestroy,
 	)
+	if len(opts.Only) == 0 {
+		return resources
+	}
+	return selectDestroyResources(resources, opts.Only)
+}
+
+// selectDestroyResources keeps only the named containers and their images.
+// Network references are dropped so the shared network is left untouched.
+func selectDestroyResources(resources []resource.Resource, only []string) []resource.Resource {
+	keep := make(map[resource.ResourceID]bool, len(only)*2)
+	for _, res := range resources {
+		c, ok := res.(*resource.Container)
+		if !ok || !slices.Contains(only, c.Name) {
+			continue
+		}
+		c.Networks = nil
+		keep[c.ID()] = true
+		keep[c.Image.ID()] = true
+	}
+
+	result := make([]resource.Resource, 0, len(keep))
+	for _, res := range resources {
+		if keep[res.ID()] {
+			result = append(result, res)
+		}
+	}
+	return result
 }
 
 func buildCoreImages(opts ResourceBuildOptions) map[string]resource.ImageResource {
@@ -356,12 +469,25 @@ func buildResourcesWithMode(
 	dataDir string,
 	runtimeCfg RuntimeConfig,
 	includeMonitoring bool,
+	dashboards config.DashboardsSpec,
 	coreImages map[string]resource.ImageResource,
 	monImages map[string]string,
+	limits map[string]types.ResourceLimits,
+	volumes []types.VolumeMount,
+	extraHosts []string,
 	mode containerResourceMode,
 ) []resource.Resource {
-	core := coreContainers(dataDir, runtimeCfg)
-	mon := monitoringContainers(dataDir, runtimeCfg)
+	core := coreContainers(dataDir, runtimeCfg, volumes)
+	mon := monitoringContainers(dataDir, runtimeCfg, dashboards)
+	for _, specs := range [][]ContainerSpec{core, mon} {
+		for i := range specs {
+			specs[i].Resources = limits[specs[i].Name]
+			if len(extraHosts) > 0 {
+				// Specs share the built-in slice, so append to a copy.
+				specs[i].ExtraHosts = slices.Concat(specs[i].ExtraHosts, extraHosts)
+			}
+		}
+	}
 	labels := map[string]string{LabelKey: LabelValue}
 
 	capacity := 1 + len(core)*2
@@ -436,6 +562,8 @@ func newContainerResource(
 			ExtraHosts:    nil,
 			RestartPolicy: "",
 			User:          "",
+			Healthcheck:   nil,
+			Resources:     types.ResourceLimits{MemoryBytes: 0, NanoCPUs: 0},
 		}
 	}
 
@@ -451,6 +579,8 @@ func newContainerResource(
 		ExtraHosts:    spec.ExtraHosts,
 		RestartPolicy: "",
 		User:          user,
+		Healthcheck:   spec.Healthcheck,
+		Resources:     spec.Resources,
 	}
 }
 
@@ -468,13 +598,14 @@ func toEnvSlice(env map[string]string) []string {
 type hostPathExpectation struct {
 	hostPath  string
 	expectDir bool
+	anyType   bool // only require the path to exist
 }
 
 func hostPathExpectations(opts ResourceBuildOptions) []hostPathExpectation {
-	core := coreContainers(opts.DataDir, opts.RuntimeConfig)
+	core := coreContainers(opts.DataDir, opts.RuntimeConfig, opts.Volumes)
 	all := core
 	if opts.IncludeMonitoring {
-		all = append(all, monitoringContainers(opts.DataDir, opts.RuntimeConfig)...)
+		all = append(all, monitoringContainers(opts.DataDir, opts.RuntimeConfig, opts.Dashboards)...)
 	}
 
 	// Dedupe by host path while preserving first expectation.
@@ -488,18 +619,37 @@ func hostPathExpectations(opts ResourceBuildOptions) []hostPathExpectation {
 				continue
 			}
 			seen[volume.HostPath] = struct{}{}
-			result = append(result, hostPathExpectation{
-				hostPath:  volume.HostPath,
-				expectDir: filepath.Ext(filepath.Base(volume.ContainerPath)) == "",
-			})
+			if slices.Contains(opts.Volumes, volume) {
+				result = append(result, userVolumeExpectation(volume))
+				continue
+			}
+			result = append(result, volumeExpectation(volume))
 		}
 	}
 	return result
 }
 
+// volumeExpectation expects a directory unless the container path has a file extension.
+func volumeExpectation(volume types.VolumeMount) hostPathExpectation {
+	return hostPathExpectation{
+		hostPath:  volume.HostPath,
+		expectDir: filepath.Ext(filepath.Base(volume.ContainerPath)) == "",
+		anyType:   false,
+	}
+}
+
+// userVolumeExpectation accepts a file or a directory: a --volume mounts whatever the
+// host path is, so its container path says nothing about the type.
+func userVolumeExpectation(volume types.VolumeMount) hostPathExpectation {
+	return hostPathExpectation{hostPath: volume.HostPath, expectDir: false, anyType: true}
+}
+
 // ValidateResourceHostPaths ensures all bind-mounted host paths exist and have expected type.
 func ValidateResourceHostPaths(opts ResourceBuildOptions) error {
-	expectations := hostPathExpectations(opts)
+	return validateHostPaths(hostPathExpectations(opts))
+}
+
+func validateHostPaths(expectations []hostPathExpectation) error {
 	missing := make([]string, 0)
 	wrongType := make([]string, 0)
 
@@ -514,6 +664,9 @@ func ValidateResourceHostPaths(opts ResourceBuildOptions) error {
 			return fmt.Errorf("stat mounted path %q: %w", exp.hostPath, err)
 		}
 
+		if exp.anyType {
+			continue
+		}
 		if exp.expectDir && !info.IsDir() {
 			wrongType = append(wrongType, exp.hostPath+" (expected directory)")
 		}
@@ -1,13 +1,20 @@
 package localtest
 
 import (
+	"encoding/json"
 	"errors"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"altinn.studio/devenv/pkg/container"
+	"altinn.studio/devenv/pkg/container/types"
+	"altinn.studio/devenv/pkg/resource"
+	"altinn.studio/studioctl/internal/config"
+	"altinn.studio/studioctl/internal/networking"
 )
 
 func TestValidateResourceHostPaths(t *testing.T) {
@@ -114,3 +121,549 @@ func createMonitoringLayout(t *testing.T, dataDir string) {
 		t.Fatalf("create grafana dashboards directory: %v", err)
 	}
 }
+
+func TestSelectContainers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input []string
+		want  []string
+	}{
+		{
+			name:  "short names",
+			input: []string{"pdf3", "grafana"},
+			want:  []string{ContainerPDF3, ContainerMonitoringGrafana},
+		},
+		{
+			name:  "includes dependents",
+			input: []string{ContainerLocaltest},
+			want:  []string{ContainerLocaltest, ContainerPDF3},
+		},
+		{
+			name:  "includes transitive dependents",
+			input: []string{"mimir"},
+			want:  []string{ContainerMonitoringMimir, ContainerMonitoringOtelCollector, ContainerMonitoringGrafana},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := SelectContainers(tt.input)
+			if err != nil {
+				t.Fatalf("SelectContainers() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("SelectContainers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("unknown name", func(t *testing.T) {
+		t.Parallel()
+		_, err := SelectContainers([]string{"pdf3", "nope"})
+		if !errors.Is(err, ErrUnknownComponent) {
+			t.Fatalf("SelectContainers() error = %v, want ErrUnknownComponent", err)
+		}
+	})
+}
+
+func TestBuildResourcesForDestroy_Only(t *testing.T) {
+	t.Parallel()
+
+	only, err := SelectContainers([]string{"pdf3", "grafana"})
+	if err != nil {
+		t.Fatalf("SelectContainers() error = %v", err)
+	}
+	opts := ResourceDestroyOptions{
+		DataDir: t.TempDir(),
+		Images: config.ImagesConfig{
+			Core:       config.CoreImages{PDF3: config.ImageSpec{Image: "pdf3", Tag: "1"}},
+			Monitoring: config.MonitoringImages{Grafana: config.ImageSpec{Image: "grafana", Tag: "1"}},
+		},
+		Only:              only,
+		IncludeMonitoring: true,
+		Installation:      container.InstallationDocker,
+	}
+
+	var containers []string
+	for _, res := range BuildResourcesForDestroy(opts) {
+		switch r := res.(type) {
+		case *resource.Network:
+			t.Fatalf("network %q targeted for destruction", r.Name)
+		case *resource.Container:
+			if len(r.Networks) != 0 {
+				t.Fatalf("container %q references networks %v", r.Name, r.Networks)
+			}
+			containers = append(containers, r.Name)
+		}
+	}
+	want := []string{ContainerPDF3, ContainerMonitoringGrafana}
+	if !slices.Equal(containers, want) {
+		t.Fatalf("destroyed containers = %v, want %v", containers, want)
+	}
+
+	if _, err := buildResourceGraph(BuildResourcesForDestroy(opts)); err != nil {
+		t.Fatalf("buildResourceGraph() error = %v", err)
+	}
+}
+
+func TestBuildResources_MonitoringLimits(t *testing.T) {
+	t.Parallel()
+
+	monitoringCap, err := ParseResourceLimits("512m", "0.5")
+	if err != nil {
+		t.Fatalf("ParseResourceLimits() error = %v", err)
+	}
+	limits, err := resolveResourceLimits(
+		map[string]config.ResourceLimitSpec{"grafana": {Memory: "256m", CPUs: "2"}},
+		monitoringCap,
+	)
+	if err != nil {
+		t.Fatalf("resolveResourceLimits() error = %v", err)
+	}
+
+	opts := newResourceBuildOptions(t.TempDir(), true)
+	opts.Limits = limits
+
+	capped := types.ResourceLimits{MemoryBytes: 512 << 20, NanoCPUs: 500_000_000}
+	want := map[string]types.ResourceLimits{
+		ContainerLocaltest:               {},
+		ContainerPDF3:                    {},
+		ContainerMonitoringTempo:         capped,
+		ContainerMonitoringMimir:         capped,
+		ContainerMonitoringLoki:          capped,
+		ContainerMonitoringOtelCollector: capped,
+		ContainerMonitoringGrafana:       {MemoryBytes: 256 << 20, NanoCPUs: 500_000_000},
+	}
+	for _, res := range BuildResources(opts) {
+		c, ok := res.(*resource.Container)
+		if !ok {
+			continue
+		}
+		if c.Resources != want[c.Name] {
+			t.Errorf("container %s resources = %+v, want %+v", c.Name, c.Resources, want[c.Name])
+		}
+	}
+}
+
+func TestBuildResources_LocaltestHealthcheck(t *testing.T) {
+	t.Parallel()
+
+	for _, res := range BuildResources(newResourceBuildOptions(t.TempDir(), false)) {
+		c, ok := res.(*resource.Container)
+		if !ok {
+			continue
+		}
+		if c.Name == ContainerLocaltest && c.Healthcheck == nil {
+			t.Fatalf("container %s has no healthcheck", c.Name)
+		}
+		if c.Name != ContainerLocaltest && c.Healthcheck != nil {
+			t.Fatalf("container %s has unexpected healthcheck %+v", c.Name, c.Healthcheck)
+		}
+	}
+}
+
+func TestBuildResources_ExtraHosts(t *testing.T) {
+	t.Parallel()
+
+	extra, err := ParseExtraHosts([]string{"api.example.test:10.0.0.5", "v6.example.test:[fd00::1]"})
+	if err != nil {
+		t.Fatalf("ParseExtraHosts() error = %v", err)
+	}
+	opts := newResourceBuildOptions(t.TempDir(), true)
+	opts.ExtraHosts = extra
+
+	added := []string{"api.example.test:10.0.0.5", "v6.example.test:fd00::1"}
+	builtIn := []string{
+		"host.docker.internal:127.0.0.1",
+		"host.containers.internal:127.0.0.1",
+		networking.LocalDomain + ":127.0.0.1",
+	}
+	for _, res := range BuildResources(opts) {
+		c, ok := res.(*resource.Container)
+		if !ok {
+			continue
+		}
+		if !slices.Equal(c.ExtraHosts[len(c.ExtraHosts)-len(added):], added) {
+			t.Errorf("container %s extra hosts = %v, want the --add-host mappings last", c.Name, c.ExtraHosts)
+		}
+		if c.Name == ContainerLocaltest || c.Name == ContainerPDF3 {
+			if !slices.Equal(c.ExtraHosts[:len(builtIn)], builtIn) {
+				t.Errorf("container %s extra hosts = %v, want built-in mappings %v kept", c.Name, c.ExtraHosts, builtIn)
+			}
+		}
+	}
+}
+
+func TestParseExtraHosts(t *testing.T) {
+	t.Parallel()
+
+	invalid := []string{
+		"", "api.example.test", "api.example.test:", ":10.0.0.5", "api:not-an-ip", "-api:10.0.0.5", "a b:10.0.0.5",
+	}
+	for _, value := range invalid {
+		if _, err := ParseExtraHosts([]string{value}); !errors.Is(err, ErrInvalidExtraHost) {
+			t.Errorf("ParseExtraHosts(%q) error = %v, want ErrInvalidExtraHost", value, err)
+		}
+	}
+}
+
+func TestParseVolumes(t *testing.T) {
+	t.Parallel()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd() error = %v", err)
+	}
+	tests := []struct {
+		value string
+		want  types.VolumeMount
+	}{
+		{value: "/src/app:/app", want: types.VolumeMount{HostPath: "/src/app", ContainerPath: "/app"}},
+		{
+			value: "/src/app:/app:ro",
+			want:  types.VolumeMount{HostPath: "/src/app", ContainerPath: "/app", ReadOnly: true},
+		},
+		{value: "/src/app:/app/:rw", want: types.VolumeMount{HostPath: "/src/app", ContainerPath: "/app"}},
+		{value: "App:/app", want: types.VolumeMount{HostPath: filepath.Join(wd, "App"), ContainerPath: "/app"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseVolumes([]string{tt.value})
+		if err != nil || len(got) != 1 || got[0] != tt.want {
+			t.Errorf("ParseVolumes(%q) = %+v, %v; want %+v", tt.value, got, err, tt.want)
+		}
+	}
+
+	for _, value := range []string{"", "/src/app", "/src/app:ro", ":/app", "/src/app:app", "/src/app:/"} {
+		if _, err := ParseVolumes([]string{value}); !errors.Is(err, ErrInvalidVolume) {
+			t.Errorf("ParseVolumes(%q) error = %v, want ErrInvalidVolume", value, err)
+		}
+	}
+}
+
+func TestBuildResources_Volumes(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+	createCoreLayout(t, dataDir)
+	appDir := t.TempDir()
+	// User volumes are not checked against the container path: a directory may be
+	// mounted at a path with an extension and a file at one without.
+	confDir := t.TempDir()
+	settingsFile := filepath.Join(t.TempDir(), "settings")
+	if err := os.WriteFile(settingsFile, []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	volumes, err := ParseVolumes([]string{
+		appDir + ":/app:ro",
+		confDir + ":/etc/app.d",
+		settingsFile + ":/etc/settings",
+	})
+	if err != nil {
+		t.Fatalf("ParseVolumes() error = %v", err)
+	}
+	opts := newResourceBuildOptions(dataDir, false)
+	opts.Volumes = volumes
+
+	if err := ValidateResourceHostPaths(opts); err != nil {
+		t.Fatalf("ValidateResourceHostPaths() error = %v, want nil", err)
+	}
+	want := types.VolumeMount{HostPath: appDir, ContainerPath: "/app", ReadOnly: true}
+	for _, res := range BuildResources(opts) {
+		c, ok := res.(*resource.Container)
+		if !ok {
+			continue
+		}
+		if got := slices.Contains(c.Volumes, want); got != (c.Name == ContainerLocaltest) {
+			t.Errorf("container %s volumes = %+v, want %+v only on %s", c.Name, c.Volumes, want, ContainerLocaltest)
+		}
+	}
+
+	if err := os.Remove(appDir); err != nil {
+		t.Fatalf("remove %s: %v", appDir, err)
+	}
+	if err := ValidateResourceHostPaths(opts); !errors.Is(err, ErrInvalidResourceLayout) ||
+		!strings.Contains(err.Error(), appDir) {
+		t.Fatalf("ValidateResourceHostPaths() error = %v, want missing %s", err, appDir)
+	}
+}
+
+func TestContainerStatusFromState(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	startedAt := now.Add(-(90*time.Minute + 1500*time.Millisecond))
+	tests := []struct {
+		want  ContainerStatus
+		name  string
+		state types.ContainerState
+	}{
+		{
+			name: "running with healthcheck",
+			state: types.ContainerState{
+				StartedAt: startedAt,
+				Status:    "running",
+				Health:    types.HealthHealthy,
+				Running:   true,
+			},
+			want: ContainerStatus{
+				StartedAt: startedAt,
+				Name:      ContainerLocaltest,
+				Status:    "running",
+				Health:    types.HealthHealthy,
+				Uptime:    "1h30m2s",
+			},
+		},
+		{
+			name:  "exited keeps no uptime",
+			state: types.ContainerState{StartedAt: startedAt, Status: "exited", Running: false, ExitCode: 1},
+			want:  ContainerStatus{Name: ContainerLocaltest, Status: "exited"},
+		},
+		{
+			name:  "start time ahead of clock",
+			state: types.ContainerState{StartedAt: now.Add(time.Second), Status: "running", Running: true},
+			want: ContainerStatus{
+				StartedAt: now.Add(time.Second),
+				Name:      ContainerLocaltest,
+				Status:    "running",
+				Uptime:    "0s",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := containerStatusFromState(ContainerLocaltest, tt.state, now); got != tt.want {
+				t.Errorf("containerStatusFromState() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatus_JSON(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	status := newStatus()
+	status.Containers = []ContainerStatus{
+		containerStatusFromState(ContainerLocaltest, types.ContainerState{
+			StartedAt: now.Add(-time.Minute),
+			Status:    "running",
+			Health:    types.HealthStarting,
+			Running:   true,
+		}, now),
+		newContainerStatus(ContainerPDF3, "not found", ""),
+	}
+	status.AnyRunning = true
+	status.Health = status.rollupHealth()
+
+	payload, err := json.Marshal(status)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `{"health":"degraded","containers":[` +
+		`{"startedAt":"2026-10-17T11:59:00Z","name":"localtest","status":"running",` +
+		`"health":"starting","uptime":"1m0s"},` +
+		`{"name":"localtest-pdf3","status":"not found"}],"running":false,"anyRunning":true}`
+	if string(payload) != want {
+		t.Errorf("json.Marshal(Status) =\n%s\nwant\n%s", payload, want)
+	}
+}
+
+func TestParseResourceLimits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		memory  string
+		cpus    string
+		want    types.ResourceLimits
+		wantErr bool
+	}{
+		{memory: "", cpus: "", want: types.ResourceLimits{}},
+		{memory: "1g", cpus: "1.5", want: types.ResourceLimits{MemoryBytes: 1 << 30, NanoCPUs: 1_500_000_000}},
+		{memory: "2048", cpus: "", want: types.ResourceLimits{MemoryBytes: 2048}},
+		{memory: "512x", wantErr: true},
+		{memory: "0m", wantErr: true},
+		{cpus: "-1", wantErr: true},
+		{cpus: "many", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseResourceLimits(tt.memory, tt.cpus)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidResourceLimit) {
+				t.Errorf("ParseResourceLimits(%q, %q) error = %v, want ErrInvalidResourceLimit", tt.memory, tt.cpus, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseResourceLimits(%q, %q) = %+v, %v; want %+v", tt.memory, tt.cpus, got, err, tt.want)
+		}
+	}
+}
+
+func TestBuildResources_GrafanaDashboards(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+	builtinDir := filepath.Join(dataDir, "infra", "grafana-dashboards")
+	extra := filepath.Join(t.TempDir(), "team.json")
+
+	tests := []struct {
+		want       map[string]string // container path -> host path
+		name       string
+		dashboards config.DashboardsSpec
+	}{
+		{
+			name:       "default mounts all built-ins",
+			dashboards: config.DashboardsSpec{},
+			want:       map[string]string{"/var/lib/grafana/dashboards": builtinDir},
+		},
+		{
+			name:       "selected subset",
+			dashboards: config.DashboardsSpec{Include: []string{"app.json", "aspnetcore.json"}},
+			want: map[string]string{
+				"/var/lib/grafana/dashboards/app.json":        filepath.Join(builtinDir, "app.json"),
+				"/var/lib/grafana/dashboards/aspnetcore.json": filepath.Join(builtinDir, "aspnetcore.json"),
+			},
+		},
+		{
+			name:       "extra dashboards only",
+			dashboards: config.DashboardsSpec{Extra: []string{extra}},
+			want: map[string]string{
+				"/var/lib/grafana/dashboards/Altinn":          builtinDir,
+				"/var/lib/grafana/dashboards/Extra/team.json": extra,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			opts := newResourceBuildOptions(dataDir, true)
+			opts.Dashboards = tt.dashboards
+
+			got := grafanaDashboardMounts(t, BuildResources(opts))
+			if len(got) != len(tt.want) {
+				t.Fatalf("dashboard mounts = %v, want %v", got, tt.want)
+			}
+			for containerPath, hostPath := range tt.want {
+				if got[containerPath] != hostPath {
+					t.Errorf("mount %s = %q, want %q", containerPath, got[containerPath], hostPath)
+				}
+			}
+		})
+	}
+
+	t.Run("missing selected dashboard", func(t *testing.T) {
+		t.Parallel()
+		dataDir := t.TempDir()
+		createCoreLayout(t, dataDir)
+		createMonitoringLayout(t, dataDir)
+		opts := newResourceBuildOptions(dataDir, true)
+		opts.Dashboards = config.DashboardsSpec{Include: []string{"app.json"}}
+
+		err := ValidateResourceHostPaths(opts)
+		if !errors.Is(err, ErrInvalidResourceLayout) {
+			t.Fatalf("ValidateResourceHostPaths() error = %v, want ErrInvalidResourceLayout", err)
+		}
+		if !strings.Contains(err.Error(), filepath.Join(dataDir, "infra", "grafana-dashboards", "app.json")) {
+			t.Fatalf("error %q does not contain missing dashboard", err.Error())
+		}
+	})
+
+	t.Run("invalid entries", func(t *testing.T) {
+		t.Parallel()
+		for _, spec := range []config.DashboardsSpec{
+			{Include: []string{"../app.json"}},
+			{Include: []string{"app.yaml"}},
+			{Extra: []string{"relative/team.json"}},
+			{Extra: []string{"/a/team.json", "/b/team.json"}},
+		} {
+			if err := validateDashboards(spec); !errors.Is(err, ErrInvalidDashboard) {
+				t.Errorf("validateDashboards(%+v) error = %v, want ErrInvalidDashboard", spec, err)
+			}
+		}
+	})
+}
+
+func grafanaDashboardMounts(t *testing.T, resources []resource.Resource) map[string]string {
+	t.Helper()
+	for _, res := range resources {
+		c, ok := res.(*resource.Container)
+		if !ok || c.Name != ContainerMonitoringGrafana {
+			continue
+		}
+		mounts := make(map[string]string)
+		for _, volume := range c.Volumes {
+			if strings.HasPrefix(volume.ContainerPath, "/var/lib/grafana/dashboards") {
+				mounts[volume.ContainerPath] = volume.HostPath
+			}
+		}
+		return mounts
+	}
+	t.Fatal("grafana container not found")
+	return nil
+}
+
+// stubResource is a resource with arbitrary dependencies, for graph validation tests.
+type stubResource struct {
+	id   resource.ResourceID
+	deps []resource.ResourceID
+}
+
+func (r stubResource) ID() resource.ResourceID { return r.id }
+
+func (r stubResource) Dependencies() []resource.ResourceRef { return resource.DepIDs(r.deps...) }
+
+func TestBuildResourceGraph_DiagnosesBrokenDependencies(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		wantErr error
+		extra   []resource.Resource
+		want    []string
+	}{
+		"misnamed network": {
+			extra: []resource.Resource{
+				&resource.Container{
+					Name:     "extra",
+					Image:    resource.RefID("image:localtest"),
+					Networks: resource.DepIDs("network:typo"),
+				},
+				stubResource{id: "image:localtest"},
+				stubResource{id: "app", deps: []resource.ResourceID{"container:extra"}},
+			},
+			wantErr: resource.ErrMissingDependency,
+			want:    []string{"app -> container:extra -> network:typo (missing)"},
+		},
+		"cycle": {
+			extra: []resource.Resource{
+				stubResource{id: "b", deps: []resource.ResourceID{"c"}},
+				stubResource{id: "c", deps: []resource.ResourceID{"b"}},
+				stubResource{id: "a", deps: []resource.ResourceID{"b"}},
+			},
+			wantErr: resource.ErrDependencyCycle,
+			want:    []string{"dependency cycle detected: b -> c -> b"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := buildResourceGraph(tt.extra)
+			if !errors.Is(err, ErrInvalidResourceGraph) || !errors.Is(err, tt.wantErr) {
+				t.Fatalf("buildResourceGraph() error = %v, want %v and %v", err, ErrInvalidResourceGraph, tt.wantErr)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("buildResourceGraph() error = %q, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
@@ -2,41 +2,64 @@ package localtest
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"os"
 	"runtime"
 	"strconv"
 
 	"altinn.studio/devenv/pkg/container"
+	containertypes "altinn.studio/devenv/pkg/container/types"
 	"altinn.studio/studioctl/internal/config"
 	"altinn.studio/studioctl/internal/networking"
 )
 
-// DefaultLoadBalancerPort is the default localtest load balancer port.
-const DefaultLoadBalancerPort = 8000
+const (
+	// DefaultLoadBalancerPort is the default localtest load balancer port.
+	DefaultLoadBalancerPort = 8000
+
+	// loadBalancerPortFallbacks is how many ports after the default are tried
+	// when the default is taken and no port was requested.
+	loadBalancerPortFallbacks = 10
+)
+
+// ErrPortInUse is returned when the load balancer port is taken by another process.
+var ErrPortInUse = errors.New("port already in use")
 
 type runtimeConfigResolver struct {
 	cfg    *config.Config
 	client container.ContainerClient
 	debugf func(format string, args ...any)
+	warnf  func(format string, args ...any)
+	// portFree reports whether a host port can be bound.
+	portFree func(port int) bool
 }
 
 func newRuntimeConfigResolver(
 	cfg *config.Config,
 	client container.ContainerClient,
 	debugf func(format string, args ...any),
+	warnf func(format string, args ...any),
 ) *runtimeConfigResolver {
 	if debugf == nil {
 		debugf = func(string, ...any) {}
 	}
+	if warnf == nil {
+		warnf = func(string, ...any) {}
+	}
 	return &runtimeConfigResolver{
-		cfg:    cfg,
-		client: client,
-		debugf: debugf,
+		cfg:      cfg,
+		client:   client,
+		debugf:   debugf,
+		warnf:    warnf,
+		portFree: isPortFree,
 	}
 }
 
-func (r *runtimeConfigResolver) Build(ctx context.Context, portFlag int) (RuntimeConfig, error) {
+// Build resolves the runtime configuration. Without portFlag the default load
+// balancer port is used, or the next free one if it is taken and strictPort is unset.
+func (r *runtimeConfigResolver) Build(ctx context.Context, portFlag int, strictPort bool) (RuntimeConfig, error) {
 	installation := r.client.Installation()
 
 	n := networking.NewNetworking(r.client, r.cfg, r.debugf)
@@ -48,19 +71,72 @@ func (r *runtimeConfigResolver) Build(ctx context.Context, portFlag int) (Runtim
 		r.debugf("using cached network metadata")
 	}
 
+	port, err := r.resolveLoadBalancerPort(ctx, portFlag, strictPort)
+	if err != nil {
+		return RuntimeConfig{}, err
+	}
+
 	return RuntimeConfig{
 		HostGateway:      metadata.HostGateway,
-		LoadBalancerPort: strconv.Itoa(resolveLoadBalancerPort(portFlag)),
+		LoadBalancerPort: strconv.Itoa(port),
 		User:             runtimeContainerUser(),
 		Installation:     installation,
 	}, nil
 }
 
-func resolveLoadBalancerPort(portFlag int) int {
-	if portFlag == 0 {
-		return DefaultLoadBalancerPort
+// resolveLoadBalancerPort returns portFlag when set. Otherwise it returns the
+// default port, falling back to the next free port when the default is taken.
+// The default is kept when localtest itself is running, since it may hold the port.
+func (r *runtimeConfigResolver) resolveLoadBalancerPort(ctx context.Context, portFlag int, strictPort bool) (int, error) {
+	if portFlag != 0 {
+		return portFlag, nil
+	}
+	if r.portFree(DefaultLoadBalancerPort) || r.localtestRunning(ctx) {
+		return DefaultLoadBalancerPort, nil
+	}
+	if strictPort {
+		return 0, fmt.Errorf(
+			"%w: %d (stop the process using it or choose another with --port)",
+			ErrPortInUse,
+			DefaultLoadBalancerPort,
+		)
+	}
+
+	for port := DefaultLoadBalancerPort + 1; port <= DefaultLoadBalancerPort+loadBalancerPortFallbacks; port++ {
+		if r.portFree(port) {
+			r.warnf("Port %d is in use; using port %d instead (use --strict-port to fail instead)",
+				DefaultLoadBalancerPort, port)
+			return port, nil
+		}
+	}
+	return 0, fmt.Errorf(
+		"%w: %d-%d (choose a free port with --port)",
+		ErrPortInUse,
+		DefaultLoadBalancerPort,
+		DefaultLoadBalancerPort+loadBalancerPortFallbacks,
+	)
+}
+
+func (r *runtimeConfigResolver) localtestRunning(ctx context.Context) bool {
+	state, err := r.client.ContainerState(ctx, ContainerLocaltest)
+	if err != nil {
+		if !errors.Is(err, containertypes.ErrContainerNotFound) {
+			r.debugf("inspect %s container: %v", ContainerLocaltest, err)
+		}
+		return false
+	}
+	return state.Running
+}
+
+// isPortFree reports whether port can be bound on all host interfaces.
+func isPortFree(port int) bool {
+	listener, err := net.Listen("tcp", ":"+strconv.Itoa(port))
+	if err != nil {
+		return false
 	}
-	return portFlag
+	//nolint:errcheck // the listener only probed the port
+	listener.Close()
+	return true
 }
 
 func runtimeContainerUser() string {
@@ -0,0 +1,112 @@
+package localtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"altinn.studio/devenv/pkg/container/mock"
+	"altinn.studio/devenv/pkg/container/types"
+)
+
+func TestResolveLoadBalancerPort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		wantErr     error
+		name        string
+		wantWarning string
+		busy        []int
+		portFlag    int
+		wantPort    int
+		running     bool
+		strictPort  bool
+	}{
+		{
+			name:     "default is free",
+			wantPort: DefaultLoadBalancerPort,
+		},
+		{
+			name:        "default busy falls back to next free port",
+			busy:        []int{DefaultLoadBalancerPort, DefaultLoadBalancerPort + 1},
+			wantPort:    DefaultLoadBalancerPort + 2,
+			wantWarning: "Port 8000 is in use; using port 8002 instead",
+		},
+		{
+			name:       "default busy with strict port",
+			busy:       []int{DefaultLoadBalancerPort},
+			strictPort: true,
+			wantErr:    ErrPortInUse,
+		},
+		{
+			name:     "default busy while localtest is running",
+			busy:     []int{DefaultLoadBalancerPort},
+			running:  true,
+			wantPort: DefaultLoadBalancerPort,
+		},
+		{
+			name:     "explicit port is not probed",
+			busy:     []int{9000},
+			portFlag: 9000,
+			wantPort: 9000,
+		},
+		{
+			name:    "whole fallback range busy",
+			busy:    portRange(DefaultLoadBalancerPort, DefaultLoadBalancerPort+loadBalancerPortFallbacks),
+			wantErr: ErrPortInUse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := mock.New()
+			client.ContainerStateFunc = func(context.Context, string) (types.ContainerState, error) {
+				if !tt.running {
+					return types.ContainerState{}, types.ErrContainerNotFound
+				}
+				return types.ContainerState{Status: "running", Running: true}, nil
+			}
+			var warnings []string
+			warnf := func(format string, args ...any) { warnings = append(warnings, fmt.Sprintf(format, args...)) }
+			resolver := newRuntimeConfigResolver(nil, client, nil, warnf)
+			resolver.portFree = func(port int) bool {
+				for _, busy := range tt.busy {
+					if port == busy {
+						return false
+					}
+				}
+				return true
+			}
+
+			port, err := resolver.resolveLoadBalancerPort(context.Background(), tt.portFlag, tt.strictPort)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("resolveLoadBalancerPort() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveLoadBalancerPort() error = %v", err)
+			}
+			if port != tt.wantPort {
+				t.Fatalf("port = %d, want %d", port, tt.wantPort)
+			}
+			gotWarning := strings.Join(warnings, "\n")
+			if (tt.wantWarning == "") != (gotWarning == "") || !strings.Contains(gotWarning, tt.wantWarning) {
+				t.Fatalf("warnings = %q, want %q", gotWarning, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func portRange(from, to int) []int {
+	ports := make([]int, 0, to-from+1)
+	for port := from; port <= to; port++ {
+		ports = append(ports, port)
+	}
+	return ports
+}
@@ -0,0 +1,46 @@
+package localtest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidTeardownTimeout is returned when a teardown timeout cannot be parsed or is too short.
+var ErrInvalidTeardownTimeout = errors.New("invalid teardown timeout")
+
+const (
+	// DefaultTeardownTimeout is how long stopping the environment may take when not configured.
+	DefaultTeardownTimeout = 30 * time.Second
+
+	// MinTeardownTimeout is the shortest accepted teardown timeout; containers get 10s to stop.
+	MinTeardownTimeout = 10 * time.Second
+
+	// teardownCheckTimeout bounds listing the containers left behind by a timed-out teardown.
+	teardownCheckTimeout = 5 * time.Second
+)
+
+// ParseTeardownTimeout parses a teardown timeout such as "2m" from config.
+// An empty value means DefaultTeardownTimeout.
+func ParseTeardownTimeout(value string) (time.Duration, error) {
+	if strings.TrimSpace(value) == "" {
+		return DefaultTeardownTimeout, nil
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q (e.g. 30s, 2m)", ErrInvalidTeardownTimeout, value)
+	}
+	if err := ValidateTeardownTimeout(d); err != nil {
+		return 0, err
+	}
+	return d, nil
+}
+
+// ValidateTeardownTimeout rejects timeouts shorter than MinTeardownTimeout.
+func ValidateTeardownTimeout(d time.Duration) error {
+	if d < MinTeardownTimeout {
+		return fmt.Errorf("%w: %s (minimum %s)", ErrInvalidTeardownTimeout, d, MinTeardownTimeout)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package localtest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"altinn.studio/devenv/pkg/container/mock"
+	"altinn.studio/devenv/pkg/container/types"
+	"altinn.studio/studioctl/internal/config"
+	"altinn.studio/studioctl/internal/ui"
+)
+
+func TestParseTeardownTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "empty uses default", value: "", want: DefaultTeardownTimeout},
+		{name: "minutes", value: "2m", want: 2 * time.Minute},
+		{name: "minimum", value: "10s", want: MinTeardownTimeout},
+		{name: "below minimum", value: "5s", wantErr: true},
+		{name: "not a duration", value: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseTeardownTimeout(tt.value)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTeardownTimeout) {
+					t.Fatalf("ParseTeardownTimeout(%q) error = %v, want %v", tt.value, err, ErrInvalidTeardownTimeout)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("ParseTeardownTimeout(%q) = %s, %v, want %s", tt.value, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTeardown_ReportsContainersLeftRunning(t *testing.T) {
+	t.Parallel()
+
+	client := mock.New()
+	client.ContainerStopFunc = func(ctx context.Context, _ string, _ *int) error {
+		<-ctx.Done() // the runtime never answers in time
+		return ctx.Err()
+	}
+	client.ContainerInspectFunc = func(_ context.Context, name string) (types.ContainerInfo, error) {
+		if name == ContainerLocaltest {
+			return types.ContainerInfo{State: types.ContainerState{Status: "running", Running: true}}, nil
+		}
+		return types.ContainerInfo{}, types.ErrContainerNotFound
+	}
+	cfg, err := config.New(config.Flags{Home: t.TempDir()}, "test-version")
+	if err != nil {
+		t.Fatalf("config.New() error = %v", err)
+	}
+	env := NewEnv(cfg, ui.NewOutput(io.Discard, io.Discard, false), client)
+
+	err = env.teardown(20 * time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("teardown() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if !strings.Contains(err.Error(), "containers not stopped: "+ContainerLocaltest) {
+		t.Errorf("teardown() error = %q, want it to name %s", err, ContainerLocaltest)
+	}
+}
@@ -0,0 +1,159 @@
+package localtest
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"altinn.studio/devenv/pkg/resource"
+)
+
+// Startup phases reported by 'env up --timing', in report order.
+const (
+	PhaseValidate       = "validate"
+	PhaseInstall        = "install"
+	PhaseImagePull      = "image_pull"
+	PhaseNetworkCreate  = "network_create"
+	PhaseContainerStart = "container_start"
+)
+
+// PhaseTiming is the time spent in one startup phase.
+type PhaseTiming struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"-"`
+	Millis   int64         `json:"ms"`
+}
+
+// StartupTiming is the phase breakdown of a localtest startup.
+// Phases that did not run (such as install when resources are current) are omitted.
+type StartupTiming struct {
+	Phases      []PhaseTiming `json:"phases"`
+	Total       time.Duration `json:"-"`
+	TotalMillis int64         `json:"totalMs"`
+}
+
+// startupTimer collects phase durations during Env.Up.
+//
+// Sequential phases are timed with track and summed. Resource phases are timed
+// from executor events: a phase spans from its first started resource to its
+// last finished one, since resources of one kind are applied in parallel.
+type startupTimer struct {
+	now     func() time.Time
+	started time.Time
+	phases  map[string]*phaseSpan
+	mu      sync.Mutex
+}
+
+type phaseSpan struct {
+	first   time.Time
+	last    time.Time
+	tracked time.Duration
+}
+
+func newStartupTimer(now func() time.Time) *startupTimer {
+	return &startupTimer{
+		now:     now,
+		started: now(),
+		phases:  make(map[string]*phaseSpan),
+		mu:      sync.Mutex{},
+	}
+}
+
+// track runs fn and adds its duration to phase. A nil timer just runs fn.
+func (t *startupTimer) track(phase string, fn func() error) error {
+	if t == nil {
+		return fn()
+	}
+	start := t.now()
+	err := fn()
+	elapsed := t.now().Sub(start)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.span(phase).tracked += elapsed
+	return err
+}
+
+// OnEvent records resource apply events; it implements resource.Observer.
+func (t *startupTimer) OnEvent(event resource.Event) {
+	phase := resourcePhase(event.Resource)
+	if phase == "" {
+		return
+	}
+	at := t.now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	span := t.span(phase)
+	switch event.Type {
+	case resource.EventApplyStart:
+		if span.first.IsZero() || at.Before(span.first) {
+			span.first = at
+		}
+	case resource.EventApplyDone, resource.EventApplyFailed:
+		if at.After(span.last) {
+			span.last = at
+		}
+	case resource.EventDestroyStart, resource.EventDestroyDone, resource.EventDestroyFailed:
+	}
+}
+
+// report returns the phases that ran, in report order, with the time since the timer started.
+func (t *startupTimer) report() StartupTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	total := t.now().Sub(t.started)
+	timing := StartupTiming{
+		Phases:      make([]PhaseTiming, 0, len(t.phases)),
+		Total:       total,
+		TotalMillis: total.Milliseconds(),
+	}
+	for _, name := range []string{PhaseValidate, PhaseInstall, PhaseImagePull, PhaseNetworkCreate, PhaseContainerStart} {
+		span, ok := t.phases[name]
+		if !ok {
+			continue
+		}
+		duration := span.tracked
+		if !span.first.IsZero() && span.last.After(span.first) {
+			duration += span.last.Sub(span.first)
+		}
+		timing.Phases = append(timing.Phases, PhaseTiming{
+			Name:     name,
+			Duration: duration,
+			Millis:   duration.Milliseconds(),
+		})
+	}
+	return timing
+}
+
+func (t *startupTimer) span(phase string) *phaseSpan {
+	span, ok := t.phases[phase]
+	if !ok {
+		span = &phaseSpan{first: time.Time{}, last: time.Time{}, tracked: 0}
+		t.phases[phase] = span
+	}
+	return span
+}
+
+// resourcePhase maps a resource ID to its startup phase.
+func resourcePhase(id resource.ResourceID) string {
+	kind, _, _ := strings.Cut(string(id), ":")
+	switch kind {
+	case "image":
+		return PhaseImagePull
+	case "network":
+		return PhaseNetworkCreate
+	case "container":
+		return PhaseContainerStart
+	}
+	return ""
+}
+
+// formatPhaseDuration rounds d for display: milliseconds below a second, else tenths of a second.
+func formatPhaseDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(100 * time.Millisecond).String()
+}
@@ -0,0 +1,97 @@
+package localtest
+
+import (
+	"encoding/json"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"altinn.studio/devenv/pkg/container/mock"
+	"altinn.studio/devenv/pkg/resource"
+	"altinn.studio/studioctl/internal/config"
+)
+
+func TestStartupTimer_ReportsPhases(t *testing.T) {
+	t.Parallel()
+
+	// Every clock reading advances 10ms so each phase has a non-zero duration.
+	var mu sync.Mutex
+	clock := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		clock = clock.Add(10 * time.Millisecond)
+		return clock
+	}
+
+	dataDir := t.TempDir()
+	createCoreLayout(t, dataDir)
+	opts := newResourceBuildOptions(dataDir, false)
+	defaults, err := config.LoadDefaults()
+	if err != nil {
+		t.Fatalf("LoadDefaults() error = %v", err)
+	}
+	opts.Images = defaults.Images
+
+	timer := newStartupTimer(now)
+	if err := timer.track(PhaseValidate, func() error { return ValidateResourceHostPaths(opts) }); err != nil {
+		t.Fatalf("ValidateResourceHostPaths() error = %v", err)
+	}
+
+	graph, err := buildResourceGraph(BuildResources(opts))
+	if err != nil {
+		t.Fatalf("buildResourceGraph() error = %v", err)
+	}
+	executor := resource.NewExecutor(mock.New())
+	executor.SetObserver(timer)
+	if err := executor.Apply(t.Context(), graph); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	timing := timer.report()
+	names := make([]string, 0, len(timing.Phases))
+	for _, phase := range timing.Phases {
+		names = append(names, phase.Name)
+		if phase.Duration <= 0 {
+			t.Errorf("phase %s duration = %s, want > 0", phase.Name, phase.Duration)
+		}
+	}
+	want := []string{PhaseValidate, PhaseImagePull, PhaseNetworkCreate, PhaseContainerStart}
+	if !slices.Equal(names, want) {
+		t.Fatalf("phases = %v, want %v (install only when triggered)", names, want)
+	}
+
+	payload, err := json.Marshal(timing)
+	if err != nil {
+		t.Fatalf("marshal timing: %v", err)
+	}
+	var got struct {
+		TotalMs *int64 `json:"totalMs"`
+		Phases  []struct {
+			Ms   *int64 `json:"ms"`
+			Name string `json:"name"`
+		} `json:"phases"`
+	}
+	if err := json.Unmarshal(payload, &got); err != nil {
+		t.Fatalf("unmarshal timing: %v\n%s", err, payload)
+	}
+	if got.TotalMs == nil || len(got.Phases) != len(want) {
+		t.Fatalf("json = %s, want totalMs and %d phases", payload, len(want))
+	}
+	for i, phase := range got.Phases {
+		if phase.Name != want[i] || phase.Ms == nil {
+			t.Errorf("json phase %d = %s, want name %q with ms", i, payload, want[i])
+		}
+	}
+}
+
+func TestStartupTimer_NilTracksWithoutRecording(t *testing.T) {
+	t.Parallel()
+
+	var timer *startupTimer
+	ran := false
+	if err := timer.track(PhaseInstall, func() error { ran = true; return nil }); err != nil || !ran {
+		t.Fatalf("nil timer track() = %v, ran = %v; want fn to run", err, ran)
+	}
+}
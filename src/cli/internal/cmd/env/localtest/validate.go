@@ -8,9 +8,30 @@ import (
 	"altinn.studio/devenv/pkg/container"
 )
 
+const (
+	// legacyStopTimeoutSeconds is how long legacy containers get to shut down gracefully.
+	legacyStopTimeoutSeconds = 10
+	// legacyNameSuffix is appended to the names of stopped legacy containers, freeing the
+	// original names for the CLI-managed containers.
+	legacyNameSuffix = "-legacy"
+)
+
 // CheckForLegacyLocaltest checks if legacy localtest containers are running.
 // Returns an error if containers exist without the studioctl management label.
 func CheckForLegacyLocaltest(ctx context.Context, client container.ContainerClient) error {
+	legacyContainers, err := FindLegacyLocaltest(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	if len(legacyContainers) > 0 {
+		return fmt.Errorf("%w: %v", ErrLegacyLocaltestRunning, legacyContainers)
+	}
+	return nil
+}
+
+// FindLegacyLocaltest returns the running localtest containers that lack the studioctl management label.
+func FindLegacyLocaltest(ctx context.Context, client container.ContainerClient) ([]string, error) {
 	containers := coreContainerNames()
 	var legacyContainers []string
 
@@ -20,7 +41,7 @@ func CheckForLegacyLocaltest(ctx context.Context, client container.ContainerClie
 			continue
 		}
 		if err != nil {
-			return fmt.Errorf("inspect container %s: %w", name, err)
+			return nil, fmt.Errorf("inspect container %s: %w", name, err)
 		}
 
 		if !info.State.Running {
@@ -34,8 +55,28 @@ func CheckForLegacyLocaltest(ctx context.Context, client container.ContainerClie
 		legacyContainers = append(legacyContainers, name)
 	}
 
-	if len(legacyContainers) > 0 {
-		return fmt.Errorf("%w: %v", ErrLegacyLocaltestRunning, legacyContainers)
+	return legacyContainers, nil
+}
+
+// StopLegacyLocaltest stops the given legacy containers and renames them with LegacyName.
+// They are kept, so their data and configuration can still be inspected or reused.
+func StopLegacyLocaltest(ctx context.Context, client container.ContainerClient, names []string) error {
+	for _, name := range names {
+		timeout := legacyStopTimeoutSeconds
+		if err := client.ContainerStop(ctx, name, &timeout); err != nil {
+			if errors.Is(err, container.ErrContainerNotFound) {
+				continue
+			}
+			return fmt.Errorf("stop legacy container %s: %w", name, err)
+		}
+		if err := client.ContainerRename(ctx, name, LegacyName(name)); err != nil {
+			return fmt.Errorf("rename legacy container %s: %w", name, err)
+		}
 	}
 	return nil
 }
+
+// LegacyName is the name a stopped legacy container is kept under.
+func LegacyName(name string) string {
+	return name + legacyNameSuffix
+}
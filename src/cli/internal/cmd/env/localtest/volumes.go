@@ -0,0 +1,65 @@
+package localtest
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"altinn.studio/devenv/pkg/container/types"
+)
+
+// ErrInvalidVolume is returned when a --volume value is not a host:container[:ro] bind mount.
+var ErrInvalidVolume = errors.New("invalid volume")
+
+// ParseVolumes parses bind mounts of the form /host/path:/container/path[:ro|:rw] for the
+// localtest container. Relative host paths are resolved against the working directory.
+// Host paths are not checked here; see ValidateResourceHostPaths.
+func ParseVolumes(values []string) ([]types.VolumeMount, error) {
+	volumes := make([]types.VolumeMount, 0, len(values))
+	for _, value := range values {
+		volume, err := parseVolume(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q (want /host/path:/container/path[:ro])", ErrInvalidVolume, value)
+		}
+		volumes = append(volumes, volume)
+	}
+	return volumes, nil
+}
+
+func parseVolume(value string) (types.VolumeMount, error) {
+	spec := strings.TrimSpace(value)
+	readOnly := false
+	if rest, ok := strings.CutSuffix(spec, ":ro"); ok {
+		spec, readOnly = rest, true
+	} else if rest, ok := strings.CutSuffix(spec, ":rw"); ok {
+		spec = rest
+	}
+
+	// Split at the last colon so Windows host paths (C:\app) keep their drive letter.
+	idx := strings.LastIndex(spec, ":")
+	if idx <= 0 {
+		return types.VolumeMount{}, ErrInvalidVolume
+	}
+	hostPath, containerPath := spec[:idx], spec[idx+1:]
+	if !path.IsAbs(containerPath) || containerPath == "/" {
+		return types.VolumeMount{}, ErrInvalidVolume
+	}
+	hostPath, err := filepath.Abs(hostPath)
+	if err != nil {
+		return types.VolumeMount{}, fmt.Errorf("resolve host path: %w", err)
+	}
+
+	volume := newVolume(hostPath, path.Clean(containerPath))
+	volume.ReadOnly = readOnly
+	return volume, nil
+}
+
+func volumeExpectations(volumes []types.VolumeMount) []hostPathExpectation {
+	result := make([]hostPathExpectation, 0, len(volumes))
+	for _, volume := range volumes {
+		result = append(result, userVolumeExpectation(volume))
+	}
+	return result
+}
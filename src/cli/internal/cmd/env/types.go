@@ -4,6 +4,8 @@ package env
 import (
 	"context"
 	"errors"
+	"io"
+	"time"
 )
 
 // ErrAlreadyStopped is returned when a runtime has no resources to stop.
@@ -12,21 +14,82 @@ var ErrAlreadyStopped = errors.New("environment already stopped")
 // Env manages a development environment lifecycle.
 type Env interface {
 	Preflight(ctx context.Context) error
-	Up(ctx context.Context, opts UpOptions) error
-	Down(ctx context.Context) error
+	Up(ctx context.Context, opts UpOptions) (UpResult, error)
+	Down(ctx context.Context, opts DownOptions) (DownResult, error)
 	Logs(ctx context.Context, opts LogsOptions) error
 }
 
 // UpOptions configures environment startup.
 type UpOptions struct {
-	Port        int
-	Detach      bool
-	Monitoring  bool
-	OpenBrowser bool
+	// TimingJSON, when set with Timing, receives the timing report as JSON instead of it being
+	// printed with the other output.
+	TimingJSON io.Writer
+	// MemLimit and CPULimit cap every monitoring container (e.g. "512m", "0.5").
+	// Empty means unlimited.
+	MemLimit string
+	CPULimit string
+	// Volumes are extra bind mounts for the localtest container (e.g. "/src/app:/app:ro").
+	Volumes []string
+	// ExtraHosts are host:ip mappings added to every container (e.g. "api.example.test:10.0.0.5").
+	ExtraHosts []string
+	// PullTimeout cancels an image pull that runs longer; zero means no limit.
+	PullTimeout time.Duration
+	// TeardownTimeout bounds stopping the environment after a foreground run or failed start;
+	// zero means the default.
+	TeardownTimeout time.Duration
+	Port            int
+	Detach          bool
+	Monitoring      bool
+	OpenBrowser     bool
+	// Recreate replaces the started containers even when they already match the desired state.
+	Recreate bool
+	// StrictPort fails instead of falling back to another port when the default is taken.
+	StrictPort bool
+	// Timing prints how long each startup phase took.
+	Timing bool
+}
+
+// UpResult describes an environment started by Up.
+type UpResult struct {
+	// URL is where the environment is reachable from the host.
+	URL string
+	// Containers is the state of each container right after startup.
+	Containers []ContainerStatus
+	Port       int
+}
+
+// ContainerStatus is the state of one environment container.
+type ContainerStatus struct {
+	// StartedAt is when a running container last started; zero when it is not running.
+	StartedAt time.Time `json:"startedAt,omitzero"`
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	// Health is "healthy", "unhealthy" or "starting"; empty when the container has no healthcheck.
+	Health string `json:"health,omitempty"`
+	// Uptime is how long a running container has been up, rounded to seconds (e.g. "1h2m3s").
+	Uptime string `json:"uptime,omitempty"`
+}
+
+// DownResult describes an environment stopped by Down.
+type DownResult struct {
+	// Stopped lists the containers that were stopped and removed.
+	Stopped []string
+}
+
+// DownOptions configures environment teardown.
+type DownOptions struct {
+	// Only limits teardown to these containers and anything that depends on them.
+	// Empty stops the whole environment.
+	Only []string
 }
 
 // LogsOptions configures log streaming.
 type LogsOptions struct {
-	Component string
-	Follow    bool
+	// Level is the minimum severity to show (e.g. "warn"); empty shows every line.
+	Level string
+	// Components limits streaming to these containers; empty streams all of them.
+	Components []string
+	// DropUnclassified hides lines whose severity cannot be detected when Level is set.
+	DropUnclassified bool
+	Follow           bool
 }
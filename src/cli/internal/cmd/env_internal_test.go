@@ -0,0 +1,154 @@
+//nolint:testpackage // testing unexported runtime detection hooks
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"altinn.studio/devenv/pkg/container"
+	"altinn.studio/studioctl/internal/config"
+	"altinn.studio/studioctl/internal/ui"
+)
+
+var (
+	errConnectionRefused = errors.New("dial unix /var/run/docker.sock: connect: connection refused")
+	errPermissionDenied  = fmt.Errorf("dial unix /var/run/docker.sock: %w", os.ErrPermission)
+)
+
+func TestEnvCommand_RuntimeUnavailable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		installed string
+		want      string
+		args      []string
+	}{
+		{
+			name:      "status daemon down",
+			installed: "docker",
+			want:      "localtest is not running (docker is installed but not running)",
+			args:      []string{"status"},
+		},
+		{
+			name:      "status not installed",
+			installed: "",
+			want:      "localtest is not running (no container runtime installed",
+			args:      []string{"status"},
+		},
+		{
+			name:      "status json",
+			installed: "podman",
+			want:      `{"health":"stopped","containers":[],"running":false,"anyRunning":false}`,
+			args:      []string{"status", "--json"},
+		},
+		{
+			name:      "down daemon down",
+			installed: "podman",
+			want:      "localtest is already stopped (podman is installed but not running)",
+			args:      []string{"down"},
+		},
+		{
+			name:      "down not installed",
+			installed: "",
+			want:      "localtest is already stopped (no container runtime installed",
+			args:      []string{"down"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			command, stdout := newUnavailableRuntimeEnvCommand(t, tt.installed, noRuntimeError())
+			if err := command.Run(context.Background(), tt.args); err != nil {
+				t.Fatalf("Run() error = %v, want nil", err)
+			}
+			if !strings.Contains(stdout.String(), tt.want) {
+				t.Errorf("Run() output = %q, want %q", stdout.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvCommand_RuntimeUnavailable_UpFails(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		want      error
+		name      string
+		installed string
+	}{
+		{name: "daemon down", installed: "docker", want: ErrContainerRuntimeNotRunning},
+		{name: "not installed", installed: "", want: ErrNoContainerRuntime},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			command, _ := newUnavailableRuntimeEnvCommand(t, tt.installed, noRuntimeError())
+			err := command.Run(context.Background(), []string{"up"})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Run() error = %v, want %v", err, tt.want)
+			}
+			if !errors.Is(err, errConnectionRefused) {
+				t.Errorf("Run() error = %v, want detection error wrapped", err)
+			}
+		})
+	}
+}
+
+func TestEnvCommand_RuntimeDetectionError(t *testing.T) {
+	t.Parallel()
+
+	for _, args := range [][]string{{"status"}, {"down"}} {
+		t.Run(args[0], func(t *testing.T) {
+			t.Parallel()
+
+			command, stdout := newUnavailableRuntimeEnvCommand(t, "docker", errPermissionDenied)
+			err := command.Run(context.Background(), args)
+			if !errors.Is(err, os.ErrPermission) {
+				t.Fatalf("Run() error = %v, want %v", err, os.ErrPermission)
+			}
+			if errors.Is(err, ErrContainerRuntimeNotRunning) || errors.Is(err, ErrNoContainerRuntime) {
+				t.Errorf("Run() error = %v, want a detection error rather than an unavailable runtime", err)
+			}
+			if stdout.Len() != 0 {
+				t.Errorf("Run() output = %q, want none", stdout.String())
+			}
+		})
+	}
+}
+
+func noRuntimeError() error {
+	return fmt.Errorf("%w: %w", container.ErrNoRuntime, errConnectionRefused)
+}
+
+func newUnavailableRuntimeEnvCommand(t *testing.T, installed string, detectErr error) (*EnvCommand, *bytes.Buffer) {
+	t.Helper()
+
+	cfg, err := config.New(config.Flags{Home: t.TempDir(), SocketDir: "", Verbose: false}, "test-version")
+	if err != nil {
+		t.Fatalf("config.New() error = %v", err)
+	}
+
+	var stdout bytes.Buffer
+	command := NewEnvCommand(cfg, ui.NewOutput(&stdout, &stdout, false))
+	command.detect = func(context.Context) (container.ContainerClient, error) {
+		return nil, detectErr
+	}
+	command.lookPath = func(file string) (string, error) {
+		if file == installed {
+			return "/usr/bin/" + file, nil
+		}
+		return "", exec.ErrNotFound
+	}
+	return command, &stdout
+}
@@ -38,6 +38,34 @@ func TestEnvCommand_RunUp_PortValidation(t *testing.T) {
 	}
 }
 
+func TestEnvCommand_RunStatus_WatchIntervalValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "zero inline", args: []string{"status", "--watch=0s"}},
+		{name: "malformed inline", args: []string{"status", "--watch=soon"}},
+		{name: "malformed separate", args: []string{"status", "--watch", "soon"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			command := newTestEnvCommand(t)
+			err := command.Run(context.Background(), tt.args)
+			if err == nil {
+				t.Fatal("Run() error = nil, want invalid watch interval error")
+			}
+			if !strings.Contains(err.Error(), "watch interval") {
+				t.Fatalf("Run() error = %v, want invalid watch interval", err)
+			}
+		})
+	}
+}
+
 func TestEnvCommand_RunUp_Help(t *testing.T) {
 	t.Parallel()
 
@@ -48,13 +76,55 @@ func TestEnvCommand_RunUp_Help(t *testing.T) {
 	}
 }
 
+func TestEnvCommand_RunUp_BadConfigTeardownTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		wantErrText string
+		args        []string
+	}{
+		{name: "help", args: []string{"up", "--help"}},
+		{
+			name:        "flag overrides config",
+			args:        []string{"up", "--teardown-timeout=1m", "--port=-1"},
+			wantErrText: "invalid port",
+		},
+		{name: "config used", args: []string{"up"}, wantErrText: "config teardownTimeout"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			command := newTestEnvCommandWithConfig(t, func(cfg *config.Config) { cfg.TeardownTimeout = "soon" })
+			err := command.Run(context.Background(), tt.args)
+			if tt.wantErrText == "" {
+				if err != nil {
+					t.Fatalf("Run() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErrText) {
+				t.Fatalf("Run() error = %v, want %s", err, tt.wantErrText)
+			}
+		})
+	}
+}
+
 func newTestEnvCommand(t *testing.T) *cmd.EnvCommand {
 	t.Helper()
+	return newTestEnvCommandWithConfig(t, func(*config.Config) {})
+}
+
+func newTestEnvCommandWithConfig(t *testing.T, modify func(cfg *config.Config)) *cmd.EnvCommand {
+	t.Helper()
 
 	cfg, err := config.New(config.Flags{Home: t.TempDir(), SocketDir: "", Verbose: false}, "test-version")
 	if err != nil {
 		t.Fatalf("config.New() error = %v", err)
 	}
+	modify(cfg)
 
 	out := ui.NewOutput(io.Discard, io.Discard, false)
 	return cmd.NewEnvCommand(cfg, out)
@@ -1,6 +1,9 @@
 package cmd
 
-import "errors"
+import (
+	"errors"
+	"strconv"
+)
 
 // Sentinel errors for the cmd package.
 var (
@@ -22,6 +25,9 @@ var (
 	// ErrNoContainerRuntime is returned when no container runtime is found.
 	ErrNoContainerRuntime = errors.New("no container runtime found")
 
+	// ErrContainerRuntimeNotRunning is returned when a container runtime is installed but not reachable.
+	ErrContainerRuntimeNotRunning = errors.New("container runtime not running")
+
 	// ErrWindowsVersionTooOld is returned when Windows version lacks AF_UNIX support.
 	ErrWindowsVersionTooOld = errors.New("windows version too old")
 
@@ -48,4 +54,23 @@ var (
 
 	// ErrInvalidFlagValue is returned when a flag value is invalid.
 	ErrInvalidFlagValue = errors.New("invalid flag value")
+
+	// ErrDeadlineExceeded is returned when a command is cancelled by --deadline.
+	ErrDeadlineExceeded = errors.New("timed out")
 )
+
+// ExitError makes the CLI exit with Code instead of 1.
+// Err is reported like any other error; a nil Err exits silently.
+type ExitError struct {
+	Err  error
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return "exit status " + strconv.Itoa(e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error { return e.Err }
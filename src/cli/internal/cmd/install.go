@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+
+	"altinn.studio/studioctl/internal/config"
+	"altinn.studio/studioctl/internal/install"
+	"altinn.studio/studioctl/internal/osutil"
+	"altinn.studio/studioctl/internal/ui"
+)
+
+// InstallCommand implements the 'install' subcommand.
+type InstallCommand struct {
+	cfg *config.Config
+	out *ui.Output
+}
+
+// NewInstallCommand creates a new install command.
+func NewInstallCommand(cfg *config.Config, out *ui.Output) *InstallCommand {
+	return &InstallCommand{cfg: cfg, out: out}
+}
+
+// Name returns the command name.
+func (c *InstallCommand) Name() string { return "install" }
+
+// Synopsis returns a short description.
+func (c *InstallCommand) Synopsis() string { return "Inspect localtest resources" }
+
+// Usage returns the full help text.
+func (c *InstallCommand) Usage() string {
+	return fmt.Sprintf(`Usage: %s install <subcommand> [options]
+
+Inspect installed localtest resources.
+
+Subcommands:
+  diff    Show resource files changed between the installed and another version
+
+Run '%s install <subcommand> --help' for more information.
+`, osutil.CurrentBin(), osutil.CurrentBin())
+}
+
+// Run executes the command.
+func (c *InstallCommand) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		c.out.Print(c.Usage())
+		return nil
+	}
+
+	subCmd := args[0]
+	subArgs := args[1:]
+
+	switch subCmd {
+	case "diff":
+		return c.runDiff(ctx, subArgs)
+	case "-h", flagHelp, helpSubcmd:
+		c.out.Print(c.Usage())
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownSubcommand, subCmd)
+	}
+}
+
+func (c *InstallCommand) runDiff(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("install diff", flag.ContinueOnError)
+	fs.Usage = func() {
+		c.out.Printf(`Usage: %s install diff --version VERSION [options]
+
+Compare installed localtest resources against another release without
+extracting it.
+
+Options:
+  --version VERSION   Release version to compare against (e.g. v1.2.3, or latest)
+  --json              Output as JSON
+  -h, --help          Show this help message
+`, osutil.CurrentBin())
+	}
+
+	var targetVersion string
+	var jsonOutput bool
+	fs.StringVar(&targetVersion, "version", "", "Release version to compare against")
+	fs.BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return fmt.Errorf("parsing flags: %w", err)
+	}
+	if targetVersion == "" {
+		return fmt.Errorf("%w: --version", ErrMissingArgument)
+	}
+
+	installed, err := install.ReadManifest(c.cfg.DataDir)
+	if err != nil {
+		if errors.Is(err, install.ErrManifestNotFound) {
+			return fmt.Errorf(
+				"%w (reinstall resources with '%s self install' to create one)",
+				err, osutil.CurrentBin(),
+			)
+		}
+		return fmt.Errorf("read installed manifest: %w", err)
+	}
+
+	spinner := ui.NewSpinner(c.out, "Fetching resource manifest for "+targetVersion+"...")
+	if !jsonOutput && !c.cfg.Verbose {
+		spinner.Start()
+	}
+	target, err := install.FetchManifest(ctx, targetVersion)
+	spinner.Stop()
+	if err != nil {
+		return fmt.Errorf("fetch manifest: %w", err)
+	}
+
+	diff := install.Diff(installed, target)
+
+	if jsonOutput {
+		payload, err := json.Marshal(map[string]any{
+			"version": targetVersion,
+			"added":   diff.Added,
+			"removed": diff.Removed,
+			"changed": diff.Changed,
+		})
+		if err != nil {
+			return fmt.Errorf("marshal diff json: %w", err)
+		}
+		c.out.Printf("%s\n", payload)
+		return nil
+	}
+
+	installedVersion, err := install.InstalledVersion(c.cfg.DataDir)
+	if err != nil {
+		installedVersion = "installed resources"
+	}
+	c.renderDiff(installedVersion, targetVersion, diff)
+	return nil
+}
+
+func (c *InstallCommand) renderDiff(installedVersion, targetVersion string, diff install.ManifestDiff) {
+	if diff.Empty() {
+		c.out.Printf("No resource changes between %s and %s.\n", installedVersion, targetVersion)
+		return
+	}
+
+	c.out.Printf("Resource changes from %s to %s:\n", installedVersion, targetVersion)
+	for _, path := range diff.Added {
+		c.out.Printf("  + %s\n", path)
+	}
+	for _, path := range diff.Removed {
+		c.out.Printf("  - %s\n", path)
+	}
+	for _, path := range diff.Changed {
+		c.out.Printf("  ~ %s\n", path)
+	}
+	c.out.Println("")
+	c.out.Printf("%d added, %d removed, %d changed\n", len(diff.Added), len(diff.Removed), len(diff.Changed))
+}
@@ -8,12 +8,15 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"slices"
 	"strings"
 	"syscall"
+	"time"
 
 	"altinn.studio/studioctl/internal/config"
 	"altinn.studio/studioctl/internal/osutil"
 	"altinn.studio/studioctl/internal/ui"
+	"altinn.studio/studioctl/internal/update"
 )
 
 // version is set at build time via ldflags.
@@ -27,6 +30,8 @@ var (
 const (
 	flagHelp      = "--help"
 	flagVersion   = "--version"
+	flagNoColor   = "--no-color"
+	flagQuiet     = "--quiet"
 	helpSubcmd    = "help"
 	versionSubcmd = "version"
 )
@@ -48,7 +53,7 @@ type CLI struct {
 
 // NewCLI builds a CLI with default command registrations.
 func NewCLI(cfg *config.Config) *CLI {
-	out := ui.DefaultOutput(cfg.Verbose)
+	out := ui.DefaultOutput(cfg.Verbose, cfg.NoColor)
 	cli := &CLI{
 		cfg:      cfg,
 		out:      out,
@@ -61,8 +66,10 @@ func NewCLI(cfg *config.Config) *CLI {
 	cli.Register(NewDoctorCommand(cfg, out))
 	cli.Register(NewSelfCommand(cfg, out))
 	cli.Register(NewAppCommand(cfg, out))
+	cli.Register(NewInstallCommand(cfg, out))
 	cli.Register(NewServersCommand(cfg, out))
 	cli.Register(NewShellCommand(cfg, out))
+	cli.Register(NewVersionCommand(cfg, out))
 
 	return cli
 }
@@ -86,7 +93,7 @@ func (c *CLI) Run(ctx context.Context, args []string) int {
 		return 0
 	}
 
-	if cmdName == "-V" || cmdName == flagVersion || cmdName == versionSubcmd {
+	if cmdName == "-V" || cmdName == flagVersion {
 		c.out.Printf("%s %s\n", osutil.CurrentBin(), c.cfg.Version)
 		return 0
 	}
@@ -99,12 +106,55 @@ func (c *CLI) Run(ctx context.Context, args []string) int {
 		return 1
 	}
 
-	if err := cmd.Run(ctx, args[1:]); err != nil {
-		c.out.Error(err.Error())
-		return 1
+	var notice *update.Notice
+	if c.updateNoticeEnabled(cmdName) {
+		notice = update.NewNotifier(c.cfg.Home).Start(ctx, c.cfg.Version)
+	}
+
+	code := 0
+	if err := c.runCommand(ctx, cmd, args[1:]); err != nil {
+		code = 1
+		var exitErr *ExitError
+		if errors.As(err, &exitErr) {
+			code = exitErr.Code
+		}
+		if exitErr == nil || exitErr.Err != nil {
+			c.out.Error(err.Error())
+		}
+	}
+
+	if result, ok := notice.Ready(); ok {
+		c.out.Warningf("A new release of %s is available: %s -> %s", osutil.CurrentBin(), result.Current, result.Latest)
+		c.out.Warningf("Upgrade with: %s", update.UpgradeCommand)
 	}
 
-	return 0
+	return code
+}
+
+// runCommand runs cmd, cancelling it once the --deadline has passed.
+func (c *CLI) runCommand(ctx context.Context, cmd Command, args []string) error {
+	if c.cfg.Deadline <= 0 {
+		return cmd.Run(ctx, args)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Deadline)
+	defer cancel()
+	err := cmd.Run(ctx, args)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s (--deadline): %w", ErrDeadlineExceeded, c.cfg.Deadline, err)
+	}
+	return err
+}
+
+// updateNoticeEnabled reports whether the opt-in background update check runs for
+// this invocation. It is off unless enabled in config, and never runs for quiet or
+// non-interactive use or when the version command already checks explicitly.
+func (c *CLI) updateNoticeEnabled(cmdName string) bool {
+	return c.cfg.CheckForUpdates &&
+		!c.cfg.Quiet &&
+		c.cfg.Home != "" &&
+		cmdName != versionSubcmd &&
+		c.out.IsErrTerminal()
 }
 
 func (c *CLI) printUsage() {
@@ -119,7 +169,7 @@ func (c *CLI) printUsage() {
 		}
 	}
 
-	order := []string{"run", "env", "auth", "app", "install", "doctor", "self", "servers", "shell"}
+	order := []string{"run", "env", "auth", "app", "install", "doctor", "self", "servers", "shell", "version"}
 	for _, name := range order {
 		if cmd, ok := c.commands[name]; ok {
 			c.out.Printf("  %-*s  %s\n", maxLen+2, name, cmd.Synopsis())
@@ -129,7 +179,10 @@ func (c *CLI) printUsage() {
 	c.out.Printf("\nGlobal Options:\n")
 	c.out.Printf("  --home DIR        Override home directory (default: %s)\n", defaultHomePathForHelp())
 	c.out.Printf("  --socket-dir DIR  Override socket directory\n")
+	c.out.Printf("  --deadline DUR    Cancel the command if it runs longer than DUR (e.g. 90s, 5m)\n")
+	c.out.Printf("  --no-color        Disable colored output (also NO_COLOR)\n")
 	c.out.Printf("  -v, --verbose     Verbose output\n")
+	c.out.Printf("  -q, --quiet       Suppress update notices\n")
 	c.out.Printf("  -V, --version     Print version\n")
 	c.out.Printf("  -h, --help        Print help\n")
 	c.out.Printf("\nRun '%s <command> --help' for more information on a command.\n", osutil.CurrentBin())
@@ -151,6 +204,10 @@ func parseVerboseFlag(arg string) bool {
 	return false
 }
 
+func parseQuietFlag(arg string) bool {
+	return arg == "-q" || arg == flagQuiet
+}
+
 func parseStringFlag(args []string, i int, name string) (value string, skip int, handled bool, err error) {
 	arg := args[i]
 	prefix := "--" + name + "="
@@ -175,10 +232,21 @@ func parseStringFlag(args []string, i int, name string) (value string, skip int,
 
 func isKnownGlobalFlag(arg string) bool {
 	switch arg {
-	case "--home", "--socket-dir", "-v", "--verbose", "-h", flagHelp, "-V", flagVersion:
+	case "--home", "--socket-dir", "--deadline", "-v", "--verbose", "-q", flagQuiet, flagNoColor,
+		"-h", flagHelp, "-V", flagVersion:
 		return true
 	}
-	return strings.HasPrefix(arg, "--home=") || strings.HasPrefix(arg, "--socket-dir=")
+	return strings.HasPrefix(arg, "--home=") || strings.HasPrefix(arg, "--socket-dir=") ||
+		strings.HasPrefix(arg, "--deadline=")
+}
+
+// parseDeadline parses a positive --deadline duration such as "90s" or "5m".
+func parseDeadline(val string) (time.Duration, error) {
+	deadline, err := time.ParseDuration(val)
+	if err != nil || deadline <= 0 {
+		return 0, fmt.Errorf("%w: %q (want a positive duration such as 90s or 5m)", ErrInvalidFlagValue, val)
+	}
+	return deadline, nil
 }
 
 func isPassthroughFlag(arg string) bool {
@@ -191,6 +259,8 @@ func ParseGlobalFlags() (config.Flags, []string, error) {
 	args := os.Args[1:]
 	var remaining []string
 	verbose := false
+	noColor := false
+	quiet := false
 
 	for i := 0; i < len(args); i++ {
 		arg := args[i]
@@ -199,6 +269,14 @@ func ParseGlobalFlags() (config.Flags, []string, error) {
 			verbose = true
 			continue
 		}
+		if arg == flagNoColor {
+			noColor = true
+			continue
+		}
+		if parseQuietFlag(arg) {
+			quiet = true
+			continue
+		}
 
 		if val, skip, ok, err := parseStringFlag(args, i, "home"); err != nil {
 			return config.Flags{}, nil, fmt.Errorf("parsing --home flag: %w", err)
@@ -214,6 +292,17 @@ func ParseGlobalFlags() (config.Flags, []string, error) {
 			i += skip
 			continue
 		}
+		if val, skip, ok, err := parseStringFlag(args, i, "deadline"); err != nil {
+			return config.Flags{}, nil, fmt.Errorf("parsing --deadline flag: %w", err)
+		} else if ok {
+			deadline, err := parseDeadline(val)
+			if err != nil {
+				return config.Flags{}, nil, fmt.Errorf("parsing --deadline flag: %w", err)
+			}
+			flags.Deadline = deadline
+			i += skip
+			continue
+		}
 
 		if isPassthroughFlag(arg) {
 			remaining = append(remaining, arg)
@@ -230,6 +319,8 @@ func ParseGlobalFlags() (config.Flags, []string, error) {
 	}
 
 	flags.Verbose = verbose
+	flags.NoColor = noColor
+	flags.Quiet = quiet
 	return flags, remaining, nil
 }
 
@@ -238,8 +329,11 @@ func shouldInitializeConfig(args []string) bool {
 		return false
 	}
 	switch args[0] {
-	case "-V", flagVersion, versionSubcmd, "-h", flagHelp, helpSubcmd:
+	case "-V", flagVersion, "-h", flagHelp, helpSubcmd:
 		return false
+	case versionSubcmd:
+		// Only the update check needs a home directory, for its cache.
+		return slices.Contains(args[1:], "--check")
 	}
 	for _, arg := range args {
 		if arg == "-h" || arg == flagHelp {
@@ -260,7 +354,10 @@ func newEphemeralConfig(flags config.Flags, version string) *config.Config {
 		BinDir:    "",
 		Images:    images,
 		Version:   version,
+		Deadline:  flags.Deadline,
 		Verbose:   flags.Verbose,
+		NoColor:   flags.NoColor,
+		Quiet:     flags.Quiet,
 	}
 }
 
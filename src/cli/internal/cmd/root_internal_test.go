@@ -0,0 +1,82 @@
+//nolint:testpackage // testing the unexported update notice gate and CLI output
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"altinn.studio/studioctl/internal/config"
+	"altinn.studio/studioctl/internal/ui"
+)
+
+func TestCLI_UpdateNoticeEnabled(t *testing.T) {
+	t.Parallel()
+
+	defaults, err := config.New(config.Flags{Home: t.TempDir()}, "v1.0.0")
+	if err != nil {
+		t.Fatalf("config.New() error = %v", err)
+	}
+	if defaults.CheckForUpdates {
+		t.Fatal("CheckForUpdates = true by default, want opt-in")
+	}
+
+	var out bytes.Buffer
+	cli := NewCLI(defaults)
+	cli.out = ui.NewOutput(&out, &out, false)
+	if cli.updateNoticeEnabled("env") {
+		t.Error("updateNoticeEnabled() = true without checkForUpdates, want false")
+	}
+
+	optedIn := *defaults
+	optedIn.CheckForUpdates = true
+	cli.cfg = &optedIn
+	if cli.updateNoticeEnabled("env") {
+		t.Error("updateNoticeEnabled() = true for non-terminal output, want false")
+	}
+
+	optedIn.Quiet = true
+	if cli.updateNoticeEnabled("env") {
+		t.Error("updateNoticeEnabled() = true with --quiet, want false")
+	}
+}
+
+// slowCommand blocks until its context is done, like an operation stuck on the network.
+type slowCommand struct{}
+
+func (slowCommand) Name() string     { return "slow" }
+func (slowCommand) Synopsis() string { return "" }
+func (slowCommand) Usage() string    { return "" }
+
+func (slowCommand) Run(ctx context.Context, _ []string) error {
+	<-ctx.Done()
+	return fmt.Errorf("waiting for network: %w", ctx.Err())
+}
+
+func TestCLI_Run_Deadline(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	cfg := &config.Config{Deadline: 20 * time.Millisecond}
+	cli := NewCLI(cfg)
+	cli.out = ui.NewOutput(&out, &out, false)
+	cli.Register(slowCommand{})
+
+	done := make(chan int, 1)
+	go func() { done <- cli.Run(context.Background(), []string{"slow"}) }()
+
+	select {
+	case code := <-done:
+		if code != 1 {
+			t.Fatalf("exit code = %d, want 1", code)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("command was not cancelled by the deadline")
+	}
+	if got := out.String(); !strings.Contains(got, "timed out after 20ms (--deadline)") {
+		t.Fatalf("output = %q, want deadline error", got)
+	}
+}
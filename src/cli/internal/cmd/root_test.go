@@ -6,6 +6,7 @@ import (
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"altinn.studio/studioctl/internal/cmd"
 	"altinn.studio/studioctl/internal/config"
@@ -19,6 +20,7 @@ func TestParseGlobalFlags(t *testing.T) {
 		args        []string
 		wantArgs    []string
 		wantVerbose bool
+		wantNoColor bool
 	}{
 		{
 			name:        "no flags",
@@ -67,6 +69,13 @@ func TestParseGlobalFlags(t *testing.T) {
 			wantHome:    "/home",
 			wantVerbose: true,
 		},
+		{
+			name:        "no-color flag",
+			args:        []string{"studioctl", "--no-color", "env", "status"},
+			wantArgs:    []string{"env", "status"},
+			wantVerbose: false,
+			wantNoColor: true,
+		},
 		{
 			name:        "help flag preserved",
 			args:        []string{"studioctl", "--help"},
@@ -105,6 +114,10 @@ func TestParseGlobalFlags(t *testing.T) {
 				t.Errorf("Verbose = %v, want %v", flags.Verbose, tt.wantVerbose)
 			}
 
+			if flags.NoColor != tt.wantNoColor {
+				t.Errorf("NoColor = %v, want %v", flags.NoColor, tt.wantNoColor)
+			}
+
 			if !slices.Equal(args, tt.wantArgs) {
 				t.Errorf("args = %v, want %v", args, tt.wantArgs)
 			}
@@ -112,6 +125,23 @@ func TestParseGlobalFlags(t *testing.T) {
 	}
 }
 
+func TestParseGlobalFlags_Deadline(t *testing.T) {
+	oldArgs := os.Args
+	os.Args = []string{"studioctl", "--deadline", "90s", "env", "up", "--timing"}
+	defer func() { os.Args = oldArgs }()
+
+	flags, args, err := cmd.ParseGlobalFlags()
+	if err != nil {
+		t.Fatalf("ParseGlobalFlags() error = %v", err)
+	}
+	if flags.Deadline != 90*time.Second {
+		t.Errorf("Deadline = %s, want 90s", flags.Deadline)
+	}
+	if want := []string{"env", "up", "--timing"}; !slices.Equal(args, want) {
+		t.Errorf("args = %v, want %v", args, want)
+	}
+}
+
 func TestParseGlobalFlags_Errors(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -138,6 +168,16 @@ func TestParseGlobalFlags_Errors(t *testing.T) {
 			args:       []string{"studioctl", "--socket-dir", "--home=/tmp", "run"},
 			wantErrMsg: "flag --socket-dir requires a value, got flag --home=/tmp",
 		},
+		{
+			name:       "deadline flag not a duration",
+			args:       []string{"studioctl", "--deadline", "soon", "doctor"},
+			wantErrMsg: `invalid flag value: "soon"`,
+		},
+		{
+			name:       "deadline flag not positive",
+			args:       []string{"studioctl", "--deadline=0s", "doctor"},
+			wantErrMsg: `invalid flag value: "0s"`,
+		},
 	}
 
 	for _, tt := range tests {
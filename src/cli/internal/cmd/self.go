@@ -8,6 +8,7 @@ import (
 
 	selfsvc "altinn.studio/studioctl/internal/cmd/self"
 	"altinn.studio/studioctl/internal/config"
+	"altinn.studio/studioctl/internal/install"
 	"altinn.studio/studioctl/internal/osutil"
 	"altinn.studio/studioctl/internal/ui"
 )
@@ -78,19 +79,30 @@ func (c *SelfCommand) runInstall(ctx context.Context, args []string) error {
 Install %s binary to PATH and download localtest resources.
 
 Options:
-  --path DIR          Install binary to specific directory (non-interactive)
-  --skip-resources    Skip downloading localtest resources
-  -h, --help          Show this help message
+  --path DIR                Install binary to specific directory (non-interactive)
+  --skip-resources          Skip downloading localtest resources
+  --max-archive-size BYTES  Maximum resource archive size (default: %d, env: %s)
+  --max-file-size BYTES     Maximum size of a single resource file (default: %d, env: %s)
+  --restamp                 If the installed resources are intact but were installed by another
+                            version, mark them as current instead of downloading them again
+  -h, --help                Show this help message
 
 If --path is not specified, an interactive picker will prompt you to
 choose from detected installation locations.
-`, osutil.CurrentBin(), osutil.CurrentBin())
+`, osutil.CurrentBin(), osutil.CurrentBin(),
+			install.DefaultMaxArchiveSize, config.EnvInstallMaxArchiveSize,
+			install.DefaultMaxFileSize, config.EnvInstallMaxFileSize)
 	}
 
 	var targetPath string
 	var skipResources bool
+	var restamp bool
+	var limits selfsvc.ResourceLimits
 	fs.StringVar(&targetPath, "path", "", "Install to specific directory")
 	fs.BoolVar(&skipResources, "skip-resources", false, "Skip downloading localtest resources")
+	fs.Int64Var(&limits.MaxArchiveSize, "max-archive-size", 0, "Maximum resource archive size in bytes")
+	fs.Int64Var(&limits.MaxFileSize, "max-file-size", 0, "Maximum size of a single resource file in bytes")
+	fs.BoolVar(&restamp, "restamp", false, "Mark intact resources as current instead of reinstalling")
 
 	if err := fs.Parse(args); err != nil {
 		if errors.Is(err, flag.ErrHelp) {
@@ -99,6 +111,10 @@ choose from detected installation locations.
 		return fmt.Errorf("parsing flags: %w", err)
 	}
 
+	if err := validateResourceLimits(limits); err != nil {
+		return err
+	}
+
 	candidates := c.service.DetectCandidates()
 
 	if targetPath == "" {
@@ -112,7 +128,21 @@ choose from detected installation locations.
 		targetPath = selected
 	}
 
-	return c.performInstall(ctx, targetPath, candidates, skipResources)
+	return c.performInstall(ctx, targetPath, candidates, skipResources, restamp, limits)
+}
+
+func validateResourceLimits(limits selfsvc.ResourceLimits) error {
+	if limits.MaxArchiveSize != 0 {
+		if err := install.ValidateSizeLimit(limits.MaxArchiveSize); err != nil {
+			return fmt.Errorf("%w: --max-archive-size: %w", ErrInvalidFlagValue, err)
+		}
+	}
+	if limits.MaxFileSize != 0 {
+		if err := install.ValidateSizeLimit(limits.MaxFileSize); err != nil {
+			return fmt.Errorf("%w: --max-file-size: %w", ErrInvalidFlagValue, err)
+		}
+	}
+	return nil
 }
 
 func (c *SelfCommand) pickInstallLocation(ctx context.Context, candidates []selfsvc.Candidate) (string, error) {
@@ -139,6 +169,8 @@ func (c *SelfCommand) performInstall(
 	targetPath string,
 	candidates []selfsvc.Candidate,
 	skipResources bool,
+	restamp bool,
+	limits selfsvc.ResourceLimits,
 ) error {
 	c.out.Printf("Installing binary to %s...\n", targetPath)
 
@@ -161,7 +193,7 @@ func (c *SelfCommand) performInstall(
 	}
 
 	if !skipResources {
-		if err := c.installResources(ctx); err != nil {
+		if err := c.installResources(ctx, restamp, limits); err != nil {
 			return err
 		}
 	}
@@ -179,7 +211,7 @@ func (c *SelfCommand) handleNoWritableLocations() error {
 	return selfsvc.ErrNoWritableLocation
 }
 
-func (c *SelfCommand) installResources(ctx context.Context) error {
+func (c *SelfCommand) installResources(ctx context.Context, restamp bool, limits selfsvc.ResourceLimits) error {
 	c.out.Println("")
 
 	if c.service.ResourcesInstalled() {
@@ -187,12 +219,24 @@ func (c *SelfCommand) installResources(ctx context.Context) error {
 		return nil
 	}
 
+	if restamp {
+		err := c.service.RestampResources()
+		if err == nil {
+			c.out.Successf("Localtest resources marked as installed for %s.", c.cfg.Version)
+			return nil
+		}
+		if !errors.Is(err, install.ErrCannotRestamp) {
+			return fmt.Errorf("restamp resources: %w", err)
+		}
+		c.out.Infof("Reinstalling resources: %v", err)
+	}
+
 	spinner := ui.NewSpinner(c.out, "Installing localtest resources...")
 	if !c.cfg.Verbose {
 		spinner.Start()
 	}
 
-	result, err := c.service.InstallResources(ctx)
+	result, err := c.service.InstallResources(ctx, limits)
 	if err != nil {
 		spinner.StopWithError("Failed to install resources")
 		return fmt.Errorf("install resources: %w", err)
@@ -32,6 +32,13 @@ type InstallBinaryResult struct {
 	AlreadyInstalled bool
 }
 
+// ResourceLimits overrides size limits for resource archive extraction.
+// Zero values fall back to environment overrides or install defaults.
+type ResourceLimits struct {
+	MaxArchiveSize int64
+	MaxFileSize    int64
+}
+
 // InstallResourcesResult contains resources install outcome.
 type InstallResourcesResult struct {
 	ConfigError      error
@@ -79,7 +86,7 @@ func (s *Service) PathInstructions(dir string) string {
 }
 
 // InstallResources installs localtest resources if needed.
-func (s *Service) InstallResources(ctx context.Context) (InstallResourcesResult, error) {
+func (s *Service) InstallResources(ctx context.Context, limits ResourceLimits) (InstallResourcesResult, error) {
 	if s.ResourcesInstalled() {
 		return InstallResourcesResult{
 			ConfigError:      nil,
@@ -89,9 +96,11 @@ func (s *Service) InstallResources(ctx context.Context) (InstallResourcesResult,
 	}
 
 	opts := install.Options{
-		DataDir: s.dataDir,
-		Version: s.version,
-		Force:   false,
+		DataDir:        s.dataDir,
+		Version:        s.version,
+		MaxArchiveSize: limits.MaxArchiveSize,
+		MaxFileSize:    limits.MaxFileSize,
+		Force:          false,
 	}
 	if err := install.Install(ctx, opts); err != nil {
 		return InstallResourcesResult{}, fmt.Errorf("install resources: %w", err)
@@ -112,6 +121,16 @@ func (s *Service) InstallResources(ctx context.Context) (InstallResourcesResult,
 	}, nil
 }
 
+// RestampResources marks intact resources installed by another version as installed for the
+// current version, without downloading them. It returns install.ErrCannotRestamp when a full
+// install is needed.
+func (s *Service) RestampResources() error {
+	if err := install.Restamp(s.dataDir, s.version); err != nil {
+		return fmt.Errorf("restamp resources: %w", err)
+	}
+	return nil
+}
+
 // ResourcesInstalled reports whether localtest resources are already installed.
 func (s *Service) ResourcesInstalled() bool {
 	return install.IsInstalled(s.dataDir, s.version)
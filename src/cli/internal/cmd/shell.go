@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
@@ -12,17 +13,29 @@ import (
 	"altinn.studio/studioctl/internal/ui"
 )
 
-var errUnexpectedAliasStatus = errors.New("unexpected alias result status")
+var (
+	errUnexpectedAliasStatus = errors.New("unexpected alias result status")
+	errUnexpectedPathStatus  = errors.New("unexpected path result status")
+)
+
+// Exit codes for 'shell alias --check'. 1 remains the code for errors.
+const (
+	aliasCheckExitConfigured = 0
+	aliasCheckExitAbsent     = 2
+	aliasCheckExitConflict   = 3
+)
 
 // ShellCommand implements the 'shell' subcommand.
 type ShellCommand struct {
+	cfg     *config.Config
 	out     *ui.Output
 	service *shellsvc.Service
 }
 
 // NewShellCommand creates a new shell command.
-func NewShellCommand(_ *config.Config, out *ui.Output) *ShellCommand {
+func NewShellCommand(cfg *config.Config, out *ui.Output) *ShellCommand {
 	return &ShellCommand{
+		cfg:     cfg,
 		out:     out,
 		service: shellsvc.NewService(),
 	}
@@ -42,9 +55,10 @@ Configure shell integration for %s.
 
 Subcommands:
   alias    Configure a shell alias for %s
+  path     Add the %s bin directory to PATH
 
 Run '%s shell <subcommand> --help' for more information.
-`, osutil.CurrentBin(), osutil.CurrentBin(), osutil.CurrentBin(), osutil.CurrentBin())
+`, osutil.CurrentBin(), osutil.CurrentBin(), osutil.CurrentBin(), osutil.CurrentBin(), osutil.CurrentBin())
 }
 
 // Run executes the command.
@@ -60,6 +74,8 @@ func (c *ShellCommand) Run(ctx context.Context, args []string) error {
 	switch subCmd {
 	case "alias":
 		return c.runAlias(ctx, subArgs)
+	case "path":
+		return c.runPath(ctx, subArgs)
 	case "-h", flagHelp, helpSubcmd:
 		c.out.Print(c.Usage())
 		return nil
@@ -72,6 +88,10 @@ type aliasFlags struct {
 	aliasName string
 	shell     string
 	dryRun    bool
+	printPath bool
+	update    bool
+	check     bool
+	json      bool
 }
 
 func (c *ShellCommand) runAlias(ctx context.Context, args []string) error {
@@ -84,18 +104,106 @@ func (c *ShellCommand) runAlias(ctx context.Context, args []string) error {
 		return nil
 	}
 
+	if flags.printPath {
+		resolved, err := c.service.ResolveConfigPath(ctx, flags.shell)
+		if err != nil {
+			return fmt.Errorf("resolve shell config path: %w", err)
+		}
+		c.warnGuessedPath(resolved.Guessed, resolved.Path)
+		c.out.Println(resolved.Path)
+		return nil
+	}
+	if flags.check {
+		return c.runAliasCheck(ctx, flags)
+	}
+	if flags.json {
+		return fmt.Errorf("%w: --json requires --check", ErrInvalidFlagValue)
+	}
+
 	result, err := c.service.ConfigureAlias(ctx, shellsvc.AliasOptions{
 		AliasName: flags.aliasName,
 		Shell:     flags.shell,
 		DryRun:    flags.dryRun,
+		Update:    flags.update,
 	})
 	if err != nil {
 		return fmt.Errorf("configure alias: %w", err)
 	}
 
+	c.warnGuessedPath(result.ConfigPathGuessed, result.ConfigPath)
 	return c.renderAliasResult(flags.aliasName, result)
 }
 
+// runAliasCheck reports the alias state without modifying files. The exit code
+// tells the states apart for scripts: see the aliasCheckExit constants.
+func (c *ShellCommand) runAliasCheck(ctx context.Context, flags aliasFlags) error {
+	result, err := c.service.CheckAlias(ctx, shellsvc.AliasOptions{
+		AliasName: flags.aliasName,
+		Shell:     flags.shell,
+		DryRun:    false,
+		Update:    false,
+	})
+	if err != nil {
+		return fmt.Errorf("check alias: %w", err)
+	}
+
+	var code int
+	switch result.Status {
+	case shellsvc.AliasStatusAlreadyConfigured:
+		code = aliasCheckExitConfigured
+	case shellsvc.AliasStatusAbsent:
+		code = aliasCheckExitAbsent
+	case shellsvc.AliasStatusConflict:
+		code = aliasCheckExitConflict
+	default:
+		return fmt.Errorf("%w: %q", errUnexpectedAliasStatus, result.Status)
+	}
+
+	if flags.json {
+		payload, err := json.Marshal(map[string]any{
+			"status":       result.Status,
+			"shell":        result.Shell,
+			"configPath":   result.ConfigPath,
+			"aliasLine":    result.AliasLine,
+			"existingLine": result.ExistingLine,
+			"exitCode":     code,
+		})
+		if err != nil {
+			return fmt.Errorf("marshal alias check json: %w", err)
+		}
+		c.out.Printf("%s\n", payload)
+	} else {
+		c.warnGuessedPath(result.ConfigPathGuessed, result.ConfigPath)
+		c.renderAliasCheck(flags.aliasName, result)
+	}
+
+	if code != aliasCheckExitConfigured {
+		return &ExitError{Err: nil, Code: code}
+	}
+	return nil
+}
+
+func (c *ShellCommand) renderAliasCheck(aliasName string, result shellsvc.AliasResult) {
+	switch result.Status {
+	case shellsvc.AliasStatusAlreadyConfigured:
+		c.out.Success(fmt.Sprintf("Alias '%s' is configured in %s", aliasName, result.ConfigPath))
+	case shellsvc.AliasStatusConflict:
+		c.out.Warning(fmt.Sprintf("Alias '%s' in %s has a different value:", aliasName, result.ConfigPath))
+		c.out.Printf("  Existing: %s\n", result.ExistingLine)
+		c.out.Printf("  Expected: %s\n", result.AliasLine)
+	default:
+		c.out.Printf("Alias '%s' is not configured in %s\n", aliasName, result.ConfigPath)
+	}
+}
+
+func (c *ShellCommand) warnGuessedPath(guessed bool, path string) {
+	if !guessed {
+		return
+	}
+	c.out.Warning("Could not run pwsh or powershell to read $PROFILE; using the default profile path " + path)
+	c.out.Warning("Use --print-path to check it, or add the alias to your profile manually")
+}
+
 func (c *ShellCommand) aliasUsage() string {
 	return fmt.Sprintf(`Usage: %s shell alias [options]
 
@@ -105,8 +213,18 @@ Options:
   -a, --alias NAME   Alias name (default: "s")
   -s, --shell SHELL  Shell type: bash, zsh, fish, powershell (auto-detected if not specified)
   --dry-run          Print what would be added without modifying files
+  --print-path       Print the resolved shell config file and exit
+  --update           Rewrite an existing studioctl alias with the same name (e.g. after reinstalling)
+  --check            Report whether the alias is configured without modifying files
+  --json             Output the --check result as JSON
   -h                 Show this help
 
+Exit codes with --check:
+  0  alias is configured
+  1  the check failed
+  2  alias is absent
+  3  alias exists with a different value
+
 Supported shells:
   bash        ~/.bashrc
   zsh         ~/.zshrc
@@ -118,7 +236,9 @@ Examples:
   %s shell alias -a studio    # Use 'studio' as alias name
   %s shell alias --dry-run    # Preview changes without modifying files
   %s shell alias -s zsh       # Force zsh shell type
-`, osutil.CurrentBin(), osutil.CurrentBin(), osutil.CurrentBin(), osutil.CurrentBin(), osutil.CurrentBin(), osutil.CurrentBin())
+  %s shell alias --print-path # Show which file the alias would be added to
+`, osutil.CurrentBin(), osutil.CurrentBin(), osutil.CurrentBin(), osutil.CurrentBin(), osutil.CurrentBin(),
+		osutil.CurrentBin(), osutil.CurrentBin())
 }
 
 func (c *ShellCommand) parseAliasFlags(args []string) (aliasFlags, bool, error) {
@@ -127,6 +247,10 @@ func (c *ShellCommand) parseAliasFlags(args []string) (aliasFlags, bool, error)
 		aliasName: "s",
 		shell:     "",
 		dryRun:    false,
+		printPath: false,
+		update:    false,
+		check:     false,
+		json:      false,
 	}
 
 	fs.StringVar(&f.aliasName, "a", "s", "Alias name")
@@ -134,6 +258,10 @@ func (c *ShellCommand) parseAliasFlags(args []string) (aliasFlags, bool, error)
 	fs.StringVar(&f.shell, "s", "", "Shell type")
 	fs.StringVar(&f.shell, "shell", "", "Shell type")
 	fs.BoolVar(&f.dryRun, "dry-run", false, "Preview changes")
+	fs.BoolVar(&f.printPath, "print-path", false, "Print the shell config path")
+	fs.BoolVar(&f.update, "update", false, "Rewrite an existing alias with the same name")
+	fs.BoolVar(&f.check, "check", false, "Report alias state without modifying files")
+	fs.BoolVar(&f.json, "json", false, "Output as JSON")
 
 	if err := fs.Parse(args); err != nil {
 		if errors.Is(err, flag.ErrHelp) {
@@ -159,7 +287,19 @@ func (c *ShellCommand) renderAliasResult(aliasName string, result shellsvc.Alias
 		c.out.Warning(fmt.Sprintf("Alias '%s' already exists with different value:", aliasName))
 		c.out.Printf("  Existing: %s\n", result.ExistingLine)
 		c.out.Printf("  New:      %s\n", result.AliasLine)
-		c.out.Println("Remove the existing alias manually if you want to update it.")
+		if result.ExistingForeign {
+			c.out.Println("The existing alias is not a studioctl alias; remove it or pick another name with -a.")
+			return nil
+		}
+		c.out.Printf("Run '%s shell alias --update' to replace it.\n", osutil.CurrentBin())
+		return nil
+	case shellsvc.AliasStatusUpdated:
+		c.out.Success(fmt.Sprintf("Updated alias '%s' in %s", aliasName, result.ConfigPath))
+		c.out.Printf("  Old: %s\n", result.ExistingLine)
+		c.out.Printf("  New: %s\n", result.AliasLine)
+		c.out.Println("")
+		c.out.Println("To use the updated alias, reload your shell configuration:")
+		c.out.Printf("  %s\n", result.ReloadCommand)
 		return nil
 	case shellsvc.AliasStatusAdded:
 		c.out.Success(fmt.Sprintf("Added alias '%s' to %s", aliasName, result.ConfigPath))
@@ -171,3 +311,79 @@ func (c *ShellCommand) renderAliasResult(aliasName string, result shellsvc.Alias
 		return fmt.Errorf("%w: %q", errUnexpectedAliasStatus, result.Status)
 	}
 }
+
+type pathFlags struct {
+	shell  string
+	dryRun bool
+}
+
+func (c *ShellCommand) runPath(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("shell path", flag.ContinueOnError)
+	f := pathFlags{shell: "", dryRun: false}
+	fs.StringVar(&f.shell, "s", "", "Shell type")
+	fs.StringVar(&f.shell, "shell", "", "Shell type")
+	fs.BoolVar(&f.dryRun, "dry-run", false, "Preview changes")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			c.out.Print(c.pathUsage())
+			return nil
+		}
+		return fmt.Errorf("parsing flags: %w", err)
+	}
+
+	result, err := c.service.ConfigurePath(ctx, shellsvc.PathOptions{
+		BinDir: c.cfg.BinDir,
+		Shell:  f.shell,
+		DryRun: f.dryRun,
+	})
+	if err != nil {
+		return fmt.Errorf("configure path: %w", err)
+	}
+
+	c.warnGuessedPath(result.ConfigPathGuessed, result.ConfigPath)
+	return c.renderPathResult(result)
+}
+
+func (c *ShellCommand) pathUsage() string {
+	return fmt.Sprintf(`Usage: %s shell path [options]
+
+Add the %s bin directory (%s) to PATH in your shell configuration.
+
+Options:
+  -s, --shell SHELL  Shell type: bash, zsh, fish, powershell (auto-detected if not specified)
+  --dry-run          Print what would be added without modifying files
+  -h                 Show this help
+
+Examples:
+  %s shell path              # Add the bin directory for the detected shell
+  %s shell path --dry-run    # Preview changes without modifying files
+`, osutil.CurrentBin(), osutil.CurrentBin(), c.cfg.BinDir, osutil.CurrentBin(), osutil.CurrentBin())
+}
+
+func (c *ShellCommand) renderPathResult(result shellsvc.PathResult) error {
+	switch result.Status {
+	case shellsvc.PathStatusDryRun:
+		c.out.Printf("Shell:       %s\n", result.Shell)
+		c.out.Printf("Config file: %s\n", result.ConfigPath)
+		c.out.Printf("PATH line:   %s\n", result.PathLine)
+		return nil
+	case shellsvc.PathStatusAlreadyConfigured:
+		c.out.Success("PATH already configured in " + result.ConfigPath)
+		return nil
+	case shellsvc.PathStatusConflict:
+		c.out.Warning("A PATH entry for " + osutil.CurrentBin() + " already exists with a different directory:")
+		c.out.Printf("  Existing: %s\n", result.ExistingLine)
+		c.out.Printf("  New:      %s\n", result.PathLine)
+		c.out.Println("Remove the existing line manually if you want to update it.")
+		return nil
+	case shellsvc.PathStatusAdded:
+		c.out.Success(fmt.Sprintf("Added %s to PATH in %s", c.cfg.BinDir, result.ConfigPath))
+		c.out.Println("")
+		c.out.Println("To update PATH, reload your shell configuration:")
+		c.out.Printf("  %s\n", result.ReloadCommand)
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", errUnexpectedPathStatus, result.Status)
+	}
+}
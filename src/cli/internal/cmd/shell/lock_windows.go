@@ -0,0 +1,27 @@
+//go:build windows
+
+package shell
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockFile blocks until it holds an exclusive lock on file. Windows byte-range locks are
+// mandatory, so the lock covers a single byte far past the end of the file; readers of the
+// actual contents are never blocked.
+func lockFile(file *os.File) error {
+	return windows.LockFileEx(
+		windows.Handle(file.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK, 0, 1, 0, lockRange(),
+	)
+}
+
+func unlockFile(file *os.File) error {
+	return windows.UnlockFileEx(windows.Handle(file.Fd()), 0, 1, 0, lockRange())
+}
+
+func lockRange() *windows.Overlapped {
+	//nolint:exhaustruct // only the offset matters for a synchronous lock
+	return &windows.Overlapped{Offset: ^uint32(0), OffsetHigh: ^uint32(0) >> 1}
+}
@@ -0,0 +1,151 @@
+package shell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// pathLineMarker tags the PATH line so it can be found again, including after
+// the bin directory has moved. All supported shells use # for comments.
+const pathLineMarker = "# studioctl bin dir"
+
+// ErrInvalidBinDir indicates a bin directory that cannot be written to a shell config.
+var ErrInvalidBinDir = errors.New("invalid bin directory")
+
+// PathStatus describes the result state of PATH configuration.
+type PathStatus string
+
+const (
+	// PathStatusDryRun indicates dry-run mode where no file mutation was made.
+	PathStatusDryRun PathStatus = "dry_run"
+	// PathStatusAlreadyConfigured indicates the PATH line already matches.
+	PathStatusAlreadyConfigured PathStatus = "already_configured"
+	// PathStatusConflict indicates a studioctl PATH line exists for another directory.
+	PathStatusConflict PathStatus = "conflict"
+	// PathStatusAdded indicates the PATH line was appended to shell config.
+	PathStatusAdded PathStatus = "added"
+)
+
+// PathOptions contains inputs for PATH configuration.
+type PathOptions struct {
+	BinDir string
+	Shell  string
+	DryRun bool
+}
+
+// PathResult describes the computed or applied PATH outcome.
+type PathResult struct {
+	PathLine      string
+	ConfigPath    string
+	ExistingLine  string
+	ReloadCommand string
+	Shell         string
+	Status        PathStatus
+	// ConfigPathGuessed is set when the PowerShell profile could not be queried
+	// and ConfigPath is the default location instead.
+	ConfigPathGuessed bool
+}
+
+// ConfigurePath resolves and optionally applies a shell config line that adds
+// the bin directory to PATH.
+func (s *Service) ConfigurePath(ctx context.Context, opts PathOptions) (PathResult, error) {
+	if opts.BinDir == "" || strings.ContainsAny(opts.BinDir, "\r\n") {
+		return PathResult{}, fmt.Errorf("%w: %q", ErrInvalidBinDir, opts.BinDir)
+	}
+
+	resolved, err := s.ResolveConfigPath(ctx, opts.Shell)
+	if err != nil {
+		return PathResult{}, err
+	}
+	shell, configPath := resolved.Shell, resolved.Path
+
+	result := PathResult{
+		PathLine:          FormatPathLine(shell, opts.BinDir),
+		ConfigPath:        configPath,
+		ExistingLine:      "",
+		ReloadCommand:     "",
+		Shell:             shell,
+		Status:            "",
+		ConfigPathGuessed: resolved.Guessed,
+	}
+
+	if opts.DryRun {
+		result.Status = PathStatusDryRun
+		return result, nil
+	}
+
+	exists, existingLine, err := pathExists(configPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return PathResult{}, fmt.Errorf("checking existing PATH entry: %w", err)
+	}
+	if exists {
+		return resolveExistingPath(result, existingLine), nil
+	}
+
+	if err := ensureConfigFileExists(configPath); err != nil {
+		return PathResult{}, fmt.Errorf("creating config file: %w", err)
+	}
+	existingLine, err = appendLine(configPath, result.PathLine, isPathLine)
+	if err != nil {
+		return PathResult{}, fmt.Errorf("writing PATH entry to %s: %w", configPath, err)
+	}
+	if existingLine != "" {
+		// Another run added the entry after the check above.
+		return resolveExistingPath(result, existingLine), nil
+	}
+
+	result.ReloadCommand = getReloadCommand(shell, configPath)
+	result.Status = PathStatusAdded
+	return result, nil
+}
+
+// FormatPathLine creates a shell-specific line that prepends binDir to PATH.
+func FormatPathLine(shell, binDir string) string {
+	switch shell {
+	case shellBash, shellZsh:
+		return fmt.Sprintf(`export PATH=%s:"$PATH" %s`, quotePOSIXSingle(binDir), pathLineMarker)
+	case shellFish:
+		return fmt.Sprintf("fish_add_path %s %s", quotePOSIXSingle(binDir), pathLineMarker)
+	case shellPowerShell:
+		return fmt.Sprintf(
+			"$env:Path = %s + [IO.Path]::PathSeparator + $env:Path %s",
+			quotePowerShellSingle(binDir),
+			pathLineMarker,
+		)
+	default:
+		return ""
+	}
+}
+
+func pathExists(configPath string) (bool, string, error) {
+	//nolint:gosec // path is constructed from known safe sources
+	file, err := os.Open(configPath)
+	if err != nil {
+		return false, "", fmt.Errorf("opening config file: %w", err)
+	}
+	defer file.Close() //nolint:errcheck // best-effort close on read
+
+	line, err := findLine(file, isPathLine)
+	if err != nil {
+		return false, "", err
+	}
+	return line != "", line, nil
+}
+
+func isPathLine(line string) bool {
+	return strings.HasSuffix(line, " "+pathLineMarker)
+}
+
+// resolveExistingPath reports a PATH entry already present in the config.
+func resolveExistingPath(result PathResult, existingLine string) PathResult {
+	result.ExistingLine = existingLine
+	if existingLine == result.PathLine {
+		result.Status = PathStatusAlreadyConfigured
+	} else {
+		result.Status = PathStatusConflict
+	}
+	return result
+}
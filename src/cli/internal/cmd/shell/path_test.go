@@ -0,0 +1,94 @@
+package shell_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	shellsvc "altinn.studio/studioctl/internal/cmd/shell"
+)
+
+func TestFormatPathLine(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		shell  string
+		binDir string
+		want   string
+	}{
+		{
+			shell:  "bash",
+			binDir: "/home/me/.altinn-studio/bin",
+			want:   `export PATH='/home/me/.altinn-studio/bin':"$PATH" # studioctl bin dir`,
+		},
+		{
+			shell:  "zsh",
+			binDir: "/home/o'neil/bin",
+			want:   `export PATH='/home/o'"'"'neil/bin':"$PATH" # studioctl bin dir`,
+		},
+		{
+			shell:  "fish",
+			binDir: "/home/me/.altinn-studio/bin",
+			want:   `fish_add_path '/home/me/.altinn-studio/bin' # studioctl bin dir`,
+		},
+		{
+			shell:  "powershell",
+			binDir: `C:\Users\o'neil\.altinn-studio\bin`,
+			want:   `$env:Path = 'C:\Users\o''neil\.altinn-studio\bin' + [IO.Path]::PathSeparator + $env:Path # studioctl bin dir`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			t.Parallel()
+			if got := shellsvc.FormatPathLine(tt.shell, tt.binDir); got != tt.want {
+				t.Fatalf("FormatPathLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// Tests that use t.Setenv cannot use t.Parallel.
+func TestConfigurePath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	configPath := filepath.Join(home, ".config", "fish", "config.fish")
+	service := shellsvc.NewService()
+	configure := func(binDir string) shellsvc.PathResult {
+		t.Helper()
+		result, err := service.ConfigurePath(context.Background(), shellsvc.PathOptions{
+			BinDir: binDir,
+			Shell:  "fish",
+			DryRun: false,
+		})
+		if err != nil {
+			t.Fatalf("ConfigurePath() error = %v", err)
+		}
+		return result
+	}
+
+	binDir := filepath.Join(home, ".altinn-studio", "bin")
+	if got := configure(binDir).Status; got != shellsvc.PathStatusAdded {
+		t.Fatalf("first run Status = %q, want %q", got, shellsvc.PathStatusAdded)
+	}
+	if got := configure(binDir).Status; got != shellsvc.PathStatusAlreadyConfigured {
+		t.Fatalf("second run Status = %q, want %q", got, shellsvc.PathStatusAlreadyConfigured)
+	}
+	moved := configure(filepath.Join(home, "elsewhere", "bin"))
+	if moved.Status != shellsvc.PathStatusConflict {
+		t.Fatalf("moved bin dir Status = %q, want %q", moved.Status, shellsvc.PathStatusConflict)
+	}
+	if want := shellsvc.FormatPathLine("fish", binDir); moved.ExistingLine != want {
+		t.Fatalf("ExistingLine = %q, want %q", moved.ExistingLine, want)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	if n := strings.Count(string(data), "fish_add_path"); n != 1 {
+		t.Fatalf("config has %d PATH lines, want 1:\n%s", n, data)
+	}
+}
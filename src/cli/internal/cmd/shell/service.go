@@ -3,9 +3,11 @@ package shell
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -24,7 +26,8 @@ const (
 	shellFish       = "fish"
 	shellPowerShell = "powershell"
 
-	powerShellProfileTimeout = 5 * time.Second
+	powerShellProfileTimeout  = 5 * time.Second
+	powerShellProfileAttempts = 2
 )
 
 var (
@@ -34,6 +37,8 @@ var (
 	ErrBinaryPath = errors.New("cannot determine binary path")
 	// ErrInvalidAliasName indicates invalid shell alias identifier syntax.
 	ErrInvalidAliasName = errors.New("invalid alias name")
+
+	errLineNotFound = errors.New("line not found")
 )
 
 // AliasStatus describes the result state of alias configuration.
@@ -48,6 +53,10 @@ const (
 	AliasStatusConflict AliasStatus = "conflict"
 	// AliasStatusAdded indicates alias line was appended to shell config.
 	AliasStatusAdded AliasStatus = "added"
+	// AliasStatusUpdated indicates a conflicting alias line was rewritten in place.
+	AliasStatusUpdated AliasStatus = "updated"
+	// AliasStatusAbsent indicates no alias with the name exists (reported by CheckAlias).
+	AliasStatusAbsent AliasStatus = "absent"
 )
 
 // AliasOptions contains inputs for alias configuration.
@@ -55,6 +64,9 @@ type AliasOptions struct {
 	AliasName string
 	Shell     string
 	DryRun    bool
+	// Update rewrites an existing alias with the same name instead of reporting a conflict,
+	// if that alias points at a studioctl binary.
+	Update bool
 }
 
 // AliasResult describes the computed or applied alias outcome.
@@ -65,50 +77,70 @@ type AliasResult struct {
 	ReloadCommand string
 	Shell         string
 	Status        AliasStatus
+	// ConfigPathGuessed is set when the PowerShell profile could not be queried
+	// and ConfigPath is the default location instead.
+	ConfigPathGuessed bool
+	// ExistingForeign is set when ExistingLine points at a program other than studioctl.
+	// Update never rewrites such an alias.
+	ExistingForeign bool
+}
+
+// ConfigPath is a resolved shell configuration file.
+type ConfigPath struct {
+	Path  string
+	Shell string
+	// Guessed is set when the PowerShell profile could not be queried
+	// and Path is the default location instead.
+	Guessed bool
 }
 
+// CommandRunner runs an external command and returns its standard output.
+type CommandRunner func(ctx context.Context, name string, args ...string) ([]byte, error)
+
 // Service contains shell application logic.
-type Service struct{}
+type Service struct {
+	runCommand CommandRunner
+}
 
 // NewService creates a new shell service.
 func NewService() *Service {
-	return &Service{}
+	return NewServiceWithRunner(runCommand)
 }
 
-// ConfigureAlias resolves and optionally applies shell alias configuration.
-func (s *Service) ConfigureAlias(ctx context.Context, opts AliasOptions) (AliasResult, error) {
-	binaryPath, err := getBinaryPath()
-	if err != nil {
-		return AliasResult{}, fmt.Errorf("%w: %w", ErrBinaryPath, err)
-	}
+// NewServiceWithRunner creates a shell service that runs external commands with run.
+func NewServiceWithRunner(run CommandRunner) *Service {
+	return &Service{runCommand: run}
+}
 
-	shell, err := resolveShell(opts.Shell)
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	output, err := exec.CommandContext(ctx, name, args...).Output()
 	if err != nil {
-		return AliasResult{}, err
+		return nil, fmt.Errorf("running %s: %w", name, err)
 	}
+	return output, nil
+}
 
-	configPath, err := getShellConfigPath(ctx, shell)
+// ResolveConfigPath returns the configuration file for the given shell, or the
+// detected shell when override is empty.
+func (s *Service) ResolveConfigPath(ctx context.Context, override string) (ConfigPath, error) {
+	shell, err := resolveShell(override)
 	if err != nil {
-		return AliasResult{}, err
+		return ConfigPath{}, err
 	}
-
-	validateErr := ValidateAliasName(opts.AliasName)
-	if validateErr != nil {
-		return AliasResult{}, validateErr
-	}
-	if strings.ContainsAny(binaryPath, "\r\n") {
-		return AliasResult{}, fmt.Errorf("%w: binary path contains newline", ErrBinaryPath)
+	path, guessed, err := s.getShellConfigPath(ctx, shell)
+	if err != nil {
+		return ConfigPath{}, err
 	}
+	return ConfigPath{Path: path, Shell: shell, Guessed: guessed}, nil
+}
 
-	aliasLine := FormatAliasLine(shell, opts.AliasName, binaryPath)
-	result := AliasResult{
-		AliasLine:     aliasLine,
-		ConfigPath:    configPath,
-		ExistingLine:  "",
-		ReloadCommand: "",
-		Shell:         shell,
-		Status:        "",
+// ConfigureAlias resolves and optionally applies shell alias configuration.
+func (s *Service) ConfigureAlias(ctx context.Context, opts AliasOptions) (AliasResult, error) {
+	result, err := s.planAlias(ctx, opts)
+	if err != nil {
+		return AliasResult{}, err
 	}
+	shell, configPath, aliasLine := result.Shell, result.ConfigPath, result.AliasLine
 
 	if opts.DryRun {
 		result.Status = AliasStatusDryRun
@@ -116,31 +148,109 @@ func (s *Service) ConfigureAlias(ctx context.Context, opts AliasOptions) (AliasR
 	}
 
 	exists, existingLine, err := aliasExists(configPath, opts.AliasName, shell)
-	if err != nil && !os.IsNotExist(err) {
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
 		return AliasResult{}, fmt.Errorf("checking existing alias: %w", err)
 	}
 	if exists {
-		result.ExistingLine = existingLine
-		if existingLine == aliasLine {
-			result.Status = AliasStatusAlreadyConfigured
-			return result, nil
-		}
-		result.Status = AliasStatusConflict
-		return result, nil
+		return resolveExistingAlias(result, opts.AliasName, existingLine, opts.Update)
 	}
 
 	if err := ensureConfigFileExists(configPath); err != nil {
 		return AliasResult{}, fmt.Errorf("creating config file: %w", err)
 	}
-	if err := appendToFile(configPath, aliasLine); err != nil {
+	existingLine, err = appendLine(configPath, aliasLine, aliasMatcher(shell, opts.AliasName))
+	if err != nil {
 		return AliasResult{}, fmt.Errorf("writing alias to %s: %w", configPath, err)
 	}
+	if existingLine != "" {
+		// Another run added the alias after the check above.
+		return resolveExistingAlias(result, opts.AliasName, existingLine, opts.Update)
+	}
 
 	result.ReloadCommand = getReloadCommand(shell, configPath)
 	result.Status = AliasStatusAdded
 	return result, nil
 }
 
+// resolveExistingAlias reports an alias already present in the config, rewriting it when update
+// is set and the alias points at a studioctl binary.
+func resolveExistingAlias(result AliasResult, aliasName, existingLine string, update bool) (AliasResult, error) {
+	result.ExistingLine = existingLine
+	if existingLine == result.AliasLine {
+		result.Status = AliasStatusAlreadyConfigured
+		return result, nil
+	}
+	result.ExistingForeign = !isStudioctlBinary(aliasTarget(result.Shell, aliasName, existingLine))
+	if !update || result.ExistingForeign {
+		result.Status = AliasStatusConflict
+		return result, nil
+	}
+	if err := replaceLine(result.ConfigPath, existingLine, result.AliasLine); err != nil {
+		return AliasResult{}, fmt.Errorf("updating alias in %s: %w", result.ConfigPath, err)
+	}
+	result.ReloadCommand = getReloadCommand(result.Shell, result.ConfigPath)
+	result.Status = AliasStatusUpdated
+	return result, nil
+}
+
+// CheckAlias reports whether the alias is configured, in conflict or absent.
+// It only reads the shell config; DryRun and Update are ignored.
+func (s *Service) CheckAlias(ctx context.Context, opts AliasOptions) (AliasResult, error) {
+	result, err := s.planAlias(ctx, opts)
+	if err != nil {
+		return AliasResult{}, err
+	}
+
+	exists, existingLine, err := aliasExists(result.ConfigPath, opts.AliasName, result.Shell)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return AliasResult{}, fmt.Errorf("checking existing alias: %w", err)
+	}
+	result.ExistingLine = existingLine
+	switch {
+	case !exists:
+		result.Status = AliasStatusAbsent
+	case existingLine == result.AliasLine:
+		result.Status = AliasStatusAlreadyConfigured
+	default:
+		result.Status = AliasStatusConflict
+		result.ExistingForeign = !isStudioctlBinary(aliasTarget(result.Shell, opts.AliasName, existingLine))
+	}
+	return result, nil
+}
+
+// planAlias resolves the shell, config file and alias line without reading the config.
+func (s *Service) planAlias(ctx context.Context, opts AliasOptions) (AliasResult, error) {
+	binaryPath, err := BinaryPath()
+	if err != nil {
+		return AliasResult{}, fmt.Errorf("%w: %w", ErrBinaryPath, err)
+	}
+
+	resolved, err := s.ResolveConfigPath(ctx, opts.Shell)
+	if err != nil {
+		return AliasResult{}, err
+	}
+	shell, configPath := resolved.Shell, resolved.Path
+
+	validateErr := ValidateAliasName(opts.AliasName)
+	if validateErr != nil {
+		return AliasResult{}, validateErr
+	}
+	if strings.ContainsAny(binaryPath, "\r\n") {
+		return AliasResult{}, fmt.Errorf("%w: binary path contains newline", ErrBinaryPath)
+	}
+
+	return AliasResult{
+		AliasLine:         FormatAliasLine(shell, opts.AliasName, binaryPath),
+		ConfigPath:        configPath,
+		ExistingLine:      "",
+		ReloadCommand:     "",
+		Shell:             shell,
+		Status:            "",
+		ConfigPathGuessed: resolved.Guessed,
+		ExistingForeign:   false,
+	}, nil
+}
+
 func resolveShell(override string) (string, error) {
 	if override != "" {
 		shell := strings.ToLower(override)
@@ -157,12 +267,19 @@ func resolveShell(override string) (string, error) {
 	return shell, nil
 }
 
-func getBinaryPath() (string, error) {
+// BinaryPath returns the path of the running binary with symlinks resolved.
+func BinaryPath() (string, error) {
 	exe, err := os.Executable()
 	if err != nil {
 		return "", fmt.Errorf("getting executable path: %w", err)
 	}
-	resolved, err := filepath.EvalSymlinks(exe)
+	return ResolveBinaryPath(exe)
+}
+
+// ResolveBinaryPath resolves symlinks in path, so installs reached through a link
+// compare equal to their target.
+func ResolveBinaryPath(path string) (string, error) {
+	resolved, err := filepath.EvalSymlinks(path)
 	if err != nil {
 		return "", fmt.Errorf("resolving symlinks: %w", err)
 	}
@@ -200,48 +317,69 @@ func isValidShell(shell string) bool {
 	}
 }
 
-func getShellConfigPath(ctx context.Context, shell string) (string, error) {
+// getShellConfigPath returns the config file for shell and whether the path was guessed.
+func (s *Service) getShellConfigPath(ctx context.Context, shell string) (string, bool, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
-		return "", fmt.Errorf("getting home directory: %w", err)
+		return "", false, fmt.Errorf("getting home directory: %w", err)
 	}
 
 	switch shell {
 	case shellBash:
-		return filepath.Join(home, ".bashrc"), nil
+		return filepath.Join(home, ".bashrc"), false, nil
 	case shellZsh:
-		return filepath.Join(home, ".zshrc"), nil
+		return filepath.Join(home, ".zshrc"), false, nil
 	case shellFish:
-		return filepath.Join(home, ".config", "fish", "config.fish"), nil
+		return filepath.Join(home, ".config", "fish", "config.fish"), false, nil
 	case shellPowerShell:
-		return getPowerShellProfilePath(ctx)
+		return s.getPowerShellProfilePath(ctx, home)
 	default:
-		return "", fmt.Errorf("%w: %s", ErrUnsupportedShell, shell)
+		return "", false, fmt.Errorf("%w: %s", ErrUnsupportedShell, shell)
 	}
 }
 
-func getPowerShellProfilePath(ctx context.Context) (string, error) {
-	ctx, cancel := context.WithTimeout(ctx, powerShellProfileTimeout)
-	defer cancel()
-
-	if output, err := exec.CommandContext(ctx, "pwsh", "-NoProfile", "-Command", "echo $PROFILE").Output(); err == nil {
-		if profile := strings.TrimSpace(string(output)); profile != "" {
-			return profile, nil
+// getPowerShellProfilePath asks pwsh, then Windows PowerShell, for $PROFILE.
+// The default profile location is returned as guessed only when neither could
+// be run; a probe that runs but prints nothing is not treated as a failure.
+func (s *Service) getPowerShellProfilePath(ctx context.Context, home string) (string, bool, error) {
+	probes := []string{"pwsh", "powershell"}
+	failed := 0
+	for _, name := range probes {
+		profile, err := s.probePowerShellProfile(ctx, name)
+		if err != nil {
+			failed++
+			continue
+		}
+		if profile != "" {
+			return profile, false, nil
 		}
 	}
 
-	if output, err := exec.CommandContext(ctx, "powershell", "-NoProfile", "-Command", "echo $PROFILE").
-		Output(); err == nil {
-		if profile := strings.TrimSpace(string(output)); profile != "" {
-			return profile, nil
+	guessed := failed == len(probes)
+	return filepath.Join(home, "Documents", "PowerShell", "Microsoft.PowerShell_profile.ps1"), guessed, nil
+}
+
+// probePowerShellProfile runs name to print $PROFILE, retrying failures that
+// may be transient such as a slow first start hitting the timeout.
+func (s *Service) probePowerShellProfile(ctx context.Context, name string) (string, error) {
+	var err error
+	for range powerShellProfileAttempts {
+		var output []byte
+		output, err = s.runPowerShell(ctx, name)
+		if err == nil {
+			return strings.TrimSpace(string(output)), nil
+		}
+		if errors.Is(err, exec.ErrNotFound) || ctx.Err() != nil {
+			break
 		}
 	}
+	return "", err
+}
 
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return "", fmt.Errorf("getting home directory: %w", err)
-	}
-	return filepath.Join(home, "Documents", "PowerShell", "Microsoft.PowerShell_profile.ps1"), nil
+func (s *Service) runPowerShell(ctx context.Context, name string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, powerShellProfileTimeout)
+	defer cancel()
+	return s.runCommand(ctx, name, "-NoProfile", "-Command", "echo $PROFILE")
 }
 
 // ValidateAliasName verifies the alias identifier format.
@@ -313,6 +451,15 @@ func aliasExists(configPath, aliasName, shell string) (bool, string, error) {
 	}
 	defer file.Close() //nolint:errcheck // best-effort close on read
 
+	line, err := findLine(file, aliasMatcher(shell, aliasName))
+	if err != nil {
+		return false, "", err
+	}
+	return line != "", line, nil
+}
+
+// aliasMatcher matches a trimmed config line defining aliasName in shell.
+func aliasMatcher(shell, aliasName string) func(string) bool {
 	var prefix string
 	switch shell {
 	case shellBash, shellZsh:
@@ -322,19 +469,66 @@ func aliasExists(configPath, aliasName, shell string) (bool, string, error) {
 	case shellPowerShell:
 		prefix = "Set-Alias -Name " + aliasName + " "
 	}
+	return func(line string) bool {
+		return strings.HasPrefix(line, prefix)
+	}
+}
+
+// aliasTarget returns the unquoted program an alias line for aliasName points at.
+func aliasTarget(shell, aliasName, line string) string {
+	var value string
+	switch shell {
+	case shellBash, shellZsh:
+		value = strings.TrimPrefix(line, "alias "+aliasName+"=")
+	case shellFish:
+		value = strings.TrimPrefix(line, "alias "+aliasName+" ")
+	case shellPowerShell:
+		value = strings.TrimPrefix(line, "Set-Alias -Name "+aliasName+" ")
+		value = strings.TrimPrefix(strings.TrimSpace(value), "-Value ")
+	}
+	value = strings.TrimSpace(value)
+	if len(value) < 2 {
+		return value
+	}
+	first, last := value[0], value[len(value)-1]
+	switch {
+	case first == '\'' && last == '\'':
+		inner := value[1 : len(value)-1]
+		if shell == shellPowerShell {
+			return strings.ReplaceAll(inner, "''", "'")
+		}
+		return strings.ReplaceAll(inner, `'"'"'`, "'")
+	case first == '"' && last == '"':
+		return value[1 : len(value)-1]
+	default:
+		return value
+	}
+}
+
+// isStudioctlBinary reports whether target names a studioctl binary, either by the default
+// command name or by the name of the running binary.
+func isStudioctlBinary(target string) bool {
+	name := strings.TrimSuffix(filepath.Base(filepath.FromSlash(target)), ".exe")
+	if name == "studioctl" {
+		return true
+	}
+	exe, err := BinaryPath()
+	return err == nil && name == strings.TrimSuffix(filepath.Base(exe), ".exe")
+}
 
-	scanner := bufio.NewScanner(file)
+// findLine returns the first line of r that matches after trimming, or "" if none does.
+func findLine(r io.Reader, match func(string) bool) (string, error) {
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
-		if strings.HasPrefix(line, prefix) {
-			return true, line, nil
+		if match(line) {
+			return line, nil
 		}
 	}
-
 	if err := scanner.Err(); err != nil {
-		return false, "", fmt.Errorf("scanning config file: %w", err)
+		return "", fmt.Errorf("scanning config file: %w", err)
 	}
-	return false, "", nil
+	return "", nil
 }
 
 func ensureConfigFileExists(path string) error {
@@ -357,34 +551,83 @@ func ensureConfigFileExists(path string) error {
 	return nil
 }
 
-func appendToFile(path, line string) error {
+// appendLine appends line to the config at path unless a matching line is already there, in
+// which case that line is returned and nothing is written. The file is locked while it is read
+// and appended to, so concurrent runs neither interleave nor add the line twice.
+func appendLine(path, line string, match func(string) bool) (string, error) {
 	//nolint:gosec // path is constructed from known safe sources
 	file, err := os.OpenFile(path, os.O_APPEND|os.O_RDWR, osutil.FilePermDefault)
 	if err != nil {
-		return fmt.Errorf("opening file for append: %w", err)
+		return "", fmt.Errorf("opening file for append: %w", err)
 	}
 	defer file.Close() //nolint:errcheck // best-effort close after write
 
-	stat, err := file.Stat()
+	if err := lockFile(file); err != nil {
+		return "", fmt.Errorf("locking file: %w", err)
+	}
+	defer unlockFile(file) //nolint:errcheck // closing the file releases the lock as well
+
+	content, err := io.ReadAll(file)
 	if err != nil {
-		return fmt.Errorf("getting file info: %w", err)
+		return "", fmt.Errorf("reading file: %w", err)
+	}
+	existing, err := findLine(bytes.NewReader(content), match)
+	if err != nil || existing != "" {
+		return existing, err
 	}
 
-	prefix := ""
-	if stat.Size() > 0 {
-		buf := make([]byte, 1)
-		if _, readErr := file.ReadAt(buf, stat.Size()-1); readErr == nil && buf[0] != '\n' {
-			prefix = "\n"
-		} else if readErr != nil {
-			prefix = "\n"
+	newlines := ""
+	if len(content) > 0 {
+		if content[len(content)-1] != '\n' {
+			newlines = "\n"
 		}
-		prefix += "\n"
+		newlines += "\n"
 	}
 
-	if _, err := file.WriteString(prefix + line + "\n"); err != nil {
-		return fmt.Errorf("writing to file: %w", err)
+	if _, err := file.WriteString(newlines + line + "\n"); err != nil {
+		return "", fmt.Errorf("writing to file: %w", err)
 	}
-	return nil
+	return "", nil
+}
+
+// replaceLine rewrites the first line of path that matches oldLine after trimming
+// surrounding whitespace. Indentation, line endings and all other lines are kept. The file
+// is locked like in appendLine, so a rewrite does not race a concurrent append or rewrite.
+func replaceLine(path, oldLine, newLine string) error {
+	//nolint:gosec // path is constructed from known safe sources
+	file, err := os.OpenFile(path, os.O_RDWR, osutil.FilePermDefault)
+	if err != nil {
+		return fmt.Errorf("opening file for rewrite: %w", err)
+	}
+	defer file.Close() //nolint:errcheck // best-effort close after write
+
+	if err := lockFile(file); err != nil {
+		return fmt.Errorf("locking file: %w", err)
+	}
+	defer unlockFile(file) //nolint:errcheck // closing the file releases the lock as well
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return fmt.Errorf("reading file: %w", err)
+	}
+
+	lines := strings.SplitAfter(string(data), "\n")
+	for i, line := range lines {
+		content := strings.TrimRight(line, "\r\n")
+		if strings.TrimSpace(content) != oldLine {
+			continue
+		}
+		indent := content[:len(content)-len(strings.TrimLeft(content, " \t"))]
+		lines[i] = indent + newLine + line[len(content):]
+		if err := file.Truncate(0); err != nil {
+			return fmt.Errorf("truncating file: %w", err)
+		}
+		if _, err := file.WriteAt([]byte(strings.Join(lines, "")), 0); err != nil {
+			return fmt.Errorf("writing file: %w", err)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: %q", errLineNotFound, oldLine)
 }
 
 func getReloadCommand(shell, configPath string) string {
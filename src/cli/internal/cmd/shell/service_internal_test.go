@@ -0,0 +1,108 @@
+package shell
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAppendLine_ConcurrentAppendsWriteOnce(t *testing.T) {
+	t.Parallel()
+
+	const (
+		runs  = 8
+		alias = "alias s='/usr/local/bin/studioctl'"
+	)
+	configPath := filepath.Join(t.TempDir(), ".bashrc")
+	if err := os.WriteFile(configPath, []byte("export EDITOR=vim"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	// A slow matcher widens the window between reading the file and appending to it.
+	match := func(line string) bool {
+		time.Sleep(10 * time.Millisecond)
+		return line == alias
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, runs)
+	for i := range runs {
+		wg.Go(func() {
+			_, errs[i] = appendLine(configPath, alias, match)
+		})
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("appendLine() error = %v", err)
+		}
+	}
+
+	after, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	if got := strings.Count(string(after), alias); got != 1 {
+		t.Fatalf("alias written %d times, want 1:\n%s", got, after)
+	}
+	if want := "export EDITOR=vim\n\n" + alias + "\n"; string(after) != want {
+		t.Fatalf("config =\n%q\nwant\n%q", after, want)
+	}
+}
+
+func TestReplaceLine_WaitsForLock(t *testing.T) {
+	t.Parallel()
+
+	const (
+		oldAlias = "alias s='/opt/old/studioctl'"
+		newAlias = "alias s='/usr/local/bin/studioctl'"
+		appended = "export EDITOR=vim"
+	)
+	configPath := filepath.Join(t.TempDir(), ".bashrc")
+	if err := os.WriteFile(configPath, []byte(oldAlias+"\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	// Hold the lock like a concurrent appendLine would.
+	holder, err := os.OpenFile(configPath, os.O_APPEND|os.O_RDWR, 0)
+	if err != nil {
+		t.Fatalf("open config: %v", err)
+	}
+	defer func() {
+		if err := holder.Close(); err != nil {
+			t.Logf("close config: %v", err)
+		}
+	}()
+	if err := lockFile(holder); err != nil {
+		t.Fatalf("lockFile() error = %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- replaceLine(configPath, oldAlias, newAlias) }()
+	select {
+	case err := <-done:
+		t.Fatalf("replaceLine() returned %v while the file was locked", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if _, err := holder.WriteString(appended + "\n"); err != nil {
+		t.Fatalf("append to config: %v", err)
+	}
+	if err := unlockFile(holder); err != nil {
+		t.Fatalf("unlockFile() error = %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("replaceLine() error = %v", err)
+	}
+
+	after, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	if want := newAlias + "\n" + appended + "\n"; string(after) != want {
+		t.Fatalf("config =\n%q\nwant\n%q", after, want)
+	}
+}
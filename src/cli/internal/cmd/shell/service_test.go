@@ -1,6 +1,13 @@
 package shell_test
 
 import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	shellsvc "altinn.studio/studioctl/internal/cmd/shell"
@@ -43,3 +50,182 @@ func TestFormatAliasLine_Bash_EscapesSingleQuote(t *testing.T) {
 		t.Fatalf("FormatAliasLine() = %q, want %q", got, want)
 	}
 }
+
+func TestResolveConfigPath_PowerShellProbe(t *testing.T) {
+	t.Parallel()
+
+	errProbe := errors.New("probe failed")
+	const profile = `C:\Users\me\Documents\PowerShell\Microsoft.PowerShell_profile.ps1`
+
+	tests := []struct {
+		responses   map[string][]probeResponse
+		name        string
+		wantPath    string
+		wantCalls   []string
+		wantGuessed bool
+	}{
+		{
+			name:      "pwsh succeeds",
+			responses: map[string][]probeResponse{"pwsh": {{output: profile + "\r\n"}}},
+			wantPath:  profile,
+			wantCalls: []string{"pwsh"},
+		},
+		{
+			name:      "pwsh retried after transient failure",
+			responses: map[string][]probeResponse{"pwsh": {{err: errProbe}, {output: profile}}},
+			wantPath:  profile,
+			wantCalls: []string{"pwsh", "pwsh"},
+		},
+		{
+			name: "falls back to windows powershell",
+			responses: map[string][]probeResponse{
+				"pwsh":       {{err: exec.ErrNotFound}},
+				"powershell": {{output: profile}},
+			},
+			wantPath:  profile,
+			wantCalls: []string{"pwsh", "powershell"},
+		},
+		{
+			name: "both probes fail",
+			responses: map[string][]probeResponse{
+				"pwsh":       {{err: exec.ErrNotFound}},
+				"powershell": {{err: errProbe}, {err: errProbe}},
+			},
+			wantPath:    defaultProfilePath(t),
+			wantCalls:   []string{"pwsh", "powershell", "powershell"},
+			wantGuessed: true,
+		},
+		{
+			name: "empty output is not a failure",
+			responses: map[string][]probeResponse{
+				"pwsh":       {{output: "\n"}},
+				"powershell": {{err: exec.ErrNotFound}},
+			},
+			wantPath:  defaultProfilePath(t),
+			wantCalls: []string{"pwsh", "powershell"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls []string
+			run := func(_ context.Context, name string, _ ...string) ([]byte, error) {
+				calls = append(calls, name)
+				queue := tt.responses[name]
+				if len(queue) == 0 {
+					return nil, exec.ErrNotFound
+				}
+				resp := queue[0]
+				tt.responses[name] = queue[1:]
+				return []byte(resp.output), resp.err
+			}
+
+			got, err := shellsvc.NewServiceWithRunner(run).ResolveConfigPath(context.Background(), "powershell")
+			if err != nil {
+				t.Fatalf("ResolveConfigPath() error = %v", err)
+			}
+			if got.Path != tt.wantPath {
+				t.Errorf("Path = %q, want %q", got.Path, tt.wantPath)
+			}
+			if got.Guessed != tt.wantGuessed {
+				t.Errorf("Guessed = %v, want %v", got.Guessed, tt.wantGuessed)
+			}
+			if !slices.Equal(calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+type probeResponse struct {
+	err    error
+	output string
+}
+
+func defaultProfilePath(t *testing.T) string {
+	t.Helper()
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Fatalf("UserHomeDir() error = %v", err)
+	}
+	return filepath.Join(home, "Documents", "PowerShell", "Microsoft.PowerShell_profile.ps1")
+}
+
+// Tests that use t.Setenv cannot use t.Parallel.
+func TestConfigureAlias_ExistingAlias(t *testing.T) {
+	const (
+		oldAlias     = "alias s='/opt/old/studioctl'"
+		foreignAlias = "alias s='/usr/bin/git status'"
+	)
+
+	tests := []struct {
+		name        string
+		existing    string
+		wantStatus  shellsvc.AliasStatus
+		update      bool
+		wantNew     bool
+		wantForeign bool
+	}{
+		{name: "conflict without update", existing: oldAlias, wantStatus: shellsvc.AliasStatusConflict},
+		{
+			name:       "rewritten with update",
+			existing:   oldAlias,
+			update:     true,
+			wantStatus: shellsvc.AliasStatusUpdated,
+			wantNew:    true,
+		},
+		{
+			name:        "foreign alias kept with update",
+			existing:    foreignAlias,
+			update:      true,
+			wantStatus:  shellsvc.AliasStatusConflict,
+			wantForeign: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			configPath := filepath.Join(home, ".bashrc")
+			before := "# keep me\nexport EDITOR=vim\n  " + tt.existing + "\r\nalias ll='ls -l'\n"
+			if err := os.WriteFile(configPath, []byte(before), 0o600); err != nil {
+				t.Fatalf("write config: %v", err)
+			}
+
+			result, err := shellsvc.NewService().ConfigureAlias(context.Background(), shellsvc.AliasOptions{
+				AliasName: "s",
+				Shell:     "bash",
+				DryRun:    false,
+				Update:    tt.update,
+			})
+			if err != nil {
+				t.Fatalf("ConfigureAlias() error = %v", err)
+			}
+			if result.Status != tt.wantStatus {
+				t.Fatalf("Status = %q, want %q", result.Status, tt.wantStatus)
+			}
+			if result.ExistingLine != tt.existing {
+				t.Errorf("ExistingLine = %q, want %q", result.ExistingLine, tt.existing)
+			}
+			if result.ExistingForeign != tt.wantForeign {
+				t.Errorf("ExistingForeign = %v, want %v", result.ExistingForeign, tt.wantForeign)
+			}
+
+			after, err := os.ReadFile(configPath)
+			if err != nil {
+				t.Fatalf("read config: %v", err)
+			}
+			want := before
+			if tt.wantNew {
+				want = strings.Replace(before, tt.existing, result.AliasLine, 1)
+			}
+			if string(after) != want {
+				t.Fatalf("config =\n%q\nwant\n%q", after, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+//nolint:testpackage // testing alias check exit codes through the unexported CLI output
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	shellsvc "altinn.studio/studioctl/internal/cmd/shell"
+	"altinn.studio/studioctl/internal/config"
+	"altinn.studio/studioctl/internal/ui"
+)
+
+// Tests that use t.Setenv cannot use t.Parallel.
+func TestShellAliasCheck(t *testing.T) {
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatalf("os.Executable() error = %v", err)
+	}
+	binaryPath, err := filepath.EvalSymlinks(exe)
+	if err != nil {
+		t.Fatalf("EvalSymlinks() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		bashrc     string
+		wantStatus shellsvc.AliasStatus
+		wantCode   int
+	}{
+		{
+			name:       "configured",
+			bashrc:     "# rc\n" + shellsvc.FormatAliasLine("bash", "s", binaryPath) + "\n",
+			wantStatus: shellsvc.AliasStatusAlreadyConfigured,
+			wantCode:   aliasCheckExitConfigured,
+		},
+		{
+			name:       "absent",
+			bashrc:     "# rc\nalias ll='ls -l'\n",
+			wantStatus: shellsvc.AliasStatusAbsent,
+			wantCode:   aliasCheckExitAbsent,
+		},
+		{
+			name:       "conflict",
+			bashrc:     "alias s='/opt/old/studioctl'\n",
+			wantStatus: shellsvc.AliasStatusConflict,
+			wantCode:   aliasCheckExitConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			configPath := filepath.Join(home, ".bashrc")
+			if err := os.WriteFile(configPath, []byte(tt.bashrc), 0o600); err != nil {
+				t.Fatalf("write bashrc: %v", err)
+			}
+
+			var out bytes.Buffer
+			cfg := &config.Config{Home: t.TempDir()}
+			cli := NewCLI(cfg)
+			cli.out = ui.NewOutput(&out, &out, false)
+			cli.commands["shell"] = NewShellCommand(cfg, cli.out)
+
+			code := cli.Run(context.Background(), []string{"shell", "alias", "--check", "-s", "bash", "--json"})
+			if code != tt.wantCode {
+				t.Fatalf("exit code = %d, want %d (output %q)", code, tt.wantCode, out.String())
+			}
+
+			var payload struct {
+				Status   shellsvc.AliasStatus `json:"status"`
+				ExitCode int                  `json:"exitCode"`
+			}
+			if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+				t.Fatalf("unmarshal output %q: %v", out.String(), err)
+			}
+			if payload.Status != tt.wantStatus || payload.ExitCode != tt.wantCode {
+				t.Fatalf("payload = %+v, want status %q exit code %d", payload, tt.wantStatus, tt.wantCode)
+			}
+
+			data, err := os.ReadFile(configPath)
+			if err != nil {
+				t.Fatalf("read bashrc: %v", err)
+			}
+			if string(data) != tt.bashrc {
+				t.Fatalf("bashrc modified:\n%s", data)
+			}
+		})
+	}
+}
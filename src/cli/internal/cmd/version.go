@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+
+	"altinn.studio/studioctl/internal/config"
+	"altinn.studio/studioctl/internal/osutil"
+	"altinn.studio/studioctl/internal/ui"
+	"altinn.studio/studioctl/internal/update"
+)
+
+// VersionCommand implements the 'version' subcommand.
+type VersionCommand struct {
+	cfg        *config.Config
+	out        *ui.Output
+	newChecker func(home string) *update.Checker
+}
+
+// NewVersionCommand creates a new version command.
+func NewVersionCommand(cfg *config.Config, out *ui.Output) *VersionCommand {
+	return &VersionCommand{cfg: cfg, out: out, newChecker: update.NewChecker}
+}
+
+// Name returns the command name.
+func (c *VersionCommand) Name() string { return versionSubcmd }
+
+// Synopsis returns a short description.
+func (c *VersionCommand) Synopsis() string { return "Print version and check for updates" }
+
+// Usage returns the full help text.
+func (c *VersionCommand) Usage() string {
+	return fmt.Sprintf(`Usage: %s version [options]
+
+Print the studioctl version.
+
+Options:
+  --check     Check whether a newer release is available
+  --json      Output as JSON
+  -h, --help  Show this help message
+`, osutil.CurrentBin())
+}
+
+// Run executes the command.
+func (c *VersionCommand) Run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("version", flag.ContinueOnError)
+	fs.Usage = func() { c.out.Print(c.Usage()) }
+
+	var check bool
+	var jsonOutput bool
+	fs.BoolVar(&check, "check", false, "Check for a newer release")
+	fs.BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return fmt.Errorf("parsing flags: %w", err)
+	}
+
+	if !check {
+		if jsonOutput {
+			return c.printJSON(map[string]string{"version": c.cfg.Version})
+		}
+		c.out.Printf("%s %s\n", osutil.CurrentBin(), c.cfg.Version)
+		return nil
+	}
+
+	spinner := ui.NewSpinner(c.out, "Checking for updates...")
+	if !jsonOutput && !c.cfg.Verbose {
+		spinner.Start()
+	}
+	result, err := c.newChecker(c.cfg.Home).Check(ctx, c.cfg.Version)
+	spinner.Stop()
+	if err != nil {
+		return fmt.Errorf("check for updates: %w", err)
+	}
+
+	if jsonOutput {
+		return c.printJSON(map[string]any{
+			"version":         result.Current,
+			"latest":          result.Latest,
+			"updateAvailable": result.UpdateAvailable,
+			"checkedAt":       result.CheckedAt,
+			"upgradeCommand":  update.UpgradeCommand,
+		})
+	}
+
+	c.out.Printf("%s %s\n", osutil.CurrentBin(), result.Current)
+	if !result.UpdateAvailable {
+		c.out.Printf("You are up to date (latest release: %s).\n", result.Latest)
+		return nil
+	}
+	c.out.Printf("A new release is available: %s -> %s\n", result.Current, result.Latest)
+	c.out.Printf("Upgrade with:\n  %s\n", update.UpgradeCommand)
+	return nil
+}
+
+func (c *VersionCommand) printJSON(v any) error {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("marshal version json: %w", err)
+	}
+	c.out.Printf("%s\n", payload)
+	return nil
+}
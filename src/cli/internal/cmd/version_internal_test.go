@@ -0,0 +1,101 @@
+//nolint:testpackage // testing with a stubbed latest-release resolver
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"altinn.studio/studioctl/internal/config"
+	"altinn.studio/studioctl/internal/ui"
+	"altinn.studio/studioctl/internal/update"
+)
+
+func TestVersionCommand_Check(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		latest      string
+		wantMissing string
+		wantOutput  []string
+		wantUpdate  bool
+	}{
+		{
+			name:        "up to date",
+			latest:      "v1.2.0",
+			wantOutput:  []string{"You are up to date (latest release: v1.2.0)."},
+			wantMissing: update.UpgradeCommand,
+			wantUpdate:  false,
+		},
+		{
+			name:   "update available",
+			latest: "v1.3.0",
+			wantOutput: []string{
+				"A new release is available: v1.2.0 -> v1.3.0",
+				update.UpgradeCommand,
+			},
+			wantMissing: "up to date",
+			wantUpdate:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			home := t.TempDir()
+			calls := 0
+			newCommand := func(stdout *bytes.Buffer) *VersionCommand {
+				command := NewVersionCommand(
+					&config.Config{Home: home, Version: "v1.2.0", Verbose: true},
+					ui.NewOutput(stdout, stdout, false),
+				)
+				command.newChecker = func(home string) *update.Checker {
+					checker := update.NewChecker(home)
+					checker.Resolve = func(context.Context) (string, error) {
+						calls++
+						return tt.latest, nil
+					}
+					return checker
+				}
+				return command
+			}
+
+			var stdout bytes.Buffer
+			if err := newCommand(&stdout).Run(t.Context(), []string{"--check"}); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("Run() output = %q, want %q", stdout.String(), want)
+				}
+			}
+			if strings.Contains(stdout.String(), tt.wantMissing) {
+				t.Errorf("Run() output = %q, should not contain %q", stdout.String(), tt.wantMissing)
+			}
+
+			var jsonOut bytes.Buffer
+			if err := newCommand(&jsonOut).Run(t.Context(), []string{"--check", "--json"}); err != nil {
+				t.Fatalf("Run(--json) error = %v", err)
+			}
+			var got struct {
+				CheckedAt       time.Time `json:"checkedAt"`
+				Latest          string    `json:"latest"`
+				UpdateAvailable bool      `json:"updateAvailable"`
+			}
+			if err := json.Unmarshal(jsonOut.Bytes(), &got); err != nil {
+				t.Fatalf("unmarshal output: %v\n%s", err, jsonOut.String())
+			}
+			if got.Latest != tt.latest || got.UpdateAvailable != tt.wantUpdate || got.CheckedAt.IsZero() {
+				t.Errorf("json output = %+v, want latest %s, updateAvailable %v", got, tt.latest, tt.wantUpdate)
+			}
+			if calls != 1 {
+				t.Errorf("resolver calls = %d, want 1 (second check served from cache)", calls)
+			}
+		})
+	}
+}
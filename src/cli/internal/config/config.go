@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"time"
 
 	"gopkg.in/yaml.v3"
 
@@ -34,6 +35,20 @@ const (
 	// EnvResourcesTarball overrides resource install source with a local tarball path.
 	// Intended for development/tooling, not normal end-user flows.
 	EnvResourcesTarball = "STUDIOCTL_RESOURCES_TARBALL"
+
+	// EnvInstallMaxArchiveSize overrides the maximum resource archive size in bytes.
+	EnvInstallMaxArchiveSize = "STUDIOCTL_INSTALL_MAX_ARCHIVE_SIZE"
+
+	// EnvInstallMaxFileSize overrides the maximum size of a single resource file in bytes.
+	EnvInstallMaxFileSize = "STUDIOCTL_INSTALL_MAX_FILE_SIZE"
+
+	// EnvCABundle points to a PEM file with extra CA certificates trusted for outbound HTTPS,
+	// e.g. behind a TLS-inspecting corporate proxy.
+	EnvCABundle = "STUDIOCTL_CA_BUNDLE"
+
+	// EnvProxy overrides HTTPS_PROXY/HTTP_PROXY for studioctl's outbound requests.
+	// NO_PROXY is still honored.
+	EnvProxy = "STUDIOCTL_PROXY"
 )
 
 // Sentinel errors for configuration validation.
@@ -44,36 +59,53 @@ var (
 	// ErrSocketDirRequired is returned when the socket directory is not set.
 	ErrSocketDirRequired = errors.New("socket directory is required")
 
+	// ErrHomeNotUsable is returned when the home directory is not a writable directory.
+	ErrHomeNotUsable = errors.New("home directory is not usable")
+
 	// ErrInvalidConfigVersion is returned when config file version is invalid.
 	ErrInvalidConfigVersion = errors.New("invalid config version")
 )
 
 // Config holds all configuration for studioctl.
 type Config struct {
-	Home      string       // Base directory for studioctl data
-	SocketDir string       // Directory for Unix domain sockets
-	LogDir    string       // Directory for log files
-	DataDir   string       // Directory for container volumes
-	BinDir    string       // Directory for binaries (app-manager)
-	Images    ImagesConfig // Container image configuration
-	Version   string       // Build version (embedded at build time)
-	Verbose   bool         // Verbose output (-v)
+	Home            string                       // Base directory for studioctl data
+	SocketDir       string                       // Directory for Unix domain sockets
+	LogDir          string                       // Directory for log files
+	DataDir         string                       // Directory for container volumes
+	BinDir          string                       // Directory for binaries (app-manager)
+	Images          ImagesConfig                 // Container image configuration
+	Limits          map[string]ResourceLimitSpec // Per-container resource limits, keyed by container name
+	Version         string                       // Build version (embedded at build time)
+	TeardownTimeout string                       // Default for 'env up --teardown-timeout' (e.g. "2m")
+	Dashboards      DashboardsSpec               // Grafana dashboards provisioned with monitoring
+	Deadline        time.Duration                // Cancel the command after this long; zero means no limit (--deadline)
+	Verbose         bool                         // Verbose output (-v)
+	NoColor         bool                         // Disable colored output (--no-color)
+	Quiet           bool                         // Suppress non-essential notices (-q)
+	CheckForUpdates bool                         // Daily background update notice (opt-in)
 }
 
 // Flags holds CLI flag values that override config.
 type Flags struct {
 	Home      string
 	SocketDir string
+	Deadline  time.Duration
 	Verbose   bool
+	NoColor   bool
+	Quiet     bool
 }
 
 // New creates a Config with values resolved from flags, environment, and defaults.
 // Directories are created if they don't exist.
+// Returns ErrHomeNotUsable if the home path is not a writable directory.
 func New(flags Flags, version string) (*Config, error) {
 	home, err := resolveHome(flags.Home)
 	if err != nil {
 		return nil, fmt.Errorf("resolve home: %w", err)
 	}
+	if err := checkHomeIsDir(home); err != nil {
+		return nil, err
+	}
 
 	socketDir, err := resolveSocketDir(flags.SocketDir, home)
 	if err != nil {
@@ -86,7 +118,7 @@ func New(flags Flags, version string) (*Config, error) {
 		return nil, fmt.Errorf("load config: %w", err)
 	}
 
-	return newResolvedConfig(flags, version, home, socketDir, persisted.Images, true)
+	return newResolvedConfig(flags, version, home, socketDir, persisted, true)
 }
 
 // NewDoctorFallback creates a minimal config for running doctor when normal config init fails.
@@ -106,15 +138,14 @@ func NewDoctorFallback(flags Flags, version string) (*Config, error) {
 		return nil, fmt.Errorf("load embedded defaults: %w", err)
 	}
 
-	images := defaults.Images
-	if images.Utility.Busybox.Image == "" {
-		images.Utility.Busybox = ImageSpec{
+	if defaults.Images.Utility.Busybox.Image == "" {
+		defaults.Images.Utility.Busybox = ImageSpec{
 			Image: "busybox",
 			Tag:   "stable",
 		}
 	}
 
-	return newResolvedConfig(flags, version, home, socketDir, images, false)
+	return newResolvedConfig(flags, version, home, socketDir, defaults, false)
 }
 
 func newResolvedConfig(
@@ -122,24 +153,34 @@ func newResolvedConfig(
 	version string,
 	home string,
 	socketDir string,
-	images ImagesConfig,
+	persisted PersistedConfig,
 	ensureDirs bool,
 ) (*Config, error) {
 	cfg := &Config{
-		Home:      home,
-		SocketDir: socketDir,
-		LogDir:    filepath.Join(home, "logs"),
-		DataDir:   filepath.Join(home, "data"),
-		BinDir:    filepath.Join(home, "bin"),
-		Images:    images,
-		Version:   version,
-		Verbose:   flags.Verbose,
+		Home:            home,
+		SocketDir:       socketDir,
+		LogDir:          filepath.Join(home, "logs"),
+		DataDir:         filepath.Join(home, "data"),
+		BinDir:          filepath.Join(home, "bin"),
+		Images:          persisted.Images,
+		Limits:          persisted.Limits,
+		Dashboards:      persisted.Dashboards,
+		Deadline:        flags.Deadline,
+		TeardownTimeout: persisted.TeardownTimeout,
+		Version:         version,
+		Verbose:         flags.Verbose,
+		NoColor:         flags.NoColor,
+		Quiet:           flags.Quiet,
+		CheckForUpdates: persisted.CheckForUpdates,
 	}
 
 	if ensureDirs {
 		if err := cfg.ensureDirectories(); err != nil {
 			return nil, err
 		}
+		if err := checkHomeWritable(home); err != nil {
+			return nil, err
+		}
 	}
 
 	return cfg, nil
@@ -291,10 +332,31 @@ type ImagesConfig struct {
 	Utility    UtilityImages    `yaml:"utility"`
 }
 
+// ResourceLimitSpec holds optional resource limits for one container.
+// Memory uses docker-style units (e.g. "512m", "2g") and CPUs is a decimal count (e.g. "1.5").
+// Empty values mean unlimited.
+type ResourceLimitSpec struct {
+	Memory string `yaml:"memory"`
+	CPUs   string `yaml:"cpus"`
+}
+
+// DashboardsSpec selects the Grafana dashboards provisioned by the monitoring stack.
+// With both lists empty the whole built-in dashboards directory is provisioned.
+type DashboardsSpec struct {
+	// Include lists built-in dashboard file names under infra/grafana-dashboards; empty means all.
+	Include []string `yaml:"include,omitempty"`
+	// Extra lists absolute host paths of additional dashboard JSON files.
+	Extra []string `yaml:"extra,omitempty"`
+}
+
 // PersistedConfig is the root structure for the persisted config file.
 type PersistedConfig struct {
-	Images  ImagesConfig `yaml:"images"`
-	Version int          `yaml:"version"`
+	Limits          map[string]ResourceLimitSpec `yaml:"limits,omitempty"`
+	Images          ImagesConfig                 `yaml:"images"`
+	TeardownTimeout string                       `yaml:"teardownTimeout,omitempty"`
+	Dashboards      DashboardsSpec               `yaml:"dashboards,omitempty"`
+	Version         int                          `yaml:"version"`
+	CheckForUpdates bool                         `yaml:"checkForUpdates,omitempty"`
 }
 
 // Install writes the embedded config to the home directory.
@@ -404,6 +466,45 @@ func merge(defaults, user PersistedConfig) PersistedConfig {
 	// Utility images
 	result.Images.Utility.Busybox = mergeImageSpec(defaults.Images.Utility.Busybox, user.Images.Utility.Busybox)
 
+	result.Limits = mergeLimits(defaults.Limits, user.Limits)
+
+	// Dashboard lists replace the defaults as a whole.
+	if len(user.Dashboards.Include) > 0 {
+		result.Dashboards.Include = user.Dashboards.Include
+	}
+	if len(user.Dashboards.Extra) > 0 {
+		result.Dashboards.Extra = user.Dashboards.Extra
+	}
+
+	if user.CheckForUpdates {
+		result.CheckForUpdates = true
+	}
+	if user.TeardownTimeout != "" {
+		result.TeardownTimeout = user.TeardownTimeout
+	}
+
+	return result
+}
+
+// mergeLimits merges per-container limits; non-empty user fields override defaults.
+func mergeLimits(defaults, user map[string]ResourceLimitSpec) map[string]ResourceLimitSpec {
+	if len(defaults) == 0 && len(user) == 0 {
+		return nil
+	}
+	result := make(map[string]ResourceLimitSpec, len(defaults)+len(user))
+	for name, spec := range defaults {
+		result[name] = spec
+	}
+	for name, spec := range user {
+		merged := result[name]
+		if spec.Memory != "" {
+			merged.Memory = spec.Memory
+		}
+		if spec.CPUs != "" {
+			merged.CPUs = spec.CPUs
+		}
+		result[name] = merged
+	}
 	return result
 }
 
@@ -424,3 +525,35 @@ func migrate(cfg PersistedConfig, currentVersion int) (PersistedConfig, error) {
 
 	return cfg, nil
 }
+
+// checkHomeIsDir rejects a home path that exists but is not a directory.
+// A missing home is fine; ensureDirectories creates it.
+func checkHomeIsDir(home string) error {
+	info, err := os.Stat(home)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrHomeNotUsable, home, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%w: %s is not a directory", ErrHomeNotUsable, home)
+	}
+	return nil
+}
+
+// checkHomeWritable verifies files can be created in the home directory.
+func checkHomeWritable(home string) error {
+	probe, err := os.CreateTemp(home, ".studioctl-write-check-*")
+	if err != nil {
+		return fmt.Errorf("%w: %s is not writable: %w", ErrHomeNotUsable, home, err)
+	}
+	name := probe.Name()
+	if err := probe.Close(); err != nil {
+		return fmt.Errorf("close write check file: %w", err)
+	}
+	if err := os.Remove(name); err != nil {
+		return fmt.Errorf("remove write check file: %w", err)
+	}
+	return nil
+}
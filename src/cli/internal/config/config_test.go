@@ -1,6 +1,7 @@
 package config_test
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -64,6 +65,30 @@ func TestNew(t *testing.T) {
 	})
 }
 
+func TestNew_CheckForUpdates(t *testing.T) {
+	t.Parallel()
+
+	home := t.TempDir()
+	cfg, err := config.New(newTestFlags(home), "test-version")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if cfg.CheckForUpdates {
+		t.Error("CheckForUpdates = true by default, want false")
+	}
+
+	if err := os.WriteFile(filepath.Join(home, "config.yaml"), []byte("version: 1\ncheckForUpdates: true\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	cfg, err = config.New(newTestFlags(home), "test-version")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if !cfg.CheckForUpdates {
+		t.Error("CheckForUpdates = false with checkForUpdates: true in config, want true")
+	}
+}
+
 func TestNewWithCustomHome(t *testing.T) {
 	t.Parallel()
 
@@ -75,8 +100,17 @@ func TestNewWithCustomHome(t *testing.T) {
 		t.Fatalf("New() error = %v", err)
 	}
 
-	if cfg.Home != customHome {
-		t.Errorf("Home = %q, want %q", cfg.Home, customHome)
+	derived := map[string]struct{ got, want string }{
+		"Home":      {cfg.Home, customHome},
+		"SocketDir": {cfg.SocketDir, customHome},
+		"LogDir":    {cfg.LogDir, filepath.Join(customHome, "logs")},
+		"DataDir":   {cfg.DataDir, filepath.Join(customHome, "data")},
+		"BinDir":    {cfg.BinDir, filepath.Join(customHome, "bin")},
+	}
+	for name, path := range derived {
+		if path.got != path.want {
+			t.Errorf("%s = %q, want %q", name, path.got, path.want)
+		}
 	}
 
 	// Verify directories were created
@@ -88,6 +122,20 @@ func TestNewWithCustomHome(t *testing.T) {
 	}
 }
 
+func TestNew_HomeNotADirectory(t *testing.T) {
+	t.Parallel()
+
+	homeFile := filepath.Join(t.TempDir(), "home")
+	if err := os.WriteFile(homeFile, []byte("not a directory"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	_, err := config.New(newTestFlags(homeFile), "1.0.0")
+	if !errors.Is(err, config.ErrHomeNotUsable) {
+		t.Fatalf("New() error = %v, want ErrHomeNotUsable", err)
+	}
+}
+
 // Tests that use t.Setenv cannot use t.Parallel.
 func TestNewWithEnvHome(t *testing.T) {
 	tempDir := t.TempDir()
@@ -0,0 +1,76 @@
+// Package httpclient builds the HTTP clients studioctl uses for outbound requests.
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"altinn.studio/studioctl/internal/config"
+)
+
+var (
+	// ErrInvalidCABundle is returned when the configured CA bundle cannot be read or contains no certificates.
+	ErrInvalidCABundle = errors.New("invalid CA bundle")
+
+	// ErrInvalidProxy is returned when the configured proxy is not an absolute http, https or socks5 URL.
+	ErrInvalidProxy = errors.New("invalid proxy")
+)
+
+// New returns an HTTP client with the given timeout.
+// When STUDIOCTL_CA_BUNDLE is set, its certificates are trusted in addition to the system roots.
+// Proxies come from STUDIOCTL_PROXY, falling back to HTTPS_PROXY/HTTP_PROXY; NO_PROXY applies to both.
+func New(timeout time.Duration) (*http.Client, error) {
+	return newClient(timeout, os.Getenv(config.EnvCABundle), os.Getenv(config.EnvProxy))
+}
+
+func newClient(timeout time.Duration, caBundle, proxy string) (*http.Client, error) {
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("unexpected default transport type %T", http.DefaultTransport)
+	}
+	transport = transport.Clone()
+
+	proxyFn, err := proxyFunc(proxy, noProxyEnv())
+	if err != nil {
+		return nil, err
+	}
+	transport.Proxy = proxyFn
+
+	if caBundle != "" {
+		pool, err := LoadCABundle(caBundle)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    pool,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	return &http.Client{Transport: transport, Timeout: timeout}, nil
+}
+
+// LoadCABundle returns the system root pool extended with the PEM certificates in path.
+func LoadCABundle(path string) (*x509.CertPool, error) {
+	//nolint:gosec // G304: CA bundle path is explicitly configured by the user
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%s): %w", ErrInvalidCABundle, path, config.EnvCABundle, err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf(
+			"%w: %s (%s): no PEM certificates found", ErrInvalidCABundle, path, config.EnvCABundle,
+		)
+	}
+	return pool, nil
+}
@@ -0,0 +1,77 @@
+package httpclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"golang.org/x/net/http/httpproxy"
+
+	"altinn.studio/studioctl/internal/config"
+)
+
+// ProxyDirect is reported by EffectiveProxy when requests bypass any proxy.
+const ProxyDirect = "direct"
+
+// EffectiveProxy returns the proxy used for a request to target, with any credentials redacted,
+// or ProxyDirect when the request is not proxied.
+func EffectiveProxy(target string) (string, error) {
+	proxyFn, err := proxyFunc(os.Getenv(config.EnvProxy), noProxyEnv())
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequest(http.MethodGet, target, nil) //nolint:noctx // request is never sent
+	if err != nil {
+		return "", fmt.Errorf("build request for %s: %w", target, err)
+	}
+	proxyURL, err := proxyFn(req)
+	if err != nil {
+		return "", fmt.Errorf("resolve proxy for %s: %w", target, err)
+	}
+	if proxyURL == nil {
+		return ProxyDirect, nil
+	}
+	return proxyURL.Redacted(), nil
+}
+
+// proxyFunc returns the transport proxy selector. Without an override, the standard
+// HTTPS_PROXY/HTTP_PROXY/NO_PROXY environment is used as-is.
+func proxyFunc(override, noProxy string) (func(*http.Request) (*url.URL, error), error) {
+	if override == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	proxyURL, err := url.Parse(override)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidProxy, config.EnvProxy, err)
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("%w: %s=%s (want http://, https:// or socks5:// URL)", ErrInvalidProxy, config.EnvProxy,
+			proxyURL.Redacted())
+	}
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("%w: %s has no host", ErrInvalidProxy, config.EnvProxy)
+	}
+
+	// httpproxy applies the same NO_PROXY rules as http.ProxyFromEnvironment: loopback hosts,
+	// "*", exact hosts and IPs, CIDR ranges, domains, and optional ports.
+	proxyForURL := (&httpproxy.Config{
+		HTTPProxy:  proxyURL.String(),
+		HTTPSProxy: proxyURL.String(),
+		NoProxy:    noProxy,
+		CGI:        false,
+	}).ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyForURL(req.URL)
+	}, nil
+}
+
+func noProxyEnv() string {
+	if v := os.Getenv("NO_PROXY"); v != "" {
+		return v
+	}
+	return os.Getenv("no_proxy")
+}
@@ -13,10 +13,13 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
+	"altinn.studio/devenv/pkg/retry"
 	"altinn.studio/studioctl/internal/config"
+	"altinn.studio/studioctl/internal/httpclient"
 	"altinn.studio/studioctl/internal/osutil"
 )
 
@@ -24,18 +27,32 @@ const (
 	versionFile = ".version"
 
 	sourceMarkerFile = ".source-marker"
+	// tarballMarkerPrefix starts the source marker of a local tarball install, followed by its SHA-256.
+	tarballMarkerPrefix = "tarball-sha256:"
 
 	testdataDir = "testdata"
 
+	// platformDataDir holds localtest runtime data and survives reinstalls.
+	platformDataDir = "AltinnPlatformLocal"
+
+	// Suffixes of the sibling directories used while swapping in a new install.
+	stagingDirSuffix  = ".install-"
+	previousDirSuffix = ".previous"
+
 	releaseURLTemplate = "https://github.com/Altinn/altinn-studio/releases/download/{version}/localtest-resources.tar.gz"
 
 	httpTimeout = 5 * time.Minute
+)
+
+const (
+	// DefaultMaxArchiveSize is the default maximum size of the archive to extract (50MB).
+	DefaultMaxArchiveSize int64 = 50 * 1024 * 1024
 
-	// maxArchiveSize is the maximum size of the archive to extract (50MB).
-	maxArchiveSize = 50 * 1024 * 1024
+	// DefaultMaxFileSize is the default maximum size of a single file in the archive (10MB).
+	DefaultMaxFileSize int64 = 10 * 1024 * 1024
 
-	// maxFileSize is the maximum size of a single file in the archive (10MB).
-	maxFileSize = 10 * 1024 * 1024
+	// MaxSizeLimit is the upper bound accepted for archive and file size overrides (1GB).
+	MaxSizeLimit int64 = 1024 * 1024 * 1024
 )
 
 // Sentinel errors for install operations.
@@ -44,7 +61,7 @@ var (
 	ErrAlreadyInstalled = errors.New("resources already installed")
 
 	// ErrVersionRequired is returned when release mode requires version.
-	ErrVersionRequired = errors.New("version required for release mode install")
+	ErrVersionRequired = errors.New("version required for release mode install (use a release tag or \"latest\")")
 
 	// ErrDownloadFailed is returned when resource download fails.
 	ErrDownloadFailed = errors.New("failed to download resources")
@@ -63,13 +80,72 @@ var (
 
 	// ErrInvalidArchiveFileSize is returned when an archive entry has an invalid size.
 	ErrInvalidArchiveFileSize = errors.New("invalid archive file size")
+
+	// ErrArchiveTooLarge is returned when the archive exceeds maximum size.
+	ErrArchiveTooLarge = errors.New("archive exceeds maximum size")
+
+	// ErrInvalidSizeLimit is returned when a configured size limit is out of bounds.
+	ErrInvalidSizeLimit = errors.New("invalid size limit")
 )
 
 // Options configures the install operation.
 type Options struct {
-	DataDir string // Target directory for resources ($STUDIOCTL_HOME/data)
-	Version string // Current studioctl version (for version tracking)
-	Force   bool   // Force reinstall even if already present
+	DataDir        string // Target directory for resources ($STUDIOCTL_HOME/data)
+	Version        string // Current studioctl version (for version tracking)
+	MaxArchiveSize int64  // Maximum archive size in bytes (0 = env override or DefaultMaxArchiveSize)
+	MaxFileSize    int64  // Maximum size of a single file in bytes (0 = env override or DefaultMaxFileSize)
+	Force          bool   // Force reinstall even if already present
+}
+
+// sizeLimits holds the resolved size limits for an extraction.
+type sizeLimits struct {
+	archive int64
+	file    int64
+}
+
+// resolveSizeLimits resolves size limits from explicit overrides, environment, and defaults.
+func resolveSizeLimits(maxArchiveSize, maxFileSize int64) (sizeLimits, error) {
+	archive, err := resolveSizeLimit(maxArchiveSize, config.EnvInstallMaxArchiveSize, DefaultMaxArchiveSize)
+	if err != nil {
+		return sizeLimits{}, fmt.Errorf("max archive size: %w", err)
+	}
+	file, err := resolveSizeLimit(maxFileSize, config.EnvInstallMaxFileSize, DefaultMaxFileSize)
+	if err != nil {
+		return sizeLimits{}, fmt.Errorf("max file size: %w", err)
+	}
+	if file > archive {
+		return sizeLimits{}, fmt.Errorf(
+			"%w: max file size %d exceeds max archive size %d",
+			ErrInvalidSizeLimit, file, archive,
+		)
+	}
+	return sizeLimits{archive: archive, file: file}, nil
+}
+
+func resolveSizeLimit(value int64, envKey string, fallback int64) (int64, error) {
+	if value == 0 {
+		raw := strings.TrimSpace(os.Getenv(envKey))
+		if raw == "" {
+			return fallback, nil
+		}
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s=%q is not a byte count", ErrInvalidSizeLimit, envKey, raw)
+		}
+		value = parsed
+	}
+	if err := ValidateSizeLimit(value); err != nil {
+		return 0, err
+	}
+	return value, nil
+}
+
+// ValidateSizeLimit checks that a size limit override is within sane bounds.
+func ValidateSizeLimit(value int64) error {
+	if value < 1 || value > MaxSizeLimit {
+		return fmt.Errorf("%w: %d (must be 1-%d bytes)", ErrInvalidSizeLimit, value, MaxSizeLimit)
+	}
+	return nil
 }
 
 // State represents the current install state of localtest resources.
@@ -238,7 +314,7 @@ func sourceMarkerMatchesCurrent(currentVersion, installedMarker string) (bool, e
 		return true, nil
 	}
 
-	if hash, ok := strings.CutPrefix(installedMarker, "tarball-sha256:"); ok {
+	if hash, ok := strings.CutPrefix(installedMarker, tarballMarkerPrefix); ok {
 		return strings.TrimSpace(hash) != "", nil
 	}
 
@@ -255,23 +331,29 @@ func readTrustedFile(path string) ([]byte, error) {
 }
 
 // Install extracts localtest resources to the data directory.
+// opts.Version is the running studioctl version; LatestVersion is only resolved by install diff.
 func Install(ctx context.Context, opts Options) error {
 	if opts.DataDir == "" {
 		return ErrDataDirRequired
 	}
 
+	limits, err := resolveSizeLimits(opts.MaxArchiveSize, opts.MaxFileSize)
+	if err != nil {
+		return err
+	}
+
 	if !opts.Force && IsInstalled(opts.DataDir, opts.Version) {
 		return ErrAlreadyInstalled
 	}
 
 	if tarballPath := os.Getenv(config.EnvResourcesTarball); tarballPath != "" {
-		return installFromLocalTarball(tarballPath, opts)
+		return installFromLocalTarball(tarballPath, opts, limits)
 	}
 
-	return installFromRelease(ctx, opts)
+	return installFromRelease(ctx, opts, limits)
 }
 
-func installFromLocalTarball(tarballPath string, opts Options) (err error) {
+func installFromLocalTarball(tarballPath string, opts Options, limits sizeLimits) (err error) {
 	validatedPath, err := validateTarballPath(tarballPath)
 	if err != nil {
 		return err
@@ -284,11 +366,13 @@ func installFromLocalTarball(tarballPath string, opts Options) (err error) {
 	}
 	defer func() { err = closeWithError(f, "close tarball", err) }()
 
-	if err := extractTarGz(f, opts.DataDir); err != nil {
-		return fmt.Errorf("extract tarball: %w", err)
-	}
-
-	return finishInstall(opts)
+	return installStaged(opts, func(dst string) (Manifest, error) {
+		manifest, err := extractTarGz(f, dst, limits)
+		if err != nil {
+			return nil, fmt.Errorf("extract tarball: %w", err)
+		}
+		return manifest, nil
+	})
 }
 
 func validateTarballPath(path string) (string, error) {
@@ -328,45 +412,236 @@ func normalizeVersionForURL(version string) string {
 	return "studioctl/" + version
 }
 
-func installFromRelease(ctx context.Context, opts Options) (err error) {
-	if opts.Version == "" || opts.Version == "dev" {
-		return ErrVersionRequired
+// releaseURL returns the resource archive download URL for a concrete release version.
+func releaseURL(version string) string {
+	return strings.Replace(releaseURLTemplate, "{version}", normalizeVersionForURL(version), 1)
+}
+
+func installFromRelease(ctx context.Context, opts Options, limits sizeLimits) (err error) {
+	body, err := downloadRelease(ctx, opts.Version)
+	if err != nil {
+		return err
 	}
+	defer func() { err = closeWithError(body, "close response body", err) }()
 
-	versionForURL := normalizeVersionForURL(opts.Version)
-	url := strings.Replace(releaseURLTemplate, "{version}", versionForURL, 1)
+	return installStaged(opts, func(dst string) (Manifest, error) {
+		manifest, err := extractTarGz(body, dst, limits)
+		if err != nil {
+			return nil, fmt.Errorf("extract archive: %w", err)
+		}
+		return manifest, nil
+	})
+}
 
-	client := &http.Client{Timeout: httpTimeout}
+// manifestURL returns the download URL of the resource manifest published next to the archive.
+func manifestURL(version string) string {
+	return strings.TrimSuffix(releaseURL(version), ".tar.gz") + ".manifest"
+}
+
+// downloadRelease starts downloading the resource archive for version.
+// The caller must close the returned body.
+func downloadRelease(ctx context.Context, version string) (io.ReadCloser, error) {
+	if version == "" || version == "dev" {
+		return nil, ErrVersionRequired
+	}
+	return download(ctx, releaseURL(version))
+}
+
+// download starts a GET of url, used for both the archive and the manifest.
+// Network errors and 429/5xx responses are retried with backoff.
+// The caller must close the returned body.
+func download(ctx context.Context, url string) (io.ReadCloser, error) {
+	client, err := httpclient.New(httpTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("create http client: %w", err)
+	}
+
+	return retry.Do(ctx, retry.DefaultPolicy(), isRetryableDownload, func(ctx context.Context) (io.ReadCloser, error) {
+		return fetchRelease(ctx, client, url)
+	})
+}
+
+func fetchRelease(ctx context.Context, client *http.Client, url string) (io.ReadCloser, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return fmt.Errorf("create request: %w", err)
+		return nil, fmt.Errorf("create request: %w", err)
 	}
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("%w: %w", ErrDownloadFailed, err)
+		return nil, fmt.Errorf("%w: %w", ErrDownloadFailed, err)
 	}
-	defer func() { err = closeWithError(resp.Body, "close response body", err) }()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("%w: HTTP %d", ErrDownloadFailed, resp.StatusCode)
+		statusErr := &httpStatusError{code: resp.StatusCode}
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			return nil, fmt.Errorf("%w: %w (close body: %w)", ErrDownloadFailed, statusErr, closeErr)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrDownloadFailed, statusErr)
 	}
 
-	limitedReader := io.LimitReader(resp.Body, maxArchiveSize)
+	return resp.Body, nil
+}
+
+// httpStatusError records a non-200 download response.
+type httpStatusError struct {
+	code int
+}
+
+func (e *httpStatusError) Error() string {
+	return "HTTP " + strconv.Itoa(e.code)
+}
 
-	if err := extractTarGz(limitedReader, opts.DataDir); err != nil {
-		return fmt.Errorf("extract archive: %w", err)
+// isRetryableDownload reports whether a download error is worth retrying:
+// network failures, rate limiting and server errors are; other HTTP statuses are not.
+func isRetryableDownload(err error) bool {
+	if !errors.Is(err, ErrDownloadFailed) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.code == http.StatusTooManyRequests || statusErr.code >= http.StatusInternalServerError
 	}
+	return true
+}
 
-	return finishInstall(opts)
+// isNotFound reports whether a download failed because the asset does not exist.
+func isNotFound(err error) bool {
+	var statusErr *httpStatusError
+	return errors.As(err, &statusErr) && statusErr.code == http.StatusNotFound
 }
 
-func finishInstall(opts Options) error {
-	altinnDir := filepath.Join(opts.DataDir, "AltinnPlatformLocal")
+// installStaged extracts into a sibling staging directory, writes the install
+// metadata there and only then swaps it in place of opts.DataDir. On failure the
+// staging directory is removed and the previous install is left untouched.
+func installStaged(opts Options, extract func(dst string) (Manifest, error)) (err error) {
+	dataDir, err := resolveDataDir(opts.DataDir)
+	if err != nil {
+		return err
+	}
+
+	parent := filepath.Dir(dataDir)
+	if err := os.MkdirAll(parent, osutil.DirPermDefault); err != nil {
+		return fmt.Errorf("create parent of data directory: %w", err)
+	}
+	staging, err := os.MkdirTemp(parent, filepath.Base(dataDir)+stagingDirSuffix+"*")
+	if err != nil {
+		return fmt.Errorf("create staging directory: %w", err)
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if removeErr := os.RemoveAll(staging); removeErr != nil {
+			err = fmt.Errorf("%w (remove staging directory %s: %w)", err, staging, removeErr)
+		}
+	}()
+	if err := os.Chmod(staging, osutil.DirPermDefault); err != nil {
+		return fmt.Errorf("set staging directory permissions: %w", err)
+	}
+
+	manifest, err := extract(staging)
+	if err != nil {
+		return err
+	}
+	stagedOpts := opts
+	stagedOpts.DataDir = staging
+	if err := finishInstall(stagedOpts, manifest); err != nil {
+		return err
+	}
+
+	return swapDataDir(staging, dataDir)
+}
+
+// resolveDataDir follows a symlinked data directory so the swap replaces its target
+// rather than the link.
+func resolveDataDir(dataDir string) (string, error) {
+	dataDir = filepath.Clean(dataDir)
+	info, err := os.Lstat(dataDir)
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		return dataDir, nil
+	}
+	resolved, err := filepath.EvalSymlinks(dataDir)
+	if err != nil {
+		return "", fmt.Errorf("resolve data directory: %w", err)
+	}
+	return resolved, nil
+}
+
+// swapDataDir moves the staged install into place. The previous install is moved
+// aside first and restored if the rename fails; its platform data, which is not
+// part of the archive, is carried over before the previous install is removed.
+func swapDataDir(staging, dataDir string) error {
+	previous := staging + previousDirSuffix
+	hadPrevious := true
+	if err := os.Rename(dataDir, previous); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("move previous install aside: %w", err)
+		}
+		hadPrevious = false
+	}
+
+	if err := os.Rename(staging, dataDir); err != nil {
+		if hadPrevious {
+			if restoreErr := os.Rename(previous, dataDir); restoreErr != nil {
+				return fmt.Errorf(
+					"move new install into place: %w (previous install left at %s: %w)",
+					err, previous, restoreErr,
+				)
+			}
+		}
+		return fmt.Errorf("move new install into place: %w", err)
+	}
+	if !hadPrevious {
+		return nil
+	}
+
+	if err := carryOverPlatformData(previous, dataDir); err != nil {
+		return fmt.Errorf("keep %s: %w (previous install left at %s)", platformDataDir, err, previous)
+	}
+	if err := os.RemoveAll(previous); err != nil {
+		return fmt.Errorf("remove previous install %s: %w", previous, err)
+	}
+	return nil
+}
+
+// carryOverPlatformData moves the localtest platform data from the previous install
+// into the new one, replacing the empty directory created by finishInstall.
+func carryOverPlatformData(previous, dataDir string) error {
+	source := filepath.Join(previous, platformDataDir)
+	if _, err := os.Lstat(source); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	target := filepath.Join(dataDir, platformDataDir)
+	if err := os.RemoveAll(target); err != nil {
+		return fmt.Errorf("remove new %s: %w", platformDataDir, err)
+	}
+	if err := os.Rename(source, target); err != nil {
+		return fmt.Errorf("move %s: %w", platformDataDir, err)
+	}
+	return nil
+}
+
+func finishInstall(opts Options, manifest Manifest) error {
+	altinnDir := filepath.Join(opts.DataDir, platformDataDir)
 	if err := os.MkdirAll(altinnDir, osutil.DirPermDefault); err != nil {
 		return fmt.Errorf("create AltinnPlatformLocal: %w", err)
 	}
 
+	if err := writeManifest(opts.DataDir, manifest); err != nil {
+		return err
+	}
+
+	// Write the report before the markers so a failed write never leaves a directory
+	// that IsInstalled accepts without a report.
+	report, err := newReport(opts.DataDir, opts.Version, manifest, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := writeReport(opts.DataDir, report); err != nil {
+		return err
+	}
+
 	if err := writeVersionFile(opts.DataDir, opts.Version); err != nil {
 		return fmt.Errorf("write version file: %w", err)
 	}
@@ -374,17 +649,35 @@ func finishInstall(opts Options) error {
 	if err := writeSourceMarker(opts.DataDir, opts.Version); err != nil {
 		return fmt.Errorf("write source marker: %w", err)
 	}
-
 	return nil
 }
 
-func extractTarGz(r io.Reader, dst string) (err error) {
-	gzr, err := gzip.NewReader(r)
+// archiveLimitReader fails with ErrArchiveTooLarge once more than limit bytes are read.
+type archiveLimitReader struct {
+	r     io.Reader
+	limit int64
+	read  int64
+}
+
+func (l *archiveLimitReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.limit {
+		// Drop the chunk so decompression cannot complete on bytes past the limit.
+		return 0, fmt.Errorf("%w: limit %d bytes", ErrArchiveTooLarge, l.limit)
+	}
+	return n, err //nolint:wrapcheck // io.Reader contract requires returning io.EOF unwrapped
+}
+
+// extractTarGz extracts the archive into dst and returns a manifest of the extracted files.
+func extractTarGz(r io.Reader, dst string, limits sizeLimits) (manifest Manifest, err error) {
+	gzr, err := gzip.NewReader(&archiveLimitReader{r: r, limit: limits.archive, read: 0})
 	if err != nil {
-		return fmt.Errorf("create gzip reader: %w", err)
+		return nil, fmt.Errorf("create gzip reader: %w", err)
 	}
 	defer func() { err = closeWithError(gzr, "close gzip reader", err) }()
 
+	manifest = make(Manifest)
 	tr := tar.NewReader(gzr)
 
 	for {
@@ -393,18 +686,18 @@ func extractTarGz(r io.Reader, dst string) (err error) {
 			break
 		}
 		if err != nil {
-			return fmt.Errorf("read tar header: %w", err)
+			return nil, fmt.Errorf("read tar header: %w", err)
 		}
 
-		if err := extractTarEntry(tr, header, dst); err != nil {
-			return err
+		if err := extractTarEntry(tr, header, dst, limits.file, manifest); err != nil {
+			return nil, err
 		}
 	}
 
-	return nil
+	return manifest, nil
 }
 
-func extractTarEntry(tr *tar.Reader, header *tar.Header, dst string) error {
+func extractTarEntry(tr *tar.Reader, header *tar.Header, dst string, maxFileSize int64, manifest Manifest) error {
 	// Validate and sanitize path to prevent path traversal
 	cleanName := filepath.Clean(header.Name)
 	if strings.HasPrefix(cleanName, "..") || filepath.IsAbs(cleanName) {
@@ -437,48 +730,75 @@ func extractTarEntry(tr *tar.Reader, header *tar.Header, dst string) error {
 		}
 
 	case tar.TypeReg:
-		if err := extractRegularFile(tr, header, target); err != nil {
+		sum, err := extractRegularFile(tr, header, target, maxFileSize)
+		if err != nil {
 			return err
 		}
+		manifest[filepath.ToSlash(cleanName)] = sum
 	}
 
 	return nil
 }
 
-func extractRegularFile(tr *tar.Reader, header *tar.Header, target string) (err error) {
+func checkFileSize(header *tar.Header, maxFileSize int64) error {
 	if header.Size < 0 {
-		return fmt.Errorf("%w: %s (%d)", ErrInvalidArchiveFileSize, header.Name, header.Size)
+		return fmt.Errorf(
+			"%w: %s (%d, must be 0-%d bytes)",
+			ErrInvalidArchiveFileSize, header.Name, header.Size, maxFileSize,
+		)
 	}
 
 	if header.Size > maxFileSize {
-		return fmt.Errorf("%w: %s", ErrFileTooLarge, header.Name)
+		return fmt.Errorf(
+			"%w: %s (%d bytes, limit %d bytes)",
+			ErrFileTooLarge, header.Name, header.Size, maxFileSize,
+		)
+	}
+
+	return nil
+}
+
+// extractRegularFile writes the entry to target and returns the SHA-256 hex digest of its contents.
+func extractRegularFile(tr *tar.Reader, header *tar.Header, target string, maxFileSize int64) (sum string, err error) {
+	if err := checkFileSize(header, maxFileSize); err != nil {
+		return "", err
 	}
 
 	info, statErr := os.Stat(target)
 	if statErr == nil && info.IsDir() {
 		if removeErr := os.RemoveAll(target); removeErr != nil {
-			return fmt.Errorf("remove directory at file path %s: %w", target, removeErr)
+			return "", fmt.Errorf("remove directory at file path %s: %w", target, removeErr)
 		}
 	} else if statErr != nil && !errors.Is(statErr, os.ErrNotExist) {
-		return fmt.Errorf("stat file target %s: %w", target, statErr)
+		return "", fmt.Errorf("stat file target %s: %w", target, statErr)
 	}
 
 	if mkdirErr := os.MkdirAll(filepath.Dir(target), osutil.DirPermDefault); mkdirErr != nil {
-		return fmt.Errorf("create parent dir for %s: %w", target, mkdirErr)
+		return "", fmt.Errorf("create parent dir for %s: %w", target, mkdirErr)
 	}
 
 	//nolint:gosec // G304: target is sanitized in extractTarEntry
 	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, osutil.FilePermDefault)
 	if err != nil {
-		return fmt.Errorf("create file %s: %w", target, err)
+		return "", fmt.Errorf("create file %s: %w", target, err)
 	}
 	defer func() { err = closeWithError(f, "close file "+target, err) }()
 
-	if _, copyErr := io.Copy(f, io.LimitReader(tr, header.Size)); copyErr != nil {
-		return fmt.Errorf("write file %s: %w", target, copyErr)
+	h := sha256.New()
+	if _, copyErr := io.Copy(io.MultiWriter(f, h), io.LimitReader(tr, header.Size)); copyErr != nil {
+		return "", fmt.Errorf("write file %s: %w", target, copyErr)
 	}
 
-	return nil
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// InstalledVersion returns the version the resources in dataDir were installed for.
+func InstalledVersion(dataDir string) (string, error) {
+	content, err := readTrustedFile(filepath.Join(dataDir, versionFile))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
 }
 
 func writeVersionFile(dataDir, version string) error {
@@ -514,7 +834,7 @@ func expectedSourceMarker(version string) (string, error) {
 		if err != nil {
 			return "", fmt.Errorf("hash tarball: %w", err)
 		}
-		return "tarball-sha256:" + sum, nil
+		return tarballMarkerPrefix + sum, nil
 	}
 
 	return "release-version:" + normalizeVersionForURL(version), nil
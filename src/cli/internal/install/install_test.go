@@ -7,6 +7,10 @@ import (
 	"compress/gzip"
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -18,6 +22,8 @@ import (
 
 const releaseMarkerV1 = "release-version:studioctl/v1.0.0\n"
 
+var defaultLimits = sizeLimits{archive: DefaultMaxArchiveSize, file: DefaultMaxFileSize}
+
 func TestIsInstalled(t *testing.T) {
 	tests := []struct {
 		setup          func(t *testing.T, dataDir string)
@@ -378,8 +384,8 @@ func TestExtractTarGz(t *testing.T) {
 			wantErrType: ErrFileTooLarge,
 			createTar: func(t *testing.T) []byte {
 				t.Helper()
-				// Create content larger than maxFileSize (10MB)
-				largeContent := strings.Repeat("x", maxFileSize+1)
+				// Create content larger than DefaultMaxFileSize (10MB)
+				largeContent := strings.Repeat("x", int(DefaultMaxFileSize)+1)
 				return createTestTarGz(t, map[string]string{
 					"large.txt": largeContent,
 				})
@@ -414,7 +420,7 @@ func TestExtractTarGz(t *testing.T) {
 			dst := t.TempDir()
 			tarData := tt.createTar(t)
 
-			err := extractTarGz(bytes.NewReader(tarData), dst)
+			_, err := extractTarGz(bytes.NewReader(tarData), dst, defaultLimits)
 
 			if tt.wantErr {
 				if err == nil {
@@ -461,7 +467,7 @@ func TestExtractTarGz_ReplacesWrongTypePaths(t *testing.T) {
 			"infra/tempo.yaml": "tempo: {}",
 		})
 
-		if err := extractTarGz(bytes.NewReader(tarData), dst); err != nil {
+		if _, err := extractTarGz(bytes.NewReader(tarData), dst, defaultLimits); err != nil {
 			t.Fatalf("extractTarGz() error = %v", err)
 		}
 
@@ -484,7 +490,7 @@ func TestExtractTarGz_ReplacesWrongTypePaths(t *testing.T) {
 			{name: "testdata/subdir/file.txt", content: "ok", isDir: false},
 		})
 
-		if err := extractTarGz(bytes.NewReader(tarData), dst); err != nil {
+		if _, err := extractTarGz(bytes.NewReader(tarData), dst, defaultLimits); err != nil {
 			t.Fatalf("extractTarGz() error = %v", err)
 		}
 
@@ -496,10 +502,10 @@ func TestExtractRegularFile_NegativeSize(t *testing.T) {
 	t.Parallel()
 
 	target := filepath.Join(t.TempDir(), "bad.txt")
-	err := extractRegularFile(tar.NewReader(bytes.NewReader(nil)), &tar.Header{
+	_, err := extractRegularFile(tar.NewReader(bytes.NewReader(nil)), &tar.Header{
 		Name: "bad.txt",
 		Size: -1,
-	}, target)
+	}, target, DefaultMaxFileSize)
 	if err == nil {
 		t.Fatal("extractRegularFile() expected error for negative file size")
 	}
@@ -511,6 +517,110 @@ func TestExtractRegularFile_NegativeSize(t *testing.T) {
 	}
 }
 
+func TestExtractTarGz_SizeLimitOverrides(t *testing.T) {
+	t.Parallel()
+
+	tarData := createTestTarGz(t, map[string]string{
+		"testdata/file.txt": strings.Repeat("x", 64),
+	})
+
+	t.Run("file limit override rejects acceptable file", func(t *testing.T) {
+		t.Parallel()
+		_, err := extractTarGz(bytes.NewReader(tarData), t.TempDir(), sizeLimits{archive: DefaultMaxArchiveSize, file: 32})
+		if !errors.Is(err, ErrFileTooLarge) {
+			t.Fatalf("extractTarGz() error = %v, want ErrFileTooLarge", err)
+		}
+		if !strings.Contains(err.Error(), "limit 32 bytes") {
+			t.Errorf("extractTarGz() error = %q, want configured limit in message", err)
+		}
+	})
+
+	t.Run("archive limit override rejects acceptable archive", func(t *testing.T) {
+		t.Parallel()
+		_, err := extractTarGz(bytes.NewReader(tarData), t.TempDir(), sizeLimits{archive: 16, file: DefaultMaxFileSize})
+		if !errors.Is(err, ErrArchiveTooLarge) {
+			t.Fatalf("extractTarGz() error = %v, want ErrArchiveTooLarge", err)
+		}
+		if !strings.Contains(err.Error(), "limit 16 bytes") {
+			t.Errorf("extractTarGz() error = %q, want configured limit in message", err)
+		}
+	})
+}
+
+func TestResolveSizeLimits(t *testing.T) {
+	tests := []struct {
+		wantErr     error
+		name        string
+		envArchive  string
+		envFile     string
+		opts        Options
+		wantArchive int64
+		wantFile    int64
+	}{
+		{
+			name:        "defaults",
+			wantArchive: DefaultMaxArchiveSize,
+			wantFile:    DefaultMaxFileSize,
+		},
+		{
+			name:        "env overrides",
+			envArchive:  "2048",
+			envFile:     "1024",
+			wantArchive: 2048,
+			wantFile:    1024,
+		},
+		{
+			name:        "options take precedence over env",
+			envArchive:  "2048",
+			envFile:     "1024",
+			opts:        Options{MaxArchiveSize: 4096, MaxFileSize: 512},
+			wantArchive: 4096,
+			wantFile:    512,
+		},
+		{
+			name:       "env not a number",
+			envArchive: "50MB",
+			wantErr:    ErrInvalidSizeLimit,
+		},
+		{
+			name:    "negative option",
+			opts:    Options{MaxFileSize: -1},
+			wantErr: ErrInvalidSizeLimit,
+		},
+		{
+			name:    "option above upper bound",
+			opts:    Options{MaxArchiveSize: MaxSizeLimit + 1},
+			wantErr: ErrInvalidSizeLimit,
+		},
+		{
+			name:    "file limit above archive limit",
+			opts:    Options{MaxArchiveSize: 1024, MaxFileSize: 2048},
+			wantErr: ErrInvalidSizeLimit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(config.EnvInstallMaxArchiveSize, tt.envArchive)
+			t.Setenv(config.EnvInstallMaxFileSize, tt.envFile)
+
+			got, err := resolveSizeLimits(tt.opts.MaxArchiveSize, tt.opts.MaxFileSize)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("resolveSizeLimits() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveSizeLimits() unexpected error = %v", err)
+			}
+			if got.archive != tt.wantArchive || got.file != tt.wantFile {
+				t.Errorf("resolveSizeLimits() = %+v, want archive=%d file=%d", got, tt.wantArchive, tt.wantFile)
+			}
+		})
+	}
+}
+
 func TestInstall(t *testing.T) {
 	t.Run("already installed - skip", testInstallAlreadyInstalled)
 	t.Run("force reinstall", testInstallForceReinstall)
@@ -518,9 +628,13 @@ func TestInstall(t *testing.T) {
 	t.Run("release mode without version", testInstallReleaseModeNoVersion)
 	t.Run("release mode with dev version", testInstallReleaseModeDevVersion)
 	t.Run("local tarball install", testInstallLocalTarball)
+	t.Run("local tarball install report", testInstallLocalTarballReport)
+	t.Run("failed report leaves no install", testInstallFailedReportNotInstalled)
+	t.Run("local tarball file size override", testInstallLocalTarballFileSizeOverride)
 	t.Run("local tarball unchanged - skip", testInstallLocalTarballUnchangedSkip)
 	t.Run("local tarball changed - reinstall", testInstallLocalTarballChangedReinstall)
 	t.Run("tarball not found", testInstallTarballNotFound)
+	t.Run("failed extract keeps previous install", testInstallFailedExtractKeepsPrevious)
 }
 
 func testInstallAlreadyInstalled(t *testing.T) {
@@ -545,6 +659,13 @@ func testInstallForceReinstall(t *testing.T) {
 
 	// Create existing install
 	setupExistingInstall(t, dataDir, "v1.0.0")
+	platformPath := filepath.Join(dataDir, platformDataDir)
+	if err := os.MkdirAll(platformPath, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(platformPath, "storage.json"), []byte("runtime data"), 0o644); err != nil {
+		t.Fatal(err)
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -556,6 +677,13 @@ func testInstallForceReinstall(t *testing.T) {
 
 	// New file should exist
 	verifyFileExists(t, filepath.Join(dataDir, "testdata/new.txt"))
+
+	// Files from the previous install are gone, platform data is kept
+	if _, err := os.Stat(filepath.Join(dataDir, "testdata/file.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("previous testdata/file.txt still present: %v", err)
+	}
+	verifyFileContent(t, filepath.Join(platformPath, "storage.json"), "runtime data")
+	verifyNoSwapLeftovers(t, dataDir)
 }
 
 func testInstallMissingDataDir(t *testing.T) {
@@ -620,6 +748,103 @@ func testInstallLocalTarball(t *testing.T) {
 
 	// Verify source marker file
 	verifyFileExists(t, filepath.Join(dataDir, sourceMarkerFile))
+
+	// Verify manifest lists extracted files
+	manifest, err := ReadManifest(dataDir)
+	if err != nil {
+		t.Fatalf("ReadManifest() error = %v", err)
+	}
+	if _, ok := manifest["testdata/config.json"]; !ok || len(manifest) != 2 {
+		t.Errorf("ReadManifest() = %v, want entries for both extracted files", manifest)
+	}
+}
+
+func testInstallLocalTarballReport(t *testing.T) {
+	dataDir := t.TempDir()
+
+	tarball := createTestTarballFile(t, map[string]string{
+		"testdata/config.json": `{"setting": true}`,
+		"infra/otel.yaml":      "receivers: []",
+	})
+	t.Setenv(config.EnvResourcesTarball, tarball)
+	wantSource, err := expectedSourceMarker("v1.0.0")
+	if err != nil {
+		t.Fatalf("expectedSourceMarker() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	before := time.Now()
+	if err := Install(ctx, Options{DataDir: dataDir, Version: "v1.0.0", Force: false}); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+
+	report, err := ReadReport(dataDir)
+	if err != nil {
+		t.Fatalf("ReadReport() error = %v", err)
+	}
+	if report.Version != "v1.0.0" || report.Source != wantSource {
+		t.Errorf(
+			"ReadReport() version, source = %q, %q, want %q, %q",
+			report.Version, report.Source, "v1.0.0", wantSource,
+		)
+	}
+	wantBytes := int64(len(`{"setting": true}`) + len("receivers: []"))
+	if report.FileCount != 2 || report.TotalBytes != wantBytes {
+		t.Errorf("ReadReport() files, bytes = %d, %d, want 2, %d", report.FileCount, report.TotalBytes, wantBytes)
+	}
+	if report.InstalledAt.Before(before.Add(-time.Second)) || report.InstalledAt.After(time.Now()) {
+		t.Errorf("ReadReport() installedAt = %s, want between %s and now", report.InstalledAt, before)
+	}
+
+	// The report must not make an unchanged install look stale.
+	if !IsInstalled(dataDir, "v1.0.0") {
+		t.Error("IsInstalled() = false after install with report, want true")
+	}
+}
+
+func testInstallFailedReportNotInstalled(t *testing.T) {
+	dataDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dataDir, testdataDir), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, testdataDir, "file.txt"), []byte("content"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// A directory at the report path makes writing the report fail.
+	if err := os.MkdirAll(filepath.Join(dataDir, ReportFile), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := Options{DataDir: dataDir, Version: "v1.0.0", Force: false}
+	if err := finishInstall(opts, Manifest{"testdata/file.txt": ""}); err == nil {
+		t.Fatal("finishInstall() error = nil, want report write error")
+	}
+	if IsInstalled(dataDir, "v1.0.0") {
+		t.Error("IsInstalled() = true after failed report write, want false")
+	}
+}
+
+func testInstallLocalTarballFileSizeOverride(t *testing.T) {
+	dataDir := t.TempDir()
+
+	tarball := createTestTarballFile(t, map[string]string{
+		"testdata/config.json": `{"setting": true}`,
+	})
+	t.Setenv(config.EnvResourcesTarball, tarball)
+	t.Setenv(config.EnvInstallMaxFileSize, "8")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err := Install(ctx, Options{DataDir: dataDir, Version: "v1.0.0", Force: false})
+	if !errors.Is(err, ErrFileTooLarge) {
+		t.Fatalf("Install() error = %v, want ErrFileTooLarge", err)
+	}
+	if !strings.Contains(err.Error(), "limit 8 bytes") {
+		t.Errorf("Install() error = %q, want configured limit in message", err)
+	}
 }
 
 func testInstallLocalTarballUnchangedSkip(t *testing.T) {
@@ -683,8 +908,51 @@ func testInstallTarballNotFound(t *testing.T) {
 	}
 }
 
+func testInstallFailedExtractKeepsPrevious(t *testing.T) {
+	dataDir := t.TempDir()
+	setupExistingInstall(t, dataDir, "v1.0.0")
+
+	// The second entry exceeds the file size limit after the first was extracted.
+	data := createTestTarGzRaw(t, []tarEntry{
+		{name: "testdata/new.txt", content: "ok", isDir: false},
+		{name: "testdata/large.txt", content: "more than eight bytes", isDir: false},
+	})
+	tarball := filepath.Join(t.TempDir(), "test.tar.gz")
+	if err := os.WriteFile(tarball, data, 0o644); err != nil {
+		t.Fatalf("write tarball file: %v", err)
+	}
+	t.Setenv(config.EnvResourcesTarball, tarball)
+	t.Setenv(config.EnvInstallMaxFileSize, "8")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err := Install(ctx, Options{DataDir: dataDir, Version: "v1.0.0", Force: true})
+	if !errors.Is(err, ErrFileTooLarge) {
+		t.Fatalf("Install() error = %v, want %v", err, ErrFileTooLarge)
+	}
+
+	verifyFileContent(t, filepath.Join(dataDir, "testdata/file.txt"), "existing")
+	verifyFileContent(t, filepath.Join(dataDir, versionFile), "v1.0.0\n")
+	if _, err := os.Stat(filepath.Join(dataDir, "testdata/new.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("partially extracted testdata/new.txt leaked into data dir: %v", err)
+	}
+	verifyNoSwapLeftovers(t, dataDir)
+}
+
 // Test helper functions for Install tests.
 
+func verifyNoSwapLeftovers(t *testing.T, dataDir string) {
+	t.Helper()
+	leftovers, err := filepath.Glob(dataDir + ".*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(leftovers) > 0 {
+		t.Errorf("staging or previous install directories left behind: %v", leftovers)
+	}
+}
+
 func setupExistingInstall(t *testing.T, dataDir, version string) {
 	t.Helper()
 	testdataPath := filepath.Join(dataDir, testdataDir)
@@ -797,3 +1065,97 @@ func createTestTarballFile(t *testing.T, files map[string]string) string {
 
 	return path
 }
+
+func TestResolveVersion_Latest(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	resolver := newLatestResolver(func(context.Context) (string, error) {
+		calls++
+		return "v1.4.0", nil
+	})
+
+	for range 2 {
+		version, err := resolveVersion(context.Background(), LatestVersion, resolver)
+		if err != nil {
+			t.Fatalf("resolveVersion() error = %v", err)
+		}
+		want := "https://github.com/Altinn/altinn-studio/releases/download/studioctl/v1.4.0/localtest-resources.tar.gz"
+		if got := releaseURL(version); got != want {
+			t.Fatalf("releaseURL(%q) = %q, want %q", version, got, want)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("fetch called %d times, want 1 (cached)", calls)
+	}
+
+	version, err := resolveVersion(context.Background(), "v1.0.0", resolver)
+	if err != nil || version != "v1.0.0" {
+		t.Fatalf("resolveVersion(v1.0.0) = %q, %v; want unchanged", version, err)
+	}
+}
+
+func TestLatestFromReleases(t *testing.T) {
+	t.Parallel()
+
+	releases := []githubRelease{
+		{TagName: "studioctl/v2.0.0-preview.1", Prerelease: true},
+		{TagName: "app-lib/v9.0.0"},
+		{TagName: "studioctl/v1.9.0", Draft: true},
+		{TagName: "studioctl/v1.7.1"},
+		{TagName: "studioctl/v1.8.0"},
+		{TagName: "studioctl/not-a-version"},
+		{TagName: "studioctl/v1.7.0"},
+	}
+	got, err := latestFromReleases(releases)
+	if err != nil || got != "v1.8.0" {
+		t.Fatalf("latestFromReleases() = %q, %v; want v1.8.0", got, err)
+	}
+
+	if _, err := latestFromReleases(releases[:3]); !errors.Is(err, ErrNoLatestRelease) {
+		t.Fatalf("latestFromReleases() error = %v, want ErrNoLatestRelease", err)
+	}
+}
+
+func TestFetchRelease_RetryableStatuses(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status int
+		want   bool
+	}{
+		{name: "not found", status: http.StatusNotFound, want: false},
+		{name: "forbidden", status: http.StatusForbidden, want: false},
+		{name: "rate limited", status: http.StatusTooManyRequests, want: true},
+		{name: "bad gateway", status: http.StatusBadGateway, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			t.Cleanup(server.Close)
+
+			_, err := fetchRelease(t.Context(), server.Client(), server.URL)
+			if !errors.Is(err, ErrDownloadFailed) {
+				t.Fatalf("fetchRelease() error = %v, want ErrDownloadFailed", err)
+			}
+			if got := isRetryableDownload(err); got != tt.want {
+				t.Errorf("isRetryableDownload(%v) = %v, want %v", err, got, tt.want)
+			}
+			if got := isNotFound(err); got != (tt.status == http.StatusNotFound) {
+				t.Errorf("isNotFound(%v) = %v", err, got)
+			}
+		})
+	}
+
+	if !isRetryableDownload(fmt.Errorf("%w: %w", ErrDownloadFailed, io.ErrUnexpectedEOF)) {
+		t.Error("network errors should be retryable")
+	}
+	if isRetryableDownload(ErrVersionRequired) {
+		t.Error("ErrVersionRequired should not be retryable")
+	}
+}
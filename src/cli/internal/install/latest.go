@@ -0,0 +1,152 @@
+package install
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"altinn.studio/studioctl/internal/httpclient"
+)
+
+const (
+	// LatestVersion resolves to the newest published studioctl release.
+	LatestVersion = "latest"
+
+	releasesAPIURL   = "https://api.github.com/repos/Altinn/altinn-studio/releases?per_page=100"
+	releaseTagPrefix = "studioctl/"
+	latestCacheTTL   = 5 * time.Minute
+	apiTimeout       = 30 * time.Second
+)
+
+// ErrNoLatestRelease is returned when no published studioctl release can be found.
+var ErrNoLatestRelease = errors.New("no published studioctl release found")
+
+// latestResolver resolves LatestVersion to a concrete release version and caches
+// the answer briefly so repeated lookups in one run only query GitHub once.
+type latestResolver struct {
+	at    time.Time
+	fetch func(ctx context.Context) (string, error)
+	now   func() time.Time
+	cache string
+	mu    sync.Mutex
+	ttl   time.Duration
+}
+
+func newLatestResolver(fetch func(ctx context.Context) (string, error)) *latestResolver {
+	return &latestResolver{
+		at:    time.Time{},
+		fetch: fetch,
+		now:   time.Now,
+		cache: "",
+		mu:    sync.Mutex{},
+		ttl:   latestCacheTTL,
+	}
+}
+
+//nolint:gochecknoglobals // process-wide cache so diff and the update check share one GitHub lookup
+var defaultLatestResolver = newLatestResolver(fetchLatestVersion)
+
+// Resolve returns the cached latest version, fetching it when missing or stale.
+func (r *latestResolver) Resolve(ctx context.Context) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cache != "" && r.now().Sub(r.at) < r.ttl {
+		return r.cache, nil
+	}
+
+	version, err := r.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	r.cache = version
+	r.at = r.now()
+	return version, nil
+}
+
+// ResolveLatestVersion returns the newest published studioctl release version,
+// sharing the short-lived cache used by install diff.
+func ResolveLatestVersion(ctx context.Context) (string, error) {
+	return defaultLatestResolver.Resolve(ctx)
+}
+
+// resolveVersion maps LatestVersion to a concrete release version and returns other versions unchanged.
+func resolveVersion(ctx context.Context, version string, resolver *latestResolver) (string, error) {
+	if version != LatestVersion {
+		return version, nil
+	}
+	resolved, err := resolver.Resolve(ctx)
+	if err != nil {
+		return "", fmt.Errorf("resolve latest version: %w", err)
+	}
+	return resolved, nil
+}
+
+type githubRelease struct {
+	TagName    string `json:"tag_name"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+// fetchLatestVersion queries the GitHub releases API for the newest stable studioctl release.
+// The repository publishes releases for several components, so tags are filtered by prefix.
+func fetchLatestVersion(ctx context.Context) (version string, err error) {
+	client, err := httpclient.New(apiTimeout)
+	if err != nil {
+		return "", fmt.Errorf("create http client: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, releasesAPIURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrDownloadFailed, err)
+	}
+	defer func() { err = closeWithError(resp.Body, "close response body", err) }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: releases API HTTP %d", ErrDownloadFailed, resp.StatusCode)
+	}
+
+	var releases []githubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return "", fmt.Errorf("decode releases: %w", err)
+	}
+
+	return latestFromReleases(releases)
+}
+
+// latestFromReleases picks the highest stable studioctl release by semantic version.
+// GitHub orders releases by creation date, so a patch to an older line can be listed first.
+func latestFromReleases(releases []githubRelease) (string, error) {
+	var latest string
+	var latestVersion semver
+	for _, release := range releases {
+		if release.Draft || release.Prerelease {
+			continue
+		}
+		version, ok := strings.CutPrefix(release.TagName, releaseTagPrefix)
+		if !ok {
+			continue
+		}
+		parsed, err := parseSemver(version)
+		if err != nil {
+			continue
+		}
+		if latest == "" || compareSemver(parsed, latestVersion) > 0 {
+			latest, latestVersion = version, parsed
+		}
+	}
+	if latest == "" {
+		return "", ErrNoLatestRelease
+	}
+	return latest, nil
+}
@@ -0,0 +1,354 @@
+package install
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"altinn.studio/studioctl/internal/osutil"
+)
+
+const (
+	manifestFile = ".manifest"
+
+	// manifestStampFields is the field count of a manifest entry with a cached file stamp.
+	manifestStampFields = 3
+)
+
+var (
+	// ErrManifestNotFound is returned when no manifest exists for the installed resources.
+	ErrManifestNotFound = errors.New("resource manifest not found")
+
+	// ErrInvalidManifest is returned when a manifest file cannot be parsed.
+	ErrInvalidManifest = errors.New("invalid resource manifest")
+)
+
+// Manifest maps slash-separated resource paths to their SHA-256 hex digest.
+type Manifest map[string]string
+
+// ManifestDiff lists resource paths that differ between two manifests.
+// Each list is sorted.
+type ManifestDiff struct {
+	Added   []string
+	Removed []string
+	Changed []string
+}
+
+// Empty reports whether the manifests were identical.
+func (d ManifestDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Diff compares two manifests and reports added, removed, and changed paths.
+func Diff(oldManifest, newManifest Manifest) ManifestDiff {
+	diff := ManifestDiff{
+		Added:   []string{},
+		Removed: []string{},
+		Changed: []string{},
+	}
+
+	for path, newSum := range newManifest {
+		oldSum, ok := oldManifest[path]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, path)
+		case oldSum != newSum:
+			diff.Changed = append(diff.Changed, path)
+		}
+	}
+	for path := range oldManifest {
+		if _, ok := newManifest[path]; !ok {
+			diff.Removed = append(diff.Removed, path)
+		}
+	}
+
+	slices.Sort(diff.Added)
+	slices.Sort(diff.Removed)
+	slices.Sort(diff.Changed)
+	return diff
+}
+
+// fileStamp records the size and modification time a manifest hash was computed for.
+// Files whose stamp is unchanged are not re-hashed by Verify.
+type fileStamp struct {
+	size    int64
+	modTime int64 // UnixNano
+}
+
+func stampOf(info os.FileInfo) fileStamp {
+	return fileStamp{size: info.Size(), modTime: info.ModTime().UnixNano()}
+}
+
+// VerifyResult lists installed resource files that no longer match the manifest.
+// Each list is sorted.
+type VerifyResult struct {
+	Missing  []string // listed in the manifest but absent on disk
+	Modified []string // content differs from the manifest hash
+	Rehashed []string // re-hashed because size or mtime changed since the manifest was written
+}
+
+// OK reports whether every manifest entry matched.
+func (r VerifyResult) OK() bool {
+	return len(r.Missing) == 0 && len(r.Modified) == 0
+}
+
+// ReadManifest reads the manifest of the resources installed in dataDir.
+func ReadManifest(dataDir string) (Manifest, error) {
+	manifest, _, err := readManifestWithStamps(dataDir)
+	return manifest, err
+}
+
+func readManifestWithStamps(dataDir string) (Manifest, map[string]fileStamp, error) {
+	path := filepath.Join(dataDir, manifestFile)
+	content, err := readTrustedFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, fmt.Errorf("%w: %s", ErrManifestNotFound, path)
+		}
+		return nil, nil, err
+	}
+	return parseManifest(content)
+}
+
+// ParseManifest parses manifest content in "<sha256>  <path>" line format.
+// Cached file stamps ("<sha256> <size> <mtime>  <path>") are accepted and ignored.
+func ParseManifest(content []byte) (Manifest, error) {
+	manifest, _, err := parseManifest(content)
+	return manifest, err
+}
+
+func parseManifest(content []byte) (Manifest, map[string]fileStamp, error) {
+	manifest := make(Manifest)
+	stamps := make(map[string]fileStamp)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		fields, path, ok := strings.Cut(line, "  ")
+		sum, stamp, hasStamp, valid := parseManifestFields(fields)
+		if !ok || !valid || path == "" {
+			return nil, nil, fmt.Errorf("%w: line %d: %q", ErrInvalidManifest, lineNum, line)
+		}
+		manifest[path] = sum
+		if hasStamp {
+			stamps[path] = stamp
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("scan manifest: %w", err)
+	}
+	return manifest, stamps, nil
+}
+
+// parseManifestFields parses "<sha256>" or "<sha256> <size> <mtime>".
+func parseManifestFields(fields string) (sum string, stamp fileStamp, hasStamp, valid bool) {
+	parts := strings.Fields(fields)
+	switch len(parts) {
+	case 1:
+		return parts[0], fileStamp{}, false, true
+	case manifestStampFields:
+		size, sizeErr := strconv.ParseInt(parts[1], 10, 64)
+		modTime, modErr := strconv.ParseInt(parts[2], 10, 64)
+		if sizeErr != nil || modErr != nil {
+			return "", fileStamp{}, false, false
+		}
+		return parts[0], fileStamp{size: size, modTime: modTime}, true, true
+	default:
+		return "", fileStamp{}, false, false
+	}
+}
+
+// Bytes renders the manifest in "<sha256>  <path>" line format, sorted by path.
+func (m Manifest) Bytes() []byte {
+	return m.bytesWithStamps(nil)
+}
+
+// bytesWithStamps renders the manifest, adding cached file stamps where known.
+func (m Manifest) bytesWithStamps(stamps map[string]fileStamp) []byte {
+	paths := make([]string, 0, len(m))
+	for path := range m {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	var b bytes.Buffer
+	for _, path := range paths {
+		b.WriteString(m[path])
+		if stamp, ok := stamps[path]; ok {
+			fmt.Fprintf(&b, " %d %d", stamp.size, stamp.modTime)
+		}
+		b.WriteString("  ")
+		b.WriteString(path)
+		b.WriteByte('\n')
+	}
+	return b.Bytes()
+}
+
+// writeManifest writes the manifest with the current size and mtime of each installed file,
+// so later Verify runs can skip hashing files that have not changed.
+func writeManifest(dataDir string, manifest Manifest) error {
+	stamps := make(map[string]fileStamp, len(manifest))
+	for path := range manifest {
+		if info, err := os.Stat(filepath.Join(dataDir, filepath.FromSlash(path))); err == nil {
+			stamps[path] = stampOf(info)
+		}
+	}
+	path := filepath.Join(dataDir, manifestFile)
+	if err := os.WriteFile(path, manifest.bytesWithStamps(stamps), osutil.FilePermDefault); err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+	return nil
+}
+
+// Verify checks the resources installed in dataDir against their manifest.
+// Only files whose size or mtime changed since the manifest was written are re-hashed.
+// Verify never writes to dataDir, so read-only commands such as doctor can call it.
+func Verify(dataDir string) (VerifyResult, error) {
+	result := VerifyResult{Missing: []string{}, Modified: []string{}, Rehashed: []string{}}
+
+	manifest, stamps, err := readManifestWithStamps(dataDir)
+	if err != nil {
+		return result, err
+	}
+
+	for _, path := range slices.Sorted(maps.Keys(manifest)) {
+		target := filepath.Join(dataDir, filepath.FromSlash(path))
+		info, err := os.Stat(target)
+		if errors.Is(err, os.ErrNotExist) {
+			result.Missing = append(result.Missing, path)
+			continue
+		}
+		if err != nil {
+			return result, fmt.Errorf("stat %s: %w", target, err)
+		}
+
+		stamp := stampOf(info)
+		if cached, ok := stamps[path]; ok && cached == stamp {
+			continue
+		}
+
+		result.Rehashed = append(result.Rehashed, path)
+		sum, err := fileSHA256Hex(target)
+		if err != nil {
+			return result, err
+		}
+		if sum != manifest[path] {
+			result.Modified = append(result.Modified, path)
+		}
+	}
+	return result, nil
+}
+
+// FetchManifest downloads the resource manifest published with the release for version.
+// Releases published before the manifest asset existed fall back to downloading the archive
+// and building the manifest in memory, without extracting anything to disk.
+func FetchManifest(ctx context.Context, version string) (manifest Manifest, err error) {
+	limits, err := resolveSizeLimits(0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err = resolveVersion(ctx, version, defaultLatestResolver)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err = fetchManifestAsset(ctx, version, limits)
+	if !isNotFound(err) {
+		return manifest, err
+	}
+
+	body, err := downloadRelease(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = closeWithError(body, "close response body", err) }()
+
+	return buildManifest(body, limits)
+}
+
+func fetchManifestAsset(ctx context.Context, version string, limits sizeLimits) (manifest Manifest, err error) {
+	if version == "" || version == "dev" {
+		return nil, ErrVersionRequired
+	}
+	body, err := download(ctx, manifestURL(version))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = closeWithError(body, "close response body", err) }()
+
+	content, err := io.ReadAll(io.LimitReader(body, limits.file+1))
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+	if int64(len(content)) > limits.file {
+		return nil, fmt.Errorf("%w: manifest exceeds %d bytes", ErrInvalidManifest, limits.file)
+	}
+	return ParseManifest(content)
+}
+
+func buildManifest(r io.Reader, limits sizeLimits) (manifest Manifest, err error) {
+	gzr, err := gzip.NewReader(&archiveLimitReader{r: r, limit: limits.archive, read: 0})
+	if err != nil {
+		return nil, fmt.Errorf("create gzip reader: %w", err)
+	}
+	defer func() { err = closeWithError(gzr, "close gzip reader", err) }()
+
+	manifest = make(Manifest)
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read tar header: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name, ok := manifestPath(header.Name)
+		if !ok {
+			continue
+		}
+		if err := checkFileSize(header, limits.file); err != nil {
+			return nil, err
+		}
+
+		h := sha256.New()
+		if _, err := io.Copy(h, io.LimitReader(tr, header.Size)); err != nil {
+			return nil, fmt.Errorf("hash archive entry %s: %w", header.Name, err)
+		}
+		manifest[name] = hex.EncodeToString(h.Sum(nil))
+	}
+
+	return manifest, nil
+}
+
+// manifestPath returns the slash-separated manifest key for an archive entry,
+// or false if the entry would be skipped during extraction.
+func manifestPath(name string) (string, bool) {
+	cleanName := filepath.Clean(name)
+	if strings.HasPrefix(cleanName, "..") || filepath.IsAbs(cleanName) {
+		return "", false
+	}
+	return filepath.ToSlash(cleanName), true
+}
@@ -0,0 +1,225 @@
+//nolint:testpackage // testing unexported functions
+package install
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	oldManifest := Manifest{
+		"testdata/kept.json":    "aaa",
+		"testdata/changed.json": "bbb",
+		"infra/removed.yaml":    "ccc",
+	}
+	newManifest := Manifest{
+		"testdata/kept.json":    "aaa",
+		"testdata/changed.json": "ddd",
+		"infra/added.yaml":      "eee",
+		"infra/also-added.yaml": "fff",
+	}
+
+	tests := []struct {
+		name string
+		got  func(ManifestDiff) []string
+		want []string
+	}{
+		{
+			name: "added",
+			got:  func(d ManifestDiff) []string { return d.Added },
+			want: []string{"infra/added.yaml", "infra/also-added.yaml"},
+		},
+		{
+			name: "removed",
+			got:  func(d ManifestDiff) []string { return d.Removed },
+			want: []string{"infra/removed.yaml"},
+		},
+		{
+			name: "changed",
+			got:  func(d ManifestDiff) []string { return d.Changed },
+			want: []string{"testdata/changed.json"},
+		},
+	}
+
+	diff := Diff(oldManifest, newManifest)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.got(diff); !slices.Equal(got, tt.want) {
+				t.Errorf("Diff() %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("identical", func(t *testing.T) {
+		t.Parallel()
+		if d := Diff(oldManifest, oldManifest); !d.Empty() {
+			t.Errorf("Diff() of identical manifests = %+v, want empty", d)
+		}
+	})
+}
+
+func TestManifestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	manifest := Manifest{
+		"testdata/b.json": "222",
+		"testdata/a.json": "111",
+	}
+
+	content := manifest.Bytes()
+	if want := "111  testdata/a.json\n222  testdata/b.json\n"; string(content) != want {
+		t.Fatalf("Bytes() = %q, want %q", content, want)
+	}
+
+	parsed, err := ParseManifest(content)
+	if err != nil {
+		t.Fatalf("ParseManifest() error = %v", err)
+	}
+	if d := Diff(manifest, parsed); !d.Empty() {
+		t.Errorf("ParseManifest(Bytes()) differs: %+v", d)
+	}
+}
+
+func TestParseManifest_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseManifest([]byte("not-a-manifest-line\n"))
+	if !errors.Is(err, ErrInvalidManifest) {
+		t.Fatalf("ParseManifest() error = %v, want ErrInvalidManifest", err)
+	}
+}
+
+func TestReadManifest_NotFound(t *testing.T) {
+	t.Parallel()
+
+	_, err := ReadManifest(t.TempDir())
+	if !errors.Is(err, ErrManifestNotFound) {
+		t.Fatalf("ReadManifest() error = %v, want ErrManifestNotFound", err)
+	}
+}
+
+func TestBuildManifest_MatchesExtraction(t *testing.T) {
+	t.Parallel()
+
+	tarData := createTestTarGzRaw(t, []tarEntry{
+		{name: "testdata/", isDir: true},
+		{name: "testdata/config.json", content: `{"setting": true}`, isDir: false},
+		{name: "../outside.txt", content: "malicious", isDir: false},
+		{name: "infra/otel.yaml", content: "receivers: []", isDir: false},
+	})
+
+	built, err := buildManifest(bytes.NewReader(tarData), defaultLimits)
+	if err != nil {
+		t.Fatalf("buildManifest() error = %v", err)
+	}
+
+	dst := t.TempDir()
+	extracted, err := extractTarGz(bytes.NewReader(tarData), dst, defaultLimits)
+	if err != nil {
+		t.Fatalf("extractTarGz() error = %v", err)
+	}
+
+	if len(built) != 2 {
+		t.Errorf("buildManifest() = %v, want 2 entries", built)
+	}
+	if d := Diff(extracted, built); !d.Empty() {
+		t.Errorf("buildManifest() differs from extraction manifest: %+v", d)
+	}
+	if _, statErr := os.Stat(filepath.Join(dst, "testdata", "config.json")); statErr != nil {
+		t.Errorf("extracted file missing: %v", statErr)
+	}
+}
+
+func TestVerify_UsesCachedStamps(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+	tarData := createTestTarGzRaw(t, []tarEntry{
+		{name: "testdata/a.json", content: "a", isDir: false},
+		{name: "testdata/b.json", content: "b", isDir: false},
+		{name: "testdata/c.json", content: "c", isDir: false},
+	})
+	manifest, err := extractTarGz(bytes.NewReader(tarData), dataDir, defaultLimits)
+	if err != nil {
+		t.Fatalf("extractTarGz() error = %v", err)
+	}
+	if err := writeManifest(dataDir, manifest); err != nil {
+		t.Fatalf("writeManifest() error = %v", err)
+	}
+	before, err := os.ReadFile(filepath.Join(dataDir, manifestFile))
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+
+	result, err := Verify(dataDir)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if !result.OK() || len(result.Rehashed) != 0 {
+		t.Fatalf("Verify() on fresh install = %+v, want OK without rehashing", result)
+	}
+
+	touched := filepath.Join(dataDir, "testdata", "a.json")
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(touched, later, later); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "testdata", "b.json"), []byte("changed"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := os.Remove(filepath.Join(dataDir, "testdata", "c.json")); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+
+	result, err = Verify(dataDir)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if want := []string{"testdata/a.json", "testdata/b.json"}; !slices.Equal(result.Rehashed, want) {
+		t.Errorf("Verify() Rehashed = %v, want %v", result.Rehashed, want)
+	}
+	if want := []string{"testdata/b.json"}; !slices.Equal(result.Modified, want) {
+		t.Errorf("Verify() Modified = %v, want %v", result.Modified, want)
+	}
+	if want := []string{"testdata/c.json"}; !slices.Equal(result.Missing, want) {
+		t.Errorf("Verify() Missing = %v, want %v", result.Missing, want)
+	}
+
+	after, err := os.ReadFile(filepath.Join(dataDir, manifestFile))
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	if !bytes.Equal(after, before) {
+		t.Errorf("Verify() rewrote the manifest:\n%s\nwant\n%s", after, before)
+	}
+}
+
+func TestParseManifest_AcceptsStamps(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("111 5 1700000000000000000  testdata/a.json\n222  testdata/b.json\n")
+	manifest, stamps, err := parseManifest(content)
+	if err != nil {
+		t.Fatalf("parseManifest() error = %v", err)
+	}
+	if manifest["testdata/a.json"] != "111" || manifest["testdata/b.json"] != "222" {
+		t.Errorf("parseManifest() manifest = %v", manifest)
+	}
+	if want := (fileStamp{size: 5, modTime: 1700000000000000000}); stamps["testdata/a.json"] != want {
+		t.Errorf("parseManifest() stamp = %+v, want %+v", stamps["testdata/a.json"], want)
+	}
+	if _, ok := stamps["testdata/b.json"]; ok {
+		t.Error("parseManifest() recorded a stamp for a legacy entry")
+	}
+	if !bytes.Equal(manifest.bytesWithStamps(stamps), content) {
+		t.Errorf("bytesWithStamps() = %q, want %q", manifest.bytesWithStamps(stamps), content)
+	}
+}
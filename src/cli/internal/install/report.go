@@ -0,0 +1,89 @@
+package install
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"altinn.studio/studioctl/internal/config"
+	"altinn.studio/studioctl/internal/osutil"
+)
+
+// ReportFile is the name of the install report written to the data directory.
+const ReportFile = "install-report.json"
+
+// ErrReportNotFound is returned when no install report exists in the data directory.
+var ErrReportNotFound = errors.New("install report not found")
+
+// Report is a machine-readable record of a completed install, for provisioning automation.
+type Report struct {
+	InstalledAt time.Time `json:"installedAt"`
+	Version     string    `json:"version"`
+	// Source is the release URL, or "tarball-sha256:<sum>" for a local tarball install.
+	Source string `json:"source"`
+	// RestampedFrom is the version the resources were extracted for, set once a later
+	// version restamped them (see Restamp). Source still describes that original install.
+	RestampedFrom string `json:"restampedFrom,omitempty"`
+	FileCount     int    `json:"fileCount"`
+	TotalBytes    int64  `json:"totalBytes"`
+}
+
+// ReadReport reads the install report from dataDir.
+func ReadReport(dataDir string) (Report, error) {
+	path := filepath.Join(dataDir, ReportFile)
+	content, err := readTrustedFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return Report{}, fmt.Errorf("%w: %s", ErrReportNotFound, path)
+		}
+		return Report{}, err
+	}
+	var report Report
+	if err := json.Unmarshal(content, &report); err != nil {
+		return Report{}, fmt.Errorf("parse install report %s: %w", path, err)
+	}
+	return report, nil
+}
+
+// newReport describes the files in manifest as extracted into dataDir.
+func newReport(dataDir, version string, manifest Manifest, now time.Time) (Report, error) {
+	source := releaseURL(version)
+	if os.Getenv(config.EnvResourcesTarball) != "" {
+		marker, err := expectedSourceMarker(version)
+		if err != nil {
+			return Report{}, err
+		}
+		source = marker
+	}
+
+	report := Report{
+		InstalledAt: now.UTC(),
+		Version:     version,
+		Source:      source,
+		FileCount:   len(manifest),
+		TotalBytes:  0,
+	}
+	for path := range manifest {
+		info, err := os.Stat(filepath.Join(dataDir, filepath.FromSlash(path)))
+		if err != nil {
+			return Report{}, fmt.Errorf("stat installed file %s: %w", path, err)
+		}
+		report.TotalBytes += info.Size()
+	}
+	return report, nil
+}
+
+func writeReport(dataDir string, report Report) error {
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode install report: %w", err)
+	}
+	path := filepath.Join(dataDir, ReportFile)
+	if err := os.WriteFile(path, append(content, '\n'), osutil.FilePermDefault); err != nil {
+		return fmt.Errorf("write install report: %w", err)
+	}
+	return nil
+}
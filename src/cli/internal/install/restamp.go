@@ -0,0 +1,84 @@
+package install
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"altinn.studio/studioctl/internal/config"
+)
+
+// ErrCannotRestamp is returned when installed resources cannot be restamped and need a full install.
+var ErrCannotRestamp = errors.New("installed resources cannot be restamped")
+
+// Restamp marks the resources installed in dataDir as installed for version without downloading
+// or extracting them again. It applies only to a complete release install stamped with another
+// version whose files still match their manifest (see Verify), and is meant for CLI upgrades that
+// did not change the resources. Resources already installed for version are left as they are, and
+// resources installed from a local tarball are never restamped.
+func Restamp(dataDir, version string) error {
+	if version == "" || version == "dev" || version == LatestVersion {
+		return ErrVersionRequired
+	}
+	if os.Getenv(config.EnvResourcesTarball) != "" {
+		return fmt.Errorf("%w: local tarball installs are always extracted", ErrCannotRestamp)
+	}
+
+	status := CheckInstallStatus(dataDir, version)
+	switch status.State {
+	case StateInstalled:
+		return nil
+	case StateVersionMismatch, StateSourceMarkerMismatch:
+	default:
+		return fmt.Errorf("%w: resources are not fully installed (%s)", ErrCannotRestamp, status.Path)
+	}
+
+	marker, err := readTrustedFile(filepath.Join(dataDir, sourceMarkerFile))
+	if err != nil {
+		return fmt.Errorf("read source marker: %w", err)
+	}
+	if strings.HasPrefix(strings.TrimSpace(string(marker)), tarballMarkerPrefix) {
+		return fmt.Errorf("%w: resources were installed from a local tarball", ErrCannotRestamp)
+	}
+
+	result, err := Verify(dataDir)
+	if err != nil {
+		if errors.Is(err, ErrManifestNotFound) {
+			return fmt.Errorf("%w: %w", ErrCannotRestamp, err)
+		}
+		return fmt.Errorf("verify installed resources: %w", err)
+	}
+	if !result.OK() {
+		return fmt.Errorf(
+			"%w: %d missing, %d modified files",
+			ErrCannotRestamp, len(result.Missing), len(result.Modified),
+		)
+	}
+
+	if err := writeVersionFile(dataDir, version); err != nil {
+		return fmt.Errorf("write version file: %w", err)
+	}
+	if err := writeSourceMarker(dataDir, version); err != nil {
+		return fmt.Errorf("write source marker: %w", err)
+	}
+	return restampReport(dataDir, version)
+}
+
+// restampReport updates the version in the install report, if there is one. The source is kept,
+// since the files still come from the original install, and RestampedFrom records its version.
+func restampReport(dataDir, version string) error {
+	report, err := ReadReport(dataDir)
+	if errors.Is(err, ErrReportNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if report.RestampedFrom == "" {
+		report.RestampedFrom = report.Version
+	}
+	report.Version = version
+	return writeReport(dataDir, report)
+}
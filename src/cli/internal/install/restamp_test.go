@@ -0,0 +1,115 @@
+//nolint:testpackage // testing unexported functions
+package install
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRestamp(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		wantErr   error
+		modify    func(t *testing.T, dataDir string)
+		name      string
+		wantStamp bool
+	}{
+		{
+			name:      "intact content with old version",
+			modify:    func(*testing.T, string) {},
+			wantStamp: true,
+		},
+		{
+			name: "modified content",
+			modify: func(t *testing.T, dataDir string) {
+				t.Helper()
+				path := filepath.Join(dataDir, testdataDir, "file.txt")
+				if err := os.WriteFile(path, []byte("edited"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr: ErrCannotRestamp,
+		},
+		{
+			name: "no manifest",
+			modify: func(t *testing.T, dataDir string) {
+				t.Helper()
+				if err := os.Remove(filepath.Join(dataDir, manifestFile)); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr: ErrCannotRestamp,
+		},
+		{
+			name: "local tarball install",
+			modify: func(t *testing.T, dataDir string) {
+				t.Helper()
+				marker := []byte(tarballMarkerPrefix + "abc123\n")
+				if err := os.WriteFile(filepath.Join(dataDir, sourceMarkerFile), marker, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr: ErrCannotRestamp,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dataDir := t.TempDir()
+			setupExistingInstall(t, dataDir, "v1.0.0")
+			sum := sha256.Sum256([]byte("existing"))
+			if err := writeManifest(dataDir, Manifest{"testdata/file.txt": hex.EncodeToString(sum[:])}); err != nil {
+				t.Fatal(err)
+			}
+			installedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+			report := Report{InstalledAt: installedAt, Version: "v1.0.0", Source: releaseURL("v1.0.0"), FileCount: 1}
+			if err := writeReport(dataDir, report); err != nil {
+				t.Fatal(err)
+			}
+			tt.modify(t, dataDir)
+			filePath := filepath.Join(dataDir, testdataDir, "file.txt")
+			before, err := os.Stat(filePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = Restamp(dataDir, "v1.1.0")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Restamp() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := IsInstalled(dataDir, "v1.1.0"); got != tt.wantStamp {
+				t.Fatalf("IsInstalled(v1.1.0) = %t after Restamp(), want %t", got, tt.wantStamp)
+			}
+			if !tt.wantStamp {
+				return
+			}
+
+			// Nothing was extracted again: the resource file is untouched.
+			after, err := os.Stat(filePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !after.ModTime().Equal(before.ModTime()) {
+				t.Errorf("%s modified by Restamp()", filePath)
+			}
+			got, err := ReadReport(dataDir)
+			if err != nil {
+				t.Fatalf("ReadReport() error = %v", err)
+			}
+			want := report
+			want.Version = "v1.1.0"
+			want.RestampedFrom = "v1.0.0"
+			if got != want {
+				t.Errorf("ReadReport() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
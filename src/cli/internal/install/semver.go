@@ -0,0 +1,92 @@
+package install
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IsNewer reports whether latest is a higher semantic version than current.
+// Versions that do not parse (such as "dev") are never considered older.
+func IsNewer(latest, current string) bool {
+	l, err := parseSemver(latest)
+	if err != nil {
+		return false
+	}
+	c, err := parseSemver(current)
+	if err != nil {
+		return false
+	}
+	return compareSemver(l, c) > 0
+}
+
+var errInvalidVersion = errors.New("invalid version")
+
+type semver struct {
+	prerelease []string
+	core       [3]int
+}
+
+func parseSemver(raw string) (semver, error) {
+	raw = strings.TrimPrefix(strings.TrimSpace(raw), "v")
+	raw, _, _ = strings.Cut(raw, "+")
+	coreRaw, pre, hasPre := strings.Cut(raw, "-")
+
+	parts := strings.Split(coreRaw, ".")
+	var v semver
+	if len(parts) != len(v.core) {
+		return semver{}, fmt.Errorf("%w: %q", errInvalidVersion, raw)
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return semver{}, fmt.Errorf("%w: %q", errInvalidVersion, raw)
+		}
+		v.core[i] = n
+	}
+	if hasPre {
+		if pre == "" {
+			return semver{}, fmt.Errorf("%w: %q", errInvalidVersion, raw)
+		}
+		v.prerelease = strings.Split(pre, ".")
+	}
+	return v, nil
+}
+
+// compareSemver orders versions per semver precedence.
+func compareSemver(a, b semver) int {
+	for i := range a.core {
+		if a.core[i] != b.core[i] {
+			return a.core[i] - b.core[i]
+		}
+	}
+	switch {
+	case len(a.prerelease) == 0 && len(b.prerelease) == 0:
+		return 0
+	case len(a.prerelease) == 0:
+		return 1
+	case len(b.prerelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(a.prerelease) && i < len(b.prerelease); i++ {
+		if c := compareIdentifier(a.prerelease[i], b.prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	return len(a.prerelease) - len(b.prerelease)
+}
+
+func compareIdentifier(a, b string) int {
+	an, aErr := strconv.Atoi(a)
+	bn, bErr := strconv.Atoi(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		return an - bn
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
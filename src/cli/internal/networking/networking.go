@@ -191,6 +191,8 @@ func (n *Networking) RefreshNetworkMetadata(ctx context.Context) (NetworkMetadat
 		Labels:        nil,
 		User:          "",
 		CapAdd:        nil,
+		Healthcheck:   nil,
+		Resources:     types.ResourceLimits{MemoryBytes: 0, NanoCPUs: 0},
 	}
 
 	containerID, err := n.client.CreateContainer(ctx, cfg)
@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"altinn.studio/studioctl/internal/auth"
+	"altinn.studio/studioctl/internal/httpclient"
 )
 
 const (
@@ -81,16 +82,19 @@ type Client struct {
 }
 
 // NewClient creates a new Studio API client from credentials.
-func NewClient(creds *auth.EnvCredentials) *Client {
-	return &Client{
-		host:     creds.Host,
-		token:    creds.Token,
-		username: creds.Username,
-		scheme:   "https",
-		httpClient: &http.Client{
-			Timeout: httpTimeout,
-		},
+// It fails if the configured CA bundle (STUDIOCTL_CA_BUNDLE) cannot be loaded.
+func NewClient(creds *auth.EnvCredentials) (*Client, error) {
+	httpClient, err := httpclient.New(httpTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("create http client: %w", err)
 	}
+	return &Client{
+		host:       creds.Host,
+		token:      creds.Token,
+		username:   creds.Username,
+		scheme:     "https",
+		httpClient: httpClient,
+	}, nil
 }
 
 // NewClientWithHTTP creates a new client with a custom HTTP client (for testing).
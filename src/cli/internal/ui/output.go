@@ -9,14 +9,31 @@ import (
 	"sync"
 
 	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/term"
 )
 
-// Colors checks if color output is enabled.
+// Colors reports whether the NO_COLOR environment variable permits color output.
+// Output decides per writer with ColorsEnabled, which also honors --no-color and non-TTY output.
 func Colors() bool {
 	_, noColor := os.LookupEnv("NO_COLOR")
 	return !noColor
 }
 
+// ColorsEnabled is the single color decision for studioctl output to stdout.
+// Color is disabled by the --no-color flag, the NO_COLOR environment variable, or a non-terminal stdout.
+func ColorsEnabled(noColorFlag bool) bool {
+	return colorsEnabled(noColorFlag, isTerminal(os.Stdout))
+}
+
+func colorsEnabled(noColorFlag, terminal bool) bool {
+	return !noColorFlag && Colors() && terminal
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
 // Style functions return lipgloss styles for terminal output.
 // Using functions instead of package-level vars to satisfy gochecknoglobals.
 func errorStyle() lipgloss.Style   { return lipgloss.NewStyle().Foreground(lipgloss.Color("9")) }  // red
@@ -39,25 +56,57 @@ func containerColors() []lipgloss.Color {
 
 // Output provides structured output to the terminal.
 type Output struct {
-	out     io.Writer
-	err     io.Writer
-	verbose bool
-	mu      sync.Mutex
+	out       io.Writer
+	err       io.Writer
+	verbose   bool
+	colors    bool // color on out
+	errColors bool // color on err, decided separately so `2>file` gets no escapes
+	mu        sync.Mutex
 }
 
 // NewOutput creates a new Output instance.
+// Color is enabled per writer, only when NO_COLOR is unset and that writer is a terminal.
 func NewOutput(out, errOut io.Writer, verbose bool) *Output {
 	return &Output{
-		out:     out,
-		err:     errOut,
-		verbose: verbose,
-		mu:      sync.Mutex{},
+		out:       out,
+		err:       errOut,
+		verbose:   verbose,
+		colors:    colorsEnabled(false, isTerminal(out)),
+		errColors: colorsEnabled(false, isTerminal(errOut)),
+		mu:        sync.Mutex{},
 	}
 }
 
 // DefaultOutput returns an Output configured for stdout/stderr.
-func DefaultOutput(verbose bool) *Output {
-	return NewOutput(os.Stdout, os.Stderr, verbose)
+// noColor forces color off (--no-color).
+func DefaultOutput(verbose, noColor bool) *Output {
+	o := NewOutput(os.Stdout, os.Stderr, verbose)
+	o.colors = colorsEnabled(noColor, isTerminal(os.Stdout))
+	o.errColors = colorsEnabled(noColor, isTerminal(os.Stderr))
+	return o
+}
+
+// ToStderr returns an Output that writes everything, including normal messages, to this
+// output's stderr. Commands use it when stdout carries machine-readable output.
+func (o *Output) ToStderr() *Output {
+	return &Output{
+		out:       o.err,
+		err:       o.err,
+		verbose:   o.verbose,
+		colors:    o.errColors,
+		errColors: o.errColors,
+		mu:        sync.Mutex{},
+	}
+}
+
+// Stdout returns the writer for normal messages, for machine-readable output.
+func (o *Output) Stdout() io.Writer {
+	return o.out
+}
+
+// Colors reports whether this output uses color on stdout.
+func (o *Output) Colors() bool {
+	return o.colors
 }
 
 // Print writes a message to stdout.
@@ -92,7 +141,7 @@ func (o *Output) Printf(format string, args ...any) {
 
 // Error writes an error message to stderr.
 func (o *Output) Error(msg string) {
-	if Colors() {
+	if o.errColors {
 		msg = errorStyle().Render(msg)
 	}
 	o.mu.Lock()
@@ -110,7 +159,7 @@ func (o *Output) Errorf(format string, args ...any) {
 
 // Warning writes a warning message to stderr.
 func (o *Output) Warning(msg string) {
-	if Colors() {
+	if o.errColors {
 		msg = warningStyle().Render(msg)
 	}
 	o.mu.Lock()
@@ -128,7 +177,7 @@ func (o *Output) Warningf(format string, args ...any) {
 
 // Success writes a success message to stdout.
 func (o *Output) Success(msg string) {
-	if Colors() {
+	if o.Colors() {
 		msg = successStyle().Render(msg)
 	}
 	o.mu.Lock()
@@ -146,7 +195,7 @@ func (o *Output) Successf(format string, args ...any) {
 
 // Info writes an info message to stdout.
 func (o *Output) Info(msg string) {
-	if Colors() {
+	if o.Colors() {
 		msg = infoStyle().Render(msg)
 	}
 	o.mu.Lock()
@@ -163,9 +212,11 @@ func (o *Output) Infof(format string, args ...any) {
 }
 
 // Verbose writes a message only if verbose mode is enabled.
+// Token-like substrings are masked with Redact.
 func (o *Output) Verbose(msg string) {
 	if o.verbose {
-		if Colors() {
+		msg = Redact(msg)
+		if o.Colors() {
 			msg = dimStyle().Render(msg)
 		}
 		o.mu.Lock()
@@ -184,7 +235,7 @@ func (o *Output) Verbosef(format string, args ...any) {
 
 // ContainerPrefix returns a colored prefix for container log output.
 func (o *Output) ContainerPrefix(name string, colorIndex int) string {
-	if !Colors() {
+	if !o.Colors() {
 		return fmt.Sprintf("%-20s | ", name)
 	}
 
@@ -194,6 +245,26 @@ func (o *Output) ContainerPrefix(name string, colorIndex int) string {
 	return style.Render(fmt.Sprintf("%-20s", name)) + " | "
 }
 
+// IsTerminal reports whether stdout is an interactive terminal.
+func (o *Output) IsTerminal() bool {
+	return isTerminal(o.out)
+}
+
+// IsErrTerminal reports whether stderr is an interactive terminal.
+func (o *Output) IsErrTerminal() bool {
+	return isTerminal(o.err)
+}
+
+// ClearScreen clears the terminal and moves the cursor to the top-left corner.
+func (o *Output) ClearScreen() {
+	o.mu.Lock()
+	_, err := fmt.Fprint(o.out, "\033[H\033[2J")
+	o.mu.Unlock()
+	if err != nil {
+		o.logWriteErr(err)
+	}
+}
+
 // Table renders a simple key-value table.
 func (o *Output) Table(rows [][]string) {
 	if len(rows) == 0 {
@@ -231,16 +302,23 @@ func (o *Output) Table(rows [][]string) {
 type Section struct {
 	out      *Output
 	keyWidth int
+	redact   bool
 }
 
 // NewSection creates a Section with the specified key column width.
 func (o *Output) NewSection(keyWidth int) *Section {
-	return &Section{out: o, keyWidth: keyWidth}
+	return &Section{out: o, keyWidth: keyWidth, redact: false}
+}
+
+// NewRedactedSection creates a Section that masks token-like substrings in values with Redact,
+// for reports such as doctor that echo environment-derived values.
+func (o *Output) NewRedactedSection(keyWidth int) *Section {
+	return &Section{out: o, keyWidth: keyWidth, redact: true}
 }
 
 // Header prints a styled section header.
 func (s *Section) Header(title string) {
-	if Colors() {
+	if s.out.Colors() {
 		title = lipgloss.NewStyle().Bold(true).Render(title)
 	}
 	s.out.mu.Lock()
@@ -252,10 +330,14 @@ func (s *Section) Header(title string) {
 }
 
 // KeyValue prints a key-value pair with consistent column width.
+// Token-like substrings in value are masked with Redact in a redacted section.
 func (s *Section) KeyValue(key, value string) {
+	if s.redact {
+		value = Redact(value)
+	}
 	// Pad before styling so ANSI codes don't affect alignment
 	paddedKey := fmt.Sprintf("%-*s", s.keyWidth, key)
-	if Colors() {
+	if s.out.Colors() {
 		paddedKey = dimStyle().Render(paddedKey)
 	}
 	s.out.mu.Lock()
@@ -267,14 +349,18 @@ func (s *Section) KeyValue(key, value string) {
 }
 
 // KeyValueStatus prints a key-value pair with a status icon prefix.
+// Token-like substrings in value are masked with Redact in a redacted section.
 func (s *Section) KeyValueStatus(ok bool, key, value string) {
+	if s.redact {
+		value = Redact(value)
+	}
 	icon := "✓"
 	if !ok {
 		icon = "✗"
 	}
 	// Pad before styling so ANSI codes don't affect alignment
 	paddedKey := fmt.Sprintf("%-*s", s.keyWidth, key)
-	if Colors() {
+	if s.out.Colors() {
 		if ok {
 			icon = successStyle().Render(icon)
 		} else {
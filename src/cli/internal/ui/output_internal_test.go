@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"bytes"
+	"io"
 	"os"
 	"testing"
 )
@@ -30,3 +32,68 @@ func TestColors_DisabledWhenNoColorIsSet(t *testing.T) {
 		t.Fatal("Colors() = true, want false when NO_COLOR is present")
 	}
 }
+
+func TestColorsEnabled_DisablingSignals(t *testing.T) {
+	if _, hasNoColor := os.LookupEnv("NO_COLOR"); hasNoColor {
+		t.Skip("NO_COLOR is set in the test environment")
+	}
+
+	tests := []struct {
+		name        string
+		noColorFlag bool
+		terminal    bool
+		want        bool
+	}{
+		{name: "terminal without overrides", noColorFlag: false, terminal: true, want: true},
+		{name: "no-color flag", noColorFlag: true, terminal: true, want: false},
+		{name: "non-terminal output", noColorFlag: false, terminal: false, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := colorsEnabled(tt.noColorFlag, tt.terminal); got != tt.want {
+				t.Errorf("colorsEnabled(%v, %v) = %v, want %v", tt.noColorFlag, tt.terminal, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorsEnabled_NoColorEnvOverridesTerminal(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	if colorsEnabled(false, true) {
+		t.Fatal("colorsEnabled() = true, want false when NO_COLOR is set")
+	}
+}
+
+func TestNewOutput_NonTerminalWriterDisablesColors(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	out := NewOutput(&buf, io.Discard, false)
+	if out.Colors() {
+		t.Fatal("Colors() = true, want false for a non-terminal writer")
+	}
+
+	out.Error("boom")
+	out.Success("done")
+	if bytes.Contains(buf.Bytes(), []byte("\x1b[")) {
+		t.Errorf("output contains ANSI escapes: %q", buf.String())
+	}
+}
+
+func TestOutput_ToStderrKeepsStdoutFree(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	out := NewOutput(&stdout, &stderr, false).ToStderr()
+	out.Println("starting")
+	out.Error("boom")
+
+	if stdout.Len() != 0 {
+		t.Errorf("stdout = %q, want empty", stdout.String())
+	}
+	if got := stderr.String(); got != "starting\nboom\n" {
+		t.Errorf("stderr = %q, want %q", got, "starting\nboom\n")
+	}
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+)
+
+// redactedMask replaces secrets in printed text.
+const redactedMask = "<redacted>"
+
+//nolint:gochecknoglobals // compiled once, read-only
+var (
+	// githubTokenPattern matches GitHub tokens; the type prefix is kept to aid debugging.
+	githubTokenPattern = regexp.MustCompile(`\b(gh[pousr]_|github_pat_)[A-Za-z0-9_]{20,}`)
+	// secretRunPattern matches long base64 runs, such as API keys and session secrets.
+	secretRunPattern = regexp.MustCompile(`[A-Za-z0-9+/_-]{32,}={0,2}`)
+)
+
+// Redact masks token-like substrings in s, leaving normal text untouched.
+// Verbose output and redacted sections pass everything they print through it.
+func Redact(s string) string {
+	s = githubTokenPattern.ReplaceAllString(s, "${1}"+redactedMask)
+	return secretRunPattern.ReplaceAllStringFunc(s, func(run string) string {
+		if looksLikeSecret(run) {
+			return redactedMask
+		}
+		return run
+	})
+}
+
+// looksLikeSecret reports whether a long run is mixed-case base64 rather than a path,
+// identifier, word or hex digest. Paths start with a slash, words lack digits, and hex
+// runs such as sha256 checksums, git SHAs and container IDs are kept.
+func looksLikeSecret(run string) bool {
+	if strings.HasPrefix(run, "/") {
+		return false
+	}
+	lower, upper, digit, hexOnly := false, false, false, true
+	for _, r := range strings.TrimRight(run, "=") {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+			hexOnly = hexOnly && r <= 'f'
+		case r >= 'A' && r <= 'Z':
+			upper = true
+			hexOnly = hexOnly && r <= 'F'
+		case r >= '0' && r <= '9':
+			digit = true
+		default:
+			hexOnly = false
+		}
+	}
+	return !hexOnly && lower && upper && digit
+}
@@ -71,7 +71,7 @@ func (s *Spinner) Stop() {
 func (s *Spinner) StopWithSuccess(msg string) {
 	s.Stop()
 	var err error
-	if Colors() {
+	if s.out.Colors() {
 		s.out.mu.Lock()
 		_, err = fmt.Fprintln(s.out.out, successStyle().Render("✓")+" "+msg)
 		s.out.mu.Unlock()
@@ -89,7 +89,7 @@ func (s *Spinner) StopWithSuccess(msg string) {
 func (s *Spinner) StopWithError(msg string) {
 	s.Stop()
 	var err error
-	if Colors() {
+	if s.out.Colors() {
 		s.out.mu.Lock()
 		_, err = fmt.Fprintln(s.out.out, errorStyle().Render("✗")+" "+msg)
 		s.out.mu.Unlock()
@@ -125,7 +125,7 @@ func (s *Spinner) render(frame int) {
 	s.mu.Unlock()
 
 	spinner := spinnerFrames()[frame]
-	if Colors() {
+	if s.out.Colors() {
 		spinner = s.style.Render(spinner)
 	}
 
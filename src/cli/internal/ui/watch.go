@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Watch calls render immediately and then once per interval until ctx is canceled.
+// On a terminal the screen is cleared before each render; otherwise each render is
+// printed as a snapshot below a timestamp line so logs stay readable.
+// Cancellation is a clean stop and returns nil; a render error ends the loop.
+func Watch(ctx context.Context, out *Output, interval time.Duration, render func(context.Context) error) error {
+	terminal := out.IsTerminal()
+	return Poll(ctx, interval, func(ctx context.Context) error {
+		if terminal {
+			out.ClearScreen()
+			out.Printf("Every %s (Ctrl+C to stop)\n\n", interval)
+		} else {
+			out.Printf("--- %s ---\n", time.Now().Format(time.TimeOnly))
+		}
+		return render(ctx)
+	})
+}
+
+// Poll is Watch without the screen clearing and snapshot headers, for machine-readable
+// output such as one JSON document per render.
+func Poll(ctx context.Context, interval time.Duration, render func(context.Context) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := render(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("watch: %w", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
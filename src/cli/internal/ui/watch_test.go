@@ -0,0 +1,85 @@
+package ui_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"altinn.studio/studioctl/internal/ui"
+)
+
+func TestWatch_StopsCleanlyOnCancel(t *testing.T) {
+	t.Parallel()
+
+	buf := &safeBuffer{}
+	out := ui.NewOutput(buf, buf, false)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	renders := 0
+	err := ui.Watch(ctx, out, time.Millisecond, func(context.Context) error {
+		renders++
+		out.Println("status")
+		if renders == 3 {
+			cancel()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Watch() error = %v, want nil", err)
+	}
+	if renders != 3 {
+		t.Fatalf("renders = %d, want 3", renders)
+	}
+
+	got := buf.String()
+	if n := strings.Count(got, "--- "); n != 3 {
+		t.Fatalf("snapshot headers = %d, want 3; output:\n%s", n, got)
+	}
+	if strings.Contains(got, "\033[2J") {
+		t.Fatalf("non-terminal output was cleared; output:\n%q", got)
+	}
+}
+
+func TestWatch_ReturnsRenderError(t *testing.T) {
+	t.Parallel()
+
+	out := ui.NewOutput(&safeBuffer{}, &safeBuffer{}, false)
+	errRender := errors.New("render failed")
+
+	err := ui.Watch(t.Context(), out, time.Millisecond, func(context.Context) error {
+		return errRender
+	})
+	if !errors.Is(err, errRender) {
+		t.Fatalf("Watch() error = %v, want %v", err, errRender)
+	}
+}
+
+func TestPoll_PrintsOnlyRenderOutput(t *testing.T) {
+	t.Parallel()
+
+	buf := &safeBuffer{}
+	out := ui.NewOutput(buf, buf, false)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	renders := 0
+	err := ui.Poll(ctx, time.Millisecond, func(context.Context) error {
+		renders++
+		out.Println(`{"running":false}`)
+		if renders == 2 {
+			cancel()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Poll() error = %v, want nil", err)
+	}
+	if want := "{\"running\":false}\n{\"running\":false}\n"; buf.String() != want {
+		t.Fatalf("Poll() output = %q, want %q", buf.String(), want)
+	}
+}
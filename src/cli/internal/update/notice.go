@@ -0,0 +1,121 @@
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"altinn.studio/studioctl/internal/osutil"
+)
+
+const (
+	// NoticeInterval is the minimum time between background update checks.
+	NoticeInterval = 24 * time.Hour
+
+	noticeTimeout   = 5 * time.Second
+	noticeStateFile = "update-notice.json"
+)
+
+// Notifier runs the opt-in background update check at most once per NoticeInterval.
+type Notifier struct {
+	// Checker performs the version check.
+	Checker *Checker
+	// StatePath is the file the last background check time is stored in.
+	StatePath string
+	// Interval is the minimum time between background checks.
+	Interval time.Duration
+}
+
+// Notice is a background update check that may finish while a command runs.
+type Notice struct {
+	done chan Result
+}
+
+// noticeState is the persisted form of the last successful background check.
+type noticeState struct {
+	CheckedAt time.Time `json:"checkedAt"`
+	Latest    string    `json:"latest"`
+}
+
+// NewNotifier creates a notifier that keeps its state under home.
+func NewNotifier(home string) *Notifier {
+	return &Notifier{
+		Checker:   NewChecker(home),
+		StatePath: filepath.Join(home, noticeStateFile),
+		Interval:  NoticeInterval,
+	}
+}
+
+// Start begins a background check unless one succeeded within Interval. In that
+// case the stored result is reused, and Start returns nil unless it found a newer
+// release. Only successful checks are recorded, so a failed or interrupted check
+// is retried by the next command.
+func (n *Notifier) Start(ctx context.Context, current string) *Notice {
+	now := n.Checker.Now()
+	if state, ok := n.readState(); ok && !state.CheckedAt.After(now) && now.Sub(state.CheckedAt) < n.Interval {
+		if !IsNewer(state.Latest, current) {
+			return nil
+		}
+		notice := &Notice{done: make(chan Result, 1)}
+		notice.done <- Result{
+			CheckedAt:       state.CheckedAt,
+			Current:         current,
+			Latest:          state.Latest,
+			UpdateAvailable: true,
+		}
+		close(notice.done)
+		return notice
+	}
+
+	notice := &Notice{done: make(chan Result, 1)}
+	go func() {
+		defer close(notice.done)
+		ctx, cancel := context.WithTimeout(ctx, noticeTimeout)
+		defer cancel()
+		result, err := n.Checker.Check(ctx, current)
+		if err != nil {
+			return
+		}
+		n.writeState(noticeState{CheckedAt: now, Latest: result.Latest})
+		notice.done <- result
+	}()
+	return notice
+}
+
+// Ready returns the result if the check has finished and found a newer release.
+// It never waits for a check that is still running.
+func (n *Notice) Ready() (Result, bool) {
+	if n == nil {
+		return Result{}, false
+	}
+	select {
+	case result, ok := <-n.done:
+		return result, ok && result.UpdateAvailable
+	default:
+		return Result{}, false
+	}
+}
+
+func (n *Notifier) readState() (noticeState, bool) {
+	data, err := os.ReadFile(n.StatePath)
+	if err != nil {
+		return noticeState{}, false
+	}
+	var state noticeState
+	if err := json.Unmarshal(data, &state); err != nil || state.Latest == "" {
+		return noticeState{}, false
+	}
+	return state, true
+}
+
+// writeState stores the last successful check; failures only cost an extra check next time.
+func (n *Notifier) writeState(state noticeState) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return
+	}
+	//nolint:errcheck // best-effort; a failed write only costs an extra check next time
+	os.WriteFile(n.StatePath, data, osutil.FilePermDefault)
+}
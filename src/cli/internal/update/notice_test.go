@@ -0,0 +1,128 @@
+package update_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"altinn.studio/studioctl/internal/update"
+)
+
+// waitReady polls a started notice until it reports an update or timeout passes.
+func waitReady(t *testing.T, notice *update.Notice, timeout time.Duration) (update.Result, bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if result, ok := notice.Ready(); ok {
+			return result, true
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return update.Result{}, false
+}
+
+func TestNotifier_ChecksAtMostOncePerInterval(t *testing.T) {
+	t.Parallel()
+
+	home := t.TempDir()
+	now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	calls := make(chan struct{}, 10)
+	notifier := update.NewNotifier(home)
+	notifier.Checker.Now = func() time.Time { return now }
+	notifier.Checker.Resolve = func(context.Context) (string, error) {
+		calls <- struct{}{}
+		return "v1.1.0", nil
+	}
+
+	notice := notifier.Start(t.Context(), "v1.0.0")
+	if notice == nil {
+		t.Fatal("Start() = nil on first run, want a background check")
+	}
+	result, ok := waitReady(t, notice, 5*time.Second)
+	if !ok || result.Latest != "v1.1.0" {
+		t.Fatalf("Ready() = %+v, %v; want update to v1.1.0", result, ok)
+	}
+	if _, err := os.Stat(filepath.Join(home, "update-notice.json")); err != nil {
+		t.Errorf("last-check timestamp not stored under home: %v", err)
+	}
+
+	now = now.Add(23 * time.Hour)
+	result, ok = notifier.Start(t.Context(), "v1.0.0").Ready()
+	if !ok || result.Latest != "v1.1.0" {
+		t.Fatalf("Ready() within a day = %+v, %v; want the stored update to v1.1.0", result, ok)
+	}
+	if notice := notifier.Start(t.Context(), "v1.1.0"); notice != nil {
+		t.Fatal("Start() within a day after upgrading = notice, want nil")
+	}
+	if got := len(calls); got != 1 {
+		t.Errorf("resolver calls = %d, want 1", got)
+	}
+
+	now = now.Add(2 * time.Hour)
+	notice = notifier.Start(t.Context(), "v1.0.0")
+	if notice == nil {
+		t.Fatal("Start() after a day = nil, want a background check")
+	}
+	if _, ok := waitReady(t, notice, 5*time.Second); !ok {
+		t.Fatal("Ready() = false after a day, want the update notice")
+	}
+	if got := len(calls); got != 2 {
+		t.Errorf("resolver calls = %d, want 2", got)
+	}
+}
+
+func TestNotifier_RetriesFailedCheck(t *testing.T) {
+	t.Parallel()
+
+	home := t.TempDir()
+	calls := make(chan struct{}, 10)
+	failed := make(chan struct{})
+	notifier := update.NewNotifier(home)
+	notifier.Checker.CachePath = ""
+	notifier.Checker.Resolve = func(context.Context) (string, error) {
+		calls <- struct{}{}
+		if len(calls) == 1 {
+			defer close(failed)
+			return "", errors.New("offline")
+		}
+		return "v1.1.0", nil
+	}
+
+	if notifier.Start(t.Context(), "v1.0.0") == nil {
+		t.Fatal("Start() = nil on first run, want a background check")
+	}
+	<-failed
+	if _, err := os.Stat(filepath.Join(home, "update-notice.json")); !os.IsNotExist(err) {
+		t.Fatalf("failed check recorded (stat error: %v), want no state", err)
+	}
+
+	notice := notifier.Start(t.Context(), "v1.0.0")
+	if notice == nil {
+		t.Fatal("Start() after a failed check = nil, want a new background check")
+	}
+	if _, ok := waitReady(t, notice, 5*time.Second); !ok {
+		t.Fatal("Ready() = false after retrying, want the update notice")
+	}
+}
+
+func TestNotice_ReadyWithoutUpdate(t *testing.T) {
+	t.Parallel()
+
+	var notice *update.Notice
+	if _, ok := notice.Ready(); ok {
+		t.Error("nil Notice.Ready() = true, want false")
+	}
+
+	notifier := update.NewNotifier(t.TempDir())
+	notifier.Checker.Resolve = func(context.Context) (string, error) { return "v1.0.0", nil }
+	notice = notifier.Start(t.Context(), "v1.0.0")
+	if notice == nil {
+		t.Fatal("Start() = nil, want a background check")
+	}
+	if _, ok := waitReady(t, notice, 200*time.Millisecond); ok {
+		t.Error("Ready() = true for the current release, want false")
+	}
+}
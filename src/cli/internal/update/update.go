@@ -0,0 +1,130 @@
+// Package update checks whether a newer studioctl release is available.
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"altinn.studio/studioctl/internal/install"
+	"altinn.studio/studioctl/internal/osutil"
+)
+
+const (
+	// CacheTTL is how long a version check result is reused before GitHub is queried again.
+	CacheTTL = time.Hour
+
+	// UpgradeCommand installs the latest studioctl release.
+	UpgradeCommand = "curl -sSL https://altinn.studio/designer/api/v1/studioctl/install.sh | sh"
+
+	cacheFile = "update-check.json"
+)
+
+// Result is the outcome of a version check.
+type Result struct {
+	CheckedAt       time.Time `json:"checkedAt"`
+	Current         string    `json:"current"`
+	Latest          string    `json:"latest"`
+	UpdateAvailable bool      `json:"updateAvailable"`
+}
+
+// Checker compares the running version against the latest published release.
+type Checker struct {
+	// Resolve returns the latest release version.
+	Resolve func(ctx context.Context) (string, error)
+	// Now returns the current time.
+	Now func() time.Time
+	// CachePath is the file the last result is stored in; empty disables caching.
+	CachePath string
+	// TTL is how long a cached result is reused.
+	TTL time.Duration
+}
+
+// cacheEntry is the persisted form of the last successful lookup.
+type cacheEntry struct {
+	CheckedAt time.Time `json:"checkedAt"`
+	Latest    string    `json:"latest"`
+}
+
+// NewChecker creates a checker that caches results under home.
+// An empty home disables caching.
+func NewChecker(home string) *Checker {
+	cachePath := ""
+	if home != "" {
+		cachePath = filepath.Join(home, cacheFile)
+	}
+	return &Checker{
+		Resolve:   install.ResolveLatestVersion,
+		Now:       time.Now,
+		CachePath: cachePath,
+		TTL:       CacheTTL,
+	}
+}
+
+// Check reports whether a release newer than current exists. A cached result
+// younger than TTL is reused; otherwise the latest release is resolved and cached.
+func (c *Checker) Check(ctx context.Context, current string) (Result, error) {
+	now := c.Now()
+	entry, ok := c.readCache()
+	if !ok || now.Sub(entry.CheckedAt) >= c.TTL || now.Before(entry.CheckedAt) {
+		latest, err := c.Resolve(ctx)
+		if err != nil {
+			return Result{}, fmt.Errorf("resolve latest release: %w", err)
+		}
+		entry = cacheEntry{CheckedAt: now, Latest: latest}
+		c.writeCache(entry)
+	}
+
+	return Result{
+		CheckedAt:       entry.CheckedAt,
+		Current:         current,
+		Latest:          entry.Latest,
+		UpdateAvailable: IsNewer(entry.Latest, current),
+	}, nil
+}
+
+// LastChecked returns when the cached result was stored, or the zero time without one.
+func (c *Checker) LastChecked() time.Time {
+	entry, ok := c.readCache()
+	if !ok {
+		return time.Time{}
+	}
+	return entry.CheckedAt
+}
+
+func (c *Checker) readCache() (cacheEntry, bool) {
+	if c.CachePath == "" {
+		return cacheEntry{}, false
+	}
+	data, err := os.ReadFile(c.CachePath)
+	if err != nil {
+		return cacheEntry{}, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Latest == "" {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+// writeCache stores entry; failures only cost an extra lookup next time.
+func (c *Checker) writeCache(entry cacheEntry) {
+	if c.CachePath == "" {
+		return
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	//nolint:errcheck // best-effort; a failed write only costs an extra lookup next time
+	os.WriteFile(c.CachePath, data, osutil.FilePermDefault)
+}
+
+// IsNewer reports whether latest is a higher semantic version than current.
+// Versions that do not parse (such as "dev") are never considered older.
+func IsNewer(latest, current string) bool {
+	return install.IsNewer(latest, current)
+}
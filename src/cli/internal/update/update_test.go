@@ -0,0 +1,81 @@
+package update_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"altinn.studio/studioctl/internal/update"
+)
+
+var errOffline = errors.New("offline")
+
+func TestIsNewer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		latest  string
+		current string
+		want    bool
+	}{
+		{latest: "v1.3.0", current: "v1.2.9", want: true},
+		{latest: "v1.2.10", current: "v1.2.9", want: true},
+		{latest: "v1.2.0", current: "v1.2.0", want: false},
+		{latest: "v1.2.0", current: "v1.3.0", want: false},
+		{latest: "v1.2.0", current: "v1.2.0-preview.1", want: true},
+		{latest: "v1.2.0-preview.2", current: "v1.2.0-preview.10", want: false},
+		{latest: "v1.2.0", current: "dev", want: false},
+		{latest: "latest", current: "v1.2.0", want: false},
+	}
+	for _, tt := range tests {
+		if got := update.IsNewer(tt.latest, tt.current); got != tt.want {
+			t.Errorf("IsNewer(%q, %q) = %v, want %v", tt.latest, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestChecker_CachesResult(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	calls := 0
+	latest := "v1.1.0"
+	checker := &update.Checker{
+		Resolve: func(context.Context) (string, error) {
+			calls++
+			return latest, nil
+		},
+		Now:       func() time.Time { return now },
+		CachePath: filepath.Join(t.TempDir(), "update-check.json"),
+		TTL:       time.Hour,
+	}
+
+	result, err := checker.Check(t.Context(), "v1.0.0")
+	if err != nil || !result.UpdateAvailable || result.Latest != "v1.1.0" {
+		t.Fatalf("Check() = %+v, %v; want update to v1.1.0", result, err)
+	}
+
+	latest = "v1.2.0"
+	now = now.Add(30 * time.Minute)
+	result, err = checker.Check(t.Context(), "v1.0.0")
+	if err != nil || result.Latest != "v1.1.0" || calls != 1 {
+		t.Fatalf("Check() within TTL = %+v, %v after %d calls; want cached v1.1.0", result, err, calls)
+	}
+
+	now = now.Add(time.Hour)
+	result, err = checker.Check(t.Context(), "v1.0.0")
+	if err != nil || result.Latest != "v1.2.0" || calls != 2 {
+		t.Fatalf("Check() after TTL = %+v, %v after %d calls; want fresh v1.2.0", result, err, calls)
+	}
+	if got := checker.LastChecked(); !got.Equal(now) {
+		t.Errorf("LastChecked() = %s, want %s", got, now)
+	}
+
+	checker.Resolve = func(context.Context) (string, error) { return "", errOffline }
+	now = now.Add(2 * time.Hour)
+	if _, err := checker.Check(t.Context(), "v1.0.0"); !errors.Is(err, errOffline) {
+		t.Errorf("Check() error = %v, want %v", err, errOffline)
+	}
+}